@@ -19,6 +19,9 @@ func main() {
 		base.InitCmd(),
 		base.DescribeCmd(),
 		base.GenerateCmd(),
+		base.LintCmd(),
+		base.MigrateCmd(),
+		base.SchemaCmd(),
 	)
 	_ = cmd.Execute()
 }
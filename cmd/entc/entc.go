@@ -23,6 +23,9 @@ func main() {
 		base.InitCmd(),
 		base.DescribeCmd(),
 		base.GenerateCmd(migrate),
+		base.LintCmd(),
+		base.MigrateCmd(),
+		base.SchemaCmd(),
 	)
 	_ = cmd.Execute()
 }
@@ -106,22 +106,31 @@ func InitCmd() *cobra.Command {
 
 // DescribeCmd returns the describe command for ent/c packages.
 func DescribeCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "describe [flags] path",
-		Short: "printer a description of the graph schema",
-		Example: examples(
-			"ent describe ./ent/schema",
-			"ent describe github.com/a8m/x",
-		),
-		Args: cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, path []string) {
-			graph, err := entc.LoadGraph(path[0], &gen.Config{})
-			if err != nil {
-				log.Fatalln(err)
-			}
-			printer.Fprint(os.Stdout, graph)
-		},
-	}
+	var (
+		format string
+		cmd    = &cobra.Command{
+			Use:   "describe [flags] path",
+			Short: "printer a description of the graph schema",
+			Example: examples(
+				"ent describe ./ent/schema",
+				"ent describe github.com/a8m/x",
+				"ent describe --format mermaid ./ent/schema",
+				"ent describe --format dot ./ent/schema | dot -Tsvg > schema.svg",
+			),
+			Args: cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, path []string) {
+				graph, err := entc.LoadGraph(path[0], &gen.Config{})
+				if err != nil {
+					log.Fatalln(err)
+				}
+				if err := printer.FprintFormat(os.Stdout, graph, format); err != nil {
+					log.Fatalln(err)
+				}
+			},
+		}
+	)
+	cmd.Flags().StringVar(&format, "format", printer.FormatTable, fmt.Sprintf("output format %v", printer.Formats))
+	return cmd
 }
 
 // GenerateCmd returns the generate command for ent/c packages.
@@ -0,0 +1,145 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package base
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/lint"
+
+	"github.com/spf13/cobra"
+)
+
+// LintCmd returns the lint command for ent/c packages.
+func LintCmd() *cobra.Command {
+	var (
+		format string
+		naming string
+		rules  []string
+		warn   []string
+		cmd    = &cobra.Command{
+			Use:   "lint [flags] path",
+			Short: "validate the graph schema against a set of rules",
+			Example: examples(
+				"ent lint ./ent/schema",
+				"ent lint --rules string-max-len,enum-upper-case ./ent/schema",
+				"ent lint --warn time-mixin --format sarif ./ent/schema > ent.sarif",
+			),
+			Args: cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, path []string) {
+				if format != "text" && format != "json" && format != "sarif" {
+					log.Fatalln(fmt.Errorf("ent/lint: unsupported format %q", format))
+				}
+				re, err := regexp.Compile(naming)
+				if err != nil {
+					log.Fatalln(fmt.Errorf("ent/lint: invalid naming pattern: %w", err))
+				}
+				selected, err := lintRules(re, rules, warn)
+				if err != nil {
+					log.Fatalln(fmt.Errorf("ent/lint: %w", err))
+				}
+				graph, err := entc.LoadGraph(path[0], &gen.Config{})
+				if err != nil {
+					log.Fatalln(err)
+				}
+				diags := lint.Run(graph, selected...)
+				relDiagnostics(diags)
+				if err := printDiagnostics(os.Stdout, format, selected, diags); err != nil {
+					log.Fatalln(fmt.Errorf("ent/lint: %w", err))
+				}
+				var errs int
+				for _, d := range diags {
+					if d.Severity == lint.SeverityError {
+						errs++
+					}
+				}
+				if errs > 0 {
+					log.Fatalf("ent/lint: %d error(s) found\n", errs)
+				}
+			},
+		}
+	)
+	cmd.Flags().StringVar(&format, "format", "text", "output format of the diagnostics [text, json, sarif]")
+	cmd.Flags().StringVar(&naming, "naming", lint.SnakeCase.String(), "pattern of the storage keys")
+	cmd.Flags().StringSliceVar(&rules, "rules", nil, "rules to run (defaults to all rules)")
+	cmd.Flags().StringSliceVar(&warn, "warn", nil, "rules that are reported as warnings and do not fail the command")
+	return cmd
+}
+
+// lintRules returns the built-in rules that were selected by name.
+func lintRules(naming *regexp.Regexp, names, warn []string) ([]*lint.Rule, error) {
+	var (
+		all     = lint.Rules()
+		byName  = make(map[string]*lint.Rule, len(all))
+		matched []*lint.Rule
+	)
+	for i, r := range all {
+		// The naming rule is replaced with one that uses the configured pattern.
+		if r.Name == "storage-key-naming" {
+			all[i] = lint.StorageKeyNaming(naming)
+		}
+		byName[all[i].Name] = all[i]
+	}
+	for _, name := range warn {
+		r, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		r.Severity = lint.SeverityWarning
+	}
+	if len(names) == 0 {
+		return all, nil
+	}
+	for _, name := range names {
+		r, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		matched = append(matched, r)
+	}
+	return matched, nil
+}
+
+// relDiagnostics makes the diagnostic file names relative
+// to the working directory, as expected by CI systems.
+func relDiagnostics(diags []*lint.Diagnostic) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	for _, d := range diags {
+		if d.Filename == "" || !filepath.IsAbs(d.Filename) {
+			continue
+		}
+		if rel, err := filepath.Rel(wd, d.Filename); err == nil {
+			d.Filename = rel
+		}
+	}
+}
+
+// printDiagnostics prints the diagnostics to w in the given format.
+func printDiagnostics(w io.Writer, format string, rules []*lint.Rule, diags []*lint.Diagnostic) error {
+	switch format {
+	case "text":
+		if len(diags) == 0 {
+			_, err := fmt.Fprintln(w, "No issues found")
+			return err
+		}
+		return lint.WriteText(w, diags)
+	case "json":
+		return lint.WriteJSON(w, diags)
+	case "sarif":
+		return lint.WriteSARIF(w, rules, diags)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package base
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"entgo.io/ent/entc/lint"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRules(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z]+$`)
+	rules, err := lintRules(re, nil, []string{"time-mixin"})
+	require.NoError(t, err)
+	require.Len(t, rules, len(lint.Rules()))
+	for _, r := range rules {
+		switch r.Name {
+		case "time-mixin":
+			require.Equal(t, lint.SeverityWarning, r.Severity)
+		case "storage-key-naming":
+			require.Contains(t, r.Doc, re.String())
+		default:
+			require.Equal(t, lint.SeverityError, r.Severity)
+		}
+	}
+
+	rules, err = lintRules(re, []string{"enum-upper-case", "fk-index"}, nil)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, "enum-upper-case", rules[0].Name)
+	require.Equal(t, "fk-index", rules[1].Name)
+
+	_, err = lintRules(re, []string{"unknown"}, nil)
+	require.EqualError(t, err, `unknown rule "unknown"`)
+	_, err = lintRules(re, nil, []string{"unknown"})
+	require.Error(t, err)
+}
+
+func TestPrintDiagnostics(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, printDiagnostics(&b, "text", nil, nil))
+	require.Equal(t, "No issues found\n", b.String())
+
+	diags := []*lint.Diagnostic{
+		{Rule: "enum-upper-case", Severity: lint.SeverityError, Message: "enum value \"a\" is not in UPPER_CASE", Type: "User", Field: "status", Filename: "ent/schema/user.go", Line: 12},
+	}
+	b.Reset()
+	require.NoError(t, printDiagnostics(&b, "text", nil, diags))
+	require.Equal(t, "ent/schema/user.go:12: User.status: enum value \"a\" is not in UPPER_CASE (enum-upper-case)\n", b.String())
+
+	b.Reset()
+	require.NoError(t, printDiagnostics(&b, "json", nil, nil))
+	require.Equal(t, "[]\n", b.String())
+
+	b.Reset()
+	require.NoError(t, printDiagnostics(&b, "sarif", lint.Rules(), diags))
+	require.Contains(t, b.String(), `"ruleId": "enum-upper-case"`)
+
+	require.Error(t, printDiagnostics(&b, "xml", nil, diags))
+}
@@ -0,0 +1,136 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package base
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql/schema"
+
+	"ariga.io/atlas/sql/migrate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintLint(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, printLint(&b, "text", &schema.LintReport{}))
+	require.Equal(t, "No issues found\n", b.String())
+
+	b.Reset()
+	require.NoError(t, printLint(&b, "json", &schema.LintReport{}))
+	require.Equal(t, "{\n  \"diagnostics\": []\n}\n", b.String())
+
+	r := &schema.LintReport{
+		Diagnostics: []*schema.Diagnostic{
+			{Code: schema.LintDropColumn, Table: "users", Column: "age", Text: `dropping column "age" from table "users"`},
+		},
+	}
+	b.Reset()
+	require.NoError(t, printLint(&b, "text", r))
+	require.Equal(t, "drop_column: dropping column \"age\" from table \"users\"\n", b.String())
+
+	b.Reset()
+	require.NoError(t, printLint(&b, "json", r))
+	require.JSONEq(t, `{"diagnostics":[{"code":"drop_column","table":"users","column":"age","text":"dropping column \"age\" from table \"users\""}]}`, b.String())
+}
+
+func TestPrintStatus(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, printStatus(&b, nil, nil))
+	require.Equal(t, `Migration Status: OK
+  -- Current Version: No migration applied yet
+  -- Next Version:    Already at latest version
+  -- Executed Files:  0
+  -- Pending Files:   0
+`, b.String())
+
+	b.Reset()
+	revs := []*migrate.Revision{{Version: "1"}, {Version: "2", Applied: 1, Total: 2, ErrorStmt: "DROP TABLE t", Error: "no such table: t"}}
+	pending := []migrate.File{migrate.NewLocalFile("2_b.sql", nil), migrate.NewLocalFile("3_c.sql", nil)}
+	require.NoError(t, printStatus(&b, revs, pending))
+	require.Equal(t, `Migration Status: FAILED
+  -- Current Version: 2
+  -- Next Version:    2
+  -- Executed Files:  2
+  -- Pending Files:   2
+
+Version 2 failed on statement 2 of 2:
+  DROP TABLE t
+  no such table: t
+`, b.String())
+}
+
+func TestOpenDir(t *testing.T) {
+	path := t.TempDir()
+	for _, f := range []string{"atlas", "golang-migrate", "goose", "flyway", "liquibase", "dbmate"} {
+		d, err := openDir(path, f)
+		require.NoError(t, err)
+		require.NotNil(t, d)
+	}
+	_, err := openDir(path, "unknown")
+	require.EqualError(t, err, `unsupported directory format "unknown"`)
+}
+
+func TestMigrateApply(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		path = t.TempDir()
+		url  = "sqlite://" + filepath.Join(t.TempDir(), "ent.db") + "?_fk=1"
+	)
+	require.NoError(t, os.WriteFile(filepath.Join(path, "1_users.sql"), []byte("CREATE TABLE `users` (`id` integer NOT NULL);\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "2_pets.sql"), []byte("CREATE TABLE `pets` (`id` integer NOT NULL);\n"), 0644))
+	// Integrity file is missing.
+	err := withExecutor(ctx, url, path, "atlas", func(ctx context.Context, ex *migrate.Executor, _ *revisions) error {
+		return ex.ExecuteN(ctx, 0)
+	})
+	require.ErrorIs(t, err, migrate.ErrChecksumNotFound)
+	require.NoError(t, hashDir(path, "atlas"))
+
+	status := func() (revs []*migrate.Revision, pending []migrate.File) {
+		err := withExecutor(ctx, url, path, "atlas", func(ctx context.Context, ex *migrate.Executor, rrw *revisions) (err error) {
+			if revs, err = rrw.ReadRevisions(ctx); err != nil {
+				return err
+			}
+			if pending, err = ex.Pending(ctx); errors.Is(err, migrate.ErrNoPendingFiles) {
+				return nil
+			}
+			return err
+		})
+		require.NoError(t, err)
+		return
+	}
+	revs, pending := status()
+	require.Empty(t, revs)
+	require.Len(t, pending, 2)
+
+	apply := func(n int) error {
+		return withExecutor(ctx, url, path, "atlas", func(ctx context.Context, ex *migrate.Executor, _ *revisions) error {
+			return ex.ExecuteN(ctx, n)
+		})
+	}
+	require.NoError(t, apply(1))
+	revs, pending = status()
+	require.Len(t, revs, 1)
+	require.Equal(t, "1", revs[0].Version)
+	require.Equal(t, "users", revs[0].Description)
+	require.Equal(t, migrate.RevisionTypeExecute, revs[0].Type)
+	require.Equal(t, 1, revs[0].Applied)
+	require.Len(t, revs[0].PartialHashes, 1)
+	require.WithinDuration(t, time.Now(), revs[0].ExecutedAt, time.Minute)
+	require.Len(t, pending, 1)
+	require.Equal(t, "2", pending[0].Version())
+
+	require.NoError(t, apply(0))
+	revs, pending = status()
+	require.Len(t, revs, 2)
+	require.Empty(t, pending)
+	require.ErrorIs(t, apply(0), migrate.ErrNoPendingFiles)
+}
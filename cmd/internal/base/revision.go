@@ -0,0 +1,227 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+)
+
+// revisionTable is the name of the table that holds the revisions
+// of the migration files that were applied by "ent migrate apply".
+const revisionTable = "ent_schema_revisions"
+
+// revisions implements the migrate.RevisionReadWriter interface
+// by storing the revisions in a table of the migrated database.
+type revisions struct {
+	drv     *entsql.Driver
+	dialect string
+	exists  bool // table exists.
+}
+
+var _ migrate.RevisionReadWriter = (*revisions)(nil)
+
+// newRevisions returns a revisions storage for the given client.
+// The revisions table is created lazily on the first write.
+func newRevisions(ctx context.Context, c *sqlclient.Client) (*revisions, error) {
+	name, err := urlDialect(c.Name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.InspectSchema(ctx, "", &schema.InspectOptions{Tables: []string{revisionTable}})
+	if err != nil {
+		return nil, fmt.Errorf("inspect revisions table: %w", err)
+	}
+	return &revisions{
+		drv:     entsql.OpenDB(name, c.DB),
+		dialect: name,
+		exists:  len(s.Tables) > 0,
+	}, nil
+}
+
+// Ident implements the migrate.RevisionReadWriter interface.
+func (r *revisions) Ident() *migrate.TableIdent {
+	return &migrate.TableIdent{Name: revisionTable}
+}
+
+// ReadRevisions implements the migrate.RevisionReadWriter interface.
+func (r *revisions) ReadRevisions(ctx context.Context) ([]*migrate.Revision, error) {
+	if !r.exists {
+		return nil, nil
+	}
+	query, args := r.selector().OrderBy("version").Query()
+	return r.query(ctx, query, args)
+}
+
+// ReadRevision implements the migrate.RevisionReadWriter interface.
+func (r *revisions) ReadRevision(ctx context.Context, version string) (*migrate.Revision, error) {
+	if !r.exists {
+		return nil, migrate.ErrRevisionNotExist
+	}
+	query, args := r.selector().Where(entsql.EQ("version", version)).Query()
+	revs, err := r.query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		return nil, migrate.ErrRevisionNotExist
+	}
+	return revs[0], nil
+}
+
+// WriteRevision implements the migrate.RevisionReadWriter interface.
+func (r *revisions) WriteRevision(ctx context.Context, rev *migrate.Revision) error {
+	if err := r.create(ctx); err != nil {
+		return err
+	}
+	hashes, err := json.Marshal(rev.PartialHashes)
+	if err != nil {
+		return err
+	}
+	values := []any{
+		rev.Description, int64(rev.Type), rev.Applied, rev.Total, rev.ExecutedAt, int64(rev.ExecutionTime),
+		rev.Error, rev.ErrorStmt, rev.Hash, string(hashes), rev.OperatorVersion,
+	}
+	columns := []string{
+		"description", "type", "applied", "total", "executed_at", "execution_time",
+		"error", "error_stmt", "hash", "partial_hashes", "operator_version",
+	}
+	update := entsql.Dialect(r.dialect).Update(revisionTable).Where(entsql.EQ("version", rev.Version))
+	for i := range columns {
+		update.Set(columns[i], values[i])
+	}
+	var res entsql.Result
+	query, args := update.Query()
+	if err := r.drv.Exec(ctx, query, args, &res); err != nil {
+		return fmt.Errorf("update revision %q: %w", rev.Version, err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+	query, args = entsql.Dialect(r.dialect).
+		Insert(revisionTable).
+		Columns(append([]string{"version"}, columns...)...).
+		Values(append([]any{rev.Version}, values...)...).
+		Query()
+	if err := r.drv.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("insert revision %q: %w", rev.Version, err)
+	}
+	return nil
+}
+
+// DeleteRevision implements the migrate.RevisionReadWriter interface.
+func (r *revisions) DeleteRevision(ctx context.Context, version string) error {
+	if !r.exists {
+		return nil
+	}
+	query, args := entsql.Dialect(r.dialect).
+		Delete(revisionTable).
+		Where(entsql.EQ("version", version)).
+		Query()
+	return r.drv.Exec(ctx, query, args, nil)
+}
+
+// create creates the revisions table if it does not exist.
+func (r *revisions) create(ctx context.Context) error {
+	if r.exists {
+		return nil
+	}
+	var str, text, bigint, timestamp string
+	switch r.dialect {
+	case dialect.MySQL:
+		str, text, bigint, timestamp = "varchar(255)", "longtext", "bigint", "timestamp(6)"
+	case dialect.Postgres:
+		str, text, bigint, timestamp = "character varying", "text", "bigint", "timestamp with time zone"
+	default:
+		str, text, bigint, timestamp = "text", "text", "integer", "datetime"
+	}
+	query, args := entsql.Dialect(r.dialect).
+		CreateTable(revisionTable).
+		IfNotExists().
+		Columns(
+			entsql.Column("version").Type(str).Attr("NOT NULL"),
+			entsql.Column("description").Type(str).Attr("NOT NULL"),
+			entsql.Column("type").Type(bigint).Attr("NOT NULL"),
+			entsql.Column("applied").Type(bigint).Attr("NOT NULL"),
+			entsql.Column("total").Type(bigint).Attr("NOT NULL"),
+			entsql.Column("executed_at").Type(timestamp).Attr("NOT NULL"),
+			entsql.Column("execution_time").Type(bigint).Attr("NOT NULL"),
+			entsql.Column("error").Type(text).Attr("NOT NULL"),
+			entsql.Column("error_stmt").Type(text).Attr("NOT NULL"),
+			entsql.Column("hash").Type(str).Attr("NOT NULL"),
+			entsql.Column("partial_hashes").Type(text).Attr("NOT NULL"),
+			entsql.Column("operator_version").Type(str).Attr("NOT NULL"),
+		).
+		PrimaryKey("version").
+		Query()
+	if err := r.drv.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("create revisions table: %w", err)
+	}
+	r.exists = true
+	return nil
+}
+
+// selector returns a selector for all columns of the revisions table.
+func (r *revisions) selector() *entsql.Selector {
+	return entsql.Dialect(r.dialect).
+		Select(
+			"version", "description", "type", "applied", "total", "executed_at", "execution_time",
+			"error", "error_stmt", "hash", "partial_hashes", "operator_version",
+		).
+		From(entsql.Table(revisionTable))
+}
+
+// query executes the given revisions query and scans its rows.
+func (r *revisions) query(ctx context.Context, query string, args []any) ([]*migrate.Revision, error) {
+	var rows entsql.Rows
+	if err := r.drv.Query(ctx, query, args, &rows); err != nil {
+		return nil, fmt.Errorf("query revisions: %w", err)
+	}
+	defer rows.Close()
+	var revs []*migrate.Revision
+	for rows.Next() {
+		var (
+			typ, elapsed int64
+			hashes       string
+			rev          = &migrate.Revision{}
+		)
+		if err := rows.Scan(
+			&rev.Version, &rev.Description, &typ, &rev.Applied, &rev.Total, &rev.ExecutedAt, &elapsed,
+			&rev.Error, &rev.ErrorStmt, &rev.Hash, &hashes, &rev.OperatorVersion,
+		); err != nil {
+			return nil, fmt.Errorf("scan revision: %w", err)
+		}
+		rev.Type = migrate.RevisionType(typ)
+		rev.ExecutionTime = time.Duration(elapsed)
+		if err := json.Unmarshal([]byte(hashes), &rev.PartialHashes); err != nil {
+			return nil, fmt.Errorf("decode partial hashes of revision %q: %w", rev.Version, err)
+		}
+		revs = append(revs, rev)
+	}
+	return revs, rows.Err()
+}
+
+// urlDialect returns the Ent dialect of the given URL scheme or driver name.
+func urlDialect(scheme string) (string, error) {
+	switch scheme {
+	case dialect.MySQL, "maria", "mariadb":
+		return dialect.MySQL, nil
+	case dialect.Postgres, "postgresql":
+		return dialect.Postgres, nil
+	case dialect.SQLite, "sqlite":
+		return dialect.SQLite, nil
+	default:
+		return "", fmt.Errorf("unsupported database %q", scheme)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package base
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/cmd/internal/importer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSchemas(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "schema")
+	files := []*importer.File{
+		{Name: "user.go", Content: []byte("package schema\n")},
+		{Name: "pet.go", Content: []byte("package schema\n")},
+	}
+	require.NoError(t, writeSchemas(target, files[1:]))
+
+	// No file is written if one of the schemas already exists.
+	err := writeSchemas(target, files)
+	require.EqualError(t, err, "import schema "+filepath.Join(target, "pet.go")+": already exists")
+	_, err = os.Stat(filepath.Join(target, "user.go"))
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, os.Remove(filepath.Join(target, "pet.go")))
+	require.NoError(t, writeSchemas(target, files))
+	for _, f := range files {
+		buf, err := os.ReadFile(filepath.Join(target, f.Name))
+		require.NoError(t, err)
+		require.Equal(t, f.Content, buf)
+	}
+}
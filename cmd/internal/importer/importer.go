@@ -0,0 +1,771 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package importer generates ent schemas from an existing database schema.
+// Tables are converted to ent types, columns to fields, foreign-keys to edges
+// (join tables are converted to many-to-many edges), and indexes to ent indexes.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/entc/gen"
+
+	"github.com/go-openapi/inflect"
+)
+
+type (
+	// Option allows configuring the Importer using functional options.
+	Option func(*Importer)
+
+	// Importer generates ent schemas from an inspected database schema.
+	Importer struct {
+		dialect string
+		tables  []string
+		exclude []string
+		logf    func(string, ...any)
+	}
+
+	// File is a generated ent schema file.
+	File struct {
+		Name    string // file name (e.g. user.go).
+		Content []byte // formatted Go source.
+	}
+)
+
+// WithTables limits the import to the given tables.
+func WithTables(names ...string) Option {
+	return func(i *Importer) {
+		i.tables = append(i.tables, names...)
+	}
+}
+
+// WithExcludeTables excludes the given tables from the import.
+func WithExcludeTables(names ...string) Option {
+	return func(i *Importer) {
+		i.exclude = append(i.exclude, names...)
+	}
+}
+
+// WithLogf sets the function used for reporting tables and
+// columns that cannot be represented in ent and were skipped.
+func WithLogf(logf func(string, ...any)) Option {
+	return func(i *Importer) {
+		i.logf = logf
+	}
+}
+
+// New creates a new Importer for the given dialect.
+func New(dialect string, opts ...Option) *Importer {
+	i := &Importer{dialect: dialect, logf: func(string, ...any) {}}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+type (
+	// node holds the generated definitions of an ent type.
+	node struct {
+		name    string
+		t       *schema.Table
+		id      *schema.Column
+		fields  []string
+		edges   []string
+		indexes []string
+		annots  []string
+		comment string
+		names   map[string]bool   // field and edge names.
+		columns map[string]string // column to field names.
+		imports map[string]bool
+	}
+
+	// join holds a join table that is represented as a many-to-many edge.
+	join struct {
+		t            *schema.Table
+		fk1, fk2     *schema.ForeignKey
+		owner, inver *node
+	}
+)
+
+// Import generates the ent schema files for the tables of the given schema.
+func (i *Importer) Import(s *schema.Schema) ([]*File, error) {
+	var (
+		nodes []*node
+		joins []*join
+		types = make(map[string]*node)
+		byT   = make(map[*schema.Table]*node)
+	)
+	tables := i.filter(s.Tables)
+	for _, t := range tables {
+		if fk1, fk2, ok := joinKeys(t); ok {
+			joins = append(joins, &join{t: t, fk1: fk1, fk2: fk2})
+			continue
+		}
+		if t.PrimaryKey == nil || len(t.PrimaryKey.Parts) != 1 || t.PrimaryKey.Parts[0].C == nil {
+			i.logf("skipping table %q: ent types require a single-column primary key", t.Name)
+			continue
+		}
+		n := &node{
+			name:    pascal(singular(t.Name)),
+			t:       t,
+			id:      t.PrimaryKey.Parts[0].C,
+			names:   make(map[string]bool),
+			columns: make(map[string]string),
+			imports: map[string]bool{"entgo.io/ent": true},
+		}
+		if prev, ok := types[n.name]; ok {
+			i.logf("skipping table %q: type name %q is already used by table %q", t.Name, n.name, prev.t.Name)
+			continue
+		}
+		types[n.name] = n
+		byT[t] = n
+		nodes = append(nodes, n)
+	}
+	for _, n := range nodes {
+		i.fields(n, byT)
+	}
+	for _, n := range nodes {
+		i.edges(n, byT)
+	}
+	for _, j := range joins {
+		j.owner, j.inver = byT[j.fk1.RefTable], byT[j.fk2.RefTable]
+		if j.owner == nil || j.inver == nil {
+			i.logf("skipping join table %q: referenced tables were not imported", j.t.Name)
+			continue
+		}
+		m2m(j)
+	}
+	files := make([]*File, 0, len(nodes))
+	for _, n := range nodes {
+		i.indexes(n)
+		i.annotations(n, s)
+		f, err := n.file()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// filter returns the tables that should be imported.
+func (i *Importer) filter(tables []*schema.Table) []*schema.Table {
+	var ts []*schema.Table
+	for _, t := range tables {
+		if len(i.tables) > 0 && !contains(i.tables, t.Name) || contains(i.exclude, t.Name) {
+			continue
+		}
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// joinKeys returns the foreign-keys of a join table of a many-to-many relation, ordered by
+// their columns. i.e. a table with 2 columns that are its primary key, and each references
+// the primary key of another table.
+func joinKeys(t *schema.Table) (*schema.ForeignKey, *schema.ForeignKey, bool) {
+	if len(t.Columns) != 2 || len(t.ForeignKeys) != 2 || t.PrimaryKey == nil || len(t.PrimaryKey.Parts) != 2 {
+		return nil, nil, false
+	}
+	fks := make([]*schema.ForeignKey, 2)
+	for _, fk := range t.ForeignKeys {
+		if len(fk.Columns) != 1 || len(fk.RefColumns) != 1 || !isPK(fk.RefTable, fk.RefColumns[0]) {
+			return nil, nil, false
+		}
+		for i, c := range t.Columns {
+			if fk.Columns[0] == c {
+				fks[i] = fk
+			}
+		}
+	}
+	return fks[0], fks[1], fks[0] != nil && fks[1] != nil
+}
+
+// fields generates the fields of the node.
+func (i *Importer) fields(n *node, byT map[*schema.Table]*node) {
+	// The id field is defined first.
+	columns := []*schema.Column{n.id}
+	for _, c := range n.t.Columns {
+		if c != n.id {
+			columns = append(columns, c)
+		}
+	}
+	for _, c := range columns {
+		name := fieldName(c.Name)
+		if c == n.id {
+			name = "id"
+		} else if name == "id" {
+			i.logf("skipping column %q.%q: the id field is reserved for the primary key", n.t.Name, c.Name)
+			continue
+		}
+		// Edge-fields are defined with the type of the referenced
+		// primary key, because ent requires them to be identical.
+		typ := c
+		if fk := foreignKey(n.t, c); fk != nil && byT[fk.RefTable] != nil {
+			typ = &schema.Column{Name: c.Name, Type: &schema.ColumnType{Type: fk.RefColumns[0].Type.Type, Raw: fk.RefColumns[0].Type.Raw}, Attrs: c.Attrs, Default: c.Default}
+		}
+		b, ok := i.field(n, name, typ)
+		if !ok {
+			i.logf("skipping column %q.%q: unsupported type %q", n.t.Name, c.Name, c.Type.Raw)
+			continue
+		}
+		if c != n.id {
+			if d, ok := i.fieldDefault(n, typ); ok {
+				b = append(b, d)
+			}
+			if unique(n.t, c) {
+				b = append(b, "Unique()")
+			}
+			if c.Type.Null {
+				b = append(b, "Optional()")
+			}
+		}
+		if cm := (&schema.Comment{}); has(c.Attrs, cm) && cm.Text != "" {
+			b = append(b, fmt.Sprintf("Comment(%q)", cm.Text))
+		}
+		if name != c.Name {
+			b = append(b, fmt.Sprintf("StorageKey(%q)", c.Name))
+		}
+		n.names[name] = true
+		n.columns[c.Name] = name
+		n.fields = append(n.fields, strings.Join(b, ".\n"))
+	}
+}
+
+// field returns the field builder calls of the given column, and reports if the column type is supported.
+func (i *Importer) field(n *node, name string, c *schema.Column) ([]string, bool) {
+	var (
+		b    []string
+		call = func(f string, args ...string) []string {
+			return []string{fmt.Sprintf("field.%s(%s)", f, strings.Join(append([]string{strconv.Quote(name)}, args...), ", "))}
+		}
+		raw = strings.ToLower(c.Type.Raw)
+	)
+	n.imports["entgo.io/ent/schema/field"] = true
+	switch t := c.Type.Type.(type) {
+	case *schema.BoolType:
+		b = call("Bool")
+		raw = ""
+	case *schema.IntegerType:
+		b = call(i.intType(t.T, t.Unsigned))
+		raw = ""
+	case *postgres.SerialType:
+		switch t.T {
+		case "smallserial":
+			b = call("Int16")
+		case "serial":
+			b = call("Int32")
+		default:
+			b = call("Int")
+		}
+		raw = ""
+	case *schema.StringType:
+		switch tt := strings.ToLower(t.T); {
+		case strings.Contains(tt, "text") || tt == "clob":
+			b = call("Text")
+			if i.dialect == dialect.SQLite || i.dialect == dialect.MySQL && tt == "longtext" || i.dialect == dialect.Postgres && tt == "text" {
+				raw = ""
+			}
+		default:
+			b = call("String")
+			if strings.Contains(tt, "var") {
+				if t.Size > 0 && t.Size != 255 {
+					b = append(b, fmt.Sprintf("MaxLen(%d)", t.Size))
+				}
+				raw = ""
+			}
+		}
+	case *schema.EnumType:
+		b = call("Enum")
+		values := make([]string, len(t.Values))
+		for i, v := range t.Values {
+			values[i] = strconv.Quote(v)
+		}
+		b = append(b, fmt.Sprintf("Values(%s)", strings.Join(values, ", ")))
+		// Native Postgres enums are defined by their type name.
+		if raw = ""; i.dialect == dialect.Postgres && t.T != "" {
+			raw = t.T
+		}
+	case *schema.FloatType:
+		switch tt := strings.ToLower(t.T); {
+		case tt == "float" && i.dialect == dialect.MySQL, tt == "real" && i.dialect == dialect.Postgres:
+			b = call("Float32")
+		default:
+			b = call("Float")
+		}
+		raw = ""
+	case *schema.DecimalType:
+		b = call("Float")
+	case *schema.TimeType:
+		b = call("Time")
+		n.imports["time"] = true
+		if tt := strings.ToLower(t.T); i.dialect == dialect.MySQL && tt == "timestamp" ||
+			i.dialect == dialect.Postgres && tt == "timestamp with time zone" || i.dialect == dialect.SQLite && tt == "datetime" {
+			raw = ""
+		}
+	case *schema.JSONType:
+		b = call("JSON", "json.RawMessage{}")
+		n.imports["encoding/json"] = true
+		if tt := strings.ToLower(t.T); i.dialect != dialect.Postgres || tt == "jsonb" {
+			raw = ""
+		}
+	case *schema.UUIDType, *sqlite.UUIDType:
+		b = call("UUID", "uuid.UUID{}")
+		n.imports["github.com/google/uuid"] = true
+		raw = ""
+	case *schema.BinaryType:
+		b = call("Bytes")
+		if tt := strings.ToLower(t.T); i.dialect == dialect.MySQL && tt == "blob" || i.dialect == dialect.Postgres && tt == "bytea" || i.dialect == dialect.SQLite {
+			raw = ""
+		}
+	default:
+		return nil, false
+	}
+	// Keep the database type in case it is not
+	// the default type used by ent for this field.
+	if raw != "" {
+		b = append(b, fmt.Sprintf("SchemaType(map[string]string{\n%s: %q,\n})", dialectConst(i.dialect), raw))
+		n.imports["entgo.io/ent/dialect"] = true
+	}
+	return b, true
+}
+
+// intType returns the ent field type of the given integer type.
+func (i *Importer) intType(t string, unsigned bool) string {
+	// All integer types have the same type affinity in SQLite.
+	if i.dialect == dialect.SQLite {
+		return "Int"
+	}
+	var typ string
+	switch strings.ToLower(t) {
+	case "tinyint", "int1":
+		typ = "Int8"
+	case "smallint", "int2":
+		typ = "Int16"
+	case "mediumint", "int", "integer", "int4":
+		typ = "Int32"
+	default:
+		typ = "Int"
+	}
+	if unsigned {
+		typ = "U" + strings.ToLower(typ[:1]) + typ[1:]
+	}
+	return typ
+}
+
+// fieldDefault returns the Default call for the given column, if it is supported.
+func (i *Importer) fieldDefault(n *node, c *schema.Column) (string, bool) {
+	switch x := c.Default.(type) {
+	case *schema.Literal:
+		v := x.V
+		// Strip type casting (e.g. 'a'::character varying).
+		if j := strings.LastIndex(v, "::"); j > 0 && strings.HasPrefix(v, "'") {
+			v = v[:j]
+		}
+		switch c.Type.Type.(type) {
+		case *schema.StringType, *schema.EnumType:
+			if len(v) > 1 && v[0] == '\'' && v[len(v)-1] == '\'' {
+				v = strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+			}
+			return fmt.Sprintf("Default(%q)", v), true
+		case *schema.BoolType:
+			switch strings.ToLower(strings.Trim(v, "'")) {
+			case "1", "true":
+				return "Default(true)", true
+			case "0", "false":
+				return "Default(false)", true
+			}
+		case *schema.IntegerType, *postgres.SerialType:
+			if _, err := strconv.ParseInt(strings.Trim(v, "'"), 10, 64); err == nil {
+				return fmt.Sprintf("Default(%s)", strings.Trim(v, "'")), true
+			}
+		case *schema.FloatType, *schema.DecimalType:
+			if f, err := strconv.ParseFloat(strings.Trim(v, "'"), 64); err == nil {
+				return fmt.Sprintf("Default(%s)", strconv.FormatFloat(f, 'f', -1, 64)), true
+			}
+		}
+	case *schema.RawExpr:
+		switch expr := strings.ToLower(x.X); c.Type.Type.(type) {
+		case *schema.TimeType:
+			if strings.HasPrefix(expr, "current_timestamp") || strings.HasPrefix(expr, "now()") {
+				return "Default(time.Now)", true
+			}
+		case *schema.UUIDType:
+			if strings.HasPrefix(expr, "gen_random_uuid()") || strings.HasPrefix(expr, "uuid_generate_v4()") {
+				return "Default(uuid.New)", true
+			}
+		}
+		n.imports["entgo.io/ent/dialect/entsql"] = true
+		return fmt.Sprintf("Annotations(\nentsql.DefaultExpr(%q),\n)", x.X), true
+	}
+	return "", false
+}
+
+// edges generates the edges that are inferred from the foreign-keys of the node.
+func (i *Importer) edges(n *node, byT map[*schema.Table]*node) {
+	for _, fk := range n.t.ForeignKeys {
+		ref := byT[fk.RefTable]
+		switch {
+		case ref == nil:
+			i.logf("skipping foreign-key %q of table %q: referenced table %q was not imported", fk.Symbol, n.t.Name, fk.RefTable.Name)
+			continue
+		case len(fk.Columns) != 1 || fk.Columns[0] == n.id || n.columns[fk.Columns[0].Name] == "" || fk.RefColumns[0] != ref.id:
+			i.logf("skipping foreign-key %q of table %q: edges must reference a primary key from a single column", fk.Symbol, n.t.Name)
+			continue
+		}
+		var (
+			c       = fk.Columns[0]
+			o2o     = unique(n.t, c)
+			from    = strings.TrimSuffix(n.columns[c.Name], "_id")
+			to      = snake(inflect.Pluralize(n.name))
+			opts    []string
+			inverse []string
+		)
+		if o2o {
+			to = snake(n.name)
+		}
+		if from == n.columns[c.Name] {
+			from = snake(ref.name)
+		}
+		if od := fk.OnDelete; od == schema.Cascade || od == schema.SetNull {
+			opts = append(opts, fmt.Sprintf("Annotations(\nentsql.Annotation{OnDelete: entsql.%s},\n)", pascal(strings.ToLower(string(od)))))
+			ref.imports["entgo.io/ent/dialect/entsql"] = true
+		}
+		inverse = append(inverse, "Unique()", fmt.Sprintf("Field(%q)", n.columns[c.Name]))
+		if !c.Type.Null {
+			inverse = append(inverse, "Required()")
+		}
+		n.imports["entgo.io/ent/schema/edge"] = true
+		ref.imports["entgo.io/ent/schema/edge"] = true
+		// Self-reference relations are defined using a single
+		// definition that holds both the edge and its inverse.
+		if ref == n {
+			if o2o {
+				to = "next"
+			} else {
+				to = "children"
+			}
+			to = uniqueName(n, to, from)
+			from = uniqueName(n, from, "parent")
+			b := []string{fmt.Sprintf("edge.To(%q, %s.Type)", to, n.name)}
+			if o2o {
+				b = append(b, "Unique()")
+			}
+			b = append(b, opts...)
+			b = append(b, fmt.Sprintf("From(%q)", from))
+			n.edges = append(n.edges, strings.Join(append(b, inverse...), ".\n"))
+			continue
+		}
+		to = uniqueName(ref, to, from+"_"+to)
+		from = uniqueName(n, from, from+"_"+snake(ref.name))
+		b := []string{fmt.Sprintf("edge.To(%q, %s.Type)", to, n.name)}
+		if o2o {
+			b = append(b, "Unique()")
+		}
+		ref.edges = append(ref.edges, strings.Join(append(b, opts...), ".\n"))
+		b = []string{fmt.Sprintf("edge.From(%q, %s.Type)", from, ref.name), fmt.Sprintf("Ref(%q)", to)}
+		n.edges = append(n.edges, strings.Join(append(b, inverse...), ".\n"))
+	}
+}
+
+// m2m generates the many-to-many edges of the join table.
+func m2m(j *join) {
+	var (
+		owner, inver = j.owner, j.inver
+		c1, c2       = j.t.Columns[0].Name, j.t.Columns[1].Name
+		to           = snake(inflect.Pluralize(inver.name))
+		from         = snake(inflect.Pluralize(owner.name))
+		storage      = fmt.Sprintf("StorageKey(edge.Table(%q), edge.Columns(%q, %q))", j.t.Name, c1, c2)
+	)
+	owner.imports["entgo.io/ent/schema/edge"] = true
+	inver.imports["entgo.io/ent/schema/edge"] = true
+	if owner == inver {
+		to = uniqueName(owner, inflect.Pluralize(strings.TrimSuffix(c2, "_id")), j.t.Name)
+		from = uniqueName(owner, inflect.Pluralize(strings.TrimSuffix(c1, "_id")), j.t.Name+"_inverse")
+	} else {
+		to = uniqueName(owner, to, j.t.Name)
+		from = uniqueName(inver, from, j.t.Name)
+	}
+	owner.edges = append(owner.edges, strings.Join([]string{
+		fmt.Sprintf("edge.To(%q, %s.Type)", to, inver.name),
+		storage,
+	}, ".\n"))
+	inver.edges = append(inver.edges, strings.Join([]string{
+		fmt.Sprintf("edge.From(%q, %s.Type)", from, owner.name),
+		fmt.Sprintf("Ref(%q)", to),
+	}, ".\n"))
+}
+
+// indexes generates the indexes of the node.
+func (i *Importer) indexes(n *node) {
+Indexes:
+	for _, idx := range n.t.Indexes {
+		// Single-column unique indexes are defined on their fields, and indexes
+		// created implicitly for foreign-keys are created by ent as well.
+		if len(idx.Parts) == 1 && idx.Parts[0].C != nil && (idx.Unique || implicitFK(n.t, idx)) {
+			continue
+		}
+		fields := make([]string, 0, len(idx.Parts))
+		columns := make([]string, 0, len(idx.Parts))
+		for _, p := range idx.Parts {
+			if p.C == nil || n.columns[p.C.Name] == "" {
+				i.logf("skipping index %q of table %q: only column indexes are supported", idx.Name, n.t.Name)
+				continue Indexes
+			}
+			fields = append(fields, strconv.Quote(n.columns[p.C.Name]))
+			columns = append(columns, p.C.Name)
+		}
+		b := []string{fmt.Sprintf("index.Fields(%s)", strings.Join(fields, ", "))}
+		if idx.Unique {
+			b = append(b, "Unique()")
+		}
+		if name := strings.Join(append([]string{strings.ToLower(n.name)}, columns...), "_"); idx.Name != name {
+			b = append(b, fmt.Sprintf("StorageKey(%q)", idx.Name))
+		}
+		n.imports["entgo.io/ent/schema/index"] = true
+		n.indexes = append(n.indexes, strings.Join(b, ".\n"))
+	}
+}
+
+// annotations generates the entsql annotations of the node.
+func (i *Importer) annotations(n *node, s *schema.Schema) {
+	var fields []string
+	if n.t.Name != snake(inflect.Pluralize(n.name)) {
+		fields = append(fields, fmt.Sprintf("Table: %q", n.t.Name))
+	}
+	if i.dialect == dialect.MySQL {
+		if c1, c2 := (&schema.Charset{}), (&schema.Charset{}); has(n.t.Attrs, c1) && (!has(s.Attrs, c2) || c1.V != c2.V) {
+			fields = append(fields, fmt.Sprintf("Charset: %q", c1.V))
+		}
+		if c1, c2 := (&schema.Collation{}), (&schema.Collation{}); has(n.t.Attrs, c1) && (!has(s.Attrs, c2) || c1.V != c2.V) {
+			fields = append(fields, fmt.Sprintf("Collation: %q", c1.V))
+		}
+	}
+	if len(fields) > 0 {
+		n.imports["entgo.io/ent/schema"] = true
+		n.imports["entgo.io/ent/dialect/entsql"] = true
+		n.annots = append(n.annots, fmt.Sprintf("entsql.Annotation{\n%s,\n}", strings.Join(fields, ",\n")))
+	}
+	if c := (&schema.Comment{}); has(n.t.Attrs, c) {
+		n.comment = c.Text
+	}
+}
+
+// file renders the schema file of the node.
+func (n *node) file() (*File, error) {
+	var std, pkgs []string
+	for p := range n.imports {
+		if strings.Contains(p, ".") {
+			pkgs = append(pkgs, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(pkgs)
+	var b bytes.Buffer
+	if err := fileTemplate.Execute(&b, map[string]any{
+		"Name":        n.name,
+		"Comment":     n.comment,
+		"Fields":      n.fields,
+		"Edges":       n.edges,
+		"Indexes":     n.indexes,
+		"Annotations": n.annots,
+		"Imports":     [][]string{std, pkgs},
+	}); err != nil {
+		return nil, fmt.Errorf("importer: execute template for %q: %w", n.name, err)
+	}
+	buf, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("importer: format schema %q: %w", n.name, err)
+	}
+	return &File{Name: strings.ToLower(n.name) + ".go", Content: buf}, nil
+}
+
+var fileTemplate = template.Must(template.New("schema").Funcs(template.FuncMap{"lines": func(s string) []string {
+	return strings.Split(s, "\n")
+}}).Parse(`package schema
+
+import (
+{{- range $group := .Imports }}
+	{{- range $group }}
+	"{{ . }}"
+	{{- end }}
+{{ end -}}
+)
+
+// {{ .Name }} holds the schema definition for the {{ .Name }} entity.
+{{- with .Comment }}
+{{- range lines . }}
+// {{ . }}
+{{- end }}
+{{- end }}
+type {{ .Name }} struct {
+	ent.Schema
+}
+
+// Fields of the {{ .Name }}.
+func ({{ .Name }}) Fields() []ent.Field {
+	return []ent.Field{
+		{{- range .Fields }}
+		{{ . }},
+		{{- end }}
+	}
+}
+
+// Edges of the {{ .Name }}.
+func ({{ .Name }}) Edges() []ent.Edge {
+	{{- if .Edges }}
+	return []ent.Edge{
+		{{- range .Edges }}
+		{{ . }},
+		{{- end }}
+	}
+	{{- else }}
+	return nil
+	{{- end }}
+}
+{{- with .Indexes }}
+
+// Indexes of the {{ $.Name }}.
+func ({{ $.Name }}) Indexes() []ent.Index {
+	return []ent.Index{
+		{{- range . }}
+		{{ . }},
+		{{- end }}
+	}
+}
+{{- end }}
+{{- with .Annotations }}
+
+// Annotations of the {{ $.Name }}.
+func ({{ $.Name }}) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		{{- range . }}
+		{{ . }},
+		{{- end }}
+	}
+}
+{{- end }}
+`))
+
+var (
+	pascal   = gen.Funcs["pascal"].(func(string) string)
+	snake    = gen.Funcs["snake"].(func(string) string)
+	singular = gen.Funcs["singular"].(func(string) string)
+)
+
+// fieldName returns the ent field name of the given column.
+func fieldName(column string) string {
+	name := snake(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, column))
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "f_" + name
+	}
+	return name
+}
+
+// uniqueName returns the given name if it is not used by the fields or edges
+// of the node. Otherwise, the alternative name is returned (with a numeric
+// suffix if needed). The returned name is reserved.
+func uniqueName(n *node, name, alt string) string {
+	if n.names[name] {
+		name = alt
+	}
+	for i := 1; n.names[name]; i++ {
+		name = fmt.Sprintf("%s_%d", alt, i)
+	}
+	n.names[name] = true
+	return name
+}
+
+// foreignKey returns the single-column foreign-key of the given column, if exists.
+func foreignKey(t *schema.Table, c *schema.Column) *schema.ForeignKey {
+	for _, fk := range t.ForeignKeys {
+		if len(fk.Columns) == 1 && fk.Columns[0] == c && len(fk.RefColumns) == 1 && isPK(fk.RefTable, fk.RefColumns[0]) {
+			return fk
+		}
+	}
+	return nil
+}
+
+// unique reports if the column has a single-column unique index.
+func unique(t *schema.Table, c *schema.Column) bool {
+	for _, idx := range t.Indexes {
+		if idx.Unique && len(idx.Parts) == 1 && idx.Parts[0].C == c {
+			return true
+		}
+	}
+	return false
+}
+
+// implicitFK reports if the index was created implicitly
+// for a foreign-key constraint (e.g. in MySQL).
+func implicitFK(t *schema.Table, idx *schema.Index) bool {
+	for _, fk := range t.ForeignKeys {
+		if fk.Symbol == idx.Name && len(fk.Columns) == 1 && fk.Columns[0] == idx.Parts[0].C {
+			return true
+		}
+	}
+	return false
+}
+
+func isPK(t *schema.Table, c *schema.Column) bool {
+	return t != nil && t.PrimaryKey != nil && len(t.PrimaryKey.Parts) == 1 && t.PrimaryKey.Parts[0].C == c
+}
+
+// has finds the first attribute with the same type as a, and assigns it to a.
+func has[T schema.Attr](attrs []schema.Attr, a T) bool {
+	for i := range attrs {
+		if v, ok := attrs[i].(T); ok {
+			switch dst := any(a).(type) {
+			case *schema.Comment:
+				*dst = *any(v).(*schema.Comment)
+			case *schema.Charset:
+				*dst = *any(v).(*schema.Charset)
+			case *schema.Collation:
+				*dst = *any(v).(*schema.Collation)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func dialectConst(d string) string {
+	switch d {
+	case dialect.MySQL:
+		return "dialect.MySQL"
+	case dialect.Postgres:
+		return "dialect.Postgres"
+	default:
+		return "dialect.SQLite"
+	}
+}
+
+func contains(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
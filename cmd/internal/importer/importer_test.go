@@ -0,0 +1,150 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport_SQLite(t *testing.T) {
+	db, err := sql.Open(dialect.SQLite, "file:import?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL DEFAULT 'a8m', `email` text NOT NULL, `active` bool NOT NULL DEFAULT true, `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP, `nickname` text NULL)",
+		"CREATE UNIQUE INDEX `users_email` ON `users` (`email`)",
+		"CREATE INDEX `user_name_nickname` ON `users` (`name`, `nickname`)",
+		"CREATE TABLE `pets` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `owner_id` integer NULL, CONSTRAINT `pets_users_pets` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`) ON DELETE SET NULL)",
+		"CREATE TABLE `groups` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `parent_id` integer NULL, CONSTRAINT `groups_parent` FOREIGN KEY (`parent_id`) REFERENCES `groups` (`id`))",
+		"CREATE UNIQUE INDEX `groups_name` ON `groups` (`name`, `parent_id`)",
+		"CREATE TABLE `user_groups` (`user_id` integer NOT NULL, `group_id` integer NOT NULL, PRIMARY KEY (`user_id`, `group_id`), CONSTRAINT `user_groups_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `user_groups_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`) ON DELETE CASCADE)",
+		"CREATE TABLE `events` (`a` integer NOT NULL, `b` integer NOT NULL, PRIMARY KEY (`a`, `b`))",
+	} {
+		_, err := db.ExecContext(context.Background(), stmt)
+		require.NoError(t, err, stmt)
+	}
+	drv, err := sqlite.Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	var logs []string
+	files, err := New(dialect.SQLite, WithLogf(func(format string, args ...any) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	})).Import(s)
+	require.NoError(t, err)
+	require.Equal(t, []string{`skipping table "events": ent types require a single-column primary key`}, logs)
+	require.Len(t, files, 3)
+	contents := make(map[string]string)
+	for _, f := range files {
+		contents[f.Name] = string(f.Content)
+	}
+	require.Equal(t, `package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("id"),
+		field.Text("name").
+			Default("a8m"),
+		field.Text("email").
+			Unique(),
+		field.Bool("active").
+			Default(true),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Text("nickname").
+			Optional(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("pets", Pet.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.SetNull},
+			),
+		edge.To("groups", Group.Type).
+			StorageKey(edge.Table("user_groups"), edge.Columns("user_id", "group_id")),
+	}
+}
+
+// Indexes of the User.
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name", "nickname"),
+	}
+}
+`, contents["user.go"])
+	require.Contains(t, contents["pet.go"], `field.Int("owner_id").
+			Optional(),`)
+	require.Contains(t, contents["pet.go"], `edge.From("owner", User.Type).
+			Ref("pets").
+			Unique().
+			Field("owner_id"),`)
+	require.Contains(t, contents["group.go"], `edge.To("children", Group.Type).
+			From("parent").
+			Unique().
+			Field("parent_id"),`)
+	require.Contains(t, contents["group.go"], `edge.From("users", User.Type).
+			Ref("groups"),`)
+	require.Contains(t, contents["group.go"], `index.Fields("name", "parent_id").
+			Unique().
+			StorageKey("groups_name"),`)
+}
+
+func TestImport_Options(t *testing.T) {
+	users := schema.NewTable("users").AddColumns(
+		schema.NewIntColumn("id", "bigint"),
+		schema.NewColumn("name").SetType(&schema.StringType{T: "varchar", Size: 100}),
+	)
+	users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0]))
+	users.AddAttrs(&schema.Charset{V: "latin1"})
+	pets := schema.NewTable("pet_table").AddColumns(schema.NewIntColumn("id", "bigint"))
+	pets.SetPrimaryKey(schema.NewPrimaryKey(pets.Columns[0]))
+	s := schema.New("test").AddTables(users, pets).AddAttrs(&schema.Charset{V: "utf8mb4"})
+
+	files, err := New(dialect.MySQL, WithExcludeTables("users")).Import(s)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "pettable.go", files[0].Name)
+	require.Contains(t, string(files[0].Content), `entsql.Annotation{
+			Table: "pet_table",
+		},`)
+
+	files, err = New(dialect.MySQL, WithTables("users")).Import(s)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Contains(t, string(files[0].Content), `field.Int("id"),
+		field.String("name").
+			MaxLen(100),`)
+	require.Contains(t, string(files[0].Content), `Charset: "latin1",`)
+}
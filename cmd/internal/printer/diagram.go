@@ -0,0 +1,335 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package printer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"entgo.io/ent/entc/gen"
+)
+
+type (
+	// attr describes a column of a type in the diagram formats.
+	attr struct {
+		Name     string
+		Type     string
+		PK       bool
+		FK       bool
+		UK       bool
+		Optional bool
+		Comment  string
+	}
+	// relation describes an edge between two types. An edge and its
+	// back-reference (inverse) edge are described by one relation.
+	relation struct {
+		From, To     *gen.Type
+		Label        string
+		Rel          gen.Rel
+		FromOptional bool // the type in the "To" side may have no "From" types.
+		ToOptional   bool // the type in the "From" side may have no "To" types.
+		Through      *gen.Type
+	}
+)
+
+// attrs returns the columns of the given type, including
+// the foreign-keys that were not defined as edge-fields.
+func attrs(t *gen.Type) []*attr {
+	var (
+		as []*attr
+		pk = make(map[string]bool)
+	)
+	fields := t.Fields
+	if t.HasOneFieldID() && t.ID != nil {
+		as = append(as, &attr{Name: t.ID.Name, Type: t.ID.Type.String(), PK: true, Comment: t.ID.Comment()})
+	} else {
+		// Composite identifiers are listed first.
+		fields = append([]*gen.Field{}, t.EdgeSchema.ID...)
+		for _, f := range t.EdgeSchema.ID {
+			pk[f.Name] = true
+		}
+		for _, f := range t.Fields {
+			if !pk[f.Name] {
+				fields = append(fields, f)
+			}
+		}
+	}
+	for _, f := range fields {
+		as = append(as, &attr{
+			Name:     f.Name,
+			Type:     f.Type.String(),
+			PK:       pk[f.Name],
+			FK:       f.IsEdgeField(),
+			UK:       f.Unique,
+			Optional: f.Optional,
+			Comment:  f.Comment(),
+		})
+	}
+	for _, fk := range t.ForeignKeys {
+		if fk.UserDefined {
+			continue
+		}
+		as = append(as, &attr{Name: fk.Edge.Rel.Column(), Type: fk.Field.Type.String(), FK: true, UK: fk.Field.Unique, Optional: true})
+	}
+	return as
+}
+
+// keys returns the key markers (PK, FK, UK) of the attribute.
+func (a *attr) keys() []string {
+	var ks []string
+	if a.PK {
+		ks = append(ks, "PK")
+	}
+	if a.FK {
+		ks = append(ks, "FK")
+	}
+	if a.UK && !a.PK {
+		ks = append(ks, "UK")
+	}
+	return ks
+}
+
+// relations returns the relations of the graph in the order of their definition.
+func relations(g *gen.Graph) []*relation {
+	var (
+		rs   []*relation
+		seen = make(map[*gen.Edge]bool)
+	)
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			// Inverse edges are described by their assoc edges.
+			if e.IsInverse() && e.Ref != nil || seen[e] {
+				continue
+			}
+			seen[e] = true
+			r := &relation{From: n, To: e.Type, Label: e.Name, Rel: e.Rel.Type, ToOptional: e.Optional, FromOptional: true, Through: e.Through}
+			if e.IsInverse() {
+				r.From, r.To, r.Rel = e.Type, n, inverseRel(e.Rel.Type)
+			}
+			if ref := e.Ref; ref != nil {
+				seen[ref] = true
+				r.Label += "/" + ref.Name
+				r.FromOptional = ref.Optional
+			}
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// inverseRel returns the relation type from the other side.
+func inverseRel(r gen.Rel) gen.Rel {
+	switch r {
+	case gen.O2M:
+		return gen.M2O
+	case gen.M2O:
+		return gen.O2M
+	default:
+		return r
+	}
+}
+
+// fromMany reports if many "From" types can be connected to one "To" type.
+func (r *relation) fromMany() bool { return r.Rel == gen.M2O || r.Rel == gen.M2M }
+
+// toMany reports if one "From" type can be connected to many "To" types.
+func (r *relation) toMany() bool { return r.Rel == gen.O2M || r.Rel == gen.M2M }
+
+// crowFoot returns the relation in the crow's foot notation that
+// is shared by the Mermaid and PlantUML formats. e.g. "||--o{".
+func (r *relation) crowFoot() string {
+	var from, to string
+	switch {
+	case r.fromMany() && r.FromOptional:
+		from = "}o"
+	case r.fromMany():
+		from = "}|"
+	case r.FromOptional:
+		from = "|o"
+	default:
+		from = "||"
+	}
+	switch {
+	case r.toMany() && r.ToOptional:
+		to = "o{"
+	case r.toMany():
+		to = "|{"
+	case r.ToOptional:
+		to = "o|"
+	default:
+		to = "||"
+	}
+	return from + "--" + to
+}
+
+// label returns the label of the relation.
+func (r *relation) label() string {
+	if r.Through != nil {
+		return fmt.Sprintf("%s (through %s)", r.Label, r.Through.Name)
+	}
+	return r.Label
+}
+
+// annotations returns the sorted names of the given annotations.
+func annotations(ant gen.Annotations) []string {
+	names := make([]string, 0, len(ant))
+	for name := range ant {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// index returns a description of the index. e.g. "unique user_name (name)".
+func index(idx *gen.Index) string {
+	desc := fmt.Sprintf("%s (%s)", idx.Name, strings.Join(idx.Columns, ", "))
+	if idx.Unique {
+		desc = "unique " + desc
+	}
+	return desc
+}
+
+// dot prints the graph in the Graphviz DOT format.
+func (p Config) dot(g *gen.Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph ent {\n")
+	b.WriteString("\tgraph [rankdir=LR];\n")
+	b.WriteString("\tnode [shape=plaintext fontname=\"Helvetica\"];\n")
+	b.WriteString("\tedge [fontname=\"Helvetica\" fontsize=10 dir=both];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "\t%q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\" cellpadding=\"4\">\n", n.Name)
+		fmt.Fprintf(&b, "\t\t<tr><td bgcolor=\"lightgrey\" colspan=\"2\"><b>%s</b></td></tr>\n", html.EscapeString(n.Name))
+		for _, a := range attrs(n) {
+			typ := a.Type
+			if ks := a.keys(); len(ks) > 0 {
+				typ += " " + strings.Join(ks, ",")
+			}
+			if a.Optional {
+				typ += " NULL"
+			}
+			fmt.Fprintf(&b, "\t\t<tr><td align=\"left\">%s</td><td align=\"left\">%s</td></tr>\n", html.EscapeString(a.Name), html.EscapeString(typ))
+		}
+		for _, idx := range n.Indexes {
+			fmt.Fprintf(&b, "\t\t<tr><td align=\"left\" colspan=\"2\"><i>index %s</i></td></tr>\n", html.EscapeString(index(idx)))
+		}
+		if names := annotations(n.Annotations); len(names) > 0 {
+			fmt.Fprintf(&b, "\t\t<tr><td align=\"left\" colspan=\"2\"><i>@%s</i></td></tr>\n", html.EscapeString(strings.Join(names, ", @")))
+		}
+		b.WriteString("\t</table>>];\n")
+	}
+	for _, r := range relations(g) {
+		attrs := []string{
+			fmt.Sprintf("label=%q", r.label()),
+			fmt.Sprintf("arrowtail=%s", dotArrow(r.fromMany(), r.FromOptional)),
+			fmt.Sprintf("arrowhead=%s", dotArrow(r.toMany(), r.ToOptional)),
+		}
+		if r.Through != nil {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&b, "\t%q -> %q [%s];\n", r.From.Name, r.To.Name, strings.Join(attrs, " "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(p, b.String())
+	return err
+}
+
+// dotArrow returns the Graphviz arrow shape for the given cardinality.
+func dotArrow(many, optional bool) string {
+	switch {
+	case many && optional:
+		return "crowodot"
+	case many:
+		return "crowtee"
+	case optional:
+		return "teeodot"
+	default:
+		return "teetee"
+	}
+}
+
+// mermaidType matches the characters that are not allowed in Mermaid attribute types.
+var mermaidType = regexp.MustCompile(`[^\w\-\[\]()]`)
+
+// mermaid prints the graph as a Mermaid entity-relationship diagram.
+func (p Config) mermaid(g *gen.Graph) error {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "\t%s {\n", n.Name)
+		for _, a := range attrs(n) {
+			fmt.Fprintf(&b, "\t\t%s %s", mermaidType.ReplaceAllString(a.Type, "_"), a.Name)
+			if ks := a.keys(); len(ks) > 0 {
+				fmt.Fprintf(&b, " %s", strings.Join(ks, ","))
+			}
+			if c := a.Comment; c != "" {
+				fmt.Fprintf(&b, " %q", strings.ReplaceAll(c, `"`, "'"))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\t}\n")
+		// Mermaid does not support indexes and annotations, and they are printed as comments.
+		for _, idx := range n.Indexes {
+			fmt.Fprintf(&b, "\t%%%% %s index %s\n", n.Name, index(idx))
+		}
+		if names := annotations(n.Annotations); len(names) > 0 {
+			fmt.Fprintf(&b, "\t%%%% %s annotations %s\n", n.Name, strings.Join(names, ", "))
+		}
+	}
+	for _, r := range relations(g) {
+		fmt.Fprintf(&b, "\t%s %s %s : %q\n", r.From.Name, r.crowFoot(), r.To.Name, r.label())
+	}
+	_, err := io.WriteString(p, b.String())
+	return err
+}
+
+// plantuml prints the graph as a PlantUML entity-relationship diagram.
+func (p Config) plantuml(g *gen.Graph) error {
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	b.WriteString("hide circle\n")
+	b.WriteString("skinparam linetype ortho\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "entity %q as %s {\n", n.Name, n.Name)
+		as := attrs(n)
+		for i, a := range as {
+			// Primary keys are separated from the rest of the columns.
+			if i > 0 && as[i-1].PK && !a.PK {
+				b.WriteString("\t--\n")
+			}
+			b.WriteString("\t")
+			if !a.Optional {
+				b.WriteString("* ")
+			}
+			fmt.Fprintf(&b, "%s : %s", a.Name, a.Type)
+			for _, k := range a.keys() {
+				fmt.Fprintf(&b, " <<%s>>", k)
+			}
+			b.WriteString("\n")
+		}
+		if len(n.Indexes) > 0 {
+			b.WriteString("\t.. indexes ..\n")
+			for _, idx := range n.Indexes {
+				fmt.Fprintf(&b, "\t%s\n", index(idx))
+			}
+		}
+		if names := annotations(n.Annotations); len(names) > 0 {
+			b.WriteString("\t.. annotations ..\n")
+			for _, name := range names {
+				fmt.Fprintf(&b, "\t%s\n", name)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	for _, r := range relations(g) {
+		fmt.Fprintf(&b, "%s %s %s : %s\n", r.From.Name, r.crowFoot(), r.To.Name, r.label())
+	}
+	b.WriteString("@enduml\n")
+	_, err := io.WriteString(p, b.String())
+	return err
+}
@@ -0,0 +1,101 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package printer
+
+import (
+	"encoding/json"
+	"html/template"
+
+	"entgo.io/ent/entc/gen"
+)
+
+// html prints the graph as a self-contained HTML page.
+func (p Config) html(g *gen.Graph) error {
+	return pageTmpl.Execute(p, g)
+}
+
+var pageTmpl = template.Must(template.New("page").
+	Funcs(template.FuncMap{
+		"attrs":       attrs,
+		"keys":        func(a *attr) []string { return a.keys() },
+		"relations":   relations,
+		"crowFoot":    func(r *relation) string { return r.crowFoot() },
+		"label":       func(r *relation) string { return r.label() },
+		"annotations": annotations,
+		"json": func(v any) (string, error) {
+			b, err := json.MarshalIndent(v, "", "  ")
+			return string(b), err
+		},
+	}).
+	Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Ent Schema</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+nav a { margin-right: 1em; }
+section { border: 1px solid #d0d7de; border-radius: 6px; padding: 0 1em 1em; margin: 1em 0; }
+table { border-collapse: collapse; margin: 0.5em 0; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 90%; }
+pre { margin: 0; }
+.key { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Ent Schema</h1>
+<nav>{{ range $.Nodes }}<a href="#{{ .Name }}">{{ .Name }}</a>{{ end }}</nav>
+{{- range $n := $.Nodes }}
+<section id="{{ $n.Name }}">
+<h2>{{ $n.Name }}{{ if $n.IsEdgeSchema }} <small>(edge schema)</small>{{ end }}</h2>
+<p>Table: <code>{{ $n.Table }}</code></p>
+<table>
+<tr><th>Column</th><th>Type</th><th>Key</th><th>Optional</th><th>Comment</th></tr>
+{{- range $a := attrs $n }}
+<tr><td>{{ $a.Name }}</td><td><code>{{ $a.Type }}</code></td><td class="key">{{ range $i, $k := keys $a }}{{ if $i }}, {{ end }}{{ $k }}{{ end }}</td><td>{{ $a.Optional }}</td><td>{{ $a.Comment }}</td></tr>
+{{- end }}
+</table>
+{{- with $n.Edges }}
+<table>
+<tr><th>Edge</th><th>Type</th><th>Inverse</th><th>Relation</th><th>Unique</th><th>Optional</th><th>Through</th><th>Comment</th></tr>
+{{- range $e := . }}
+<tr><td>{{ $e.Name }}</td><td><a href="#{{ $e.Type.Name }}">{{ $e.Type.Name }}</a></td><td>{{ $e.Inverse }}</td><td>{{ $e.Rel.Type }}</td><td>{{ $e.Unique }}</td><td>{{ $e.Optional }}</td><td>{{ with $e.Through }}<a href="#{{ .Name }}">{{ .Name }}</a>{{ end }}</td><td>{{ $e.Comment }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with $n.Indexes }}
+<table>
+<tr><th>Index</th><th>Columns</th><th>Unique</th></tr>
+{{- range $idx := . }}
+<tr><td>{{ $idx.Name }}</td><td>{{ range $i, $c := $idx.Columns }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}</td><td>{{ $idx.Unique }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with $names := annotations $n.Annotations }}
+<table>
+<tr><th>Annotation</th><th>Value</th></tr>
+{{- range $name := $names }}
+<tr><td>{{ $name }}</td><td><pre>{{ json (index $n.Annotations $name) }}</pre></td></tr>
+{{- end }}
+</table>
+{{- end }}
+</section>
+{{- end }}
+{{- with relations $ }}
+<section id="relations">
+<h2>Relations</h2>
+<table>
+<tr><th>From</th><th>Cardinality</th><th>To</th><th>Edge</th></tr>
+{{- range $r := . }}
+<tr><td><a href="#{{ $r.From.Name }}">{{ $r.From.Name }}</a></td><td><code>{{ crowFoot $r }}</code></td><td><a href="#{{ $r.To.Name }}">{{ $r.To.Name }}</a></td><td>{{ label $r }}</td></tr>
+{{- end }}
+</table>
+</section>
+{{- end }}
+</body>
+</html>
+`))
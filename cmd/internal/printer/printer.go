@@ -11,11 +11,26 @@ import (
 	"strconv"
 	"strings"
 
+	"entgo.io/ent/entc/export"
 	"entgo.io/ent/entc/gen"
 
 	"github.com/olekukonko/tablewriter"
 )
 
+// Output formats supported by FprintFormat.
+const (
+	FormatTable    = "table"
+	FormatDOT      = "dot"
+	FormatMermaid  = "mermaid"
+	FormatPlantUML = "plantuml"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+)
+
+// Formats lists the output formats supported by FprintFormat.
+var Formats = []string{FormatTable, FormatDOT, FormatMermaid, FormatPlantUML, FormatHTML, FormatJSON, FormatYAML}
+
 // A Config controls the output of Fprint.
 type Config struct {
 	io.Writer
@@ -33,6 +48,30 @@ func Fprint(w io.Writer, g *gen.Graph) {
 	Config{Writer: w}.Print(g)
 }
 
+// FprintFormat prints a description of the graph in the given format to the given writer.
+func FprintFormat(w io.Writer, g *gen.Graph, format string) error {
+	p := Config{Writer: w}
+	switch format {
+	case FormatTable:
+		p.Print(g)
+		return nil
+	case FormatDOT:
+		return p.dot(g)
+	case FormatMermaid:
+		return p.mermaid(g)
+	case FormatPlantUML:
+		return p.plantuml(g)
+	case FormatHTML:
+		return p.html(g)
+	case FormatJSON:
+		return export.NewGraph(g).WriteJSON(w)
+	case FormatYAML:
+		return export.NewGraph(g).WriteYAML(w)
+	default:
+		return fmt.Errorf("printer: unsupported format %q", format)
+	}
+}
+
 // node returns description of a type. The format of the description is:
 //
 //	Type:
@@ -175,3 +175,89 @@ Group:
 		assert.Equal(t, tt.out, "\n"+b.String())
 	}
 }
+
+func TestPrinter_FprintFormat(t *testing.T) {
+	user := &gen.Type{
+		Name: "User",
+		ID:   &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+		Fields: []*gen.Field{
+			{Name: "name", Type: &field.TypeInfo{Type: field.TypeString}, Unique: true},
+			{Name: "created_at", Type: &field.TypeInfo{Type: field.TypeTime}, Optional: true},
+		},
+		Annotations: gen.Annotations{"EntSQL": map[string]string{"table": "users"}},
+	}
+	pet := &gen.Type{
+		Name: "Pet",
+		ID:   &gen.Field{Name: "id", Type: &field.TypeInfo{Type: field.TypeInt}},
+		Fields: []*gen.Field{
+			{Name: "name", Type: &field.TypeInfo{Type: field.TypeString}},
+		},
+		Indexes: []*gen.Index{{Name: "pet_name", Columns: []string{"name"}}},
+	}
+	pets := &gen.Edge{Name: "pets", Type: pet, Rel: gen.Relation{Type: gen.O2M}, Optional: true}
+	owner := &gen.Edge{Name: "owner", Type: user, Inverse: "pets", Unique: true, Rel: gen.Relation{Type: gen.M2O}, Ref: pets}
+	pets.Ref = owner
+	user.Edges = []*gen.Edge{pets, {Name: "friends", Type: user, Rel: gen.Relation{Type: gen.M2M}, Optional: true}}
+	pet.Edges = []*gen.Edge{owner}
+	g := &gen.Graph{Nodes: []*gen.Type{user, pet}}
+
+	b := &strings.Builder{}
+	assert.NoError(t, FprintFormat(b, g, FormatMermaid))
+	assert.Equal(t, `erDiagram
+	User {
+		int id PK
+		string name UK
+		time_Time created_at
+	}
+	%% User annotations EntSQL
+	Pet {
+		int id PK
+		string name
+	}
+	%% Pet index pet_name (name)
+	User ||--o{ Pet : "pets/owner"
+	User }o--o{ User : "friends"
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, FprintFormat(b, g, FormatPlantUML))
+	assert.Equal(t, `@startuml
+hide circle
+skinparam linetype ortho
+entity "User" as User {
+	* id : int <<PK>>
+	--
+	* name : string <<UK>>
+	created_at : time.Time
+	.. annotations ..
+	EntSQL
+}
+entity "Pet" as Pet {
+	* id : int <<PK>>
+	--
+	* name : string
+	.. indexes ..
+	pet_name (name)
+}
+User ||--o{ Pet : pets/owner
+User }o--o{ User : friends
+@enduml
+`, b.String())
+
+	b.Reset()
+	assert.NoError(t, FprintFormat(b, g, FormatDOT))
+	assert.Contains(t, b.String(), "digraph ent {\n")
+	assert.Contains(t, b.String(), `<tr><td align="left">created_at</td><td align="left">time.Time NULL</td></tr>`)
+	assert.Contains(t, b.String(), `<tr><td align="left" colspan="2"><i>index pet_name (name)</i></td></tr>`)
+	assert.Contains(t, b.String(), `"User" -> "Pet" [label="pets/owner" arrowtail=teetee arrowhead=crowodot];`)
+	assert.Contains(t, b.String(), `"User" -> "User" [label="friends" arrowtail=crowodot arrowhead=crowodot];`)
+
+	b.Reset()
+	assert.NoError(t, FprintFormat(b, g, FormatHTML))
+	assert.Contains(t, b.String(), `<section id="Pet">`)
+	assert.Contains(t, b.String(), `<tr><td>pet_name</td><td>name</td><td>false</td></tr>`)
+	assert.Contains(t, b.String(), `<td><code>||--o{</code></td>`)
+	assert.Contains(t, b.String(), `&#34;table&#34;: &#34;users&#34;`)
+
+	assert.EqualError(t, FprintFormat(b, g, "svg"), `printer: unsupported format "svg"`)
+}
@@ -139,6 +139,321 @@ type Annotation struct {
 	//	}
 	//
 	Checks map[string]string `json:"checks,omitempty"`
+
+	// Exclusions defines the EXCLUDE constraints of the table. Works only in Postgres,
+	// and guarantees that no two rows are matched by all operators of the constraint.
+	// For example, preventing double bookings of the same room:
+	//
+	//	entsql.Annotation{
+	//		Exclusions: []*entsql.Exclusion{
+	//			{
+	//				Name: "no_double_booking",
+	//				Elements: []*entsql.ExclusionElement{
+	//					{Column: "room_id", Op: "="},
+	//					{Column: "during", Op: "&&"},
+	//				},
+	//			},
+	//		},
+	//	}
+	//
+	//	CONSTRAINT "no_double_booking" EXCLUDE USING gist ("room_id" WITH =, "during" WITH &&)
+	//
+	Exclusions []*Exclusion `json:"exclusions,omitempty"`
+
+	// Generated defines a generated (computed) column, whose value is computed by
+	// the database from the given expression. Generated fields are read-only in the
+	// generated code, and cannot be set on creation or update. For example:
+	//
+	//	entsql.Annotation{
+	//		Generated: &entsql.GeneratedColumn{
+	//			Expr: "first_name || ' ' || last_name",
+	//		},
+	//	}
+	//
+	Generated *GeneratedColumn `json:"generated,omitempty"`
+
+	// NativeEnum defines the name of a native enum type for storing an enum field
+	// in PostgreSQL, instead of the default varchar column. The type is created
+	// (and its values are kept in sync) by the migration engine. For example:
+	//
+	//	entsql.Annotation{
+	//		NativeEnum: "user_status",
+	//	}
+	//
+	//	CREATE TYPE "user_status" AS ENUM ('active', 'blocked')
+	//
+	// Note, this option is ignored by MySQL, as enum fields are always
+	// stored there as ENUM columns, and by SQLite that has no enum types.
+	NativeEnum string `json:"native_enum,omitempty"`
+
+	// Partition defines the partitioning of the table, and its initial partitions.
+	// Works only in PostgreSQL and MySQL. For example:
+	//
+	//	entsql.Annotation{
+	//		Partition: &entsql.Partition{
+	//			Type:    entsql.PartitionRange,
+	//			Columns: []string{"create_time"},
+	//		},
+	//	}
+	//
+	//	CREATE TABLE "events" (...) PARTITION BY RANGE ("create_time")
+	//
+	Partition *Partition `json:"partition,omitempty"`
+
+	// Triggers defines the database triggers of the table. The triggers are created,
+	// updated and dropped by the migration engine, and it never changes triggers that
+	// were not created by it. For example, maintaining a denormalized counter:
+	//
+	//	entsql.Annotation{
+	//		Triggers: []*entsql.Trigger{
+	//			{
+	//				Name:   "posts_count",
+	//				Timing: entsql.TriggerAfter,
+	//				Events: []entsql.TriggerEvent{entsql.TriggerInsert},
+	//				Body:   "UPDATE users SET posts_count = posts_count + 1 WHERE id = NEW.user_id;",
+	//			},
+	//		},
+	//	}
+	//
+	Triggers []*Trigger `json:"triggers,omitempty"`
+
+	// Functions defines stored functions that are created by the migration engine.
+	// Works only in PostgreSQL, and mostly used for sharing the same trigger function
+	// between multiple tables. For example:
+	//
+	//	entsql.Annotation{
+	//		Functions: []*entsql.Function{
+	//			{
+	//				Name:    "set_update_time",
+	//				Returns: "trigger",
+	//				Body:    "BEGIN NEW.update_time = now(); RETURN NEW; END",
+	//			},
+	//		},
+	//	}
+	//
+	Functions []*Function `json:"functions,omitempty"`
+}
+
+// GeneratedColumn describes the expression and the storage type of a generated column.
+type GeneratedColumn struct {
+	// Expr defines the generation expression of the column.
+	Expr string `json:"expr,omitempty"`
+
+	// Exprs defines the generation expression of the column per dialect.
+	// It gets precedence over Expr for the configured dialects.
+	//
+	//	entsql.GeneratedColumn{
+	//		Exprs: map[string]string{
+	//			dialect.MySQL:    "CONCAT(first_name, ' ', last_name)",
+	//			dialect.Postgres: "first_name || ' ' || last_name",
+	//		},
+	//	}
+	//
+	Exprs map[string]string `json:"exprs,omitempty"`
+
+	// Type defines if the column value is stored on write (STORED), or computed on read
+	// (VIRTUAL). Defaults to STORED, as PostgreSQL supports only stored generated columns.
+	Type GeneratedType `json:"type,omitempty"`
+}
+
+// GeneratedType defines the storage type of a generated column.
+type GeneratedType string
+
+// Storage types of generated columns.
+const (
+	Stored  GeneratedType = "STORED"
+	Virtual GeneratedType = "VIRTUAL"
+)
+
+// Expression returns the generation expression of the column for the given
+// dialect, or an empty string if the column is not generated in this dialect.
+func (g *GeneratedColumn) Expression(dialect string) string {
+	if x, ok := g.Exprs[dialect]; ok {
+		return x
+	}
+	return g.Expr
+}
+
+// Exclusion describes a Postgres EXCLUDE constraint.
+type Exclusion struct {
+	// Name of the constraint.
+	Name string `json:"name,omitempty"`
+
+	// Using defines the index method of the constraint. Defaults to "gist". Note
+	// that using scalar columns (e.g. integers) with the "=" operator in a GiST
+	// constraint requires the btree_gist extension to be installed.
+	Using string `json:"using,omitempty"`
+
+	// Elements of the constraint.
+	Elements []*ExclusionElement `json:"elements,omitempty"`
+
+	// Where defines the predicate of a partial constraint. It should be written in
+	// its normal form, as returned by Postgres, to avoid unnecessary changes in
+	// migrations. For example, "(NOT cancelled)".
+	Where string `json:"where,omitempty"`
+}
+
+// ExclusionElement describes an element of an EXCLUDE constraint,
+// and the operator used to compare it with other rows.
+type ExclusionElement struct {
+	// Column of the element. Mutually exclusive with Expr.
+	Column string `json:"column,omitempty"`
+
+	// Expr defines an expression element. e.g. "lower(email)".
+	Expr string `json:"expr,omitempty"`
+
+	// Op is the operator of the element. e.g. "=" or "&&".
+	Op string `json:"op,omitempty"`
+}
+
+// PartitionType defines the partitioning strategy of a table.
+type PartitionType string
+
+// Partitioning strategies.
+const (
+	PartitionRange PartitionType = "RANGE"
+	PartitionList  PartitionType = "LIST"
+	PartitionHash  PartitionType = "HASH"
+)
+
+// Partition describes the partitioning of a table. Note that both PostgreSQL
+// and MySQL require the primary key and the unique indexes of a partitioned
+// table to contain the columns of the partition key. Hence, these columns are
+// added to them by the migration engine.
+type Partition struct {
+	// Type (strategy) of the partitioning. Can be one of: RANGE, LIST or HASH.
+	Type PartitionType `json:"type,omitempty"`
+
+	// Columns of the partition key.
+	Columns []string `json:"columns,omitempty"`
+
+	// Count defines the number of partitions in HASH partitioning.
+	Count int `json:"count,omitempty"`
+
+	// Partitions defines the partitions of RANGE and LIST partitioning
+	// that are created with the table. Partitions that do not exist in
+	// the database are added by the migration, but are never dropped.
+	Partitions []*PartitionBound `json:"partitions,omitempty"`
+}
+
+// PartitionBound describes a partition of a RANGE or LIST partitioned table.
+// Values and bounds are SQL literals, and they are written to the migration
+// as-is. For example, "'2023-01-01'" or "10".
+type PartitionBound struct {
+	// Name of the partition. In PostgreSQL, partitions are tables,
+	// and their names should be unique in the schema.
+	Name string `json:"name,omitempty"`
+
+	// Values of a LIST partition.
+	Values []string `json:"values,omitempty"`
+
+	// From and To define the inclusive lower bound and the exclusive upper
+	// bound of a RANGE partition. An empty bound stands for an unbounded
+	// range (MINVALUE or MAXVALUE). Note, MySQL partitions are defined only
+	// by their upper bound, as their ranges are contiguous.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// TriggerTiming defines when a trigger is fired.
+type TriggerTiming string
+
+// Trigger timings.
+const (
+	TriggerBefore    TriggerTiming = "BEFORE"
+	TriggerAfter     TriggerTiming = "AFTER"
+	TriggerInsteadOf TriggerTiming = "INSTEAD OF"
+)
+
+// TriggerEvent defines the event that fires a trigger.
+type TriggerEvent string
+
+// Trigger events.
+const (
+	TriggerInsert TriggerEvent = "INSERT"
+	TriggerUpdate TriggerEvent = "UPDATE"
+	TriggerDelete TriggerEvent = "DELETE"
+)
+
+// Trigger describes a row-level trigger of a table. Note that MySQL and SQLite
+// triggers are fired by a single event. Hence, triggers with multiple events are
+// created there as multiple triggers, suffixed with their event. For example,
+// "set_update_time_insert" and "set_update_time_update".
+type Trigger struct {
+	// Name of the trigger. Trigger names should be unique in the schema
+	// in MySQL and SQLite, and unique per table in PostgreSQL.
+	Name string `json:"name,omitempty"`
+
+	// Timing of the trigger. Can be one of: BEFORE, AFTER or INSTEAD OF.
+	Timing TriggerTiming `json:"timing,omitempty"`
+
+	// Events that fire the trigger.
+	Events []TriggerEvent `json:"events,omitempty"`
+
+	// When defines an optional condition for firing the trigger.
+	// For example, "NEW.status <> OLD.status".
+	When string `json:"when,omitempty"`
+
+	// Body defines the statements that are executed by the trigger. In PostgreSQL,
+	// the body is the PL/pgSQL code of the trigger function that is created with the
+	// trigger (and named after it), and it should end with a RETURN statement. In
+	// MySQL and SQLite, the body is executed as-is in a BEGIN ... END block.
+	Body string `json:"body,omitempty"`
+
+	// Bodies defines the body of the trigger per dialect. It gets precedence over
+	// Body for the configured dialects. Triggers without a body for the dialect
+	// are not created. For example:
+	//
+	//	entsql.Trigger{
+	//		Bodies: map[string]string{
+	//			dialect.MySQL:    "SET NEW.update_time = NOW();",
+	//			dialect.Postgres: "NEW.update_time = now(); RETURN NEW;",
+	//		},
+	//	}
+	//
+	Bodies map[string]string `json:"bodies,omitempty"`
+
+	// Function defines the name of a stored function that is executed by the
+	// trigger, instead of its body. Works only in PostgreSQL. See Annotation.Functions
+	// for declaring such functions.
+	Function string `json:"function,omitempty"`
+}
+
+// Definition returns the body of the trigger for the given dialect,
+// or an empty string if the trigger is not defined in this dialect.
+func (t *Trigger) Definition(dialect string) string {
+	if b, ok := t.Bodies[dialect]; ok {
+		return b
+	}
+	return t.Body
+}
+
+// Function describes a stored function. Functions are created in the schema
+// by the migration engine, and dropped when they are no longer declared.
+type Function struct {
+	// Name of the function. Functions with the same name that are declared
+	// by multiple schemas must have the same definition.
+	Name string `json:"name,omitempty"`
+
+	// Args defines the arguments of the function. e.g. "a integer, b integer".
+	Args string `json:"args,omitempty"`
+
+	// Returns defines the return type of the function. e.g. "integer" or "trigger".
+	Returns string `json:"returns,omitempty"`
+
+	// Language of the function body. Defaults to "plpgsql".
+	Language string `json:"language,omitempty"`
+
+	// Body of the function, as it is written in the CREATE FUNCTION statement.
+	Body string `json:"body,omitempty"`
+}
+
+// IndexMethod returns the index method of the constraint.
+func (e *Exclusion) IndexMethod() string {
+	if e.Using != "" {
+		return e.Using
+	}
+	return "gist"
 }
 
 // Name describes the annotation name.
@@ -170,6 +485,63 @@ func Checks(c map[string]string) *Annotation {
 	}
 }
 
+// Exclude defines a named EXCLUDE constraint on the table. Works only in Postgres.
+//
+//	func (Booking) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.Exclude("no_double_booking",
+//				entsql.ExcludeWith("room_id", "="),
+//				entsql.ExcludeWith("during", "&&"),
+//			),
+//		}
+//	}
+func Exclude(name string, elems ...*ExclusionElement) *Annotation {
+	return &Annotation{
+		Exclusions: []*Exclusion{
+			{Name: name, Elements: elems},
+		},
+	}
+}
+
+// PartitionBy defines the partitioning of the table by the given columns.
+// See, Annotation.Partition for full doc.
+//
+//	func (Event) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.PartitionBy(entsql.PartitionRange, "create_time"),
+//		}
+//	}
+func PartitionBy(t PartitionType, columns ...string) *Annotation {
+	return &Annotation{
+		Partition: &Partition{Type: t, Columns: columns},
+	}
+}
+
+// Triggers defines the database triggers of the table.
+// See, Annotation.Triggers for full doc.
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			entsql.Triggers(&entsql.Trigger{
+//				Name:   "set_update_time",
+//				Timing: entsql.TriggerBefore,
+//				Events: []entsql.TriggerEvent{entsql.TriggerUpdate},
+//				Body:   "NEW.update_time = now(); RETURN NEW;",
+//			}),
+//		}
+//	}
+func Triggers(ts ...*Trigger) *Annotation {
+	return &Annotation{
+		Triggers: ts,
+	}
+}
+
+// ExcludeWith returns an EXCLUDE constraint element for
+// the given column, that is compared using the operator.
+func ExcludeWith(column, op string) *ExclusionElement {
+	return &ExclusionElement{Column: column, Op: op}
+}
+
 // Default specifies a literal default value of a column. Note that using
 // this option overrides the default behavior of the code-generation.
 //
@@ -229,6 +601,65 @@ func WithComments(b bool) *Annotation {
 	}
 }
 
+// GeneratedAs defines a stored generated column that is computed by
+// the database from the given expression. For example:
+//
+//	field.String("full_name").
+//		Annotations(
+//			entsql.GeneratedAs("first_name || ' ' || last_name"),
+//		)
+//
+//	`full_name` text GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED
+func GeneratedAs(expr string) *Annotation {
+	return &Annotation{
+		Generated: &GeneratedColumn{Expr: expr, Type: Stored},
+	}
+}
+
+// GeneratedAsVirtual is like GeneratedAs, but defines a virtual generated column
+// that is computed on read. Note, PostgreSQL supports only stored generated columns.
+//
+//	field.Int("total").
+//		Annotations(
+//			entsql.GeneratedAsVirtual("price * quantity"),
+//		)
+func GeneratedAsVirtual(expr string) *Annotation {
+	return &Annotation{
+		Generated: &GeneratedColumn{Expr: expr, Type: Virtual},
+	}
+}
+
+// GeneratedAsExprs defines a stored generated column with a generation
+// expression per dialect. See, GeneratedAs for full doc.
+//
+//	field.String("full_name").
+//		Annotations(
+//			entsql.GeneratedAsExprs(map[string]string{
+//				dialect.MySQL:    "CONCAT(first_name, ' ', last_name)",
+//				dialect.Postgres: "first_name || ' ' || last_name",
+//			}),
+//		)
+func GeneratedAsExprs(exprs map[string]string) *Annotation {
+	return &Annotation{
+		Generated: &GeneratedColumn{Exprs: exprs, Type: Stored},
+	}
+}
+
+// NativeEnum stores the enum field in PostgreSQL using a native
+// enum type with the given name. See, Annotation.NativeEnum for
+// full doc.
+//
+//	field.Enum("status").
+//		Values("active", "blocked").
+//		Annotations(
+//			entsql.NativeEnum("user_status"),
+//		)
+func NativeEnum(name string) *Annotation {
+	return &Annotation{
+		NativeEnum: name,
+	}
+}
+
 // Merge implements the schema.Merger interface.
 func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	var ant Annotation
@@ -291,6 +722,56 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 			a.Checks[name] = check
 		}
 	}
+	if g := ant.Generated; g != nil {
+		a.Generated = g
+	}
+	if e := ant.NativeEnum; e != "" {
+		a.NativeEnum = e
+	}
+	if p := ant.Partition; p != nil {
+		a.Partition = p
+	}
+	if len(ant.Exclusions) > 0 {
+		exclusions := make([]*Exclusion, 0, len(a.Exclusions)+len(ant.Exclusions))
+	Exclusions:
+		for _, e1 := range a.Exclusions {
+			// Constraints with the same name are overridden.
+			for _, e2 := range ant.Exclusions {
+				if e1.Name == e2.Name {
+					continue Exclusions
+				}
+			}
+			exclusions = append(exclusions, e1)
+		}
+		a.Exclusions = append(exclusions, ant.Exclusions...)
+	}
+	if len(ant.Triggers) > 0 {
+		triggers := make([]*Trigger, 0, len(a.Triggers)+len(ant.Triggers))
+	Triggers:
+		for _, t1 := range a.Triggers {
+			// Triggers with the same name are overridden.
+			for _, t2 := range ant.Triggers {
+				if t1.Name == t2.Name {
+					continue Triggers
+				}
+			}
+			triggers = append(triggers, t1)
+		}
+		a.Triggers = append(triggers, ant.Triggers...)
+	}
+	if len(ant.Functions) > 0 {
+		functions := make([]*Function, 0, len(a.Functions)+len(ant.Functions))
+	Functions:
+		for _, f1 := range a.Functions {
+			for _, f2 := range ant.Functions {
+				if f1.Name == f2.Name {
+					continue Functions
+				}
+			}
+			functions = append(functions, f1)
+		}
+		a.Functions = append(functions, ant.Functions...)
+	}
 	return a
 }
 
@@ -436,6 +917,47 @@ type IndexAnnotation struct {
 	//		)
 	//	CREATE INDEX "table_a" ON "table"("a") WHERE (b AND c > 0)
 	Where string
+
+	// FullText defines a full-text index that can be queried using the generated
+	// search predicates. In MySQL, it is created as a FULLTEXT index, in PostgreSQL
+	// as a GIN index over the "to_tsvector" of its columns, and in SQLite as an FTS5
+	// virtual table that is kept in sync with the table using triggers.
+	//
+	//	index.Fields("title", "body").
+	//		Annotations(
+	//			entsql.FullText(),
+	//		)
+	//
+	//	CREATE FULLTEXT INDEX `post_title_body` ON `posts`(`title`, `body`)
+	//
+	FullText bool
+
+	// FullTextConfig defines the text search configuration of a full-text
+	// index in PostgreSQL (e.g. "english"). Defaults to "simple".
+	//
+	//	index.Fields("title").
+	//		Annotations(
+	//			entsql.FullText(),
+	//			entsql.FullTextConfig("english"),
+	//		)
+	//
+	//	CREATE INDEX "post_title" ON "posts" USING GIN (to_tsvector('english', "title"))
+	//
+	FullTextConfig string
+
+	// Spatial defines a spatial index on a geometry column (field.Point or
+	// field.Geometry), that is used by the spatial predicates. In MySQL, it is
+	// created as a SPATIAL index, and in PostgreSQL as a GiST index. Spatial
+	// indexes are not supported in SQLite, and therefore, are skipped.
+	//
+	//	index.Fields("location").
+	//		Annotations(
+	//			entsql.Spatial(),
+	//		)
+	//
+	//	CREATE SPATIAL INDEX `place_location` ON `places`(`location`)
+	//
+	Spatial bool
 }
 
 // Prefix returns a new index annotation with a single string column index.
@@ -591,6 +1113,49 @@ func IndexWhere(pred string) *IndexAnnotation {
 	return &IndexAnnotation{Where: pred}
 }
 
+// FullText defines a full-text index that can be queried using the generated
+// search predicates. In MySQL, the following annotation maps to:
+//
+//	index.Fields("title", "body").
+//		Annotations(
+//			entsql.FullText(),
+//		)
+//
+//	CREATE FULLTEXT INDEX `post_title_body` ON `posts`(`title`, `body`)
+func FullText() *IndexAnnotation {
+	return &IndexAnnotation{FullText: true}
+}
+
+// FullTextConfig defines the text search configuration of a full-text index in
+// PostgreSQL (e.g. "english"). Defaults to "simple". For example:
+//
+//	index.Fields("title").
+//		Annotations(
+//			entsql.FullText(),
+//			entsql.FullTextConfig("english"),
+//		)
+//
+//	CREATE INDEX "post_title" ON "posts" USING GIN (to_tsvector('english', "title"))
+func FullTextConfig(config string) *IndexAnnotation {
+	return &IndexAnnotation{FullTextConfig: config}
+}
+
+// Spatial defines a spatial index on a geometry column. In MySQL,
+// the following annotation maps to:
+//
+//	index.Fields("location").
+//		Annotations(
+//			entsql.Spatial(),
+//		)
+//
+//	CREATE SPATIAL INDEX `place_location` ON `places`(`location`)
+//
+// Note that MySQL requires the indexed column to be NOT NULL, and it
+// uses the index only if the column is restricted to a single SRID.
+func Spatial() *IndexAnnotation {
+	return &IndexAnnotation{Spatial: true}
+}
+
 // Name describes the annotation name.
 func (IndexAnnotation) Name() string {
 	return "EntSQLIndexes"
@@ -659,6 +1224,15 @@ func (a IndexAnnotation) Merge(other schema.Annotation) schema.Annotation {
 	if ant.Where != "" {
 		a.Where = ant.Where
 	}
+	if ant.FullText {
+		a.FullText = ant.FullText
+	}
+	if ant.FullTextConfig != "" {
+		a.FullTextConfig = ant.FullTextConfig
+	}
+	if ant.Spatial {
+		a.Spatial = ant.Spatial
+	}
 	return a
 }
 
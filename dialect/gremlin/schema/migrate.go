@@ -0,0 +1,191 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/gremlin"
+	"entgo.io/ent/dialect/gremlin/graph/dsl"
+)
+
+// DefaultGraph is the default name of the graph instance that
+// is bound in the Gremlin server (e.g. JanusGraph server).
+const DefaultGraph = "graph"
+
+// MigrateOption allows configuring Migrate using functional arguments.
+type MigrateOption func(*Migrate)
+
+// WithGraph sets the name of the graph instance the management
+// script is executed on. Defaults to "graph".
+func WithGraph(name string) MigrateOption {
+	return func(m *Migrate) {
+		m.graph = name
+	}
+}
+
+// WithIndexes enables creating composite indexes for indexes and unique
+// properties defined in the schema. Defaults to true.
+func WithIndexes(b bool) MigrateOption {
+	return func(m *Migrate) {
+		m.withIndexes = b
+	}
+}
+
+// WithMultiplicity enables creating edge labels with their multiplicity
+// constraints. If disabled, all edge labels are created as MULTI.
+// Defaults to true.
+func WithMultiplicity(b bool) MigrateOption {
+	return func(m *Migrate) {
+		m.withMultiplicity = b
+	}
+}
+
+// Migrate runs the migration logic for the Gremlin dialect.
+type Migrate struct {
+	drv              dialect.Driver
+	graph            string // graph instance name
+	withIndexes      bool   // create composite indexes
+	withMultiplicity bool   // create edge labels with multiplicity
+}
+
+// NewMigrate creates a migration structure for the given Gremlin driver.
+func NewMigrate(drv dialect.Driver, opts ...MigrateOption) (*Migrate, error) {
+	m := &Migrate{drv: drv, graph: DefaultGraph, withIndexes: true, withMultiplicity: true}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if d := drv.Dialect(); d != dialect.Gremlin {
+		return nil, fmt.Errorf("gremlin/schema: unsupported dialect %q", d)
+	}
+	if m.graph == "" {
+		return nil, fmt.Errorf("gremlin/schema: missing graph name")
+	}
+	return m, nil
+}
+
+// Create creates all schema resources in the graph. It works in an "append-only"
+// mode, which means, it only creates property keys, labels and indexes that do not
+// exist in the graph, and does not modify or drop existing ones.
+//
+// Note that JanusGraph enables indexes that are created in the same management
+// transaction as their property keys. Indexes that are added on existing keys
+// are installed in REGISTERED state, and need to be reindexed and enabled manually.
+func (m *Migrate) Create(ctx context.Context, labels ...*VertexLabel) error {
+	script, err := m.script(labels)
+	if err != nil {
+		return err
+	}
+	res := &gremlin.Response{}
+	if err := m.drv.Exec(ctx, script, dsl.Bindings{}, res); err != nil {
+		return fmt.Errorf("gremlin/schema: execute management script: %w", err)
+	}
+	if err := res.Err(); err != nil {
+		return fmt.Errorf("gremlin/schema: execute management script: %w", err)
+	}
+	return nil
+}
+
+// script builds the management script for the given vertex labels.
+func (m *Migrate) script(labels []*VertexLabel) (string, error) {
+	var (
+		b     strings.Builder
+		keys  []*Property
+		seen  = make(map[string]*Property)
+		edges = make(map[string]struct{})
+	)
+	for _, v := range labels {
+		for _, p := range v.Properties {
+			prev, ok := seen[p.Name]
+			if !ok {
+				seen[p.Name] = p
+				keys = append(keys, p)
+				continue
+			}
+			if prev.DataType() != p.DataType() || prev.cardinality() != p.cardinality() {
+				return "", fmt.Errorf("gremlin/schema: conflicting definitions for property key %q: %s(%s) and %s(%s)", p.Name, prev.DataType(), prev.cardinality(), p.DataType(), p.cardinality())
+			}
+		}
+	}
+	fmt.Fprintf(&b, "mgmt = %s.openManagement()\n", m.graph)
+	for _, p := range keys {
+		fmt.Fprintf(&b, "if (!mgmt.containsPropertyKey(%[1]s)) mgmt.makePropertyKey(%[1]s).dataType(%[2]s.class).cardinality(Cardinality.%[3]s).make()\n", quote(p.Name), p.DataType(), p.cardinality())
+	}
+	for _, v := range labels {
+		fmt.Fprintf(&b, "if (!mgmt.containsVertexLabel(%[1]s)) mgmt.makeVertexLabel(%[1]s).make()\n", quote(v.Name))
+	}
+	for _, v := range labels {
+		for _, e := range v.Edges {
+			if _, ok := edges[e.Name]; ok {
+				continue
+			}
+			edges[e.Name] = struct{}{}
+			mul := Multi
+			if m.withMultiplicity {
+				mul = e.multiplicity()
+			}
+			fmt.Fprintf(&b, "if (!mgmt.containsEdgeLabel(%[1]s)) mgmt.makeEdgeLabel(%[1]s).multiplicity(%[2]s).make()\n", quote(e.Name), mul)
+		}
+	}
+	if m.withIndexes {
+		for _, v := range labels {
+			for _, idx := range indexes(v) {
+				m.index(&b, v, idx)
+			}
+		}
+	}
+	b.WriteString("mgmt.commit()\n")
+	return b.String(), nil
+}
+
+// index writes the composite index definition to the builder.
+func (*Migrate) index(b *strings.Builder, v *VertexLabel, idx *Index) {
+	fmt.Fprintf(b, "if (!mgmt.containsGraphIndex(%s)) {\n", quote(idx.Name))
+	fmt.Fprintf(b, "\tidx = mgmt.buildIndex(%s, Vertex.class)", quote(idx.Name))
+	for _, p := range idx.Properties {
+		fmt.Fprintf(b, ".addKey(mgmt.getPropertyKey(%s))", quote(p.Name))
+	}
+	fmt.Fprintf(b, ".indexOnly(mgmt.getVertexLabel(%s))", quote(v.Name))
+	if idx.Unique {
+		b.WriteString(".unique()")
+	}
+	b.WriteString(".buildCompositeIndex()\n")
+	if idx.Unique {
+		b.WriteString("\tmgmt.setConsistency(idx, ConsistencyModifier.LOCK)\n")
+	}
+	b.WriteString("}\n")
+}
+
+// indexes returns the composite indexes of the vertex label, including
+// the ones that are implicitly defined by unique properties.
+func indexes(v *VertexLabel) []*Index {
+	var (
+		idx  = make([]*Index, 0, len(v.Indexes))
+		seen = make(map[string]struct{})
+	)
+	for _, p := range v.Properties {
+		if !p.Unique {
+			continue
+		}
+		name := fmt.Sprintf("%s_%s", v.Name, p.Name)
+		seen[name] = struct{}{}
+		idx = append(idx, &Index{Name: name, Unique: true, Properties: []*Property{p}})
+	}
+	for _, i := range v.Indexes {
+		if _, ok := seen[i.Name]; !ok {
+			seen[i.Name] = struct{}{}
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// quote returns a single-quoted Groovy string literal of s.
+func quote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
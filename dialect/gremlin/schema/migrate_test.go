@@ -0,0 +1,188 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/gremlin"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMigrate_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  func() []*VertexLabel
+		options []MigrateOption
+	}{
+		{
+			name: "properties",
+			labels: func() []*VertexLabel {
+				return []*VertexLabel{
+					NewVertexLabel("user").
+						AddProperty(&Property{Name: "age", Type: field.TypeInt}).
+						AddProperty(&Property{Name: "name", Type: field.TypeString}).
+						AddProperty(&Property{Name: "nickname", Type: field.TypeString, Unique: true}).
+						AddProperty(&Property{Name: "active", Type: field.TypeBool}).
+						AddProperty(&Property{Name: "created_at", Type: field.TypeTime}).
+						AddProperty(&Property{Name: "tags", Type: field.TypeString, Cardinality: Set}),
+					NewVertexLabel("pet").
+						AddProperty(&Property{Name: "name", Type: field.TypeString}).
+						AddProperty(&Property{Name: "weight", Type: field.TypeFloat64}).
+						AddProperty(&Property{Name: "uuid", Type: field.TypeUUID}).
+						AddProperty(&Property{Name: "data", Type: field.TypeJSON}),
+				}
+			},
+		},
+		{
+			name: "edges",
+			labels: func() []*VertexLabel {
+				return []*VertexLabel{
+					NewVertexLabel("user").
+						AddProperty(&Property{Name: "name", Type: field.TypeString}).
+						AddEdge(&EdgeLabel{Name: "user_pets", Multiplicity: One2Many}).
+						AddEdge(&EdgeLabel{Name: "user_spouse", Multiplicity: One2One}).
+						AddEdge(&EdgeLabel{Name: "user_friends", Multiplicity: Simple}).
+						AddEdge(&EdgeLabel{Name: "user_parent", Multiplicity: Many2One}).
+						AddEdge(&EdgeLabel{Name: "user_likes"}),
+					NewVertexLabel("pet"),
+				}
+			},
+		},
+		{
+			name: "edges_no_multiplicity",
+			labels: func() []*VertexLabel {
+				return []*VertexLabel{
+					NewVertexLabel("user").
+						AddEdge(&EdgeLabel{Name: "user_pets", Multiplicity: One2Many}).
+						AddEdge(&EdgeLabel{Name: "user_spouse", Multiplicity: One2One}),
+					NewVertexLabel("pet"),
+				}
+			},
+			options: []MigrateOption{WithMultiplicity(false)},
+		},
+		{
+			name: "indexes",
+			labels: func() []*VertexLabel {
+				user := NewVertexLabel("user").
+					AddProperty(&Property{Name: "first", Type: field.TypeString}).
+					AddProperty(&Property{Name: "last", Type: field.TypeString}).
+					AddProperty(&Property{Name: "email", Type: field.TypeString, Unique: true})
+				require.NoError(t, user.AddIndex("user_first_last", true, []string{"first", "last"}))
+				require.NoError(t, user.AddIndex("user_last", false, []string{"last"}))
+				// Unique properties define their index implicitly.
+				require.NoError(t, user.AddIndex("user_email", false, []string{"email"}))
+				return []*VertexLabel{user}
+			},
+		},
+		{
+			name: "indexes_disabled",
+			labels: func() []*VertexLabel {
+				user := NewVertexLabel("user").
+					AddProperty(&Property{Name: "name", Type: field.TypeString, Unique: true})
+				require.NoError(t, user.AddIndex("user_name_idx", false, []string{"name"}))
+				return []*VertexLabel{user}
+			},
+			options: []MigrateOption{WithIndexes(false)},
+		},
+		{
+			name: "graph",
+			labels: func() []*VertexLabel {
+				return []*VertexLabel{
+					NewVertexLabel("o'reilly").
+						AddProperty(&Property{Name: `back\slash`, Type: field.TypeInt64}),
+				}
+			},
+			options: []MigrateOption{WithGraph("social")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			m, err := NewMigrate(NewWriteDriver(&b), tt.options...)
+			require.NoError(t, err)
+			require.NoError(t, m.Create(context.Background(), tt.labels()...))
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, b.Bytes(), 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), b.String())
+		})
+	}
+}
+
+func TestMigrate_Conflicts(t *testing.T) {
+	m, err := NewMigrate(NewWriteDriver(&bytes.Buffer{}))
+	require.NoError(t, err)
+	err = m.Create(context.Background(),
+		NewVertexLabel("user").AddProperty(&Property{Name: "name", Type: field.TypeString}),
+		NewVertexLabel("pet").AddProperty(&Property{Name: "name", Type: field.TypeInt}),
+	)
+	require.EqualError(t, err, `gremlin/schema: conflicting definitions for property key "name": String(SINGLE) and Long(SINGLE)`)
+
+	err = m.Create(context.Background(),
+		NewVertexLabel("user").AddProperty(&Property{Name: "tags", Type: field.TypeString, Cardinality: List}),
+		NewVertexLabel("pet").AddProperty(&Property{Name: "tags", Type: field.TypeString}),
+	)
+	require.EqualError(t, err, `gremlin/schema: conflicting definitions for property key "tags": String(LIST) and String(SINGLE)`)
+
+	err = NewVertexLabel("user").AddIndex("user_name", false, []string{"name"})
+	require.EqualError(t, err, `unknown property "name" for index "user_name" of vertex label "user"`)
+}
+
+func TestMigrate_Dialect(t *testing.T) {
+	_, err := NewMigrate(nopDriver{dialect: dialect.MySQL})
+	require.EqualError(t, err, `gremlin/schema: unsupported dialect "mysql"`)
+	_, err = NewMigrate(NewWriteDriver(&bytes.Buffer{}), WithGraph(""))
+	require.EqualError(t, err, "gremlin/schema: missing graph name")
+}
+
+func TestMigrate_Exec(t *testing.T) {
+	drv := &execDriver{nopDriver: nopDriver{dialect: dialect.Gremlin}}
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(context.Background(), NewVertexLabel("user")))
+	require.Equal(t, "mgmt = graph.openManagement()\nif (!mgmt.containsVertexLabel('user')) mgmt.makeVertexLabel('user').make()\nmgmt.commit()\n", drv.query)
+
+	drv.code, drv.message = gremlin.StatusServerError, "boom"
+	err = m.Create(context.Background(), NewVertexLabel("user"))
+	require.EqualError(t, err, `gremlin/schema: execute management script: gremlin: code=500, message="boom"`)
+}
+
+type nopDriver struct {
+	dialect.Driver
+	dialect string
+}
+
+func (d nopDriver) Dialect() string { return d.dialect }
+
+type execDriver struct {
+	nopDriver
+	query   string
+	code    int
+	message string
+}
+
+func (d *execDriver) Exec(_ context.Context, query string, _, v any) error {
+	d.query = query
+	res := v.(*gremlin.Response)
+	res.Status.Code, res.Status.Message = gremlin.StatusSuccess, ""
+	if d.code != 0 {
+		res.Status.Code, res.Status.Message = d.code, d.message
+	}
+	return nil
+}
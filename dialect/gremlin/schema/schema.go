@@ -0,0 +1,192 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package schema contains all schema migration logic for Gremlin dialects.
+// The migration is expressed as a JanusGraph management script that defines
+// the property keys, vertex labels, edge labels and composite indexes of the graph.
+package schema
+
+import (
+	"fmt"
+
+	"entgo.io/ent/schema/field"
+)
+
+// Cardinality of a property key. The cardinality defines
+// the number of values a vertex can hold for a given key.
+type Cardinality string
+
+// Cardinality values.
+const (
+	Single Cardinality = "SINGLE"
+	List   Cardinality = "LIST"
+	Set    Cardinality = "SET"
+)
+
+// Multiplicity of an edge label. The multiplicity defines
+// the constraints on the number of edges between vertices.
+type Multiplicity string
+
+// Multiplicity values.
+const (
+	// Multi allows multiple edges of the same label between any pair of vertices.
+	Multi Multiplicity = "MULTI"
+	// Simple allows at most one edge of such label between any pair of vertices.
+	Simple Multiplicity = "SIMPLE"
+	// Many2One allows at most one outgoing edge of such label on any vertex.
+	Many2One Multiplicity = "MANY2ONE"
+	// One2Many allows at most one incoming edge of such label on any vertex.
+	One2Many Multiplicity = "ONE2MANY"
+	// One2One allows at most one incoming and one outgoing edge of such label on any vertex.
+	One2One Multiplicity = "ONE2ONE"
+)
+
+// ConstName returns the constant name of the multiplicity.
+// It's used by entc for printing the constant name in templates.
+func (m Multiplicity) ConstName() string {
+	switch m {
+	case Simple:
+		return "Simple"
+	case Many2One:
+		return "Many2One"
+	case One2Many:
+		return "One2Many"
+	case One2One:
+		return "One2One"
+	default:
+		return "Multi"
+	}
+}
+
+// VertexLabel schema definition for Gremlin dialects.
+type VertexLabel struct {
+	Name       string
+	Properties []*Property
+	properties map[string]*Property
+	Indexes    []*Index
+	Edges      []*EdgeLabel
+}
+
+// NewVertexLabel returns a new vertex label with the given name.
+func NewVertexLabel(name string) *VertexLabel {
+	return &VertexLabel{
+		Name:       name,
+		properties: make(map[string]*Property),
+	}
+}
+
+// AddProperty adds a new property to the vertex label.
+func (v *VertexLabel) AddProperty(p *Property) *VertexLabel {
+	if v.properties == nil {
+		v.properties = make(map[string]*Property)
+	}
+	v.properties[p.Name] = p
+	v.Properties = append(v.Properties, p)
+	return v
+}
+
+// Property returns the property with the given name. If exists.
+func (v *VertexLabel) Property(name string) (*Property, bool) {
+	if p, ok := v.properties[name]; ok {
+		return p, true
+	}
+	// In case the property was added
+	// directly to the Properties field.
+	for _, p := range v.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// AddIndex creates and adds a new composite index to the vertex label from
+// the given options. It fails if one of the properties does not exist.
+func (v *VertexLabel) AddIndex(name string, unique bool, properties []string) error {
+	idx := &Index{Name: name, Unique: unique, Properties: make([]*Property, 0, len(properties))}
+	for _, name := range properties {
+		p, ok := v.Property(name)
+		if !ok {
+			return fmt.Errorf("unknown property %q for index %q of vertex label %q", name, idx.Name, v.Name)
+		}
+		idx.Properties = append(idx.Properties, p)
+	}
+	v.Indexes = append(v.Indexes, idx)
+	return nil
+}
+
+// AddEdge adds a new outgoing edge label to the vertex label.
+func (v *VertexLabel) AddEdge(e *EdgeLabel) *VertexLabel {
+	v.Edges = append(v.Edges, e)
+	return v
+}
+
+// Property schema definition for Gremlin dialects. Note that property
+// keys are global in the graph, and properties that share the same name
+// in different vertex labels must share the same type and cardinality.
+type Property struct {
+	Name        string      // property key.
+	Type        field.Type  // property type.
+	Cardinality Cardinality // defaults to Single.
+	Unique      bool        // unique property.
+}
+
+// cardinality returns the cardinality of the property.
+func (p *Property) cardinality() Cardinality {
+	if p.Cardinality == "" {
+		return Single
+	}
+	return p.Cardinality
+}
+
+// DataType returns the JanusGraph data type of the property. The mapping follows
+// the GraphSON encoding of Go types (e.g. an int is encoded as g:Int64, and a float32
+// is encoded as g:Float).
+func (p *Property) DataType() string {
+	switch p.Type {
+	case field.TypeBool:
+		return "Boolean"
+	case field.TypeUint8:
+		return "Byte"
+	case field.TypeInt16:
+		return "Short"
+	case field.TypeInt8, field.TypeInt32, field.TypeUint16:
+		return "Integer"
+	case field.TypeInt, field.TypeInt64, field.TypeUint32:
+		return "Long"
+	case field.TypeFloat32:
+		return "Float"
+	case field.TypeFloat64:
+		return "Double"
+	case field.TypeString, field.TypeEnum:
+		return "String"
+	case field.TypeTime:
+		return "Date"
+	case field.TypeUUID:
+		return "UUID"
+	default:
+		return "Object"
+	}
+}
+
+// Index definition for composite indexes.
+type Index struct {
+	Name       string      // index name.
+	Unique     bool        // uniqueness.
+	Properties []*Property // indexed properties.
+}
+
+// EdgeLabel schema definition for Gremlin dialects.
+type EdgeLabel struct {
+	Name         string       // edge label.
+	Multiplicity Multiplicity // defaults to Multi.
+}
+
+// multiplicity returns the multiplicity of the edge label.
+func (e *EdgeLabel) multiplicity() Multiplicity {
+	if e.Multiplicity == "" {
+		return Multi
+	}
+	return e.Multiplicity
+}
@@ -0,0 +1,73 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/gremlin"
+)
+
+// WriteDriver is a driver that writes all driver exec operations to its writer.
+// It is used for printing the management script (dry-run) instead of executing
+// it against the Gremlin server.
+//
+//	drv := &schema.WriteDriver{Writer: os.Stdout}
+//	m, err := schema.NewMigrate(drv)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := m.Create(ctx, labels...); err != nil {
+//		log.Fatal(err)
+//	}
+type WriteDriver struct {
+	dialect.Driver // optional driver for query calls.
+	io.Writer      // target for exec statements.
+}
+
+// NewWriteDriver creates a dialect.Driver that writes all driver exec statement to its writer.
+func NewWriteDriver(w io.Writer) *WriteDriver {
+	return &WriteDriver{Writer: w}
+}
+
+// Dialect implements the dialect.Driver.Dialect method.
+func (*WriteDriver) Dialect() string { return dialect.Gremlin }
+
+// Exec implements the dialect.Driver.Exec method.
+func (w *WriteDriver) Exec(_ context.Context, query string, _, res any) error {
+	if rr, ok := res.(*gremlin.Response); ok {
+		rr.Status.Code = gremlin.StatusNoContent
+	}
+	if !strings.HasSuffix(query, "\n") {
+		query += "\n"
+	}
+	_, err := io.WriteString(w, query)
+	return err
+}
+
+// Query implements the dialect.Driver.Query method.
+func (w *WriteDriver) Query(ctx context.Context, query string, args, res any) error {
+	if w.Driver == nil {
+		return errors.New("query is not supported by the WriteDriver")
+	}
+	return w.Driver.Query(ctx, query, args, res)
+}
+
+// Tx returns a nop transaction.
+func (w *WriteDriver) Tx(context.Context) (dialect.Tx, error) {
+	return dialect.NopTx(w), nil
+}
+
+// Close implements the dialect.Driver.Close method.
+func (w *WriteDriver) Close() error {
+	if w.Driver == nil {
+		return nil
+	}
+	return w.Driver.Close()
+}
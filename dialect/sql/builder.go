@@ -2402,6 +2402,31 @@ func (s *Selector) FullJoin(t TableView) *Selector {
 	return s.join("FULL JOIN", t)
 }
 
+// JoinLateral appends a `JOIN LATERAL` clause to the statement. A lateral subquery
+// can reference columns of the preceding FROM items, and therefore, it is executed
+// for each of their rows. Note that, this clause is supported only by PostgreSQL and
+// MySQL (>= 8.0.14), and if no ON clause was set, it is joined with `ON TRUE`.
+//
+//	Dialect(dialect.Postgres).
+//		Select(t1.C("name"), t2.C("title")).
+//		From(t1).
+//		JoinLateral(
+//			Select().From(posts).
+//				Where(ColumnsEQ(posts.C("author_id"), t1.C("id"))).
+//				OrderBy(Desc(posts.C("created_at"))).
+//				Limit(3).
+//				As("t2"),
+//		)
+func (s *Selector) JoinLateral(t TableView) *Selector {
+	return s.join("JOIN LATERAL", t)
+}
+
+// LeftJoinLateral appends a `LEFT JOIN LATERAL` clause to the statement.
+// See JoinLateral for more info.
+func (s *Selector) LeftJoinLateral(t TableView) *Selector {
+	return s.join("LEFT JOIN LATERAL", t)
+}
+
 // join adds a join table to the selector with the given kind.
 func (s *Selector) join(kind string, t TableView) *Selector {
 	s.joins = append(s.joins, join{
@@ -2817,9 +2842,12 @@ func (s *Selector) Query() (string, []any) {
 			view.SetDialect(s.dialect)
 			b.Ident(view.Name())
 		}
-		if join.on != nil {
+		switch {
+		case join.on != nil:
 			b.WriteString(" ON ")
 			b.Join(join.on)
+		case strings.HasSuffix(join.kind, "LATERAL"):
+			b.WriteString(" ON TRUE")
 		}
 	}
 	if s.where != nil {
@@ -3763,6 +3791,18 @@ func (d *DialectBuilder) With(name string) *WithBuilder {
 	return b
 }
 
+// WithRecursive creates a WithBuilder for the `WITH RECURSIVE`
+// statement for the configured dialect.
+//
+//	Dialect(dialect.Postgres).
+//		WithRecursive("tree", "id").
+//		As(Select("id").From(Table("nodes")).Union(...))
+func (d *DialectBuilder) WithRecursive(name string, columns ...string) *WithBuilder {
+	b := WithRecursive(name, columns...)
+	b.SetDialect(d.dialect)
+	return b
+}
+
 // CreateIndex creates a IndexBuilder for the configured dialect.
 //
 //	Dialect(dialect.Postgres).
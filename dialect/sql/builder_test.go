@@ -2269,6 +2269,39 @@ func TestWindowFunction_Select(t *testing.T) {
 	require.Nil(t, args)
 }
 
+func TestSelector_JoinLateral(t *testing.T) {
+	d := Dialect(dialect.Postgres)
+	users, posts := d.Table("users"), d.Table("posts")
+	latest := d.Select(posts.Columns("id", "title")...).
+		From(posts).
+		Where(ColumnsEQ(posts.C("author_id"), users.C("id"))).
+		OrderBy(Desc(posts.C("created_at"))).
+		Limit(3).
+		As("latest")
+	query, args := d.Select(users.C("name"), latest.C("title")).
+		From(users).
+		JoinLateral(latest).
+		Where(EQ(users.C("active"), true)).
+		Query()
+	require.Equal(t, `SELECT "users"."name", "latest"."title" FROM "users" JOIN LATERAL (SELECT "posts"."id", "posts"."title" FROM "posts" WHERE "posts"."author_id" = "users"."id" ORDER BY "posts"."created_at" DESC LIMIT 3) AS "latest" ON TRUE WHERE "users"."active"`, query)
+	require.Empty(t, args)
+
+	d = Dialect(dialect.MySQL)
+	users, posts = d.Table("users"), d.Table("posts")
+	latest = d.Select(posts.C("title")).
+		From(posts).
+		Where(ColumnsEQ(posts.C("author_id"), users.C("id"))).
+		Limit(1).
+		As("latest")
+	query, args = d.Select(users.C("name"), latest.C("title")).
+		From(users).
+		LeftJoinLateral(latest).
+		OnP(NotNull(latest.C("title"))).
+		Query()
+	require.Equal(t, "SELECT `users`.`name`, `latest`.`title` FROM `users` LEFT JOIN LATERAL (SELECT `posts`.`title` FROM `posts` WHERE `posts`.`author_id` = `users`.`id` LIMIT 1) AS `latest` ON `latest`.`title` IS NOT NULL", query)
+	require.Empty(t, args)
+}
+
 func TestSelector_UnqualifiedColumns(t *testing.T) {
 	t1, t2 := Table("t1"), Table("t2")
 	s := Select(t1.C("a"), t2.C("b"))
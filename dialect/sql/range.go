@@ -0,0 +1,100 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// This file provides predicates for PostgreSQL range columns (field.IntRange
+// and field.TimeRange). The given ranges are expected to be formatted by their
+// type (e.g. sqlrange.Int, sqlrange.Time), and using these predicates in other
+// dialects results in a build error, as ranges are stored there as text.
+
+// RangeOverlaps returns a predicate to check if the range column
+// overlaps (has points in common with) the given range.
+func RangeOverlaps(col string, v any) *Predicate { return P().RangeOverlaps(col, v) }
+
+// RangeOverlaps returns a predicate to check if the range column overlaps the given range.
+func (p *Predicate) RangeOverlaps(col string, v any) *Predicate {
+	return p.Append(func(b *Builder) {
+		rangeOp(b, "&&", col, v)
+	})
+}
+
+// RangeContainsValue returns a predicate to check if
+// the range column contains the given element value.
+func RangeContainsValue(col string, v any) *Predicate { return P().RangeContainsValue(col, v) }
+
+// RangeContainsValue returns a predicate to check if the range column contains the given element value.
+func (p *Predicate) RangeContainsValue(col string, v any) *Predicate {
+	return p.Append(func(b *Builder) {
+		// The value is passed as a singleton range, because PostgreSQL
+		// resolves untyped arguments of the "@>" operator as ranges.
+		rangeOp(b, "@>", col, singleton(v))
+	})
+}
+
+// RangeAdjacent returns a predicate to check if the range column is adjacent
+// to the given range. i.e. the ranges do not overlap and there are no points
+// between them.
+func RangeAdjacent(col string, v any) *Predicate { return P().RangeAdjacent(col, v) }
+
+// RangeAdjacent returns a predicate to check if the range column is adjacent to the given range.
+func (p *Predicate) RangeAdjacent(col string, v any) *Predicate {
+	return p.Append(func(b *Builder) {
+		rangeOp(b, "-|-", col, v)
+	})
+}
+
+// rangeOp writes the range operator with the given column and argument.
+func rangeOp(b *Builder, op, col string, v any) {
+	if b.dialect != dialect.Postgres {
+		b.AddError(fmt.Errorf("sql: range operator %q is not supported by %s", op, b.dialect))
+		return
+	}
+	b.Ident(col).WriteString(" " + op + " ").Arg(v)
+}
+
+// singleton returns the text representation of a range that contains
+// only the given value. e.g. ["2022-01-01T10:00:00Z","2022-01-01T10:00:00Z"].
+func singleton(v any) string {
+	var s string
+	switch v := v.(type) {
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	case *time.Time:
+		s = v.Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(v)
+	}
+	s = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	return "[" + s + "," + s + "]"
+}
+
+// FieldRangeOverlaps returns a raw predicate to check if the field overlaps the given range.
+func FieldRangeOverlaps(name string, v any) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(RangeOverlaps(s.C(name), v))
+	}
+}
+
+// FieldRangeContainsValue returns a raw predicate to check if the field contains the given element value.
+func FieldRangeContainsValue(name string, v any) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(RangeContainsValue(s.C(name), v))
+	}
+}
+
+// FieldRangeAdjacent returns a raw predicate to check if the field is adjacent to the given range.
+func FieldRangeAdjacent(name string, v any) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(RangeAdjacent(s.C(name), v))
+	}
+}
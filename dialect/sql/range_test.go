@@ -0,0 +1,57 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangePredicates(t *testing.T) {
+	during := `["2022-01-01T10:00:00Z","2022-01-01T11:00:00Z")`
+	tests := []struct {
+		pred  func(*Selector)
+		query string
+		args  []any
+	}{
+		{
+			pred:  FieldRangeOverlaps("during", during),
+			query: `SELECT * FROM "bookings" WHERE "bookings"."during" && $1`,
+			args:  []any{during},
+		},
+		{
+			pred:  FieldRangeAdjacent("during", during),
+			query: `SELECT * FROM "bookings" WHERE "bookings"."during" -|- $1`,
+			args:  []any{during},
+		},
+		{
+			pred:  FieldRangeContainsValue("seats", 5),
+			query: `SELECT * FROM "bookings" WHERE "bookings"."seats" @> $1`,
+			args:  []any{`["5","5"]`},
+		},
+		{
+			pred:  FieldRangeContainsValue("during", time.Date(2022, 1, 1, 10, 30, 0, 0, time.UTC)),
+			query: `SELECT * FROM "bookings" WHERE "bookings"."during" @> $1`,
+			args:  []any{`["2022-01-01T10:30:00Z","2022-01-01T10:30:00Z"]`},
+		},
+	}
+	for _, tt := range tests {
+		s := Dialect(dialect.Postgres).Select().From(Table("bookings"))
+		tt.pred(s)
+		query, args := s.Query()
+		require.NoError(t, s.Err())
+		require.Equal(t, tt.query, query)
+		require.Equal(t, tt.args, args)
+	}
+
+	s := Dialect(dialect.MySQL).Select().From(Table("bookings"))
+	FieldRangeOverlaps("during", during)(s)
+	s.Query()
+	require.EqualError(t, s.Err(), `sql: range operator "&&" is not supported by mysql`)
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -37,13 +38,19 @@ type Atlas struct {
 	dropColumns     bool // drop deleted columns
 	dropIndexes     bool // drop deleted indexes
 	withForeignKeys bool // with foreign keys
+	nonBlocking     bool // plan non-blocking changes
 	mode            Mode
-	hooks           []Hook            // hooks to apply before creation
-	diffHooks       []DiffHook        // diff hooks to run when diffing current and desired
-	applyHook       []ApplyHook       // apply hooks to run when applying the plan
-	skip            ChangeKind        // what changes to skip and not apply
-	dir             migrate.Dir       // the migration directory to read from
-	fmt             migrate.Formatter // how to format the plan into migration files
+	hooks           []Hook                             // hooks to apply before creation
+	diffHooks       []DiffHook                         // diff hooks to run when diffing current and desired
+	applyHook       []ApplyHook                        // apply hooks to run when applying the plan
+	lint            LintFunc                           // lint function to run on the computed changes
+	data            []*DataMigration                   // data migrations to plan along with the changes
+	dataApplied     map[string]bool                    // data migrations applied by previous online migrations
+	dataChanges     map[*migrate.Change]*DataMigration // data migrations planned by online migration
+	skip            ChangeKind                         // what changes to skip and not apply
+	dir             migrate.Dir                        // the migration directory to read from
+	fmt             migrate.Formatter                  // how to format the plan into migration files
+	timeParts       []*TimePartitions                  // time-based partitions to plan along with the changes
 
 	driver  dialect.Driver // driver passed in when not using an atlas URL
 	url     *url.URL       // url of database connection
@@ -193,7 +200,16 @@ func (a *Atlas) NamedDiff(ctx context.Context, name string, tables ...*Table) er
 	if len(plan.Changes) == 0 {
 		return nil
 	}
-	return migrate.NewPlanner(nil, a.dir, opts...).WritePlan(plan)
+	inlineArgs(a.dialect, plan)
+	delimitTriggers(plan)
+	// Statements that cannot be executed in a transaction
+	// block are written to a separate migration file.
+	for _, p := range splitPlan(plan) {
+		if err := migrate.NewPlanner(nil, a.dir, opts...).WritePlan(p); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (a *Atlas) cleanSchema(ctx context.Context, name string, err0 error) (err error) {
@@ -626,6 +642,9 @@ func (a *Atlas) create(ctx context.Context, tables ...*Table) (err error) {
 			AddColumn(&Column{Name: "type", Type: field.TypeString, Unique: true}),
 		)
 	}
+	if len(a.data) > 0 {
+		tables = append(tables, dataTable())
+	}
 	if a.driver != nil {
 		a.sqlDialect, err = a.entDialect(a.driver)
 		if err != nil {
@@ -657,6 +676,16 @@ func (a *Atlas) create(ctx context.Context, tables ...*Table) (err error) {
 		return err
 	}
 	defer func() { a.atDriver = nil }()
+	if len(a.data) > 0 {
+		if a.dataApplied, err = a.loadData(ctx, tx); err != nil {
+			return rollback(tx, err)
+		}
+		a.dataChanges = make(map[*migrate.Change]*DataMigration)
+		defer func() { a.dataApplied, a.dataChanges = nil, nil }()
+	}
+	// Changes that follow the first change that cannot be executed in
+	// a transaction block are executed after the transaction is committed.
+	var rest []*migrate.Change
 	if err := func() error {
 		plan, err := a.planInspect(ctx, tx, "changes", tables)
 		if err != nil {
@@ -664,11 +693,12 @@ func (a *Atlas) create(ctx context.Context, tables ...*Table) (err error) {
 		}
 		// Apply plan (changes).
 		var applier Applier = ApplyFunc(func(ctx context.Context, tx dialect.ExecQuerier, plan *migrate.Plan) error {
-			for _, c := range plan.Changes {
-				if err := tx.Exec(ctx, c.Cmd, c.Args, nil); err != nil {
-					if c.Comment != "" {
-						err = fmt.Errorf("%s: %w", c.Comment, err)
-					}
+			for i, c := range plan.Changes {
+				if NonTransactional(c) {
+					rest = plan.Changes[i:]
+					return nil
+				}
+				if err := a.execChange(ctx, tx, c); err != nil {
 					return err
 				}
 			}
@@ -685,23 +715,80 @@ func (a *Atlas) create(ctx context.Context, tables ...*Table) (err error) {
 		}
 		return err
 	}
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	// The rest of the changes are executed in their planned order, where
+	// transactional segments are executed in their own transactions.
+	for _, seg := range segments(rest) {
+		if NonTransactional(seg[0]) {
+			for _, c := range seg {
+				if err := a.execChange(ctx, a.sqlDialect, c); err != nil {
+					return fmt.Errorf("sql/schema: %w", err)
+				}
+			}
+			continue
+		}
+		tx, err := a.sqlDialect.Tx(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range seg {
+			if err := a.execChange(ctx, tx, c); err != nil {
+				return rollback(tx, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execChange executes the planned change using the given connection.
+// Changes of data migrations execute their functions on the connection.
+func (a *Atlas) execChange(ctx context.Context, conn dialect.ExecQuerier, c *migrate.Change) error {
+	var err error
+	if m, ok := a.dataChanges[c]; ok {
+		if err = m.Func(ctx, &dataConn{ExecQuerier: conn, dialect: a.dialect}); err != nil {
+			return fmt.Errorf("data migration %q: %w", m.Name, err)
+		}
+		return nil
+	}
+	if err = conn.Exec(ctx, c.Cmd, c.Args, nil); err != nil {
+		if c.Comment != "" {
+			err = fmt.Errorf("%s: %w", c.Comment, err)
+		}
+		return err
+	}
+	return nil
 }
 
 // planInspect creates the current state by inspecting the connected database, computing the current state of the Ent schema
 // and proceeds to diff the changes to create a migration plan.
 func (a *Atlas) planInspect(ctx context.Context, conn dialect.ExecQuerier, name string, tables []*Table) (*migrate.Plan, error) {
+	fs := a.features(tables)
 	current, err := a.atDriver.InspectSchema(ctx, "", &schema.InspectOptions{
 		Tables: func() (t []string) {
 			for i := range tables {
 				t = append(t, tables[i].Name)
 			}
+			for _, f := range fs {
+				if ft, ok := f.(*fullText); ok {
+					t = append(t, ft.tables()...)
+				}
+			}
 			return t
 		}(),
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, f := range fs {
+		if err := f.inspect(ctx, conn, current); err != nil {
+			return nil, err
+		}
+	}
 	var types []string
 	if a.universalID {
 		types, err = a.loadTypes(ctx, conn)
@@ -716,7 +803,7 @@ func (a *Atlas) planInspect(ctx context.Context, conn dialect.ExecQuerier, name
 	}
 	desired := realm.Schemas[0]
 	desired.Name, desired.Attrs = current.Name, current.Attrs
-	return a.diff(ctx, name, current, desired, a.types[len(types):])
+	return a.diff(ctx, conn, name, fs, current, desired, a.types[len(types):])
 }
 
 func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*migrate.Plan, error) {
@@ -741,6 +828,12 @@ func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*
 	if err != nil {
 		return nil, a.cleanSchema(ctx, "", err)
 	}
+	fs := a.features(tables)
+	for _, f := range fs {
+		if err := f.inspect(ctx, a.sqlDialect, current); err != nil {
+			return nil, a.cleanSchema(ctx, "", err)
+		}
+	}
 	var types []string
 	if a.universalID {
 		if types, err = a.loadTypes(ctx, a.sqlDialect); err != nil && !errors.Is(err, errTypeTableNotFound) {
@@ -773,7 +866,7 @@ func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*
 			desired[i] = d
 		}
 	}
-	return a.diff(ctx, name, current,
+	return a.diff(ctx, nil, name, fs, current,
 		&schema.Schema{Name: current.Name, Attrs: current.Attrs, Tables: desired}, a.types[len(types):],
 		// For BC reason, we omit the schema qualifier from the migration scripts,
 		// but that is currently limiting versioned migration to a single schema.
@@ -784,15 +877,102 @@ func (a *Atlas) planReplay(ctx context.Context, name string, tables []*Table) (*
 	)
 }
 
-func (a *Atlas) diff(ctx context.Context, name string, current, desired *schema.Schema, newTypes []string, opts ...migrate.PlanOption) (*migrate.Plan, error) {
+// featureHandler plans the changes of a database feature that is not managed by Atlas (e.g. full-text
+// indexes, native enums or triggers) along with the schema changes. The handlers of a migration are
+// created by Atlas.features, and called in their order by each step of the migration planning.
+type featureHandler interface {
+	// inspect loads the state of the feature from the connected database, and completes the
+	// current state. In replay mode, the connection is the one the migration directory was
+	// replayed on.
+	inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error
+	// prepare prepares the current and the desired states before they are diffed.
+	prepare(current, desired *schema.Schema)
+	// changes checks and modifies the computed changes before they are planned by Atlas (e.g.
+	// removes the changes that are planned by the handler), and returns the changes to plan.
+	changes(ctx context.Context, conn dialect.ExecQuerier, changes []schema.Change) ([]schema.Change, error)
+	// plan adds the changes of the feature to the plan computed by Atlas.
+	plan(ctx context.Context, conn dialect.ExecQuerier, changes []schema.Change, plan *migrate.Plan) error
+}
+
+// nopFeature implements the featureHandler steps that are not used by a handler as no-ops.
+type nopFeature struct{}
+
+func (nopFeature) inspect(context.Context, dialect.ExecQuerier, *schema.Schema) error {
+	return nil
+}
+
+func (nopFeature) prepare(_, _ *schema.Schema) {}
+
+func (nopFeature) changes(_ context.Context, _ dialect.ExecQuerier, changes []schema.Change) ([]schema.Change, error) {
+	return changes, nil
+}
+
+func (nopFeature) plan(context.Context, dialect.ExecQuerier, []schema.Change, *migrate.Plan) error {
+	return nil
+}
+
+// features returns the feature handlers of the given tables.
+func (a *Atlas) features(tables []*Table) []featureHandler {
+	return []featureHandler{
+		&spatial{drv: a.sqlDialect, tables: tables},
+		newFullText(a.dialect, tables),
+		newEnums(a.dialect, tables),
+		newExclusions(a.dialect, tables),
+		newPartitions(a.sqlDialect, a.dialect, tables, a.timeParts),
+		newTriggers(a.sqlDialect, a.dialect, tables),
+	}
+}
+
+// diff computes the changes between the current and the desired state, and plans them along with the changes
+// of the feature handlers. The connection is optional, and used by the linter to check if modified tables are
+// empty, and by the handlers (e.g. to check if removed enum values are in use). It is nil in replay mode.
+func (a *Atlas) diff(ctx context.Context, conn dialect.ExecQuerier, name string, fs []featureHandler, current, desired *schema.Schema, newTypes []string, opts ...migrate.PlanOption) (*migrate.Plan, error) {
+	for _, f := range fs {
+		f.prepare(current, desired)
+	}
+	normalizeGenerated(current, desired)
 	changes, err := (&diffDriver{a.atDriver, a.diffHooks}).SchemaDiff(current, desired)
 	if err != nil {
 		return nil, err
 	}
+	if a.lint != nil {
+		r, err := (&linter{dialect: a.dialect, conn: conn}).analyze(ctx, changes)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.lint(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range fs {
+		if changes, err = f.changes(ctx, conn, changes); err != nil {
+			return nil, err
+		}
+	}
+	var nb *nonBlocking
+	if a.nonBlocking {
+		nb = &nonBlocking{dialect: a.dialect}
+		changes = nb.changes(changes)
+	}
 	plan, err := a.atDriver.PlanChanges(ctx, name, changes, opts...)
 	if err != nil {
 		return nil, err
 	}
+	for _, f := range fs {
+		if err := f.plan(ctx, conn, changes, plan); err != nil {
+			return nil, err
+		}
+	}
+	// Data migrations are planned before the deferred
+	// steps of the non-blocking mode (e.g. SET NOT NULL).
+	if len(a.data) > 0 {
+		if err := a.planData(ctx, plan); err != nil {
+			return nil, err
+		}
+	}
+	if nb != nil {
+		nb.plan(plan)
+	}
 	if len(newTypes) > 0 {
 		plan.Changes = append(plan.Changes, &migrate.Change{
 			Cmd:     a.sqlDialect.atTypeRangeSQL(newTypes...),
@@ -848,6 +1028,11 @@ func (d *db) ExecContext(ctx context.Context, query string, args ...any) (sql.Re
 
 // tables converts an Ent table slice to an atlas table slice
 func (a *Atlas) tables(tables []*Table) ([]*schema.Table, error) {
+	if a.withForeignKeys {
+		if err := checkPartitionFKs(a.dialect, tables); err != nil {
+			return nil, err
+		}
+	}
 	ts := make([]*schema.Table, len(tables))
 	for i, et := range tables {
 		at := schema.NewTable(et.Name)
@@ -865,6 +1050,9 @@ func (a *Atlas) tables(tables []*Table) ([]*schema.Table, error) {
 		if err := a.aIndexes(et, at); err != nil {
 			return nil, err
 		}
+		if err := a.atPartition(et, at); err != nil {
+			return nil, err
+		}
 		ts[i] = at
 	}
 	for i, t1 := range tables {
@@ -918,7 +1106,10 @@ func (a *Atlas) aColumns(et *Table, at *schema.Table) error {
 		if err := a.sqlDialect.atTypeC(c1, c2); err != nil {
 			return err
 		}
-		if err := a.atDefault(c1, c2); err != nil {
+		// Generated columns cannot have default values.
+		if x := a.atGenerated(c1); x != nil {
+			c2.SetGeneratedExpr(x)
+		} else if err := a.atDefault(c1, c2); err != nil {
 			return err
 		}
 		if c1.Unique && (len(et.PrimaryKey) != 1 || et.PrimaryKey[0] != c1) {
@@ -972,6 +1163,74 @@ func (a *Atlas) atDefault(c1 *Column, c2 *schema.Column) error {
 	return nil
 }
 
+// atGenerated returns the generation expression of the column in the
+// configured dialect, or nil if the column is not a generated column.
+func (a *Atlas) atGenerated(c1 *Column) *schema.GeneratedExpr {
+	if c1.Generated == nil {
+		return nil
+	}
+	x := c1.Generated.Expression(a.sqlDialect.Dialect())
+	if x == "" {
+		return nil
+	}
+	t := string(c1.Generated.Type)
+	if t == "" {
+		t = "STORED"
+	}
+	return &schema.GeneratedExpr{Expr: x, Type: t}
+}
+
+// normalizeGenerated sets the generation expressions of the desired columns to their
+// current form, if they are equal after normalization. Databases store expressions in
+// a normalized form (e.g. Postgres adds casts and parentheses), and Atlas does not
+// support changing the generation expression of existing columns on Postgres.
+func normalizeGenerated(current, desired *schema.Schema) {
+	for _, t2 := range desired.Tables {
+		t1, ok := current.Table(t2.Name)
+		if !ok {
+			continue
+		}
+		for _, c2 := range t2.Columns {
+			c1, ok := t1.Column(c2.Name)
+			if !ok {
+				continue
+			}
+			x1, x2 := generatedExpr(c1), generatedExpr(c2)
+			if x1 != nil && x2 != nil && x1.Expr != x2.Expr && genExprKey(x1.Expr) == genExprKey(x2.Expr) {
+				x2.Expr = x1.Expr
+			}
+		}
+	}
+}
+
+// generatedExpr returns the generation expression of the column, if exists.
+func generatedExpr(c *schema.Column) *schema.GeneratedExpr {
+	for _, a := range c.Attrs {
+		if x, ok := a.(*schema.GeneratedExpr); ok {
+			return x
+		}
+	}
+	return nil
+}
+
+var (
+	// Postgres casts (e.g. "::text"), and MySQL charset introducers (e.g. "_utf8mb4'-'").
+	reGenCast   = regexp.MustCompile(`::(character varying|double precision|timestamp with(out)? time zone|\w+)(\[\])?|\b_\w+'`)
+	reGenIgnore = regexp.MustCompile("[\\s()\"`]")
+)
+
+// genExprKey returns a key of the given generation expression for comparing it with the form
+// stored by the database. Casts, quotes of identifiers, parentheses, spaces and case are ignored.
+func genExprKey(x string) string {
+	x = reGenCast.ReplaceAllStringFunc(x, func(s string) string {
+		if strings.HasSuffix(s, "'") {
+			return "'"
+		}
+		return ""
+	})
+	return strings.ToLower(reGenIgnore.ReplaceAllString(x, ""))
+}
+
 func (a *Atlas) aIndexes(et *Table, at *schema.Table) error {
 	// Primary-key index.
 	pk := make([]*schema.Column, 0, len(et.PrimaryKey))
@@ -988,6 +1247,12 @@ func (a *Atlas) aIndexes(et *Table, at *schema.Table) error {
 	}
 	// Rest of indexes.
 	for _, idx1 := range et.Indexes {
+		// Full-text indexes in SQLite are defined as FTS5
+		// virtual tables, and are planned separately. Spatial
+		// indexes are not supported by SQLite.
+		if a.dialect == dialect.SQLite && (isFullText(idx1) || isSpatial(idx1)) {
+			continue
+		}
 		idx2 := schema.NewIndex(idx1.Name).
 			SetUnique(idx1.Unique)
 		if err := a.sqlDialect.atIndex(idx1, at, idx2); err != nil {
@@ -995,7 +1260,9 @@ func (a *Atlas) aIndexes(et *Table, at *schema.Table) error {
 		}
 		desc := descIndexes(idx1)
 		for _, p := range idx2.Parts {
-			p.Desc = desc[p.C.Name]
+			if p.C != nil {
+				p.Desc = desc[p.C.Name]
+			}
 		}
 		at.AddIndexes(idx2)
 	}
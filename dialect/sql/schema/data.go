@@ -0,0 +1,250 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+)
+
+type (
+	// DataMigration is a Go-coded data migration (e.g. a backfill of a split or renamed
+	// column) that runs as part of a schema migration. The migration function receives
+	// a driver that can be used for creating the generated client. For example:
+	//
+	//	&schema.DataMigration{
+	//		Name: "backfill users nickname",
+	//		Func: func(ctx context.Context, drv dialect.Driver) error {
+	//			client := ent.NewClient(ent.Driver(drv))
+	//			return client.User.Update().
+	//				Where(user.NicknameIsNil()).
+	//				SetNickname("unknown").
+	//				Exec(ctx)
+	//		},
+	//	}
+	//
+	// In online migration (Create), the function is executed on the migration transaction
+	// along with the schema changes, and it can read the existing data and use the IDs
+	// of the created nodes. Applied migrations are recorded by their names in the
+	// DataMigrationTable, and are not executed again by later migrations.
+	//
+	// In versioned migration (Diff and NamedDiff), the function is executed when the
+	// migration is planned, and its statements are recorded and written to the migration
+	// file of the version. Hence, versioned data migrations are write-only: statements
+	// that read data or depend on the result of other statements, like queries, INSERT
+	// statements that return the created IDs, and the IDs of inserted rows, fail the
+	// migration. Row counts of recorded statements are zero. In addition, statement
+	// arguments are inlined into the migration file, and values that cannot be formatted
+	// (e.g. time.Time) require manual changes. Therefore, prefer expressing versioned
+	// backfills as bulk statements (e.g. UPDATE users SET first_name = name).
+	DataMigration struct {
+		// Name describes the migration, and is used as the comment of its
+		// statements in the plan. Names must be unique, as they are used
+		// for tracking the applied migrations in online migration.
+		Name string
+		// Order defines if the migration statements are planned
+		// before or after the schema changes. Defaults to DataAfter.
+		Order DataOrder
+		// Func executes the migration using the given driver.
+		Func func(context.Context, dialect.Driver) error
+	}
+
+	// DataOrder defines the order of a data migration relative to the schema changes.
+	DataOrder uint
+)
+
+// DataMigrationTable defines the table name holding the
+// names of the data migrations applied by online migration.
+const DataMigrationTable = "ent_data_migrations"
+
+// Data migration orders.
+const (
+	DataAfter  DataOrder = iota // after the schema changes (default).
+	DataBefore                  // before the schema changes.
+)
+
+// WithDataMigrations registers data migrations to be planned along with the schema
+// changes. Migrations are planned in the order they are registered. In versioned
+// migration, a migration file is written only if the migration has schema changes,
+// or at least one data migration records a statement.
+func WithDataMigrations(ms ...*DataMigration) MigrateOption {
+	return func(a *Atlas) {
+		a.data = append(a.data, ms...)
+	}
+}
+
+// dataTable returns the table holding the applied data migrations.
+func dataTable() *Table {
+	return NewTable(DataMigrationTable).
+		AddPrimary(&Column{Name: "id", Type: field.TypeUint, Increment: true}).
+		AddColumn(&Column{Name: "name", Type: field.TypeString, Unique: true})
+}
+
+// loadData loads the names of the applied data migrations from the DataMigrationTable.
+func (a *Atlas) loadData(ctx context.Context, conn dialect.ExecQuerier) (map[string]bool, error) {
+	applied := make(map[string]bool)
+	exists, err := a.sqlDialect.tableExist(ctx, conn, DataMigrationTable)
+	if err != nil || !exists {
+		return applied, err
+	}
+	rows := &sql.Rows{}
+	query, args := sql.Dialect(a.dialect).
+		Select("name").From(sql.Table(DataMigrationTable)).Query()
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return nil, fmt.Errorf("query data migrations table: %w", err)
+	}
+	defer rows.Close()
+	var names []string
+	if err := sql.ScanSlice(rows, &names); err != nil {
+		return nil, err
+	}
+	for _, n := range names {
+		applied[n] = true
+	}
+	return applied, nil
+}
+
+// planData adds the data migrations to the plan. In online migration, each migration
+// is planned as a change that is executed by Atlas.execChange, applied migrations are
+// skipped, and the others are recorded in the DataMigrationTable at the end of the plan.
+// In versioned migration, the statements of the migrations are recorded and planned.
+func (a *Atlas) planData(ctx context.Context, plan *migrate.Plan) error {
+	var (
+		before, after, track []*migrate.Change
+		names                = make(map[string]bool, len(a.data))
+	)
+	for _, m := range a.data {
+		if m.Func == nil {
+			return fmt.Errorf("missing function for data migration %q", m.Name)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("duplicate data migration name %q", m.Name)
+		}
+		names[m.Name] = true
+		if a.dataApplied[m.Name] {
+			continue
+		}
+		if m.Order != DataAfter && m.Order != DataBefore {
+			return fmt.Errorf("unknown order %d for data migration %q", m.Order, m.Name)
+		}
+		var changes []*migrate.Change
+		if a.dataApplied != nil {
+			c := &migrate.Change{Comment: m.Name}
+			a.dataChanges[c] = m
+			changes = append(changes, c)
+		} else {
+			w := &dataDriver{nopDriver: nopDriver{dialect: a.dialect}, comment: m.Name}
+			if err := m.Func(ctx, w); err != nil {
+				return fmt.Errorf("data migration %q: %w", m.Name, err)
+			}
+			changes = w.changes
+		}
+		if m.Order == DataBefore {
+			before = append(before, changes...)
+		} else {
+			after = append(after, changes...)
+		}
+		if a.dataApplied != nil {
+			query, args := sql.Dialect(a.dialect).
+				Insert(DataMigrationTable).Columns("name").Values(m.Name).Query()
+			track = append(track, &migrate.Change{Cmd: query, Args: args, Comment: fmt.Sprintf("record data migration %q", m.Name)})
+		}
+	}
+	plan.Changes = append(append(append(before, plan.Changes...), after...), track...)
+	return nil
+}
+
+// dataConn is a dialect.Driver that executes the statements of a data migration on
+// the connection of the online migration. Its transactions are no-op, as the statements
+// are executed in the transaction of the migration.
+type dataConn struct {
+	dialect.ExecQuerier
+	dialect string
+}
+
+// Dialect implements the dialect.Driver.Dialect method.
+func (d *dataConn) Dialect() string { return d.dialect }
+
+// Tx returns a nop transaction.
+func (d *dataConn) Tx(context.Context) (dialect.Tx, error) {
+	return dialect.NopTx(d), nil
+}
+
+// Close implements the dialect.Driver.Close method.
+func (*dataConn) Close() error { return nil }
+
+// dataDriver is a dialect.Driver that records the statements
+// executed by a versioned data migration as changes.
+type dataDriver struct {
+	nopDriver
+	comment string
+	changes []*migrate.Change
+}
+
+// Exec implements the dialect.Driver.Exec method.
+func (d *dataDriver) Exec(_ context.Context, query string, args, res any) error {
+	if rr, ok := res.(*sql.Result); ok {
+		*rr = dataResult{}
+	}
+	return d.record(query, args)
+}
+
+// Query implements the dialect.Driver.Query method. Recorded statements are not
+// executed, and therefore, statements that return rows are not supported.
+func (d *dataDriver) Query(_ context.Context, query string, _, _ any) error {
+	return fmt.Errorf("versioned data migrations are write-only, and cannot execute statements that return rows: %s", query)
+}
+
+// Tx returns a nop transaction, as the recorded
+// statements are executed as part of the plan.
+func (d *dataDriver) Tx(context.Context) (dialect.Tx, error) {
+	return dialect.NopTx(d), nil
+}
+
+// Close implements the dialect.Driver.Close method.
+func (*dataDriver) Close() error { return nil }
+
+func (d *dataDriver) record(query string, args any) error {
+	c := &migrate.Change{Cmd: strings.TrimSuffix(query, ";"), Comment: d.comment}
+	if args != nil {
+		args, ok := args.([]any)
+		if !ok {
+			return fmt.Errorf("unexpected args type: %T", args)
+		}
+		c.Args = args
+	}
+	d.changes = append(d.changes, c)
+	return nil
+}
+
+// dataResult is the result of a recorded statement. Recorded statements are
+// not executed, and therefore, the IDs of inserted rows are not available.
+type dataResult struct{}
+
+// LastInsertId implements the sql.Result.LastInsertId method.
+func (dataResult) LastInsertId() (int64, error) {
+	return 0, errors.New("versioned data migrations are write-only, and cannot use the IDs of inserted rows")
+}
+
+// RowsAffected implements the sql.Result.RowsAffected method.
+func (dataResult) RowsAffected() (int64, error) { return 0, nil }
+
+// inlineArgs inlines the arguments of the planned changes into their statements,
+// because migration files hold only statements (e.g. recorded data migrations).
+func inlineArgs(d string, plan *migrate.Plan) {
+	w := NewWriteDriver(d, nil)
+	for _, c := range plan.Changes {
+		if len(c.Args) > 0 {
+			c.Cmd, c.Args = w.expandArgs(c.Cmd, c.Args), nil
+		}
+	}
+}
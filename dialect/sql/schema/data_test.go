@@ -0,0 +1,179 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtlas_DataMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:data?mode=memory&_fk=1")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO `users` (`name`) VALUES ('a8m Mashraki')")
+	require.NoError(t, err)
+
+	users := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "name", Type: field.TypeString},
+			{Name: "first_name", Type: field.TypeString, Nullable: true},
+		},
+	}
+	users.PrimaryKey = users.Columns[:1]
+	data := []*DataMigration{
+		{
+			Name: "backfill first_name",
+			Func: func(ctx context.Context, drv dialect.Driver) error {
+				return drv.Exec(ctx, "UPDATE `users` SET `first_name` = substr(`name`, 1, instr(`name`, ' ') - 1)", []any{}, nil)
+			},
+		},
+		{
+			Name:  "seed users",
+			Order: DataBefore,
+			Func: func(ctx context.Context, drv dialect.Driver) error {
+				tx, err := drv.Tx(ctx)
+				if err != nil {
+					return err
+				}
+				if err := tx.Exec(ctx, "INSERT INTO `users` (`name`) VALUES (?)", []any{"Ariel Mashraki"}, nil); err != nil {
+					return err
+				}
+				return tx.Commit()
+			},
+		},
+	}
+
+	// Versioned migration.
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(db, WithDir(d), WithFormatter(f), WithDataMigrations(data...))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "split_name", users))
+	requireFileEqual(t, filepath.Join(p, "split_name.sql"), "INSERT INTO `users` (`name`) VALUES ('Ariel Mashraki');\n"+
+		"ALTER TABLE `users` ADD COLUMN `first_name` text NULL;\n"+
+		"UPDATE `users` SET `first_name` = substr(`name`, 1, instr(`name`, ' ') - 1);\n")
+	require.NoError(t, migrate.Validate(d))
+
+	// Online migration.
+	m, err = NewMigrate(db, WithDataMigrations(data...))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	rows, err := db.QueryContext(ctx, "SELECT `first_name` FROM `users` ORDER BY `id`")
+	require.NoError(t, err)
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Close())
+	require.Equal(t, []string{"a8m", "Ariel"}, names)
+
+	// Applied migrations are not executed again.
+	m, err = NewMigrate(db, WithDataMigrations(data...))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	requireCount(t, db, "SELECT COUNT(*) FROM `users`", 2)
+	requireCount(t, db, "SELECT COUNT(*) FROM `ent_data_migrations`", 2)
+
+	// Nodes can be created using the generated builders, and their IDs can be used by other statements.
+	_, err = db.ExecContext(ctx, "CREATE TABLE `pets` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `owner_id` integer NOT NULL REFERENCES `users` (`id`))")
+	require.NoError(t, err)
+	createUser := &DataMigration{
+		Name: "create user",
+		Func: func(ctx context.Context, drv dialect.Driver) error {
+			user := &sqlgraph.CreateSpec{
+				Table:  "users",
+				ID:     &sqlgraph.FieldSpec{Column: "id", Type: field.TypeInt},
+				Fields: []*sqlgraph.FieldSpec{{Column: "name", Type: field.TypeString, Value: "Nati Mashraki"}},
+			}
+			if err := sqlgraph.CreateNode(ctx, drv, user); err != nil {
+				return err
+			}
+			return sqlgraph.CreateNode(ctx, drv, &sqlgraph.CreateSpec{
+				Table: "pets",
+				ID:    &sqlgraph.FieldSpec{Column: "id", Type: field.TypeInt},
+				Fields: []*sqlgraph.FieldSpec{
+					{Column: "name", Type: field.TypeString, Value: "pedro"},
+					{Column: "owner_id", Type: field.TypeInt, Value: user.ID.Value},
+				},
+			})
+		},
+	}
+	m, err = NewMigrate(db, WithDataMigrations(createUser))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	requireCount(t, db, "SELECT COUNT(*) FROM `users` WHERE `name` = 'Nati Mashraki'", 1)
+	requireCount(t, db, "SELECT COUNT(*) FROM `pets` JOIN `users` ON `pets`.`owner_id` = `users`.`id` WHERE `users`.`name` = 'Nati Mashraki'", 1)
+
+	// Data can be read in online migration.
+	readUsers := &DataMigration{
+		Name: "read users",
+		Func: func(ctx context.Context, drv dialect.Driver) error {
+			var rows sql.Rows
+			if err := drv.Query(ctx, "SELECT COUNT(*) FROM `users`", []any{}, &rows); err != nil {
+				return err
+			}
+			defer rows.Close()
+			n, err := sql.ScanInt(rows)
+			if err != nil {
+				return err
+			}
+			return drv.Exec(ctx, "INSERT INTO `users` (`name`) VALUES (?)", []any{fmt.Sprintf("user-%d", n+1)}, nil)
+		},
+	}
+	m, err = NewMigrate(db, WithDataMigrations(readUsers))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	requireCount(t, db, "SELECT COUNT(*) FROM `users` WHERE `name` = 'user-4'", 1)
+
+	// Versioned data migrations are write-only.
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f), WithDataMigrations(readUsers))
+	require.NoError(t, err)
+	require.EqualError(t, m.NamedDiff(ctx, "read_users", users), "data migration \"read users\": versioned data migrations are write-only, and cannot execute statements that return rows: SELECT COUNT(*) FROM `users`")
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f), WithDataMigrations(createUser))
+	require.NoError(t, err)
+	err = m.NamedDiff(ctx, "create_user", users)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "versioned data migrations are write-only")
+
+	// Names identify the applied migrations.
+	m, err = NewMigrate(db, WithDataMigrations(data[0], data[0]))
+	require.NoError(t, err)
+	require.EqualError(t, m.Create(ctx, users), "sql/schema: duplicate data migration name \"backfill first_name\"")
+}
+
+func requireCount(t *testing.T, db *sql.Driver, query string, n int) {
+	t.Helper()
+	rows, err := db.QueryContext(context.Background(), query)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	var c int
+	require.NoError(t, rows.Scan(&c))
+	require.Equal(t, n, c)
+}
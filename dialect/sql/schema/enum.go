@@ -0,0 +1,346 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// enums handles the changes of enum columns that are not supported by Atlas.
+// Enum values cannot be removed if they are still in use, and on Postgres,
+// native enum types are recreated when values are removed or reordered (as
+// only appending values is supported by ALTER TYPE), and existing columns are
+// converted to native enum types using explicit casts.
+type enums struct {
+	nopFeature
+	dialect string
+	tables  []*Table
+	// Native enum types that exist in the database,
+	// or created by the plan computed by Atlas.
+	types map[string]bool
+	// Native enum types that are recreated, and
+	// the changes for converting their columns.
+	recreated []*schema.EnumType
+	columns   map[string][]*migrate.Change
+	// Changes that are planned after the ones computed by Atlas.
+	after, drop []*migrate.Change
+}
+
+// newEnums returns the enum columns handler for the given tables.
+func newEnums(d string, tables []*Table) *enums {
+	return &enums{dialect: d, tables: tables, types: make(map[string]bool), columns: make(map[string][]*migrate.Change)}
+}
+
+// hasNativeEnum reports if any of the tables has a native enum column.
+func (e *enums) hasNativeEnum() bool {
+	for _, t := range e.tables {
+		for _, c := range t.Columns {
+			if c.NativeEnum != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inspect loads the native enum types that exist in the current schema.
+// The query is executed only if the tables use native enum types.
+func (e *enums) inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error {
+	if e.dialect != dialect.Postgres || !e.hasNativeEnum() {
+		return nil
+	}
+	t, n := sql.Table("pg_type").Schema("pg_catalog").As("t"), sql.Table("pg_namespace").Schema("pg_catalog").As("n")
+	query, args := sql.Dialect(dialect.Postgres).
+		Select(t.C("typname")).
+		From(t).
+		Join(n).On(n.C("oid"), t.C("typnamespace")).
+		Where(sql.And(sql.EQ(t.C("typtype"), "e"), sql.EQ(n.C("nspname"), current.Name))).
+		Query()
+	rows := &sql.Rows{}
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return fmt.Errorf("postgres: reading enum types: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("postgres: scanning enum type: %w", err)
+		}
+		e.types[name] = true
+	}
+	return rows.Err()
+}
+
+// changes checks that removed enum values are not in use, and on Postgres, removes
+// the type changes of native enum columns that cannot be planned by Atlas from the
+// given changes, and plans them separately. The connection is optional, and used to
+// check if the removed values are in use (i.e. not available in replay mode).
+func (e *enums) changes(ctx context.Context, conn dialect.ExecQuerier, changes []schema.Change) ([]schema.Change, error) {
+	if e.dialect == dialect.Postgres {
+		e.created(changes)
+	}
+	filtered := changes[:0]
+	for _, c := range changes {
+		m, ok := c.(*schema.ModifyTable)
+		if !ok {
+			filtered = append(filtered, c)
+			continue
+		}
+		modify := m.Changes[:0]
+		for _, c := range m.Changes {
+			mc, ok := c.(*schema.ModifyColumn)
+			if !ok || !mc.Change.Is(schema.ChangeType) {
+				modify = append(modify, c)
+				continue
+			}
+			from, _ := mc.From.Type.Type.(*schema.EnumType)
+			to, ok := mc.To.Type.Type.(*schema.EnumType)
+			if !ok {
+				modify = append(modify, c)
+				continue
+			}
+			if from != nil {
+				if err := e.checkRemoved(ctx, conn, m.T, mc.From, from, to); err != nil {
+					return nil, err
+				}
+			}
+			switch {
+			case e.dialect != dialect.Postgres:
+				modify = append(modify, c)
+				continue
+			case from != nil && from.T == to.T:
+				// Appending values is planned by Atlas (ALTER TYPE ... ADD VALUE).
+				if enumAppended(from.Values, to.Values) {
+					modify = append(modify, c)
+					continue
+				}
+				e.recreate(m.T, mc, to)
+			default:
+				e.convert(m.T, mc, from, to)
+			}
+			// The type change was planned above. Hence, the column is marked as
+			// unchanged for Atlas, and kept only if it has additional changes.
+			fromC, fromT := *mc.From, *mc.From.Type
+			fromT.Type, fromT.Raw = mc.To.Type.Type, mc.To.Type.Raw
+			fromC.Type = &fromT
+			mc.From = &fromC
+			if mc.Change &= ^schema.ChangeType; mc.Change != schema.NoChange {
+				modify = append(modify, mc)
+			}
+		}
+		if m.Changes = modify; len(m.Changes) > 0 {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered, nil
+}
+
+// created marks the native enum types that are created by Atlas as part of
+// creating new tables and columns, as existing types.
+func (e *enums) created(changes []schema.Change) {
+	mark := func(columns ...*schema.Column) {
+		for _, c := range columns {
+			if t, ok := c.Type.Type.(*schema.EnumType); ok {
+				e.types[t.T] = true
+			}
+		}
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddTable:
+			mark(c.T.Columns...)
+		case *schema.ModifyTable:
+			for _, c := range c.Changes {
+				if c, ok := c.(*schema.AddColumn); ok {
+					mark(c.C)
+				}
+			}
+		}
+	}
+}
+
+// checkRemoved returns an error if the enum values that are removed from the column are still in use.
+func (e *enums) checkRemoved(ctx context.Context, conn dialect.ExecQuerier, t *schema.Table, c *schema.Column, from, to *schema.EnumType) error {
+	var removed []any
+	for _, v := range from.Values {
+		if !hasValue(to.Values, v) {
+			removed = append(removed, v)
+		}
+	}
+	if conn == nil || len(removed) == 0 {
+		return nil
+	}
+	table := sql.Table(t.Name)
+	if t.Schema != nil && t.Schema.Name != "" {
+		table.Schema(t.Schema.Name)
+	}
+	rows := &sql.Rows{}
+	query, args := sql.Dialect(e.dialect).
+		SelectExpr(sql.Raw("1")).
+		From(table).
+		Where(sql.In(c.Name, removed...)).
+		Limit(1).
+		Query()
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return fmt.Errorf("sql/schema: check enum values of column %q: %w", c.Name, err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		return fmt.Errorf("sql/schema: cannot remove enum values %q from column %q of table %q, as they are still in use", removed, c.Name, t.Name)
+	}
+	return rows.Err()
+}
+
+// recreate plans the recreation of the native enum type with its new values.
+// The type is renamed, a new one is created, the column is converted to the
+// new type, and the old type is dropped after all its columns were converted.
+func (e *enums) recreate(t *schema.Table, c *schema.ModifyColumn, to *schema.EnumType) {
+	if _, ok := e.columns[to.T]; !ok {
+		e.recreated = append(e.recreated, to)
+	}
+	e.columns[to.T] = append(e.columns[to.T], e.alterType(t, c, to))
+}
+
+// convert plans the conversion of an existing column to a native enum type.
+func (e *enums) convert(t *schema.Table, c *schema.ModifyColumn, from, to *schema.EnumType) {
+	if !e.types[to.T] {
+		e.types[to.T] = true
+		e.after = append(e.after, &migrate.Change{
+			Cmd:     e.createType(to),
+			Comment: fmt.Sprintf("create enum type %q", to.T),
+		})
+	}
+	e.after = append(e.after, e.alterType(t, c, to))
+	// Drop the previous enum type, if it is not used by the desired state.
+	if from == nil || e.used(from.T) {
+		return
+	}
+	b := e.builder()
+	b.WriteString("DROP TYPE IF EXISTS ").Ident(from.T)
+	change := &migrate.Change{Cmd: b.String(), Comment: fmt.Sprintf("drop enum type %q", from.T)}
+	for _, d := range e.drop {
+		if d.Cmd == change.Cmd {
+			return
+		}
+	}
+	e.drop = append(e.drop, change)
+}
+
+// used reports if the native enum type is used by the tables.
+func (e *enums) used(name string) bool {
+	for _, t := range e.tables {
+		for _, c := range t.Columns {
+			if c.NativeEnum == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alterType returns the change for converting the column to the given native enum type.
+// The default value of the column is dropped before the conversion, as it cannot be cast
+// automatically, and set again after it.
+func (e *enums) alterType(t *schema.Table, c *schema.ModifyColumn, to *schema.EnumType) *migrate.Change {
+	b := e.builder()
+	b.WriteString("ALTER TABLE ").Ident(t.Name).Pad()
+	if c.From.Default != nil {
+		b.WriteString("ALTER COLUMN ").Ident(c.To.Name).WriteString(" DROP DEFAULT, ")
+	}
+	b.WriteString("ALTER COLUMN ").Ident(c.To.Name).WriteString(" TYPE ").Ident(to.T).
+		WriteString(" USING ").Ident(c.To.Name).WriteString("::text::").Ident(to.T)
+	if x, ok := enumDefault(c.To); ok {
+		b.WriteString(", ALTER COLUMN ").Ident(c.To.Name).WriteString(" SET DEFAULT ").WriteString(x)
+	}
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("modify %q table", t.Name),
+	}
+}
+
+// createType returns the statement for creating the native enum type.
+func (e *enums) createType(t *schema.EnumType) string {
+	b := e.builder()
+	b.WriteString("CREATE TYPE ").Ident(t.T).WriteString(" AS ENUM ").Wrap(func(b *sql.Builder) {
+		for i, v := range t.Values {
+			if i > 0 {
+				b.Comma()
+			}
+			b.WriteString(quoteValue(v))
+		}
+	})
+	return b.String()
+}
+
+// plan adds the enum changes to the plan. Recreated types are planned before the
+// changes computed by Atlas, and converted columns after them (as they may depend
+// on types created by Atlas).
+func (e *enums) plan(_ context.Context, _ dialect.ExecQuerier, _ []schema.Change, plan *migrate.Plan) error {
+	var before []*migrate.Change
+	for _, t := range e.recreated {
+		b := e.builder()
+		b.WriteString("ALTER TYPE ").Ident(t.T).WriteString(" RENAME TO ").Ident(t.T + "_old")
+		before = append(before, &migrate.Change{
+			Cmd:     b.String(),
+			Comment: fmt.Sprintf("rename enum type %q", t.T),
+		}, &migrate.Change{
+			Cmd:     e.createType(t),
+			Comment: fmt.Sprintf("create enum type %q", t.T),
+		})
+		before = append(before, e.columns[t.T]...)
+		b = e.builder()
+		b.WriteString("DROP TYPE ").Ident(t.T + "_old")
+		before = append(before, &migrate.Change{
+			Cmd:     b.String(),
+			Comment: fmt.Sprintf("drop enum type %q", t.T+"_old"),
+		})
+	}
+	plan.Changes = append(append(before, plan.Changes...), append(e.after, e.drop...)...)
+	return nil
+}
+
+// builder returns a new Postgres builder.
+func (e *enums) builder() *sql.Builder {
+	return builder(dialect.Postgres)
+}
+
+// enumDefault returns the default value expression of the enum column, if it was set.
+func enumDefault(c *schema.Column) (string, bool) {
+	switch x := c.Default.(type) {
+	case *schema.RawExpr:
+		return x.X, true
+	case *schema.Literal:
+		if len(x.V) > 1 && x.V[0] == '\'' && x.V[len(x.V)-1] == '\'' {
+			return x.V, true
+		}
+		return quoteValue(x.V), true
+	}
+	return "", false
+}
+
+// quoteValue returns the SQL string literal of the given value.
+func quoteValue(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// enumAppended reports if the enum values were changed only by appending new values.
+func enumAppended(from, to []string) bool {
+	if len(to) < len(from) {
+		return false
+	}
+	for i := range from {
+		if from[i] != to[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnum_AtTypeC(t *testing.T) {
+	c1 := &Column{Name: "status", Type: field.TypeEnum, Enums: []string{"active", "blocked"}}
+	c2 := schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&Postgres{}).atTypeC(c1, c2))
+	require.Equal(t, &schema.StringType{T: "varchar"}, c2.Type.Type)
+
+	c1.NativeEnum = "user_status"
+	c2 = schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&Postgres{}).atTypeC(c1, c2))
+	require.Equal(t, &schema.EnumType{T: "user_status", Values: []string{"active", "blocked"}}, c2.Type.Type)
+}
+
+func TestEnums_Postgres(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	conn := sql.OpenDB(dialect.Postgres, db)
+	mk.ExpectQuery(escape(`SELECT "t"."typname" FROM "pg_catalog"."pg_type" AS "t" JOIN "pg_catalog"."pg_namespace" AS "n" ON "n"."oid" = "t"."typnamespace" WHERE "t"."typtype" = $1 AND "n"."nspname" = $2`)).
+		WithArgs("e", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"typname"}).AddRow("user_status"))
+	mk.ExpectQuery(escape(`SELECT 1 FROM "public"."users" WHERE "status" IN ($1) LIMIT 1`)).
+		WithArgs("blocked").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	users := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "status", Type: field.TypeEnum, Enums: []string{"inactive", "active"}, NativeEnum: "user_status"},
+			{Name: "role", Type: field.TypeEnum, Enums: []string{"user", "admin", "owner"}, NativeEnum: "user_role"},
+			{Name: "level", Type: field.TypeEnum, Enums: []string{"low", "high"}, NativeEnum: "user_level"},
+		},
+	}
+	en := newEnums(dialect.Postgres, []*Table{users})
+	current := schema.New("public")
+	require.NoError(t, en.inspect(context.Background(), conn, current))
+
+	var (
+		t1  = schema.NewTable("users").SetSchema(current)
+		col = func(name string, typ schema.Type, x schema.Expr) *schema.Column {
+			return &schema.Column{Name: name, Type: &schema.ColumnType{Type: typ}, Default: x}
+		}
+		status   = &schema.EnumType{T: "user_status", Values: []string{"inactive", "active"}}
+		role     = &schema.EnumType{T: "user_role", Values: []string{"user", "admin", "owner"}}
+		level    = &schema.EnumType{T: "user_level", Values: []string{"low", "high"}}
+		appended = &schema.ModifyColumn{
+			From:   col("level", &schema.EnumType{T: "user_level", Values: []string{"low"}}, nil),
+			To:     col("level", level, nil),
+			Change: schema.ChangeType,
+		}
+	)
+	changes, err := en.changes(context.Background(), conn, []schema.Change{
+		&schema.ModifyTable{
+			T: t1,
+			Changes: []schema.Change{
+				// Values were removed and reordered.
+				&schema.ModifyColumn{
+					From:   col("status", &schema.EnumType{T: "user_status", Values: []string{"active", "blocked", "inactive"}}, &schema.RawExpr{X: "'active'::user_status"}),
+					To:     col("status", status, &schema.RawExpr{X: "'active'"}),
+					Change: schema.ChangeType | schema.ChangeDefault,
+				},
+				// Converted from varchar.
+				&schema.ModifyColumn{
+					From:   col("role", &schema.StringType{T: "character varying"}, nil),
+					To:     col("role", role, nil),
+					Change: schema.ChangeType,
+				},
+				// Values were appended.
+				appended,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mk.ExpectationsWereMet())
+	require.Len(t, changes, 1)
+	m := changes[0].(*schema.ModifyTable)
+	require.Len(t, m.Changes, 2, "type changes that were planned by the handler are removed")
+	require.Equal(t, schema.ChangeDefault, m.Changes[0].(*schema.ModifyColumn).Change)
+	require.Equal(t, appended, m.Changes[1])
+
+	plan := &migrate.Plan{Changes: []*migrate.Change{{Cmd: `ALTER TYPE "user_level" ADD VALUE 'high'`}}}
+	require.NoError(t, en.plan(context.Background(), nil, nil, plan))
+	cmds := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		cmds[i] = c.Cmd
+	}
+	require.Equal(t, []string{
+		`ALTER TYPE "user_status" RENAME TO "user_status_old"`,
+		`CREATE TYPE "user_status" AS ENUM ('inactive', 'active')`,
+		`ALTER TABLE "users" ALTER COLUMN "status" DROP DEFAULT, ALTER COLUMN "status" TYPE "user_status" USING "status"::text::"user_status", ALTER COLUMN "status" SET DEFAULT 'active'`,
+		`DROP TYPE "user_status_old"`,
+		`ALTER TYPE "user_level" ADD VALUE 'high'`,
+		`CREATE TYPE "user_role" AS ENUM ('user', 'admin', 'owner')`,
+		`ALTER TABLE "users" ALTER COLUMN "role" TYPE "user_role" USING "role"::text::"user_role"`,
+	}, cmds)
+}
+
+func TestEnums_ValuesInUse(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT 1 FROM `users` WHERE `status` IN (?, ?) LIMIT 1")).
+		WithArgs("blocked", "deleted").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	changes := []schema.Change{
+		&schema.ModifyTable{
+			T: schema.NewTable("users"),
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   schema.NewEnumColumn("status", schema.EnumValues("active", "blocked", "deleted")),
+					To:     schema.NewEnumColumn("status", schema.EnumValues("active")),
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	}
+	en := newEnums(dialect.MySQL, nil)
+	_, err = en.changes(context.Background(), sql.OpenDB(dialect.MySQL, db), changes)
+	require.EqualError(t, err, `sql/schema: cannot remove enum values ["blocked" "deleted"] from column "status" of table "users", as they are still in use`)
+	require.NoError(t, mk.ExpectationsWereMet())
+
+	// Values are not checked in replay mode, and MySQL enums are planned by Atlas.
+	changes, err = en.changes(context.Background(), nil, changes)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+}
@@ -0,0 +1,516 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// A LintCode identifies the kind of risky or destructive change
+// that was reported by the migration linter.
+type LintCode string
+
+// List of lint codes.
+const (
+	// LintDropTable reports a table removal.
+	LintDropTable LintCode = "drop_table"
+	// LintDropColumn reports a column removal.
+	LintDropColumn LintCode = "drop_column"
+	// LintNarrowType reports a column type change that may truncate
+	// or lose data. For example, varchar(255) to varchar(100).
+	LintNarrowType LintCode = "narrow_type"
+	// LintNotNull reports a change that adds a NOT NULL constraint
+	// (or a non-nullable column without a default value) to a table
+	// that may contain rows.
+	LintNotNull LintCode = "not_null"
+	// LintUniqueIndex reports a unique index that is added to a table
+	// that may contain rows, and therefore, may fail on duplicate values.
+	LintUniqueIndex LintCode = "unique_index"
+	// LintTableRewrite reports a change that rebuilds (copies) the table
+	// on MySQL, and locks it for writes during the migration.
+	LintTableRewrite LintCode = "table_rewrite"
+)
+
+// Destructive reports if the code describes a destructive change,
+// i.e. a change that drops data from the database.
+func (c LintCode) Destructive() bool {
+	switch c {
+	case LintDropTable, LintDropColumn, LintNarrowType:
+		return true
+	default:
+		return false
+	}
+}
+
+type (
+	// Diagnostic describes a risky or destructive schema change
+	// that was reported by the migration linter.
+	Diagnostic struct {
+		Code   LintCode      `json:"code"`
+		Table  string        `json:"table"`
+		Column string        `json:"column,omitempty"`
+		Index  string        `json:"index,omitempty"`
+		Text   string        `json:"text"`
+		Change schema.Change `json:"-"`
+	}
+
+	// LintReport holds the diagnostics reported by the migration linter.
+	LintReport struct {
+		Diagnostics []*Diagnostic `json:"diagnostics"`
+	}
+
+	// LintFunc is called with the migration lint report before the changes are
+	// applied on the database (or written to the migration directory). Returning
+	// an error aborts the migration.
+	LintFunc func(context.Context, *LintReport) error
+
+	// LintError is returned by the migration when the lint
+	// policy (e.g. LintDeny) denies the planned changes.
+	LintError struct {
+		Diagnostics []*Diagnostic
+	}
+)
+
+// Destructive reports if the report contains destructive changes.
+func (r *LintReport) Destructive() bool {
+	for _, d := range r.Diagnostics {
+		if d.Code.Destructive() {
+			return true
+		}
+	}
+	return false
+}
+
+// Error implements the error interface.
+func (e *LintError) Error() string {
+	texts := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		texts[i] = fmt.Sprintf("%s (%s)", d.Text, d.Code)
+	}
+	return fmt.Sprintf("migration denied by linter: %s", strings.Join(texts, "; "))
+}
+
+// WithLint enables the migration linter. The linter analyzes the planned
+// changes and calls fn with the report before the changes are applied on
+// the database, or written to the migration directory. For example:
+//
+//	schema.WithLint(func(ctx context.Context, r *schema.LintReport) error {
+//		for _, d := range r.Diagnostics {
+//			log.Printf("%s: %s", d.Code, d.Text)
+//		}
+//		if r.Destructive() {
+//			return errors.New("destructive changes are not allowed")
+//		}
+//		return nil
+//	})
+//
+// Note that, when the migration state is computed by inspecting the database,
+// the linter checks if the modified tables contain rows. In replay mode, or
+// when the database cannot be queried, all tables are considered non-empty.
+func WithLint(fn LintFunc) MigrateOption {
+	return func(a *Atlas) {
+		a.lint = fn
+	}
+}
+
+// LintDeny returns a LintFunc that aborts the migration with a *LintError if one
+// of the diagnostics matches the given codes. If no codes were provided, all
+// diagnostics are denied.
+//
+//	schema.WithLint(schema.LintDeny(schema.LintDropTable, schema.LintDropColumn))
+func LintDeny(codes ...LintCode) LintFunc {
+	return func(_ context.Context, r *LintReport) error {
+		var denied []*Diagnostic
+		for _, d := range r.Diagnostics {
+			if len(codes) == 0 || hasCode(codes, d.Code) {
+				denied = append(denied, d)
+			}
+		}
+		if len(denied) > 0 {
+			return &LintError{Diagnostics: denied}
+		}
+		return nil
+	}
+}
+
+func hasCode(codes []LintCode, c LintCode) bool {
+	for i := range codes {
+		if codes[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint computes the changes between the database and the given tables, and
+// returns the lint report without applying them. The option configured by
+// WithLint (if any) is also executed.
+func (a *Atlas) Lint(ctx context.Context, tables ...*Table) (*LintReport, error) {
+	if a.legacy {
+		return nil, errors.New("sql/schema: linting is not supported by the legacy migration engine")
+	}
+	var (
+		report *LintReport
+		lint   = a.lint
+		hooks  = a.applyHook
+	)
+	defer func() { a.lint, a.applyHook = lint, hooks }()
+	a.lint = func(ctx context.Context, r *LintReport) error {
+		report = r
+		if lint != nil {
+			return lint(ctx, r)
+		}
+		return nil
+	}
+	// Skip applying the changes.
+	a.applyHook = []ApplyHook{func(Applier) Applier {
+		return ApplyFunc(func(context.Context, dialect.ExecQuerier, *migrate.Plan) error { return nil })
+	}}
+	if err := a.Create(ctx, tables...); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// linter analyzes the changes computed by the differ.
+type linter struct {
+	dialect string
+	conn    dialect.ExecQuerier // optional connection for inspecting table rows.
+	rows    map[string]bool     // cache for tables' rows checking.
+}
+
+// analyze analyzes the changes and returns the lint report.
+func (l *linter) analyze(ctx context.Context, changes []schema.Change) (*LintReport, error) {
+	r := &LintReport{}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.DropTable:
+			r.Diagnostics = append(r.Diagnostics, &Diagnostic{
+				Code:   LintDropTable,
+				Table:  c.T.Name,
+				Text:   fmt.Sprintf("dropping table %q", c.T.Name),
+				Change: c,
+			})
+		case *schema.ModifyTable:
+			ds, err := l.modifyTable(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			r.Diagnostics = append(r.Diagnostics, ds...)
+		}
+	}
+	return r, nil
+}
+
+// modifyTable analyzes the changes of a table modification.
+func (l *linter) modifyTable(ctx context.Context, m *schema.ModifyTable) ([]*Diagnostic, error) {
+	var (
+		ds      []*Diagnostic
+		rewrite bool
+		t       = m.T.Name
+	)
+	for _, c := range m.Changes {
+		switch c := c.(type) {
+		case *schema.DropColumn:
+			rewrite = true
+			ds = append(ds, &Diagnostic{
+				Code:   LintDropColumn,
+				Table:  t,
+				Column: c.C.Name,
+				Text:   fmt.Sprintf("dropping column %q from table %q", c.C.Name, t),
+				Change: c,
+			})
+		case *schema.AddColumn:
+			if c.C.Type.Null || c.C.Default != nil {
+				continue
+			}
+			switch populated, err := l.populated(ctx, m.T); {
+			case err != nil:
+				return nil, err
+			case populated:
+				ds = append(ds, &Diagnostic{
+					Code:   LintNotNull,
+					Table:  t,
+					Column: c.C.Name,
+					Text:   fmt.Sprintf("adding non-nullable column %q without a default value to table %q may fail if it contains rows", c.C.Name, t),
+					Change: c,
+				})
+			}
+		case *schema.ModifyColumn:
+			if c.Change.Is(schema.ChangeType) {
+				rewrite = rewrite || !inplaceEnum(c.From.Type, c.To.Type)
+				if reason, ok := narrowType(c.From.Type, c.To.Type); ok {
+					ds = append(ds, &Diagnostic{
+						Code:   LintNarrowType,
+						Table:  t,
+						Column: c.From.Name,
+						Text:   fmt.Sprintf("changing the type of column %q in table %q %s", c.From.Name, t, reason),
+						Change: c,
+					})
+				}
+			}
+			if c.Change.Is(schema.ChangeCharset) || c.Change.Is(schema.ChangeCollate) {
+				rewrite = true
+			}
+			if c.Change.Is(schema.ChangeNull) && !c.To.Type.Null {
+				rewrite = true
+				switch populated, err := l.populated(ctx, m.T); {
+				case err != nil:
+					return nil, err
+				case populated:
+					ds = append(ds, &Diagnostic{
+						Code:   LintNotNull,
+						Table:  t,
+						Column: c.From.Name,
+						Text:   fmt.Sprintf("modifying nullable column %q in table %q to non-nullable may fail if it contains NULL values", c.From.Name, t),
+						Change: c,
+					})
+				}
+			}
+		case *schema.AddIndex:
+			if !c.I.Unique {
+				continue
+			}
+			d, err := l.uniqueIndex(ctx, m.T, c.I, c)
+			if err != nil {
+				return nil, err
+			}
+			if d != nil {
+				ds = append(ds, d)
+			}
+		case *schema.ModifyIndex:
+			if !c.Change.Is(schema.ChangeUnique) || !c.To.Unique {
+				continue
+			}
+			d, err := l.uniqueIndex(ctx, m.T, c.To, c)
+			if err != nil {
+				return nil, err
+			}
+			if d != nil {
+				ds = append(ds, d)
+			}
+		case *schema.ModifyAttr:
+			switch c.To.(type) {
+			case *schema.Charset, *schema.Collation:
+				rewrite = true
+			}
+		}
+	}
+	if rewrite && l.dialect == dialect.MySQL {
+		ds = append(ds, &Diagnostic{
+			Code:   LintTableRewrite,
+			Table:  t,
+			Text:   fmt.Sprintf("modifying table %q requires a table rebuild, and blocks concurrent writes during the migration", t),
+			Change: m,
+		})
+	}
+	return ds, nil
+}
+
+// uniqueIndex returns a diagnostic for a unique index that is added to a non-empty table.
+func (l *linter) uniqueIndex(ctx context.Context, t *schema.Table, idx *schema.Index, c schema.Change) (*Diagnostic, error) {
+	populated, err := l.populated(ctx, t)
+	if err != nil || !populated {
+		return nil, err
+	}
+	return &Diagnostic{
+		Code:   LintUniqueIndex,
+		Table:  t.Name,
+		Index:  idx.Name,
+		Text:   fmt.Sprintf("adding unique index %q to table %q may fail if it contains duplicate values", idx.Name, t.Name),
+		Change: c,
+	}, nil
+}
+
+// populated reports if the given table contains rows. Tables
+// are considered populated if the connection is unavailable.
+func (l *linter) populated(ctx context.Context, t *schema.Table) (bool, error) {
+	if l.conn == nil {
+		return true, nil
+	}
+	if v, ok := l.rows[t.Name]; ok {
+		return v, nil
+	}
+	table := entsql.Table(t.Name)
+	if t.Schema != nil && t.Schema.Name != "" {
+		table.Schema(t.Schema.Name)
+	}
+	rows := &entsql.Rows{}
+	query, args := entsql.Dialect(l.dialect).
+		SelectExpr(entsql.Raw("1")).
+		From(table).
+		Limit(1).
+		Query()
+	if err := l.conn.Query(ctx, query, args, rows); err != nil {
+		return false, fmt.Errorf("sql/schema: check rows of table %q: %w", t.Name, err)
+	}
+	defer rows.Close()
+	populated := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if l.rows == nil {
+		l.rows = make(map[string]bool)
+	}
+	l.rows[t.Name] = populated
+	return populated, nil
+}
+
+// inplaceEnum reports if the column type change only appends values to an enum,
+// which is done by MySQL in-place, unless the storage size of the column is changed
+// (i.e. the number of values exceeds 255).
+func inplaceEnum(from, to *schema.ColumnType) bool {
+	f, ok1 := from.Type.(*schema.EnumType)
+	t, ok2 := to.Type.(*schema.EnumType)
+	return ok1 && ok2 && enumAppended(f.Values, t.Values) && (len(f.Values) > 255 || len(t.Values) <= 255)
+}
+
+// narrowType reports if changing the column type from one type to another
+// may truncate or lose data, and returns the reason for it.
+func narrowType(from, to *schema.ColumnType) (string, bool) {
+	switch f := from.Type.(type) {
+	case *schema.StringType:
+		if t, ok := to.Type.(*schema.StringType); ok {
+			if f.Size != 0 && t.Size != 0 && t.Size < f.Size || f.Size == 0 && t.Size != 0 {
+				return fmt.Sprintf("from %s to %s may truncate data", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.BinaryType:
+		if t, ok := to.Type.(*schema.BinaryType); ok {
+			if f.Size != nil && t.Size != nil && *t.Size < *f.Size || f.Size == nil && t.Size != nil {
+				return fmt.Sprintf("from %s to %s may truncate data", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.IntegerType:
+		if t, ok := to.Type.(*schema.IntegerType); ok {
+			if intRank(t.T) < intRank(f.T) || f.Unsigned != t.Unsigned {
+				return fmt.Sprintf("from %s to %s may overflow existing values", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.FloatType:
+		if t, ok := to.Type.(*schema.FloatType); ok {
+			if floatRank(t) < floatRank(f) {
+				return fmt.Sprintf("from %s to %s may lose precision", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.DecimalType:
+		if t, ok := to.Type.(*schema.DecimalType); ok {
+			if t.Precision < f.Precision || t.Scale < f.Scale {
+				return fmt.Sprintf("from %s to %s may lose precision", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.TimeType:
+		if t, ok := to.Type.(*schema.TimeType); ok {
+			if f.Precision != nil && t.Precision != nil && *t.Precision < *f.Precision {
+				return fmt.Sprintf("from %s to %s may lose precision", typeName(from), typeName(to)), true
+			}
+			return "", false
+		}
+	case *schema.EnumType:
+		if t, ok := to.Type.(*schema.EnumType); ok {
+			for _, v := range f.Values {
+				if !hasValue(t.Values, v) {
+					return fmt.Sprintf("removes the enum value %q", v), true
+				}
+			}
+			return "", false
+		}
+	}
+	// Changing from one type family to another
+	// (e.g. from text to integer) may fail or lose data.
+	return fmt.Sprintf("from %s to %s may fail or lose data", typeName(from), typeName(to)), true
+}
+
+// typeName returns the database type name of the column type.
+func typeName(c *schema.ColumnType) string {
+	if c.Raw != "" {
+		return c.Raw
+	}
+	switch t := c.Type.(type) {
+	case *schema.StringType:
+		if t.Size > 0 {
+			return fmt.Sprintf("%s(%d)", t.T, t.Size)
+		}
+		return t.T
+	case *schema.BinaryType:
+		return t.T
+	case *schema.IntegerType:
+		if t.Unsigned {
+			return t.T + " unsigned"
+		}
+		return t.T
+	case *schema.FloatType:
+		return t.T
+	case *schema.DecimalType:
+		return fmt.Sprintf("%s(%d,%d)", t.T, t.Precision, t.Scale)
+	case *schema.TimeType:
+		return t.T
+	case *schema.EnumType:
+		return fmt.Sprintf("enum(%s)", strings.Join(t.Values, ","))
+	case *schema.BoolType:
+		return t.T
+	case *schema.JSONType:
+		return t.T
+	case *schema.UUIDType:
+		return t.T
+	case *schema.SpatialType:
+		return t.T
+	case *schema.UnsupportedType:
+		return t.T
+	default:
+		return fmt.Sprintf("%T", t)
+	}
+}
+
+// intRank returns the storage size rank of an integer type.
+func intRank(t string) int {
+	switch strings.ToLower(t) {
+	case "tinyint":
+		return 1
+	case "smallint", "int2", "smallserial":
+		return 2
+	case "mediumint":
+		return 3
+	case "int", "integer", "int4", "serial":
+		return 4
+	default: // bigint, int8, bigserial and sqlite integers.
+		return 5
+	}
+}
+
+// floatRank returns the precision rank of a floating-point type.
+func floatRank(t *schema.FloatType) int {
+	if t.Precision > 0 {
+		return t.Precision
+	}
+	switch strings.ToLower(t.T) {
+	case "float", "real", "float4":
+		return 24
+	default:
+		return 53
+	}
+}
+
+func hasValue(values []string, v string) bool {
+	for i := range values {
+		if values[i] == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinter_Analyze(t *testing.T) {
+	var (
+		users = schema.NewTable("users")
+		col   = func(name string, t schema.Type, null bool) *schema.Column {
+			return &schema.Column{Name: name, Type: &schema.ColumnType{Type: t, Null: null}}
+		}
+	)
+	changes := []schema.Change{
+		&schema.DropTable{T: schema.NewTable("pets")},
+		&schema.AddTable{T: schema.NewTable("groups")},
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.DropColumn{C: col("age", &schema.IntegerType{T: "bigint"}, false)},
+				&schema.AddColumn{C: col("nickname", &schema.StringType{T: "varchar", Size: 255}, true)},
+				&schema.AddColumn{C: col("name", &schema.StringType{T: "varchar", Size: 255}, false)},
+				&schema.AddColumn{C: &schema.Column{Name: "active", Type: &schema.ColumnType{Type: &schema.BoolType{T: "bool"}}, Default: &schema.Literal{V: "true"}}},
+				&schema.ModifyColumn{
+					From:   col("title", &schema.StringType{T: "varchar", Size: 255}, false),
+					To:     col("title", &schema.StringType{T: "varchar", Size: 100}, false),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   col("bio", &schema.StringType{T: "varchar", Size: 100}, false),
+					To:     col("bio", &schema.StringType{T: "varchar", Size: 255}, false),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   col("count", &schema.IntegerType{T: "bigint"}, false),
+					To:     col("count", &schema.IntegerType{T: "int"}, false),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   col("state", &schema.EnumType{Values: []string{"on", "off"}}, false),
+					To:     col("state", &schema.EnumType{Values: []string{"on"}}, false),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   col("code", &schema.StringType{T: "varchar", Size: 10}, false),
+					To:     col("code", &schema.IntegerType{T: "bigint"}, false),
+					Change: schema.ChangeType,
+				},
+				&schema.ModifyColumn{
+					From:   col("email", &schema.StringType{T: "varchar", Size: 255}, true),
+					To:     col("email", &schema.StringType{T: "varchar", Size: 255}, false),
+					Change: schema.ChangeNull,
+				},
+				&schema.AddIndex{I: &schema.Index{Name: "users_email", Unique: true}},
+				&schema.AddIndex{I: &schema.Index{Name: "users_name"}},
+			},
+		},
+	}
+	r, err := (&linter{dialect: dialect.MySQL}).analyze(context.Background(), changes)
+	require.NoError(t, err)
+	codes := make([]LintCode, len(r.Diagnostics))
+	for i, d := range r.Diagnostics {
+		codes[i] = d.Code
+		require.Equal(t, d.Code != LintDropTable, d.Table == "users")
+		require.NotNil(t, d.Change)
+	}
+	require.Equal(t, []LintCode{
+		LintDropTable,
+		LintDropColumn,
+		LintNotNull,
+		LintNarrowType,
+		LintNarrowType,
+		LintNarrowType,
+		LintNarrowType,
+		LintNotNull,
+		LintUniqueIndex,
+		LintTableRewrite,
+	}, codes)
+	require.True(t, r.Destructive())
+	require.Equal(t, `changing the type of column "title" in table "users" from varchar(255) to varchar(100) may truncate data`, r.Diagnostics[3].Text)
+	require.Equal(t, `changing the type of column "state" in table "users" removes the enum value "off"`, r.Diagnostics[5].Text)
+	require.Equal(t, `changing the type of column "code" in table "users" from varchar(10) to bigint may fail or lose data`, r.Diagnostics[6].Text)
+	require.Equal(t, "users_email", r.Diagnostics[8].Index)
+
+	// Table rewrites are reported only on MySQL.
+	r, err = (&linter{dialect: dialect.Postgres}).analyze(context.Background(), changes)
+	require.NoError(t, err)
+	require.Len(t, r.Diagnostics, 9)
+	require.NotEqual(t, LintTableRewrite, r.Diagnostics[8].Code)
+
+	// Appending enum values is done in-place on MySQL.
+	r, err = (&linter{dialect: dialect.MySQL}).analyze(context.Background(), []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   col("state", &schema.EnumType{T: "enum", Values: []string{"on", "off"}}, false),
+					To:     col("state", &schema.EnumType{T: "enum", Values: []string{"on", "off", "unknown"}}, false),
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, r.Diagnostics)
+}
+
+func TestAtlas_Lint(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:lint?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+
+	users := func() *Table {
+		return NewTable("users").
+			AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+			AddColumn(&Column{Name: "name", Type: field.TypeString}).
+			AddColumn(&Column{Name: "age", Type: field.TypeInt, Nullable: true})
+	}
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users()))
+
+	// New tables do not report diagnostics.
+	m, err = NewMigrate(drv, WithDropColumn(true))
+	require.NoError(t, err)
+	r, err := m.Lint(ctx, users(), NewTable("pets").AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}))
+	require.NoError(t, err)
+	require.Empty(t, r.Diagnostics)
+
+	// Linting does not apply changes.
+	exists, err := (&SQLite{Driver: drv}).tableExist(ctx, drv, "pets")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Empty tables do not report data-dependent changes.
+	withEmail := users().AddColumn(&Column{Name: "email", Type: field.TypeString, Unique: true})
+	r, err = m.Lint(ctx, withEmail)
+	require.NoError(t, err)
+	require.Empty(t, r.Diagnostics)
+
+	_, err = drv.ExecContext(ctx, "INSERT INTO `users` (`name`) VALUES ('a8m')")
+	require.NoError(t, err)
+	r, err = m.Lint(ctx, withEmail)
+	require.NoError(t, err)
+	require.Len(t, r.Diagnostics, 2)
+	require.Equal(t, LintNotNull, r.Diagnostics[0].Code)
+	require.Equal(t, "email", r.Diagnostics[0].Column)
+	require.Equal(t, LintUniqueIndex, r.Diagnostics[1].Code)
+
+	// Destructive changes.
+	withoutAge := NewTable("users").
+		AddPrimary(&Column{Name: "id", Type: field.TypeInt, Increment: true}).
+		AddColumn(&Column{Name: "name", Type: field.TypeString})
+	r, err = m.Lint(ctx, withoutAge)
+	require.NoError(t, err)
+	require.Len(t, r.Diagnostics, 1)
+	require.Equal(t, LintDropColumn, r.Diagnostics[0].Code)
+	require.True(t, r.Destructive())
+
+	// Lint policy fails the migration.
+	m, err = NewMigrate(drv, WithDropColumn(true), WithLint(LintDeny(LintDropColumn)))
+	require.NoError(t, err)
+	err = m.Create(ctx, withoutAge)
+	var lerr *LintError
+	require.True(t, errors.As(err, &lerr))
+	require.Len(t, lerr.Diagnostics, 1)
+	require.EqualError(t, err, `sql/schema: migration denied by linter: dropping column "age" from table "users" (drop_column)`)
+	// Changes were not applied.
+	m, err = NewMigrate(drv, WithDropColumn(true))
+	require.NoError(t, err)
+	r, err = m.Lint(ctx, withoutAge)
+	require.NoError(t, err)
+	require.Len(t, r.Diagnostics, 1)
+
+	// Non-matching codes are allowed.
+	m, err = NewMigrate(drv, WithDropColumn(true), WithLint(LintDeny(LintDropTable)))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, withoutAge))
+}
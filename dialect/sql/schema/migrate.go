@@ -139,6 +139,13 @@ func (m *Migrate) Create(ctx context.Context, tables ...*Table) error {
 }
 
 func (m *Migrate) create(ctx context.Context, tables ...*Table) error {
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Generated != nil {
+				return fmt.Errorf("sql/schema: generated column %q of table %q is not supported by the legacy migration engine", c.Name, t.Name)
+			}
+		}
+	}
 	if err := m.init(ctx); err != nil {
 		return err
 	}
@@ -15,9 +15,11 @@ import (
 	"time"
 
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqltool"
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"github.com/DATA-DOG/go-sqlmock"
@@ -307,6 +309,103 @@ func TestMigrate_Diff(t *testing.T) {
 	require.NoError(t, migrate.Validate(d))
 }
 
+func TestMigrate_GeneratedColumns(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:generated?mode=memory&_fk=1")
+	require.NoError(t, err)
+	items := &Table{
+		Name: "items",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "price", Type: field.TypeInt},
+			{Name: "quantity", Type: field.TypeInt},
+			{Name: "total", Type: field.TypeInt, Generated: &entsql.GeneratedColumn{Expr: "price * quantity"}},
+			{Name: "first_name", Type: field.TypeString},
+			{Name: "last_name", Type: field.TypeString},
+			{Name: "full_name", Type: field.TypeString, Generated: &entsql.GeneratedColumn{Expr: "first_name || ' ' || last_name"}},
+			{Name: "label", Type: field.TypeString, Nullable: true, Generated: &entsql.GeneratedColumn{
+				Exprs: map[string]string{dialect.SQLite: "'item-' || id"},
+				Type:  entsql.Virtual,
+			}},
+			// Not generated in the configured dialect.
+			{Name: "name", Type: field.TypeString, Default: "unknown", Generated: &entsql.GeneratedColumn{
+				Exprs: map[string]string{dialect.MySQL: "'unknown'"},
+			}},
+		},
+	}
+	items.PrimaryKey = items.Columns[:1]
+
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "items", items))
+	requireFileEqual(t, filepath.Join(p, "items.sql"), "CREATE TABLE `items` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `price` integer NOT NULL, `quantity` integer NOT NULL, `total` integer NOT NULL AS (price * quantity) STORED, `first_name` text NOT NULL, `last_name` text NOT NULL, `full_name` text NOT NULL AS (first_name || ' ' || last_name) STORED, `label` text NULL AS ('item-' || id) VIRTUAL, `name` text NOT NULL DEFAULT 'unknown');\n")
+
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, items))
+	_, err = db.ExecContext(ctx, "INSERT INTO `items` (`price`, `quantity`, `first_name`, `last_name`) VALUES (10, 3, 'a8m', 'Mashraki')")
+	require.NoError(t, err)
+	rows, err := db.QueryContext(ctx, "SELECT `total`, `full_name`, `label` FROM `items`")
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	var (
+		total           int
+		fullName, label string
+	)
+	require.NoError(t, rows.Scan(&total, &fullName, &label))
+	require.NoError(t, rows.Close())
+	require.Equal(t, 30, total)
+	require.Equal(t, "a8m Mashraki", fullName)
+	require.Equal(t, "item-1", label)
+
+	// No changes are planned for an up-to-date schema.
+	p = t.TempDir()
+	d, err = migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "noop", items))
+	require.NoFileExists(t, filepath.Join(p, "noop.sql"))
+
+	// Generated columns are not supported by the legacy engine.
+	m, err = NewMigrate(db, WithAtlas(false))
+	require.NoError(t, err)
+	require.EqualError(t, m.Create(ctx, items), `sql/schema: generated column "total" of table "items" is not supported by the legacy migration engine`)
+}
+
+func TestNormalizeGenerated(t *testing.T) {
+	table := func(x string) *schema.Table {
+		return schema.NewTable("users").AddColumns(
+			schema.NewStringColumn("first_name", "varchar"),
+			schema.NewStringColumn("last_name", "varchar"),
+			schema.NewStringColumn("full_name", "varchar").SetGeneratedExpr(&schema.GeneratedExpr{Expr: x, Type: "STORED"}),
+		)
+	}
+	// The normalized form of the expression, as stored by Postgres.
+	current := schema.New("public").AddTables(table(`(((first_name)::text || ' '::text) || (last_name)::text)`))
+	desired := schema.New("public").AddTables(table("first_name || ' ' || last_name"))
+	_, err := postgres.DefaultDiff.SchemaDiff(current, desired)
+	require.EqualError(t, err, `changing the generation expression for a column "full_name" is not supported`)
+	normalizeGenerated(current, desired)
+	changes, err := postgres.DefaultDiff.SchemaDiff(current, desired)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Actual changes are not normalized.
+	desired = schema.New("public").AddTables(table("last_name || ' ' || first_name"))
+	normalizeGenerated(current, desired)
+	_, err = postgres.DefaultDiff.SchemaDiff(current, desired)
+	require.Error(t, err)
+}
+
 func requireFileEqual(t *testing.T, name, contents string) {
 	c, err := os.ReadFile(name)
 	require.NoError(t, err)
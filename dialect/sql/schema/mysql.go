@@ -280,6 +280,11 @@ func (d *MySQL) cType(c *Column) (t string) {
 		t = fmt.Sprintf("enum(%s)", strings.Join(values, ", "))
 	case field.TypeUUID:
 		t = "char(36) binary"
+	case field.TypeGeometry:
+		t = "geometry"
+	case field.TypeRange:
+		// Ranges are stored in their text representation.
+		t = "varchar(255)"
 	case field.TypeOther:
 		t = c.typ
 	default:
@@ -829,6 +834,9 @@ func (d *MySQL) supportsDefault(c *Column) bool {
 }
 
 func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
+	if c1.Type == field.TypeGeometry {
+		return d.atSpatialC(c1, c2)
+	}
 	if c1.SchemaType != nil && c1.SchemaType[dialect.MySQL] != "" {
 		t, err := mysql.ParseType(strings.ToLower(c1.SchemaType[dialect.MySQL]))
 		if err != nil {
@@ -894,6 +902,8 @@ func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
 		}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = &schema.FloatType{T: c1.scanTypeOr(mysql.TypeDouble)}
+	case field.TypeRange:
+		t = &schema.StringType{T: mysql.TypeVarchar, Size: 255}
 	case field.TypeTime:
 		t = &schema.TimeType{T: c1.scanTypeOr(mysql.TypeTimestamp)}
 		// In MariaDB or in MySQL < v8.0.2, the TIMESTAMP column has both `DEFAULT CURRENT_TIMESTAMP`
@@ -920,6 +930,68 @@ func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
 	return nil
 }
 
+// atSpatialC sets the spatial type of the column. The SRID attribute
+// is kept as part of the type (e.g. "point srid 4326"), if supported.
+func (d *MySQL) atSpatialC(c1 *Column, c2 *schema.Column) error {
+	typ := "geometry"
+	if c1.SchemaType != nil && c1.SchemaType[dialect.MySQL] != "" {
+		typ = strings.ToLower(c1.SchemaType[dialect.MySQL])
+	}
+	base, srid, ok := strings.Cut(typ, " srid ")
+	t, err := mysql.ParseType(base)
+	if err != nil {
+		return err
+	}
+	if s, isSpatial := t.(*schema.SpatialType); isSpatial && ok && d.supportsSRID() {
+		s.T = base + " srid " + strings.TrimSpace(srid)
+	}
+	c2.Type.Type = t
+	return nil
+}
+
+// supportsSRID reports if the database supports restricting
+// spatial columns to a spatial reference system (SRID).
+func (d *MySQL) supportsSRID() bool {
+	_, maria := d.mariadb()
+	return !maria && compareVersions(d.version, "8.0.3") >= 0
+}
+
+// inspectSpatial sets the SRID attribute of the spatial columns
+// in the given schema, as it is not inspected by Atlas.
+func (d *MySQL) inspectSpatial(ctx context.Context, conn dialect.ExecQuerier, s *schema.Schema) error {
+	if !d.supportsSRID() {
+		return nil
+	}
+	rows := &sql.Rows{}
+	query, args := sql.Select("TABLE_NAME", "COLUMN_NAME", "SRS_ID").
+		From(sql.Table("COLUMNS").Schema("INFORMATION_SCHEMA")).
+		Where(sql.And(d.matchSchema(), sql.NotNull("SRS_ID"))).
+		Query()
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return fmt.Errorf("mysql: reading spatial columns: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			table, column string
+			srid          int
+		)
+		if err := rows.Scan(&table, &column, &srid); err != nil {
+			return fmt.Errorf("mysql: scanning spatial column: %w", err)
+		}
+		t, ok := s.Table(table)
+		if !ok {
+			continue
+		}
+		if c, ok := t.Column(column); ok {
+			if st, ok := c.Type.Type.(*schema.SpatialType); ok {
+				st.T = fmt.Sprintf("%s srid %d", st.T, srid)
+			}
+		}
+	}
+	return rows.Err()
+}
+
 func (d *MySQL) atUniqueC(t1 *Table, c1 *Column, t2 *schema.Table, c2 *schema.Column) {
 	// For UNIQUE columns, MySQL create an implicit index
 	// named as the column with an extra index in case the
@@ -971,6 +1043,10 @@ func (d *MySQL) atIndex(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) error
 	}
 	if t, ok := indexType(idx1, dialect.MySQL); ok {
 		idx2.AddAttrs(&mysql.IndexType{T: t})
+	} else if isFullText(idx1) {
+		idx2.AddAttrs(&mysql.IndexType{T: mysql.IndexTypeFullText})
+	} else if isSpatial(idx1) {
+		idx2.AddAttrs(&mysql.IndexType{T: mysql.IndexTypeSpatial})
 	}
 	return nil
 }
@@ -995,3 +1071,60 @@ func (MySQL) atTypeRangeSQL(ts ...string) string {
 	}
 	return fmt.Sprintf("INSERT INTO `%s` (`type`) VALUES %s", TypeTable, strings.Join(ts, ", "))
 }
+
+// inspectPartitions returns the partitions of the given tables, as
+// partitions are not inspected by Atlas.
+func (d *MySQL) inspectPartitions(ctx context.Context, conn dialect.ExecQuerier, _ *schema.Schema, tables []string) (map[string]*tablePartitions, error) {
+	names := make([]any, len(tables))
+	for i := range tables {
+		names[i] = tables[i]
+	}
+	rows := &sql.Rows{}
+	query, args := sql.Select("TABLE_NAME", "PARTITION_NAME", "PARTITION_METHOD", "PARTITION_EXPRESSION", "PARTITION_DESCRIPTION").
+		From(sql.Table("PARTITIONS").Schema("INFORMATION_SCHEMA")).
+		Where(sql.And(d.matchSchema(), sql.In("TABLE_NAME", names...), sql.NotNull("PARTITION_NAME"))).
+		OrderBy("TABLE_NAME", "PARTITION_ORDINAL_POSITION").
+		Query()
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return nil, fmt.Errorf("mysql: reading table partitions: %w", err)
+	}
+	defer rows.Close()
+	parts := make(map[string]*tablePartitions)
+	for rows.Next() {
+		var (
+			table, name, method string
+			expr, desc          sql.NullString
+		)
+		if err := rows.Scan(&table, &name, &method, &expr, &desc); err != nil {
+			return nil, fmt.Errorf("mysql: scanning table partition: %w", err)
+		}
+		p, ok := parts[table]
+		if !ok {
+			p = &tablePartitions{method: method, expr: expr.String}
+			parts[table] = p
+		}
+		p.names = append(p.names, name)
+		if strings.EqualFold(desc.String, "MAXVALUE") {
+			p.maxValue = name
+		}
+	}
+	return parts, rows.Err()
+}
+
+// inspectTriggers returns the triggers of the given tables.
+func (d *MySQL) inspectTriggers(ctx context.Context, conn dialect.ExecQuerier, _ *schema.Schema, tables []string) ([]*dbObject, []*dbObject, error) {
+	names := make([]any, len(tables))
+	for i := range tables {
+		names[i] = tables[i]
+	}
+	query, args := sql.Select("EVENT_OBJECT_TABLE", "TRIGGER_NAME", "ACTION_STATEMENT").
+		From(sql.Table("TRIGGERS").Schema("INFORMATION_SCHEMA")).
+		Where(sql.And(d.matchSchema("TRIGGER_SCHEMA"), sql.In("EVENT_OBJECT_TABLE", names...))).
+		OrderBy("TRIGGER_NAME").
+		Query()
+	triggers, err := scanObjects(ctx, conn, query, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mysql: reading triggers: %w", err)
+	}
+	return triggers, nil, nil
+}
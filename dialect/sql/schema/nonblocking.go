@@ -0,0 +1,321 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// WithNonBlocking enables the non-blocking planning mode for MySQL and Postgres. This mode
+// trades the simplicity of the default plan for statements that avoid long-held table locks
+// on large tables:
+//
+//   - On Postgres, indexes of existing tables are created (and dropped) CONCURRENTLY. Since
+//     these statements cannot be executed inside a transaction block, the plan is split into
+//     ordered segments of transactional and non-transactional statements, that are executed
+//     one after the other, or written to separate migration files.
+//
+//   - On Postgres, setting a NOT NULL constraint on an existing table is split into steps: an
+//     optional backfill of NULL values with the column default, a CHECK constraint created
+//     as NOT VALID, its validation, and the SET NOT NULL itself that relies on the validated
+//     constraint and does not scan the table. The validation is executed outside of the
+//     transaction block, in order to not hold the lock acquired by adding the constraint
+//     while the table is scanned.
+//
+//   - On MySQL, ALTER TABLE statements that support online DDL are executed with the
+//     ALGORITHM=INPLACE, LOCK=NONE clauses. Note that MySQL fails the statement, instead
+//     of locking the table, if it cannot be executed this way.
+//
+// The option has no effect on other dialects.
+func WithNonBlocking(b bool) MigrateOption {
+	return func(a *Atlas) {
+		a.nonBlocking = b
+	}
+}
+
+// NonTransactional reports if the given planned change cannot (or should not) be executed
+// inside a transaction block. For example, CREATE INDEX CONCURRENTLY statements on Postgres,
+// or the VALIDATE CONSTRAINT statements that are planned by the non-blocking mode.
+func NonTransactional(c *migrate.Change) bool {
+	return reNonTx.MatchString(c.Cmd)
+}
+
+var reNonTx = regexp.MustCompile(`(?i)^\s*((CREATE\s+(UNIQUE\s+)?INDEX|DROP\s+INDEX)\s+CONCURRENTLY\b|ALTER\s+TABLE\s+.+\s+VALIDATE\s+CONSTRAINT\s)`)
+
+// segments splits the changes into ordered segments of consecutive
+// changes that are either all transactional or all non-transactional.
+func segments(changes []*migrate.Change) [][]*migrate.Change {
+	var segs [][]*migrate.Change
+	for i, c := range changes {
+		if i == 0 || NonTransactional(c) != NonTransactional(changes[i-1]) {
+			segs = append(segs, nil)
+		}
+		segs[len(segs)-1] = append(segs[len(segs)-1], c)
+	}
+	return segs
+}
+
+// nonBlocking rewrites the schema changes and their plan to avoid blocking
+// table locks. It is used by the Atlas engine when WithNonBlocking is set.
+type nonBlocking struct {
+	dialect string
+	// NOT NULL constraints that are deferred
+	// to the end of the plan on Postgres.
+	notNull []*deferredNotNull
+}
+
+// deferredNotNull holds a column that its NOT NULL constraint
+// was removed from the original change to be added in steps.
+type deferredNotNull struct {
+	t *schema.Table
+	c *schema.Column
+	// backfill NULL values with the default
+	// value of the column, if it was set.
+	backfill bool
+}
+
+// changes prepares the schema changes before they are planned.
+func (n *nonBlocking) changes(changes []schema.Change) []schema.Change {
+	if n.dialect != dialect.Postgres {
+		return changes
+	}
+	keep := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		m, ok := c.(*schema.ModifyTable)
+		if !ok {
+			keep = append(keep, c)
+			continue
+		}
+		m.Changes = n.modifyTable(m.T, m.Changes)
+		if len(m.Changes) > 0 {
+			keep = append(keep, m)
+		}
+	}
+	return keep
+}
+
+func (n *nonBlocking) modifyTable(t *schema.Table, changes []schema.Change) []schema.Change {
+	keep := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddIndex:
+			concurrently(c.I)
+		case *schema.DropIndex:
+			// Unique constraints are dropped using the ALTER TABLE command.
+			if !isUniqueConstraint(c.I) {
+				concurrently(c.I)
+			}
+		case *schema.ModifyIndex:
+			concurrently(c.From)
+			concurrently(c.To)
+		case *schema.AddColumn:
+			// Adding a column with a default value does not rewrite the table
+			// (since PostgreSQL 11), but a NOT NULL column without a default
+			// is added as nullable, and the constraint is set afterwards.
+			if c.C.Type.Null || c.C.Default != nil {
+				break
+			}
+			c.C = nullable(c.C)
+			n.notNull = append(n.notNull, &deferredNotNull{t: t, c: c.C})
+		case *schema.ModifyColumn:
+			if !c.Change.Is(schema.ChangeNull) || c.To.Type.Null {
+				break
+			}
+			n.notNull = append(n.notNull, &deferredNotNull{t: t, c: c.To, backfill: c.To.Default != nil})
+			if c.Change &= ^schema.ChangeNull; c.Change == schema.NoChange {
+				continue
+			}
+			c.To = nullable(c.To)
+		}
+		keep = append(keep, c)
+	}
+	return keep
+}
+
+// plan rewrites the plan statements and appends the deferred steps.
+func (n *nonBlocking) plan(plan *migrate.Plan) {
+	switch n.dialect {
+	case dialect.MySQL:
+		for _, c := range plan.Changes {
+			if m, ok := c.Source.(*schema.ModifyTable); ok && inplace(m.Changes) {
+				c.Cmd += ", ALGORITHM=INPLACE, LOCK=NONE"
+			}
+		}
+	case dialect.Postgres:
+		for _, d := range n.notNull {
+			plan.Changes = append(plan.Changes, d.steps()...)
+		}
+		for _, c := range plan.Changes {
+			if NonTransactional(c) {
+				plan.Transactional = false
+			}
+		}
+	}
+}
+
+// steps returns the Postgres statements for setting a NOT NULL constraint
+// on a column without holding an exclusive lock while the table is scanned.
+func (d *deferredNotNull) steps() []*migrate.Change {
+	var (
+		changes []*migrate.Change
+		check   = fmt.Sprintf("%s_%s_not_null", d.t.Name, d.c.Name)
+		alter   = func() *entsql.Builder {
+			return build(dialect.Postgres).WriteString("ALTER TABLE ").Ident(d.t.Name).Pad()
+		}
+	)
+	if x, ok := defaultExpr(d.c); ok && d.backfill {
+		b := build(dialect.Postgres).WriteString("UPDATE ").Ident(d.t.Name).WriteString(" SET ").Ident(d.c.Name).
+			WriteString(" = ").WriteString(x).WriteString(" WHERE ").Ident(d.c.Name).WriteString(" IS NULL")
+		changes = append(changes, &migrate.Change{
+			Cmd:     b.String(),
+			Comment: fmt.Sprintf("backfill NULL values of column %q in table %q", d.c.Name, d.t.Name),
+		})
+	}
+	return append(changes,
+		&migrate.Change{
+			Cmd: alter().WriteString("ADD CONSTRAINT ").Ident(check).WriteString(" CHECK (").Ident(d.c.Name).
+				WriteString(" IS NOT NULL) NOT VALID").String(),
+			Reverse: alter().WriteString("DROP CONSTRAINT ").Ident(check).String(),
+			Comment: fmt.Sprintf("add not valid check constraint %q to table %q", check, d.t.Name),
+		},
+		&migrate.Change{
+			Cmd:     alter().WriteString("VALIDATE CONSTRAINT ").Ident(check).String(),
+			Comment: fmt.Sprintf("validate check constraint %q of table %q", check, d.t.Name),
+		},
+		&migrate.Change{
+			Cmd:     alter().WriteString("ALTER COLUMN ").Ident(d.c.Name).WriteString(" SET NOT NULL").String(),
+			Reverse: alter().WriteString("ALTER COLUMN ").Ident(d.c.Name).WriteString(" DROP NOT NULL").String(),
+			Comment: fmt.Sprintf("set column %q of table %q as not null", d.c.Name, d.t.Name),
+		},
+		&migrate.Change{
+			Cmd:     alter().WriteString("DROP CONSTRAINT ").Ident(check).String(),
+			Comment: fmt.Sprintf("drop check constraint %q from table %q", check, d.t.Name),
+		},
+	)
+}
+
+// splitPlan splits the plan into ordered plans of transactional changes and changes that
+// cannot be executed in a transaction block, without changing the order of the statements.
+// Plans are versioned one after the other, and a single plan is returned if no split is needed.
+// For example, a plan of transactional, non-transactional and transactional changes is split
+// into the "<name>", "<name>_non_tx" and "<name>_2" plans.
+func splitPlan(plan *migrate.Plan) []*migrate.Plan {
+	segs := segments(plan.Changes)
+	switch {
+	case len(segs) == 0:
+		return []*migrate.Plan{plan}
+	case len(segs) == 1:
+		if NonTransactional(segs[0][0]) {
+			plan.Transactional = false
+		}
+		return []*migrate.Plan{plan}
+	}
+	now := time.Now().UTC()
+	if plan.Version != "" {
+		if v, err := time.Parse(versionFormat, plan.Version); err == nil {
+			now = v
+		}
+	}
+	var (
+		txn, nonTxn int
+		plans       = make([]*migrate.Plan, len(segs))
+	)
+	for i, changes := range segs {
+		p := &migrate.Plan{
+			Version:       now.Add(time.Duration(i) * time.Second).Format(versionFormat),
+			Name:          plan.Name,
+			Reversible:    plan.Reversible,
+			Transactional: !NonTransactional(changes[0]),
+			Changes:       changes,
+		}
+		n := &txn
+		if !p.Transactional {
+			n = &nonTxn
+			p.Name += "_non_tx"
+		}
+		if *n++; *n > 1 {
+			p.Name = fmt.Sprintf("%s_%d", p.Name, *n)
+		}
+		plans[i] = p
+	}
+	return plans
+}
+
+// versionFormat is the time format used by Atlas for migration versions.
+const versionFormat = "20060102150405"
+
+// inplace reports if all changes of an ALTER TABLE
+// statement support MySQL online DDL without locking.
+func inplace(changes []schema.Change) bool {
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddColumn, *schema.DropColumn, *schema.AddIndex, *schema.DropIndex, *schema.DropForeignKey:
+		case *schema.ModifyColumn:
+			// Changing the column type requires copying the table.
+			if c.Change.Is(schema.ChangeType) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return len(changes) > 0
+}
+
+// concurrently sets the Postgres CONCURRENTLY clause on the index.
+func concurrently(idx *schema.Index) {
+	for _, a := range idx.Attrs {
+		if _, ok := a.(*postgres.Concurrently); ok {
+			return
+		}
+	}
+	idx.Attrs = append(idx.Attrs, &postgres.Concurrently{})
+}
+
+// isUniqueConstraint reports if the index was defined as a
+// Postgres UNIQUE constraint, and not as a UNIQUE INDEX.
+func isUniqueConstraint(idx *schema.Index) bool {
+	for _, a := range idx.Attrs {
+		if c, ok := a.(*postgres.Constraint); ok && c.IsUnique() {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable returns a nullable copy of the column.
+func nullable(c *schema.Column) *schema.Column {
+	c1, t1 := *c, *c.Type
+	t1.Null = true
+	c1.Type = &t1
+	return &c1
+}
+
+// defaultExpr returns the SQL expression of the column default.
+func defaultExpr(c *schema.Column) (string, bool) {
+	switch x := c.Default.(type) {
+	case *schema.Literal:
+		return x.V, true
+	case *schema.RawExpr:
+		return x.X, true
+	default:
+		return "", false
+	}
+}
+
+// build returns a new statement builder for the given dialect.
+func build(d string) *entsql.Builder {
+	b := &entsql.Builder{}
+	b.SetDialect(d)
+	return b
+}
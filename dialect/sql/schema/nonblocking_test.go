@@ -0,0 +1,220 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonBlocking_Postgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT setting FROM pg_settings").
+		WillReturnRows(sqlmock.NewRows([]string{"setting"}).AddRow("130000").AddRow("en_US.utf8").AddRow("en_US.utf8"))
+	drv, err := postgres.Open(db)
+	require.NoError(t, err)
+
+	var (
+		users = schema.NewTable("users").
+			AddColumns(
+				schema.NewIntColumn("id", "bigint"),
+				schema.NewStringColumn("name", "varchar"),
+			)
+		email  = schema.NewStringColumn("email", "varchar")
+		status = schema.NewNullStringColumn("status", "varchar")
+		age    = schema.NewIntColumn("age", "bigint")
+	)
+	status.Default = &schema.Literal{V: "'active'"}
+	users.AddColumns(email, status)
+	changes := []schema.Change{
+		&schema.AddTable{T: schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "bigint"))},
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.AddColumn{C: email},
+				&schema.AddColumn{C: age.SetDefault(&schema.Literal{V: "0"})},
+				&schema.ModifyColumn{
+					From:   status,
+					To:     schema.NewStringColumn("status", "varchar").SetDefault(status.Default),
+					Change: schema.ChangeNull,
+				},
+				&schema.AddIndex{I: schema.NewIndex("users_name").AddColumns(users.Columns[1])},
+				&schema.DropIndex{I: schema.NewUniqueIndex("users_email").AddColumns(email)},
+			},
+		},
+	}
+	nb := &nonBlocking{dialect: dialect.Postgres}
+	changes = nb.changes(changes)
+	plan, err := drv.PlanChanges(context.Background(), "changes", changes, func(opts *migrate.PlanOptions) {
+		var noQualifier string
+		opts.SchemaQualifier = &noQualifier
+	})
+	require.NoError(t, err)
+	nb.plan(plan)
+	require.False(t, plan.Transactional)
+
+	var stmts []string
+	for _, c := range plan.Changes {
+		stmts = append(stmts, c.Cmd)
+	}
+	require.Equal(t, []string{
+		`CREATE TABLE "pets" ("id" bigint NOT NULL)`,
+		`DROP INDEX CONCURRENTLY "users_email"`,
+		`ALTER TABLE "users" ADD COLUMN "email" character varying NULL, ADD COLUMN "age" bigint NOT NULL DEFAULT 0`,
+		`CREATE INDEX CONCURRENTLY "users_name" ON "users" ("name")`,
+		`ALTER TABLE "users" ADD CONSTRAINT "users_email_not_null" CHECK ("email" IS NOT NULL) NOT VALID`,
+		`ALTER TABLE "users" VALIDATE CONSTRAINT "users_email_not_null"`,
+		`ALTER TABLE "users" ALTER COLUMN "email" SET NOT NULL`,
+		`ALTER TABLE "users" DROP CONSTRAINT "users_email_not_null"`,
+		`UPDATE "users" SET "status" = 'active' WHERE "status" IS NULL`,
+		`ALTER TABLE "users" ADD CONSTRAINT "users_status_not_null" CHECK ("status" IS NOT NULL) NOT VALID`,
+		`ALTER TABLE "users" VALIDATE CONSTRAINT "users_status_not_null"`,
+		`ALTER TABLE "users" ALTER COLUMN "status" SET NOT NULL`,
+		`ALTER TABLE "users" DROP CONSTRAINT "users_status_not_null"`,
+	}, stmts)
+	for i, tx := range []bool{true, false, true, false, true, false, true, true, true, true, false, true, true} {
+		require.Equal(t, tx, !NonTransactional(plan.Changes[i]), plan.Changes[i].Cmd)
+	}
+
+	// The plan is split into ordered segments.
+	plans := splitPlan(plan)
+	require.Len(t, plans, 9)
+	for i, p := range []struct {
+		name    string
+		tx      bool
+		changes int
+	}{
+		{"changes", true, 1},
+		{"changes_non_tx", false, 1},
+		{"changes_2", true, 1},
+		{"changes_non_tx_2", false, 1},
+		{"changes_3", true, 1},
+		{"changes_non_tx_3", false, 1},
+		{"changes_4", true, 4},
+		{"changes_non_tx_4", false, 1},
+		{"changes_5", true, 2},
+	} {
+		require.Equal(t, p.name, plans[i].Name)
+		require.Equal(t, p.tx, plans[i].Transactional, p.name)
+		require.Len(t, plans[i].Changes, p.changes, p.name)
+		if i > 0 {
+			require.Less(t, plans[i-1].Version, plans[i].Version)
+		}
+	}
+}
+
+func TestNonBlocking_DropIndexColumn(t *testing.T) {
+	plan := &migrate.Plan{
+		Name:          "changes",
+		Transactional: true,
+		Changes: []*migrate.Change{
+			{Cmd: `DROP INDEX CONCURRENTLY "users_name_email"`},
+			{Cmd: `ALTER TABLE "users" DROP COLUMN "email"`},
+			{Cmd: `CREATE INDEX CONCURRENTLY "users_name" ON "users" ("name")`},
+		},
+	}
+	// The index is dropped before the column it depends on,
+	// and the new index is created after the column was dropped.
+	plans := splitPlan(plan)
+	require.Len(t, plans, 3)
+	for i, p := range []struct {
+		name string
+		cmd  string
+	}{
+		{"changes_non_tx", `DROP INDEX CONCURRENTLY "users_name_email"`},
+		{"changes", `ALTER TABLE "users" DROP COLUMN "email"`},
+		{"changes_non_tx_2", `CREATE INDEX CONCURRENTLY "users_name" ON "users" ("name")`},
+	} {
+		require.Equal(t, p.name, plans[i].Name)
+		require.Equal(t, i == 1, plans[i].Transactional)
+		require.Len(t, plans[i].Changes, 1)
+		require.Equal(t, p.cmd, plans[i].Changes[0].Cmd)
+	}
+}
+
+func TestNonBlocking_MySQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT @@version").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "collation", "charset"}).AddRow("8.0.19", "utf8mb4_bin", "utf8mb4"))
+	drv, err := mysql.Open(db)
+	require.NoError(t, err)
+
+	var (
+		users = schema.NewTable("users").
+			AddColumns(
+				schema.NewIntColumn("id", "bigint"),
+				schema.NewStringColumn("name", "varchar(255)"),
+			)
+		pets = schema.NewTable("pets").
+			AddColumns(
+				schema.NewIntColumn("id", "bigint"),
+				schema.NewNullIntColumn("owner_id", "bigint"),
+			)
+	)
+	changes := []schema.Change{
+		&schema.ModifyTable{
+			T: users,
+			Changes: []schema.Change{
+				&schema.AddColumn{C: schema.NewNullIntColumn("age", "bigint")},
+				&schema.AddIndex{I: schema.NewIndex("users_name").AddColumns(users.Columns[1])},
+			},
+		},
+		&schema.ModifyTable{
+			T: pets,
+			Changes: []schema.Change{
+				&schema.ModifyColumn{
+					From:   schema.NewIntColumn("id", "int"),
+					To:     pets.Columns[0],
+					Change: schema.ChangeType,
+				},
+			},
+		},
+	}
+	nb := &nonBlocking{dialect: dialect.MySQL}
+	changes = nb.changes(changes)
+	plan, err := drv.PlanChanges(context.Background(), "changes", changes)
+	require.NoError(t, err)
+	nb.plan(plan)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, "ALTER TABLE `users` ADD COLUMN `age` bigint NULL, ADD INDEX `users_name` (`name`), ALGORITHM=INPLACE, LOCK=NONE", plan.Changes[0].Cmd)
+	// Column type changes require copying the table.
+	require.Equal(t, "ALTER TABLE `pets` MODIFY COLUMN `id` bigint NOT NULL", plan.Changes[1].Cmd)
+	require.Len(t, splitPlan(plan), 1)
+}
+
+func TestDirWriter_NonTransactional(t *testing.T) {
+	p := t.TempDir()
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	w := &DirWriter{Dir: dir}
+	drv := NewWriteDriver(dialect.Postgres, w)
+	require.NoError(t, drv.Exec(context.Background(), `ALTER TABLE "users" ADD COLUMN "age" bigint NULL`, nil, nil))
+	w.Change("Add column.")
+	require.NoError(t, drv.Exec(context.Background(), `CREATE INDEX CONCURRENTLY "users_age" ON "users" ("age")`, nil, nil))
+	w.Change("Create index.")
+	require.NoError(t, w.Flush("add_age"))
+	files, err := os.ReadDir(p)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Contains(t, files[0].Name(), "_add_age.sql")
+	require.Contains(t, files[1].Name(), "_add_age_non_tx.sql")
+	require.Equal(t, "atlas.sum", files[2].Name())
+	buf, err := os.ReadFile(filepath.Join(p, files[1].Name()))
+	require.NoError(t, err)
+	require.Equal(t, "-- Create index.\nCREATE INDEX CONCURRENTLY \"users_age\" ON \"users\" (\"age\");\n", string(buf))
+}
@@ -0,0 +1,596 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+)
+
+type (
+	// TimePartitions describes the time-based partitions of a table that is partitioned
+	// by range on a time column (e.g. create_time). The migration creates the partition
+	// of the current interval and the partitions of the next intervals, and detaches the
+	// partitions that are older than the retention. For example:
+	//
+	//	&schema.TimePartitions{
+	//		Table:     "events",
+	//		Interval:  schema.IntervalMonth,
+	//		Ahead:     3,
+	//		Retention: 12,
+	//	}
+	//
+	// Partitions are named by the table and the start of their interval in UTC (e.g.
+	// events_202301), and only partitions that follow this convention are detached.
+	TimePartitions struct {
+		// Table name. The table must be partitioned by RANGE on a single column.
+		Table string
+		// Interval defines the time range of each partition.
+		Interval PartitionInterval
+		// Ahead defines the number of partitions that are created
+		// in advance, in addition to the partition of the current
+		// interval.
+		Ahead int
+		// Retention defines the number of past partitions to keep attached to the
+		// table. Older partitions are detached from the table, and are kept in the
+		// database as regular tables. Zero means all partitions are kept. Detaching
+		// partitions is supported only by PostgreSQL, and only when the migration
+		// inspects the database (i.e. not when replaying a migration directory).
+		Retention int
+		// Now returns the current time. Defaults to time.Now.
+		Now func() time.Time
+	}
+
+	// PartitionInterval defines the time range of time-based partitions.
+	PartitionInterval uint
+)
+
+// Partition intervals.
+const (
+	IntervalDay PartitionInterval = iota + 1
+	IntervalMonth
+	IntervalYear
+)
+
+// WithTimePartitions registers time-based partitions to be planned along with the schema changes.
+func WithTimePartitions(ps ...*TimePartitions) MigrateOption {
+	return func(a *Atlas) {
+		a.timeParts = append(a.timeParts, ps...)
+	}
+}
+
+// start returns the start of the interval that contains t.
+func (i PartitionInterval) start(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case IntervalDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// add adds n intervals to t.
+func (i PartitionInterval) add(t time.Time, n int) time.Time {
+	switch i {
+	case IntervalDay:
+		return t.AddDate(0, 0, n)
+	case IntervalMonth:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(n, 0, 0)
+	}
+}
+
+// layout returns the layout of the partition names.
+func (i PartitionInterval) layout() string {
+	switch i {
+	case IntervalDay:
+		return "20060102"
+	case IntervalMonth:
+		return "200601"
+	default:
+		return "2006"
+	}
+}
+
+// isPartitioned reports if the table is partitioned.
+func isPartitioned(t *Table) bool {
+	return t.Annotation != nil && t.Annotation.Partition != nil
+}
+
+// atPartition sets the partitioning of the table in its Atlas state, and adds the columns
+// of the partition key to its primary key and unique indexes, as required by PostgreSQL
+// and MySQL. In MySQL, the partitioning is defined on creation, using the table options.
+func (a *Atlas) atPartition(et *Table, at *schema.Table) error {
+	if !isPartitioned(et) || a.dialect == dialect.SQLite {
+		return nil
+	}
+	p := et.Annotation.Partition
+	key := make([]*schema.Column, 0, len(p.Columns))
+	for _, name := range p.Columns {
+		c, ok := at.Column(name)
+		if !ok {
+			return fmt.Errorf("unexpected partition key column %q for table %q", name, et.Name)
+		}
+		key = append(key, c)
+	}
+	for _, idx := range append([]*schema.Index{at.PrimaryKey}, at.Indexes...) {
+		if idx == nil || !idx.Unique && idx != at.PrimaryKey {
+			continue
+		}
+	Key:
+		for _, c := range key {
+			for _, part := range idx.Parts {
+				if part.C == c {
+					continue Key
+				}
+			}
+			idx.AddColumns(c)
+		}
+	}
+	switch a.dialect {
+	case dialect.Postgres:
+		attr := &postgres.Partition{T: string(p.Type)}
+		for _, c := range key {
+			attr.Parts = append(attr.Parts, &postgres.PartitionPart{C: c})
+		}
+		at.AddAttrs(attr)
+	case dialect.MySQL:
+		// RANGE and LIST COLUMNS partitioning does not accept
+		// TIMESTAMP columns. Hence, they are defined as DATETIME.
+		for _, c := range key {
+			if t, ok := c.Type.Type.(*schema.TimeType); ok && p.Type != entsql.PartitionHash && strings.EqualFold(t.T, mysql.TypeTimestamp) {
+				t.T = mysql.TypeDateTime
+			}
+		}
+		clause, err := mysqlPartitionBy(et.Name, p)
+		if err != nil {
+			return err
+		}
+		at.AddAttrs(&mysql.CreateOptions{V: clause})
+	}
+	return nil
+}
+
+// checkPartitionFKs checks that the foreign keys of the given tables can be
+// defined along with their partitioning. MySQL does not support foreign keys
+// on partitioned tables, and PostgreSQL requires the referenced columns to be
+// unique, which is not the case when the partition key is added to the primary
+// key of the referenced table.
+func checkPartitionFKs(d string, tables []*Table) error {
+	if d != dialect.MySQL && d != dialect.Postgres {
+		return nil
+	}
+	for _, t := range tables {
+		for _, fk := range t.ForeignKeys {
+			switch ref := fk.RefTable; {
+			case d == dialect.MySQL && isPartitioned(t):
+				return fmt.Errorf("sql/schema: foreign key %q cannot be defined on partitioned table %q in MySQL. Disable foreign keys using schema.WithForeignKeys(false)", fk.Symbol, t.Name)
+			case d == dialect.MySQL && isPartitioned(ref):
+				return fmt.Errorf("sql/schema: foreign key %q of table %q cannot reference partitioned table %q in MySQL. Disable foreign keys using schema.WithForeignKeys(false)", fk.Symbol, t.Name, ref.Name)
+			case d == dialect.Postgres && isPartitioned(ref) && !partitionRefs(ref.Annotation.Partition, fk.RefColumns):
+				return fmt.Errorf("sql/schema: foreign key %q of table %q cannot reference partitioned table %q, as its columns do not include the partition key %q. Disable foreign keys using schema.WithForeignKeys(false)", fk.Symbol, t.Name, ref.Name, ref.Annotation.Partition.Columns)
+			}
+		}
+	}
+	return nil
+}
+
+// partitionRefs reports if the referenced columns include the partition key.
+func partitionRefs(p *entsql.Partition, refs []*Column) bool {
+Key:
+	for _, name := range p.Columns {
+		for _, c := range refs {
+			if c.Name == name {
+				continue Key
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// tablePartitions describes the partitions of a table that exist in the database.
+type tablePartitions struct {
+	// Partitioning method and key expression, as returned by
+	// the INFORMATION_SCHEMA.PARTITIONS table (MySQL only).
+	method, expr string
+	// Names of the partitions, ordered by their position.
+	names []string
+	// Name of the partition that holds the MAXVALUE bound (MySQL only).
+	maxValue string
+}
+
+// has reports if the partition exists.
+func (p *tablePartitions) has(name string) bool {
+	for _, n := range p.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionInspector is implemented by dialects that support
+// inspecting the partitions of the tables.
+type partitionInspector interface {
+	inspectPartitions(context.Context, dialect.ExecQuerier, *schema.Schema, []string) (map[string]*tablePartitions, error)
+}
+
+// partitions handles the parts of partitioned tables that are not supported by
+// Atlas: the partitions of the tables, the partitioning of existing tables in
+// MySQL, and the time-based partitions that are configured for the migration.
+type partitions struct {
+	nopFeature
+	drv     sqlDialect
+	dialect string
+	tables  []*Table
+	times   []*TimePartitions
+	// Partitions that exist in the database, by table name.
+	current map[string]*tablePartitions
+}
+
+// newPartitions returns the partitions handler for the given tables.
+func newPartitions(drv sqlDialect, d string, tables []*Table, times []*TimePartitions) *partitions {
+	return &partitions{drv: drv, dialect: d, tables: tables, times: times, current: make(map[string]*tablePartitions)}
+}
+
+// inspect loads the partitions of the tables that exist in the database. The
+// query is executed only if the tables are partitioned (in the desired state,
+// or in the current state in MySQL), or time-based partitions are configured.
+func (p *partitions) inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error {
+	pi, ok := p.drv.(partitionInspector)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, t := range p.tables {
+		_, exists := current.Table(t.Name)
+		if exists && (isPartitioned(t) || p.dialect == dialect.MySQL && mysqlPartitioned(current, t.Name)) {
+			names = append(names, t.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	parts, err := pi.inspectPartitions(ctx, conn, current, names)
+	if err != nil {
+		return err
+	}
+	p.current = parts
+	return nil
+}
+
+// mysqlPartitioned reports if the table is partitioned according to its inspected create options.
+func mysqlPartitioned(s *schema.Schema, name string) bool {
+	t, ok := s.Table(name)
+	if !ok {
+		return false
+	}
+	for _, a := range t.Attrs {
+		if o, ok := a.(*mysql.CreateOptions); ok && strings.Contains(strings.ToLower(o.V), "partitioned") {
+			return true
+		}
+	}
+	return false
+}
+
+// plan appends the partition changes to the plan, after the changes computed by Atlas (i.e.
+// after the partitioned tables were created). The connection is optional, and partitions are
+// detached only if it is available.
+func (p *partitions) plan(_ context.Context, conn dialect.ExecQuerier, changes []schema.Change, plan *migrate.Plan) error {
+	created := make(map[string]bool)
+	for _, c := range changes {
+		if c, ok := c.(*schema.AddTable); ok {
+			created[c.T.Name] = true
+		}
+	}
+	var add []*migrate.Change
+	for _, t := range p.tables {
+		var (
+			cs  []*migrate.Change
+			err error
+		)
+		switch p.dialect {
+		case dialect.Postgres:
+			cs = p.postgres(t, created[t.Name])
+		case dialect.MySQL:
+			cs, err = p.mysql(t, created[t.Name])
+		}
+		if err != nil {
+			return err
+		}
+		add = append(add, cs...)
+	}
+	for _, tp := range p.times {
+		cs, err := p.timePartitions(conn, tp)
+		if err != nil {
+			return err
+		}
+		add = append(add, cs...)
+	}
+	plan.Changes = append(plan.Changes, add...)
+	return nil
+}
+
+// postgres returns the changes for creating the declared partitions of the table
+// that do not exist in the database. The state of the table is updated accordingly.
+func (p *partitions) postgres(t *Table, created bool) []*migrate.Change {
+	if !isPartitioned(t) {
+		return nil
+	}
+	cur := p.current[t.Name]
+	if created || cur == nil {
+		cur = &tablePartitions{}
+		p.current[t.Name] = cur
+	}
+	var (
+		cs   []*migrate.Change
+		part = t.Annotation.Partition
+	)
+	for i := 0; i < part.Count; i++ {
+		if name := fmt.Sprintf("%s_%d", t.Name, i); !cur.has(name) {
+			cs = append(cs, p.partitionOf(t.Name, name, fmt.Sprintf("WITH (MODULUS %d, REMAINDER %d)", part.Count, i)))
+			cur.names = append(cur.names, name)
+		}
+	}
+	for _, b := range part.Partitions {
+		if cur.has(b.Name) {
+			continue
+		}
+		bound := "IN (" + strings.Join(b.Values, ", ") + ")"
+		if part.Type == entsql.PartitionRange {
+			bound = fmt.Sprintf("FROM (%s) TO (%s)", boundOr(b.From, "MINVALUE"), boundOr(b.To, "MAXVALUE"))
+		}
+		cs = append(cs, p.partitionOf(t.Name, b.Name, bound))
+		cur.names = append(cur.names, b.Name)
+	}
+	return cs
+}
+
+// partitionOf returns the change for creating a partition of the table in PostgreSQL.
+func (p *partitions) partitionOf(table, name, bound string) *migrate.Change {
+	b := builder(dialect.Postgres)
+	b.WriteString("CREATE TABLE ").Ident(name).WriteString(" PARTITION OF ").Ident(table).WriteString(" FOR VALUES " + bound)
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("create %q partition of table %q", name, table),
+	}
+}
+
+// mysql returns the changes for partitioning (or repartitioning) an existing table, and
+// for adding the declared partitions that do not exist in the database. Tables that are
+// created by the plan are partitioned on creation (see atPartition).
+func (p *partitions) mysql(t *Table, created bool) ([]*migrate.Change, error) {
+	cur, exists := p.current[t.Name]
+	if !isPartitioned(t) {
+		if created || !exists {
+			return nil, nil
+		}
+		delete(p.current, t.Name)
+		b := builder(dialect.MySQL)
+		b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" REMOVE PARTITIONING")
+		return []*migrate.Change{{Cmd: b.String(), Comment: fmt.Sprintf("remove partitioning of table %q", t.Name)}}, nil
+	}
+	part := t.Annotation.Partition
+	declared := mysqlDeclared(part)
+	if created {
+		p.current[t.Name] = declared
+		return nil, nil
+	}
+	if !exists || !mysqlSameKey(cur, declared, part) {
+		clause, err := mysqlPartitionBy(t.Name, part)
+		if err != nil {
+			return nil, err
+		}
+		p.current[t.Name] = declared
+		b := builder(dialect.MySQL)
+		b.WriteString("ALTER TABLE ").Ident(t.Name).Pad().WriteString(clause)
+		return []*migrate.Change{{Cmd: b.String(), Comment: fmt.Sprintf("partition table %q", t.Name)}}, nil
+	}
+	var defs []string
+	for _, b := range part.Partitions {
+		if !cur.has(b.Name) && (part.Type == entsql.PartitionList || b.To != "") {
+			defs = append(defs, mysqlPartitionDef(part, b))
+			cur.names = append(cur.names, b.Name)
+		}
+	}
+	if c := p.mysqlAdd(t.Name, cur, defs); c != nil {
+		return []*migrate.Change{c}, nil
+	}
+	return nil, nil
+}
+
+// mysqlAdd returns the change for adding partitions to a MySQL table. In case the table has a
+// MAXVALUE partition, it is reorganized into the new partitions (as ranges must be increasing).
+func (p *partitions) mysqlAdd(table string, cur *tablePartitions, defs []string) *migrate.Change {
+	if len(defs) == 0 {
+		return nil
+	}
+	b := builder(dialect.MySQL)
+	b.WriteString("ALTER TABLE ").Ident(table)
+	if m := cur.maxValue; m != "" {
+		defs = append(defs, fmt.Sprintf("PARTITION %s VALUES LESS THAN (MAXVALUE)", b.Quote(m)))
+		b.WriteString(" REORGANIZE PARTITION ").Ident(m).WriteString(" INTO (" + strings.Join(defs, ", ") + ")")
+	} else {
+		b.WriteString(" ADD PARTITION (" + strings.Join(defs, ", ") + ")")
+	}
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("add partitions to table %q", table),
+	}
+}
+
+// timePartitions returns the changes for creating (and detaching) the time-based partitions.
+func (p *partitions) timePartitions(conn dialect.ExecQuerier, tp *TimePartitions) ([]*migrate.Change, error) {
+	var t *Table
+	for i := range p.tables {
+		if p.tables[i].Name == tp.Table {
+			t = p.tables[i]
+		}
+	}
+	switch {
+	case t == nil:
+		return nil, fmt.Errorf("sql/schema: table %q of time partitions was not found", tp.Table)
+	case !isPartitioned(t) || t.Annotation.Partition.Type != entsql.PartitionRange || len(t.Annotation.Partition.Columns) != 1:
+		return nil, fmt.Errorf("sql/schema: time partitions of table %q require range partitioning by a single column", tp.Table)
+	case tp.Interval < IntervalDay || tp.Interval > IntervalYear:
+		return nil, fmt.Errorf("sql/schema: invalid partition interval %d for table %q", tp.Interval, tp.Table)
+	case tp.Retention > 0 && p.dialect != dialect.Postgres:
+		return nil, fmt.Errorf("sql/schema: detaching partitions is not supported by %s", p.dialect)
+	}
+	cur := p.current[t.Name]
+	if cur == nil {
+		cur = &tablePartitions{}
+		p.current[t.Name] = cur
+	}
+	now := time.Now
+	if tp.Now != nil {
+		now = tp.Now
+	}
+	var (
+		cs    []*migrate.Change
+		defs  []string
+		start = tp.Interval.start(now())
+	)
+	for i := 0; i <= tp.Ahead; i++ {
+		from := tp.Interval.add(start, i)
+		to := tp.Interval.add(from, 1)
+		name := t.Name + "_" + from.Format(tp.Interval.layout())
+		if cur.has(name) {
+			continue
+		}
+		cur.names = append(cur.names, name)
+		switch p.dialect {
+		case dialect.Postgres:
+			cs = append(cs, p.partitionOf(t.Name, name, fmt.Sprintf("FROM ('%s') TO ('%s')", from.Format(time.RFC3339), to.Format(time.RFC3339))))
+		case dialect.MySQL:
+			defs = append(defs, mysqlPartitionDef(t.Annotation.Partition, &entsql.PartitionBound{Name: name, To: "'" + to.Format("2006-01-02 15:04:05") + "'"}))
+		}
+	}
+	if c := p.mysqlAdd(t.Name, cur, defs); c != nil {
+		cs = append(cs, c)
+	}
+	if tp.Retention == 0 || conn == nil {
+		return cs, nil
+	}
+	oldest := tp.Interval.add(start, -tp.Retention)
+	for _, name := range cur.names {
+		v := strings.TrimPrefix(name, t.Name+"_")
+		from, err := time.Parse(tp.Interval.layout(), v)
+		if err != nil || v == name || len(v) != len(tp.Interval.layout()) || !from.Before(oldest) {
+			continue
+		}
+		b := builder(dialect.Postgres)
+		b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" DETACH PARTITION ").Ident(name)
+		cs = append(cs, &migrate.Change{
+			Cmd:     b.String(),
+			Comment: fmt.Sprintf("detach %q partition of table %q", name, t.Name),
+		})
+	}
+	return cs, nil
+}
+
+// mysqlPartitionBy returns the PARTITION BY clause of the table in MySQL. RANGE
+// and LIST partitioning use the COLUMNS form, and HASH partitioning is defined
+// using KEY partitioning, as both support columns of all types. RANGE tables
+// without a MAXVALUE partition get one, as MySQL requires at least one partition.
+func mysqlPartitionBy(table string, p *entsql.Partition) (string, error) {
+	b := builder(dialect.MySQL)
+	columns := make([]string, len(p.Columns))
+	for i, c := range p.Columns {
+		columns[i] = b.Quote(c)
+	}
+	key := "(" + strings.Join(columns, ", ") + ")"
+	if p.Type == entsql.PartitionHash {
+		return fmt.Sprintf("PARTITION BY KEY%s PARTITIONS %d", key, p.Count), nil
+	}
+	var (
+		defs     []string
+		maxValue bool
+	)
+	for _, pb := range p.Partitions {
+		defs = append(defs, mysqlPartitionDef(p, pb))
+		maxValue = maxValue || pb.To == ""
+	}
+	switch {
+	case p.Type == entsql.PartitionRange && !maxValue:
+		defs = append(defs, mysqlPartitionDef(p, &entsql.PartitionBound{Name: mysqlMaxValue}))
+	case p.Type == entsql.PartitionList && len(defs) == 0:
+		return "", fmt.Errorf("sql/schema: list partitioning of table %q requires at least one partition in MySQL", table)
+	}
+	return fmt.Sprintf("PARTITION BY %s COLUMNS%s (%s)", p.Type, key, strings.Join(defs, ", ")), nil
+}
+
+// mysqlMaxValue is the name of the MAXVALUE partition that is added to RANGE tables in MySQL.
+const mysqlMaxValue = "pmax"
+
+// mysqlPartitionDef returns the definition of a partition in MySQL.
+func mysqlPartitionDef(p *entsql.Partition, pb *entsql.PartitionBound) string {
+	b := builder(dialect.MySQL)
+	if p.Type == entsql.PartitionList {
+		return fmt.Sprintf("PARTITION %s VALUES IN (%s)", b.Quote(pb.Name), strings.Join(pb.Values, ", "))
+	}
+	return fmt.Sprintf("PARTITION %s VALUES LESS THAN (%s)", b.Quote(pb.Name), boundOr(pb.To, "MAXVALUE"))
+}
+
+// mysqlDeclared returns the partitions state of a table that was partitioned by the migration.
+func mysqlDeclared(p *entsql.Partition) *tablePartitions {
+	d := &tablePartitions{method: string(p.Type) + " COLUMNS", expr: strings.Join(p.Columns, ",")}
+	if p.Type == entsql.PartitionHash {
+		d.method = "KEY"
+		for i := 0; i < p.Count; i++ {
+			d.names = append(d.names, fmt.Sprintf("p%d", i))
+		}
+	}
+	for _, b := range p.Partitions {
+		d.names = append(d.names, b.Name)
+		if p.Type == entsql.PartitionRange && b.To == "" {
+			d.maxValue = b.Name
+		}
+	}
+	if p.Type == entsql.PartitionRange && d.maxValue == "" {
+		d.names = append(d.names, mysqlMaxValue)
+		d.maxValue = mysqlMaxValue
+	}
+	return d
+}
+
+// mysqlSameKey reports if the existing partitioning of the table matches the declared one.
+func mysqlSameKey(cur, declared *tablePartitions, p *entsql.Partition) bool {
+	expr := strings.NewReplacer("`", "", " ", "").Replace(cur.expr)
+	if !strings.EqualFold(cur.method, declared.method) || expr != declared.expr {
+		return false
+	}
+	return p.Type != entsql.PartitionHash || len(cur.names) == p.Count
+}
+
+// boundOr returns the bound, or the given value if it is empty.
+func boundOr(b, v string) string {
+	if b == "" {
+		return v
+	}
+	return b
+}
+
+// builder returns a new builder for the given dialect.
+func builder(d string) *sql.Builder {
+	b := &sql.Builder{}
+	b.SetDialect(d)
+	return b
+}
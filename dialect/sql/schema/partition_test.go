@@ -0,0 +1,269 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtlas_AtPartition(t *testing.T) {
+	et := &Table{
+		Name: "events",
+		Annotation: &entsql.Annotation{
+			Partition: &entsql.Partition{
+				Type:    entsql.PartitionRange,
+				Columns: []string{"created_at"},
+				Partitions: []*entsql.PartitionBound{
+					{Name: "events_2022", To: "'2023-01-01'"},
+				},
+			},
+		},
+	}
+	newTable := func() *schema.Table {
+		id, created := schema.NewIntColumn("id", "bigint"), schema.NewTimeColumn("created_at", "timestamp")
+		at := schema.NewTable("events").AddColumns(id, created)
+		at.SetPrimaryKey(schema.NewPrimaryKey(id))
+		at.AddIndexes(schema.NewUniqueIndex("events_id_key").AddColumns(id), schema.NewIndex("events_created_at").AddColumns(created))
+		return at
+	}
+
+	at := newTable()
+	require.NoError(t, (&Atlas{dialect: dialect.Postgres}).atPartition(et, at))
+	require.Len(t, at.PrimaryKey.Parts, 2)
+	require.Equal(t, "created_at", at.PrimaryKey.Parts[1].C.Name)
+	require.Len(t, at.Indexes[0].Parts, 2, "partition key is added to unique indexes")
+	require.Len(t, at.Indexes[1].Parts, 1, "non-unique indexes are not changed")
+	p := at.Attrs[0].(*postgres.Partition)
+	require.Equal(t, "RANGE", p.T)
+	require.Equal(t, "created_at", p.Parts[0].C.Name)
+
+	at = newTable()
+	require.NoError(t, (&Atlas{dialect: dialect.MySQL}).atPartition(et, at))
+	require.Len(t, at.PrimaryKey.Parts, 2)
+	require.Equal(t, "PARTITION BY RANGE COLUMNS(`created_at`) (PARTITION `events_2022` VALUES LESS THAN ('2023-01-01'), PARTITION `pmax` VALUES LESS THAN (MAXVALUE))", at.Attrs[0].(*mysql.CreateOptions).V)
+	c, _ := at.Column("created_at")
+	require.Equal(t, "datetime", c.Type.Type.(*schema.TimeType).T, "timestamp partition keys are defined as datetime")
+
+	at = newTable()
+	require.NoError(t, (&Atlas{dialect: dialect.SQLite}).atPartition(et, at))
+	require.Len(t, at.PrimaryKey.Parts, 1)
+	require.Empty(t, at.Attrs)
+
+	et.Annotation.Partition = &entsql.Partition{Type: entsql.PartitionHash, Columns: []string{"id"}, Count: 4}
+	at = newTable()
+	require.NoError(t, (&Atlas{dialect: dialect.MySQL}).atPartition(et, at))
+	require.Equal(t, "PARTITION BY KEY(`id`) PARTITIONS 4", at.Attrs[0].(*mysql.CreateOptions).V)
+
+	et.Annotation.Partition = &entsql.Partition{Type: entsql.PartitionList, Columns: []string{"id"}}
+	require.EqualError(t, (&Atlas{dialect: dialect.MySQL}).atPartition(et, newTable()), `sql/schema: list partitioning of table "events" requires at least one partition in MySQL`)
+	et.Annotation.Partition.Columns = []string{"unknown"}
+	require.EqualError(t, (&Atlas{dialect: dialect.MySQL}).atPartition(et, newTable()), `unexpected partition key column "unknown" for table "events"`)
+}
+
+func TestCheckPartitionFKs(t *testing.T) {
+	var (
+		id      = &Column{Name: "id", Type: field.TypeInt}
+		created = &Column{Name: "created_at", Type: field.TypeTime}
+		events  = &Table{
+			Name:       "events",
+			Columns:    []*Column{id, created},
+			PrimaryKey: []*Column{id},
+			Annotation: &entsql.Annotation{
+				Partition: &entsql.Partition{Type: entsql.PartitionRange, Columns: []string{"created_at"}},
+			},
+		}
+		eventID = &Column{Name: "event_id", Type: field.TypeInt}
+		logs    = &Table{
+			Name:    "logs",
+			Columns: []*Column{eventID},
+			ForeignKeys: []*ForeignKey{
+				{Symbol: "logs_events_logs", Columns: []*Column{eventID}, RefTable: events, RefColumns: []*Column{id}},
+			},
+		}
+	)
+	tables := []*Table{events, logs}
+	require.NoError(t, checkPartitionFKs(dialect.SQLite, tables))
+	require.EqualError(t, checkPartitionFKs(dialect.Postgres, tables), `sql/schema: foreign key "logs_events_logs" of table "logs" cannot reference partitioned table "events", as its columns do not include the partition key ["created_at"]. Disable foreign keys using schema.WithForeignKeys(false)`)
+	require.EqualError(t, checkPartitionFKs(dialect.MySQL, tables), `sql/schema: foreign key "logs_events_logs" of table "logs" cannot reference partitioned table "events" in MySQL. Disable foreign keys using schema.WithForeignKeys(false)`)
+	_, err := (&Atlas{dialect: dialect.Postgres, withForeignKeys: true}).tables(tables)
+	require.Error(t, err, "foreign keys are checked when the tables are converted")
+
+	// Referencing the partition key is allowed in PostgreSQL.
+	logs.ForeignKeys[0].RefColumns = []*Column{id, created}
+	require.NoError(t, checkPartitionFKs(dialect.Postgres, tables))
+
+	// Foreign keys of partitioned tables are not supported in MySQL.
+	events.Annotation.Partition.Columns = []string{"id"}
+	logs.Annotation = events.Annotation
+	logs.ForeignKeys[0].RefTable = &Table{Name: "users"}
+	require.NoError(t, checkPartitionFKs(dialect.Postgres, tables))
+	require.EqualError(t, checkPartitionFKs(dialect.MySQL, tables), `sql/schema: foreign key "logs_events_logs" cannot be defined on partitioned table "logs" in MySQL. Disable foreign keys using schema.WithForeignKeys(false)`)
+}
+
+func TestPartitions_Postgres(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	conn := sql.OpenDB(dialect.Postgres, db)
+	mk.ExpectQuery(escape(`SELECT "p"."relname", "c"."relname" FROM "pg_catalog"."pg_inherits" AS "i" JOIN "pg_catalog"."pg_class" AS "p" ON "p"."oid" = "i"."inhparent" JOIN "pg_catalog"."pg_class" AS "c" ON "c"."oid" = "i"."inhrelid" JOIN "pg_catalog"."pg_namespace" AS "n" ON "n"."oid" = "p"."relnamespace" WHERE "n"."nspname" = $1 AND "p"."relname" IN ($2, $3) ORDER BY "p"."relname", "c"."relname"`)).
+		WithArgs("public", "events", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "relname"}).
+			AddRow("events", "events_202209").
+			AddRow("events", "events_202211").
+			AddRow("events", "events_202212").
+			AddRow("events", "events_archive").
+			AddRow("users", "users_0"))
+	var (
+		events = &Table{
+			Name: "events",
+			Annotation: &entsql.Annotation{
+				Partition: entsql.PartitionBy(entsql.PartitionRange, "created_at").Partition,
+			},
+		}
+		users = &Table{
+			Name: "users",
+			Annotation: &entsql.Annotation{
+				Partition: &entsql.Partition{Type: entsql.PartitionHash, Columns: []string{"id"}, Count: 2},
+			},
+		}
+		groups = &Table{
+			Name: "groups",
+			Annotation: &entsql.Annotation{
+				Partition: &entsql.Partition{
+					Type:    entsql.PartitionList,
+					Columns: []string{"region"},
+					Partitions: []*entsql.PartitionBound{
+						{Name: "groups_eu", Values: []string{"'eu'"}},
+					},
+				},
+			},
+		}
+		tp = &TimePartitions{
+			Table:     "events",
+			Interval:  IntervalMonth,
+			Ahead:     1,
+			Retention: 2,
+			Now: func() time.Time {
+				return time.Date(2022, 12, 15, 10, 0, 0, 0, time.UTC)
+			},
+		}
+		current = schema.New("public").AddTables(schema.NewTable("events"), schema.NewTable("users"))
+	)
+	pt := newPartitions(&Postgres{}, dialect.Postgres, []*Table{events, users, groups}, []*TimePartitions{tp})
+	require.NoError(t, pt.inspect(context.Background(), conn, current))
+	require.NoError(t, mk.ExpectationsWereMet())
+
+	plan := &migrate.Plan{}
+	require.NoError(t, pt.plan(context.Background(), conn, []schema.Change{&schema.AddTable{T: schema.NewTable("groups")}}, plan))
+	require.Equal(t, []string{
+		`CREATE TABLE "users_1" PARTITION OF "users" FOR VALUES WITH (MODULUS 2, REMAINDER 1)`,
+		`CREATE TABLE "groups_eu" PARTITION OF "groups" FOR VALUES IN ('eu')`,
+		`CREATE TABLE "events_202301" PARTITION OF "events" FOR VALUES FROM ('2023-01-01T00:00:00Z') TO ('2023-02-01T00:00:00Z')`,
+		`ALTER TABLE "events" DETACH PARTITION "events_202209"`,
+	}, planCmds(plan))
+
+	// Partitions are not detached in replay mode.
+	pt = newPartitions(&Postgres{}, dialect.Postgres, []*Table{events}, []*TimePartitions{tp})
+	plan = &migrate.Plan{}
+	require.NoError(t, pt.plan(context.Background(), nil, nil, plan))
+	require.Equal(t, []string{
+		`CREATE TABLE "events_202212" PARTITION OF "events" FOR VALUES FROM ('2022-12-01T00:00:00Z') TO ('2023-01-01T00:00:00Z')`,
+		`CREATE TABLE "events_202301" PARTITION OF "events" FOR VALUES FROM ('2023-01-01T00:00:00Z') TO ('2023-02-01T00:00:00Z')`,
+	}, planCmds(plan))
+
+	pt = newPartitions(&Postgres{}, dialect.Postgres, []*Table{users}, []*TimePartitions{{Table: "users", Interval: IntervalDay}})
+	require.EqualError(t, pt.plan(context.Background(), nil, nil, &migrate.Plan{}), `sql/schema: time partitions of table "users" require range partitioning by a single column`)
+}
+
+func TestPartitions_MySQL(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	conn := sql.OpenDB(dialect.MySQL, db)
+	mk.ExpectQuery(escape("SELECT `TABLE_NAME`, `PARTITION_NAME`, `PARTITION_METHOD`, `PARTITION_EXPRESSION`, `PARTITION_DESCRIPTION` FROM `INFORMATION_SCHEMA`.`PARTITIONS` WHERE `TABLE_SCHEMA` = (SELECT DATABASE()) AND `TABLE_NAME` IN (?, ?, ?) AND `PARTITION_NAME` IS NOT NULL ORDER BY `TABLE_NAME`, `PARTITION_ORDINAL_POSITION`")).
+		WithArgs("events", "users", "logs").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "PARTITION_NAME", "PARTITION_METHOD", "PARTITION_EXPRESSION", "PARTITION_DESCRIPTION"}).
+			AddRow("events", "events_2022", "RANGE COLUMNS", "`created_at`", "'2023-01-01 00:00:00'").
+			AddRow("events", "pmax", "RANGE COLUMNS", "`created_at`", "MAXVALUE").
+			AddRow("logs", "p0", "HASH", "`id`", nil))
+	var (
+		events = &Table{
+			Name: "events",
+			Annotation: &entsql.Annotation{
+				Partition: &entsql.Partition{
+					Type:    entsql.PartitionRange,
+					Columns: []string{"created_at"},
+					Partitions: []*entsql.PartitionBound{
+						{Name: "events_2022", To: "'2023-01-01 00:00:00'"},
+						{Name: "events_2023", To: "'2024-01-01 00:00:00'"},
+					},
+				},
+			},
+		}
+		users = &Table{
+			Name: "users",
+			Annotation: &entsql.Annotation{
+				Partition: &entsql.Partition{Type: entsql.PartitionHash, Columns: []string{"id"}, Count: 4},
+			},
+		}
+		logs    = &Table{Name: "logs"}
+		pets    = &Table{Name: "pets"}
+		current = schema.New("test").AddTables(
+			schema.NewTable("events"),
+			schema.NewTable("users"),
+			schema.NewTable("logs").AddAttrs(&mysql.CreateOptions{V: "partitioned"}),
+			schema.NewTable("pets"),
+		)
+	)
+	pt := newPartitions(&MySQL{}, dialect.MySQL, []*Table{events, users, logs, pets}, nil)
+	require.NoError(t, pt.inspect(context.Background(), conn, current))
+	require.NoError(t, mk.ExpectationsWereMet())
+
+	plan := &migrate.Plan{}
+	require.NoError(t, pt.plan(context.Background(), conn, nil, plan))
+	require.Equal(t, []string{
+		"ALTER TABLE `events` REORGANIZE PARTITION `pmax` INTO (PARTITION `events_2023` VALUES LESS THAN ('2024-01-01 00:00:00'), PARTITION `pmax` VALUES LESS THAN (MAXVALUE))",
+		"ALTER TABLE `users` PARTITION BY KEY(`id`) PARTITIONS 4",
+		"ALTER TABLE `logs` REMOVE PARTITIONING",
+	}, planCmds(plan))
+
+	tp := &TimePartitions{
+		Table:    "events",
+		Interval: IntervalYear,
+		Now: func() time.Time {
+			return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+	pt = newPartitions(&MySQL{}, dialect.MySQL, []*Table{events}, []*TimePartitions{tp})
+	plan = &migrate.Plan{}
+	require.NoError(t, pt.plan(context.Background(), nil, []schema.Change{&schema.AddTable{T: schema.NewTable("events")}}, plan))
+	require.Equal(t, []string{
+		"ALTER TABLE `events` REORGANIZE PARTITION `pmax` INTO (PARTITION `events_2024` VALUES LESS THAN ('2025-01-01 00:00:00'), PARTITION `pmax` VALUES LESS THAN (MAXVALUE))",
+	}, planCmds(plan))
+
+	tp.Retention = 1
+	pt = newPartitions(&MySQL{}, dialect.MySQL, []*Table{events}, []*TimePartitions{tp})
+	require.EqualError(t, pt.plan(context.Background(), nil, nil, &migrate.Plan{}), "sql/schema: detaching partitions is not supported by mysql")
+}
+
+func planCmds(plan *migrate.Plan) []string {
+	cmds := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		cmds[i] = c.Cmd
+	}
+	return cmds
+}
@@ -8,11 +8,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 
@@ -359,6 +361,10 @@ func (d *Postgres) cType(c *Column) (t string) {
 		t = "jsonb"
 	case field.TypeUUID:
 		t = "uuid"
+	case field.TypeGeometry:
+		t = "geometry"
+	case field.TypeRange:
+		t = "int8range"
 	case field.TypeString:
 		t = "varchar"
 		if c.Size > maxCharSize {
@@ -693,6 +699,16 @@ func (d *Postgres) supportsDefault(*Column) bool {
 }
 
 func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
+	if c1.Type == field.TypeGeometry {
+		// PostGIS types are inspected as user-defined types, that hold
+		// their subtype and SRID. e.g. "geometry(Point,4326)".
+		t := &postgres.UserDefinedType{T: "geometry"}
+		if c1.SchemaType != nil && c1.SchemaType[dialect.Postgres] != "" {
+			t.T = c1.SchemaType[dialect.Postgres]
+		}
+		c2.Type.Type = t
+		return nil
+	}
 	if c1.SchemaType != nil && c1.SchemaType[dialect.Postgres] != "" {
 		t, err := postgres.ParseType(strings.ToLower(c1.SchemaType[dialect.Postgres]))
 		if err != nil {
@@ -724,6 +740,8 @@ func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &postgres.UUIDType{T: postgres.TypeUUID}
 	case field.TypeJSON:
 		t = &schema.JSONType{T: postgres.TypeJSONB}
+	case field.TypeRange:
+		t = &postgres.RangeType{T: "int8range"}
 	case field.TypeString:
 		t = &schema.StringType{T: postgres.TypeVarChar}
 		if c1.Size > maxCharSize {
@@ -733,8 +751,12 @@ func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.TimeType{T: c1.scanTypeOr(postgres.TypeTimestampWTZ)}
 	case field.TypeEnum:
 		// Although atlas supports enum types, we keep backwards compatibility
-		// with previous versions of ent and use varchar (see cType).
+		// with previous versions of ent and use varchar (see cType), unless
+		// the column was configured to use a native enum type.
 		t = &schema.StringType{T: postgres.TypeVarChar}
+		if c1.NativeEnum != "" {
+			t = &schema.EnumType{T: c1.NativeEnum, Values: c1.Enums}
+		}
 	case field.TypeOther:
 		t = &schema.UnsupportedType{T: c1.typ}
 	default:
@@ -807,6 +829,9 @@ func indexOpClass(idx *Index) map[string]string {
 }
 
 func (d *Postgres) atIndex(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) error {
+	if isFullText(idx1) {
+		return d.atFullText(idx1, t2, idx2)
+	}
 	opc := indexOpClass(idx1)
 	for _, c1 := range idx1.Columns {
 		c2, ok := t2.Column(c1.Name)
@@ -825,6 +850,8 @@ func (d *Postgres) atIndex(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) er
 	}
 	if t, ok := indexType(idx1, dialect.Postgres); ok {
 		idx2.AddAttrs(&postgres.IndexType{T: t})
+	} else if isSpatial(idx1) {
+		idx2.AddAttrs(&postgres.IndexType{T: postgres.IndexTypeGiST})
 	}
 	if ant, supportsInclude := idx1.Annotation, compareVersions(d.version, "11.0.0") >= 0; ant != nil && len(ant.IncludeColumns) > 0 && supportsInclude {
 		columns := make([]*schema.Column, len(ant.IncludeColumns))
@@ -843,9 +870,291 @@ func (d *Postgres) atIndex(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) er
 	return nil
 }
 
+// atFullText converts a full-text index to a GIN index that is
+// defined on the "to_tsvector" expression of its columns.
+func (d *Postgres) atFullText(idx1 *Index, t2 *schema.Table, idx2 *schema.Index) error {
+	for _, c1 := range idx1.Columns {
+		if _, ok := t2.Column(c1.Name); !ok {
+			return fmt.Errorf("unexpected index %q column: %q", idx1.Name, c1.Name)
+		}
+	}
+	idx2.AddParts(&schema.IndexPart{X: &schema.RawExpr{X: fullTextIndex(idx1).TSVector()}})
+	t := postgres.IndexTypeGIN
+	if v, ok := indexType(idx1, dialect.Postgres); ok {
+		t = v
+	}
+	idx2.AddAttrs(&postgres.IndexType{T: t})
+	if idx1.Annotation.Where != "" {
+		idx2.AddAttrs(&postgres.IndexPredicate{P: idx1.Annotation.Where})
+	}
+	return nil
+}
+
 func (Postgres) atTypeRangeSQL(ts ...string) string {
 	for i := range ts {
 		ts[i] = fmt.Sprintf("('%s')", ts[i])
 	}
 	return fmt.Sprintf(`INSERT INTO "%s" ("type") VALUES %s`, TypeTable, strings.Join(ts, ", "))
 }
+
+// exclusions handles the EXCLUDE constraints of the tables (defined using the
+// entsql.Annotation), as they are not supported by Atlas. Their backing indexes
+// are removed from the current state, and the constraints are planned separately
+// after the changes computed by Atlas.
+type exclusions struct {
+	nopFeature
+	dialect string
+	tables  []*Table
+	// Definitions of the constraints that exist in
+	// the database, by table and constraint name.
+	current map[string]map[string]string
+}
+
+// newExclusions returns the EXCLUDE constraints handler for the given tables.
+func newExclusions(d string, tables []*Table) *exclusions {
+	return &exclusions{dialect: d, tables: tables, current: make(map[string]map[string]string)}
+}
+
+// inspect removes the indexes that back EXCLUDE constraints from the current
+// state, and loads the definitions of their constraints from the database.
+func (e *exclusions) inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error {
+	if e.dialect != dialect.Postgres {
+		return nil
+	}
+	var found bool
+	for _, t := range current.Tables {
+		for _, idx := range t.Indexes {
+			found = found || isExclusionIndex(idx)
+		}
+		t.Indexes = removeExclusions(t.Indexes)
+		for _, c := range t.Columns {
+			c.Indexes = removeExclusions(c.Indexes)
+		}
+	}
+	// Each EXCLUDE constraint is backed by an index. Hence, there is
+	// no need to query the database if no such indexes were found.
+	if !found {
+		return nil
+	}
+	c, t, n := sql.Table("pg_constraint").Schema("pg_catalog").As("c"),
+		sql.Table("pg_class").Schema("pg_catalog").As("t"),
+		sql.Table("pg_namespace").Schema("pg_catalog").As("n")
+	query, args := sql.Dialect(dialect.Postgres).
+		Select(t.C("relname"), c.C("conname")).
+		AppendSelectExpr(sql.Expr("pg_get_constraintdef(c.oid)")).
+		From(c).
+		Join(t).On(t.C("oid"), c.C("conrelid")).
+		Join(n).On(n.C("oid"), t.C("relnamespace")).
+		Where(sql.And(sql.EQ(c.C("contype"), "x"), sql.EQ(n.C("nspname"), current.Name))).
+		Query()
+	rows := &sql.Rows{}
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return fmt.Errorf("postgres: reading exclusion constraints: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, name, def string
+		if err := rows.Scan(&table, &name, &def); err != nil {
+			return fmt.Errorf("postgres: scanning exclusion constraint: %w", err)
+		}
+		if e.current[table] == nil {
+			e.current[table] = make(map[string]string)
+		}
+		e.current[table][name] = def
+	}
+	return rows.Err()
+}
+
+// removeExclusions returns the given indexes without the indexes that back EXCLUDE constraints.
+func removeExclusions(indexes []*schema.Index) []*schema.Index {
+	filtered := indexes[:0]
+	for _, idx := range indexes {
+		if !isExclusionIndex(idx) {
+			filtered = append(filtered, idx)
+		}
+	}
+	return filtered
+}
+
+// isExclusionIndex reports if the index backs an EXCLUDE constraint.
+func isExclusionIndex(idx *schema.Index) bool {
+	for _, a := range idx.Attrs {
+		if c, ok := a.(*postgres.Constraint); ok && c.T == "x" {
+			return true
+		}
+	}
+	return false
+}
+
+// plan appends the changes of the EXCLUDE constraints to the plan. Constraints
+// are dropped before the changes computed by Atlas (as they may depend on the
+// modified columns), and created after them. Changed constraints are recreated.
+func (e *exclusions) plan(_ context.Context, _ dialect.ExecQuerier, _ []schema.Change, plan *migrate.Plan) error {
+	if e.dialect != dialect.Postgres {
+		return nil
+	}
+	var drop, add []*migrate.Change
+	for _, t := range e.tables {
+		var desired []*entsql.Exclusion
+		if t.Annotation != nil {
+			desired = t.Annotation.Exclusions
+		}
+		names := make(map[string]bool, len(desired))
+		for _, x := range desired {
+			names[x.Name] = true
+			def, exists := e.current[t.Name][x.Name]
+			switch {
+			case !exists:
+				add = append(add, e.add(t, x))
+			case normalExpr(def) != normalExpr(exclusionDef(x)):
+				drop = append(drop, e.drop(t, x.Name))
+				add = append(add, e.add(t, x))
+			}
+		}
+		for name := range e.current[t.Name] {
+			if !names[name] {
+				drop = append(drop, e.drop(t, name))
+			}
+		}
+	}
+	sort.Slice(drop, func(i, j int) bool { return drop[i].Cmd < drop[j].Cmd })
+	plan.Changes = append(append(drop, plan.Changes...), add...)
+	return nil
+}
+
+// add returns the change for creating the EXCLUDE constraint on the table.
+func (e *exclusions) add(t *Table, x *entsql.Exclusion) *migrate.Change {
+	b := &sql.Builder{}
+	b.SetDialect(dialect.Postgres)
+	b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" ADD CONSTRAINT ").Ident(x.Name).Pad().WriteString(exclusionDef(x))
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("create %q exclusion constraint", x.Name),
+	}
+}
+
+// drop returns the change for dropping the EXCLUDE constraint from the table.
+func (e *exclusions) drop(t *Table, name string) *migrate.Change {
+	b := &sql.Builder{}
+	b.SetDialect(dialect.Postgres)
+	b.WriteString("ALTER TABLE ").Ident(t.Name).WriteString(" DROP CONSTRAINT IF EXISTS ").Ident(name)
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("drop %q exclusion constraint", name),
+	}
+}
+
+// exclusionDef returns the definition of the EXCLUDE constraint, in the
+// same format it is returned by pg_get_constraintdef (before normalization).
+func exclusionDef(x *entsql.Exclusion) string {
+	b := &sql.Builder{}
+	b.SetDialect(dialect.Postgres)
+	b.WriteString("EXCLUDE USING ").WriteString(x.IndexMethod()).Pad().Wrap(func(b *sql.Builder) {
+		for i, el := range x.Elements {
+			if i > 0 {
+				b.Comma()
+			}
+			if el.Column != "" {
+				b.Ident(el.Column)
+			} else {
+				b.WriteString("(").WriteString(el.Expr).WriteString(")")
+			}
+			b.WriteString(" WITH ").WriteString(el.Op)
+		}
+	})
+	if x.Where != "" {
+		b.WriteString(" WHERE ").Wrap(func(b *sql.Builder) {
+			b.WriteString(x.Where)
+		})
+	}
+	return b.String()
+}
+
+// inspectPartitions returns the partitions of the given tables, as the
+// partitions (child tables) are not inspected by Atlas.
+func (d *Postgres) inspectPartitions(ctx context.Context, conn dialect.ExecQuerier, s *schema.Schema, tables []string) (map[string]*tablePartitions, error) {
+	names := make([]any, len(tables))
+	for i := range tables {
+		names[i] = tables[i]
+	}
+	var (
+		i = sql.Table("pg_inherits").Schema("pg_catalog").As("i")
+		p = sql.Table("pg_class").Schema("pg_catalog").As("p")
+		c = sql.Table("pg_class").Schema("pg_catalog").As("c")
+		n = sql.Table("pg_namespace").Schema("pg_catalog").As("n")
+	)
+	query, args := sql.Dialect(dialect.Postgres).
+		Select(p.C("relname"), c.C("relname")).
+		From(i).
+		Join(p).On(p.C("oid"), i.C("inhparent")).
+		Join(c).On(c.C("oid"), i.C("inhrelid")).
+		Join(n).On(n.C("oid"), p.C("relnamespace")).
+		Where(sql.And(sql.EQ(n.C("nspname"), s.Name), sql.In(p.C("relname"), names...))).
+		OrderBy(p.C("relname"), c.C("relname")).
+		Query()
+	rows := &sql.Rows{}
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return nil, fmt.Errorf("postgres: reading table partitions: %w", err)
+	}
+	defer rows.Close()
+	parts := make(map[string]*tablePartitions)
+	for rows.Next() {
+		var table, name string
+		if err := rows.Scan(&table, &name); err != nil {
+			return nil, fmt.Errorf("postgres: scanning table partition: %w", err)
+		}
+		if parts[table] == nil {
+			parts[table] = &tablePartitions{}
+		}
+		parts[table].names = append(parts[table].names, name)
+	}
+	return parts, rows.Err()
+}
+
+// inspectTriggers returns the triggers of the given tables, and the functions
+// of the schema that were created by the migration. The marker of the objects
+// that were created by the migration is stored in their comments.
+func (d *Postgres) inspectTriggers(ctx context.Context, conn dialect.ExecQuerier, s *schema.Schema, tables []string) ([]*dbObject, []*dbObject, error) {
+	var (
+		triggers []*dbObject
+		n        = sql.Table("pg_namespace").Schema("pg_catalog").As("n")
+	)
+	if len(tables) > 0 {
+		names := make([]any, len(tables))
+		for i := range tables {
+			names[i] = tables[i]
+		}
+		t, c := sql.Table("pg_trigger").Schema("pg_catalog").As("t"), sql.Table("pg_class").Schema("pg_catalog").As("c")
+		query, args := sql.Dialect(dialect.Postgres).
+			Select(c.C("relname"), t.C("tgname"), fmt.Sprintf("obj_description(%s, 'pg_trigger')", t.C("oid"))).
+			From(t).
+			Join(c).On(c.C("oid"), t.C("tgrelid")).
+			Join(n).On(n.C("oid"), c.C("relnamespace")).
+			Where(sql.And(sql.EQ(t.C("tgisinternal"), false), sql.EQ(n.C("nspname"), s.Name), sql.In(c.C("relname"), names...))).
+			OrderBy(t.C("tgname")).
+			Query()
+		var err error
+		if triggers, err = scanObjects(ctx, conn, query, args); err != nil {
+			return nil, nil, fmt.Errorf("postgres: reading triggers: %w", err)
+		}
+	}
+	var (
+		p       = sql.Table("pg_proc").Schema("pg_catalog").As("p")
+		comment = fmt.Sprintf("obj_description(%s, 'pg_proc')", p.C("oid"))
+	)
+	query, args := sql.Dialect(dialect.Postgres).
+		Select(fmt.Sprintf("pg_get_function_identity_arguments(%s)", p.C("oid")), p.C("proname"), comment).
+		From(p).
+		Join(n).On(n.C("oid"), p.C("pronamespace")).
+		Where(sql.And(sql.EQ(n.C("nspname"), s.Name), sql.HasPrefix(comment, "ent:"))).
+		OrderBy(p.C("proname")).
+		Query()
+	functions, err := scanObjects(ctx, conn, query, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: reading functions: %w", err)
+	}
+	for _, f := range functions {
+		f.args, f.table = f.table, ""
+	}
+	return triggers, functions, nil
+}
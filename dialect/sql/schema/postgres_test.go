@@ -16,6 +16,9 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
 )
@@ -1034,3 +1037,90 @@ func (m pgMock) fkExists(fk string, exists bool) {
 		WithArgs("FOREIGN KEY", fk).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
 }
+
+func TestPostgres_Exclusions(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape(`SELECT "t"."relname", "c"."conname", pg_get_constraintdef(c.oid) FROM "pg_catalog"."pg_constraint" AS "c" JOIN "pg_catalog"."pg_class" AS "t" ON "t"."oid" = "c"."conrelid" JOIN "pg_catalog"."pg_namespace" AS "n" ON "n"."oid" = "t"."relnamespace" WHERE "c"."contype" = $1 AND "n"."nspname" = $2`)).
+		WithArgs("x", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "conname", "pg_get_constraintdef"}).
+			AddRow("bookings", "no_double_booking", "EXCLUDE USING gist (room_id WITH =, during WITH &&) WHERE ((NOT cancelled))").
+			AddRow("bookings", "no_overlap", "EXCLUDE USING gist (during WITH &&)").
+			AddRow("bookings", "unknown", "EXCLUDE USING gist (room_id WITH =)"))
+	during := schema.NewColumn("during")
+	backed := schema.NewIndex("no_double_booking").AddParts(schema.NewColumnPart(during)).
+		AddAttrs(&postgres.Constraint{N: "no_double_booking", T: "x"})
+	during.Indexes = []*schema.Index{backed}
+	current := schema.New("public").AddTables(
+		schema.NewTable("bookings").AddColumns(during).AddIndexes(backed, schema.NewIndex("bookings_during")),
+	)
+	bookings := &Table{
+		Name: "bookings",
+		Annotation: &entsql.Annotation{
+			Exclusions: []*entsql.Exclusion{
+				{
+					Name: "no_double_booking",
+					Elements: []*entsql.ExclusionElement{
+						{Column: "room_id", Op: "="},
+						{Column: "during", Op: "&&"},
+					},
+					Where: "NOT cancelled",
+				},
+				{
+					Name:     "no_overlap",
+					Using:    "gist",
+					Elements: []*entsql.ExclusionElement{{Expr: "tstzrange(starts_at, ends_at)", Op: "&&"}},
+				},
+				{
+					Name:     "unique_email",
+					Using:    "btree",
+					Elements: []*entsql.ExclusionElement{{Expr: "lower(email)", Op: "="}},
+				},
+			},
+		},
+	}
+	ex := newExclusions(dialect.Postgres, []*Table{bookings})
+	require.NoError(t, ex.inspect(context.Background(), sql.OpenDB(dialect.Postgres, db), current))
+	require.NoError(t, mk.ExpectationsWereMet())
+	// Indexes that back EXCLUDE constraints are not managed by Atlas.
+	require.Len(t, current.Tables[0].Indexes, 1)
+	require.Equal(t, "bookings_during", current.Tables[0].Indexes[0].Name)
+	require.Empty(t, during.Indexes)
+
+	plan := &migrate.Plan{Changes: []*migrate.Change{{Cmd: `ALTER TABLE "bookings" ADD COLUMN "email" character varying NOT NULL`}}}
+	require.NoError(t, ex.plan(context.Background(), nil, nil, plan))
+	cmds := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		cmds[i] = c.Cmd
+	}
+	require.Equal(t, []string{
+		`ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "no_overlap"`,
+		`ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "unknown"`,
+		`ALTER TABLE "bookings" ADD COLUMN "email" character varying NOT NULL`,
+		`ALTER TABLE "bookings" ADD CONSTRAINT "no_overlap" EXCLUDE USING gist ((tstzrange(starts_at, ends_at)) WITH &&)`,
+		`ALTER TABLE "bookings" ADD CONSTRAINT "unique_email" EXCLUDE USING btree ((lower(email)) WITH =)`,
+	}, cmds)
+
+	// No queries are executed if there are no exclusion constraints in the database.
+	ex = newExclusions(dialect.Postgres, []*Table{bookings})
+	require.NoError(t, ex.inspect(context.Background(), sql.OpenDB(dialect.Postgres, db), current))
+	require.NoError(t, mk.ExpectationsWereMet())
+	// Other dialects are ignored.
+	ex = newExclusions(dialect.MySQL, []*Table{bookings})
+	plan = &migrate.Plan{}
+	require.NoError(t, ex.plan(context.Background(), nil, nil, plan))
+	require.Empty(t, plan.Changes)
+}
+
+func TestPostgres_AtTypeRange(t *testing.T) {
+	c1 := &Column{Name: "during", Type: field.TypeRange, SchemaType: map[string]string{dialect.Postgres: "tstzrange"}}
+	c2 := schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&Postgres{}).atTypeC(c1, c2))
+	require.Equal(t, &postgres.RangeType{T: "tstzrange"}, c2.Type.Type)
+	c2 = schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&MySQL{}).atTypeC(c1, c2))
+	require.Equal(t, &schema.StringType{T: "varchar", Size: 255}, c2.Type.Type)
+	c2 = schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&SQLite{}).atTypeC(c1, c2))
+	require.Equal(t, &schema.StringType{T: "text"}, c2.Type.Type)
+}
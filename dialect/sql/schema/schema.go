@@ -296,6 +296,12 @@ type Column struct {
 	indexes    Indexes           // linked indexes.
 	foreign    *ForeignKey       // linked foreign-key.
 	Comment    string            // column comment.
+	// Generated defines the expression of a generated (computed) column.
+	// Generated columns are rejected by the legacy (non-Atlas) migration.
+	Generated *entsql.GeneratedColumn
+	// NativeEnum holds the name of the native enum type of the column (Postgres only).
+	// Native enum types are ignored by the legacy (non-Atlas) migration.
+	NativeEnum string
 }
 
 // Expr represents a raw expression. It is used to distinguish between
@@ -0,0 +1,409 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// isFullText reports if the given index is a full-text index.
+func isFullText(idx *Index) bool {
+	return idx.Annotation != nil && idx.Annotation.FullText
+}
+
+// fullTextIndex returns the sql.FullTextIndex of the given index.
+func fullTextIndex(idx *Index) *entsql.FullTextIndex {
+	ft := &entsql.FullTextIndex{Name: idx.Name, Config: idx.Annotation.FullTextConfig}
+	for _, c := range idx.Columns {
+		ft.Columns = append(ft.Columns, c.Name)
+	}
+	return ft
+}
+
+// fullText handles the parts of full-text indexes that are not supported by
+// Atlas. On Postgres, the index expressions are normalized before diffing,
+// and on SQLite, indexes are planned as FTS5 virtual tables that are kept in
+// sync with their content tables using triggers.
+type fullText struct {
+	nopFeature
+	dialect string
+	indexes []*fullTextTable
+	// Names of the tables that are managed by the migration.
+	managed map[string]bool
+	// FTS5 tables that exist in the database
+	// and were removed from the current state.
+	current map[string]*schema.Table
+	// FTS5 tables of the managed tables that exist in the database, but their
+	// indexes were removed from the schema, along with their triggers.
+	undeclared   []string
+	undeclaredTr map[string][]string
+}
+
+// fullTextTable holds a full-text index and its table.
+type fullTextTable struct {
+	t   *Table
+	idx *Index
+}
+
+// newFullText returns the full-text indexes handler for the given tables.
+func newFullText(d string, tables []*Table) *fullText {
+	f := &fullText{dialect: d, managed: make(map[string]bool), current: make(map[string]*schema.Table), undeclaredTr: make(map[string][]string)}
+	for _, t := range tables {
+		f.managed[t.Name] = true
+		for _, idx := range t.Indexes {
+			if isFullText(idx) {
+				f.indexes = append(f.indexes, &fullTextTable{t: t, idx: idx})
+			}
+		}
+	}
+	return f
+}
+
+// tables returns the names of the tables that should be
+// inspected in addition to the tables of the schema.
+func (f *fullText) tables() []string {
+	if f.dialect != dialect.SQLite {
+		return nil
+	}
+	names := make([]string, len(f.indexes))
+	for i, ft := range f.indexes {
+		names[i] = ft.idx.Name
+	}
+	return names
+}
+
+// reContent extracts the content table from the definition of FTS5 tables.
+var reContent = regexp.MustCompile(`(?i)\bcontent\s*=\s*'((?:[^']|'')*)'`)
+
+// inspect loads the FTS5 tables that exist in the database and are not declared by the
+// schema, along with their triggers. Only tables that were created for the full-text
+// indexes of the managed tables (i.e. use them as their content tables) are loaded.
+func (f *fullText) inspect(ctx context.Context, conn dialect.ExecQuerier, _ *schema.Schema) error {
+	if f.dialect != dialect.SQLite || len(f.managed) == 0 {
+		return nil
+	}
+	query, args := entsql.Select("tbl_name", "name", "sql").
+		From(entsql.Table("sqlite_master")).
+		Where(entsql.Or(
+			entsql.And(entsql.EQ("type", "table"), entsql.Like("sql", "CREATE VIRTUAL TABLE%")),
+			entsql.EQ("type", "trigger"),
+		)).
+		OrderBy("name").
+		Query()
+	objects, err := scanObjects(ctx, conn, query, args)
+	if err != nil {
+		return fmt.Errorf("sqlite: reading full-text tables: %w", err)
+	}
+	declared := make(map[string]bool, len(f.indexes))
+	for _, ft := range f.indexes {
+		declared[ft.idx.Name] = true
+	}
+	undeclared := make(map[string]bool)
+	for _, o := range objects {
+		m := reContent.FindStringSubmatch(o.source)
+		if o.table != o.name || len(m) != 2 || !f.managed[strings.ReplaceAll(m[1], "''", "'")] || declared[o.name] {
+			continue
+		}
+		f.undeclared = append(f.undeclared, o.name)
+		undeclared[o.name] = true
+	}
+	for _, o := range objects {
+		for _, s := range []string{"_ai", "_ad", "_au"} {
+			if name := strings.TrimSuffix(o.name, s); o.table != o.name && f.managed[o.table] && name != o.name && undeclared[name] {
+				f.undeclaredTr[name] = append(f.undeclaredTr[name], o.name)
+			}
+		}
+	}
+	return nil
+}
+
+// prepare prepares the current state before it is diffed with the desired state.
+func (f *fullText) prepare(current, desired *schema.Schema) {
+	switch f.dialect {
+	case dialect.Postgres:
+		f.normalize(current, desired)
+	case dialect.SQLite:
+		f.virtualTables(current)
+	}
+}
+
+// normalize sets the expressions of the current full-text indexes to their
+// desired form, if they are equal after normalization. Postgres returns the
+// expressions of indexes in their normal form, which is different from how
+// they are defined. e.g. to_tsvector('simple'::regconfig, (name)::text).
+func (f *fullText) normalize(current, desired *schema.Schema) {
+	for _, ft := range f.indexes {
+		t1, ok1 := current.Table(ft.t.Name)
+		t2, ok2 := desired.Table(ft.t.Name)
+		if !ok1 || !ok2 {
+			continue
+		}
+		idx1, ok1 := t1.Index(ft.idx.Name)
+		idx2, ok2 := t2.Index(ft.idx.Name)
+		if !ok1 || !ok2 || len(idx1.Parts) != 1 || len(idx2.Parts) != 1 {
+			continue
+		}
+		x1, ok1 := idx1.Parts[0].X.(*schema.RawExpr)
+		x2, ok2 := idx2.Parts[0].X.(*schema.RawExpr)
+		if ok1 && ok2 && normalExpr(x1.X) == normalExpr(x2.X) {
+			x1.X = x2.X
+		}
+	}
+}
+
+var reCast = regexp.MustCompile(`::\w+( varying)?`)
+
+// normalExpr returns the normal form of the
+// expression that is used for comparison.
+func normalExpr(x string) string {
+	x = reCast.ReplaceAllString(strings.ToLower(x), "")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '(', ')', '"', '\'', ' ', '\t', '\n':
+			return -1
+		}
+		return r
+	}, x)
+}
+
+// fts5Shadow holds the suffixes of the shadow tables
+// that are created by SQLite for FTS5 virtual tables.
+var fts5Shadow = []string{"_data", "_idx", "_content", "_docsize", "_config"}
+
+// virtualTables removes the virtual tables and their shadow tables from the current
+// state, as they are not managed by Atlas. FTS5 tables are stored for planning.
+func (f *fullText) virtualTables(current *schema.Schema) {
+	var virtual []string
+	for _, t := range current.Tables {
+		var stmt sqlite.CreateStmt
+		for _, a := range t.Attrs {
+			if s, ok := a.(*sqlite.CreateStmt); ok {
+				stmt = *s
+			}
+		}
+		if strings.HasPrefix(strings.ToUpper(stmt.S), "CREATE VIRTUAL TABLE") {
+			virtual = append(virtual, t.Name)
+			f.current[t.Name] = t
+		}
+	}
+	if len(virtual) == 0 {
+		return
+	}
+	tables := make([]*schema.Table, 0, len(current.Tables))
+Tables:
+	for _, t := range current.Tables {
+		for _, v := range virtual {
+			if t.Name == v {
+				continue Tables
+			}
+			for _, s := range fts5Shadow {
+				if t.Name == v+s {
+					continue Tables
+				}
+			}
+		}
+		tables = append(tables, t)
+	}
+	current.Tables = tables
+}
+
+// plan appends the changes of the full-text indexes to the plan.
+func (f *fullText) plan(_ context.Context, _ dialect.ExecQuerier, changes []schema.Change, plan *migrate.Plan) error {
+	if f.dialect != dialect.SQLite {
+		return nil
+	}
+	modified := make(map[string]bool)
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyTable); ok {
+			modified[m.T.Name] = true
+		}
+	}
+	// FTS5 tables of removed indexes are dropped before the schema changes,
+	// as their triggers reference the columns of their content tables.
+	var drops []*migrate.Change
+	for _, name := range f.undeclared {
+		drops = append(drops, f.dropTable(name, f.undeclaredTr[name])...)
+	}
+	plan.Changes = append(drops, plan.Changes...)
+	for _, ft := range f.indexes {
+		t, exists := f.current[ft.idx.Name]
+		switch {
+		case !exists:
+			plan.Changes = append(plan.Changes, f.create(ft)...)
+		case !sameColumns(t, ft.idx):
+			plan.Changes = append(plan.Changes, f.drop(ft)...)
+			plan.Changes = append(plan.Changes, f.create(ft)...)
+		case modified[ft.t.Name]:
+			// Triggers are dropped when SQLite tables are copied
+			// during the migration. Hence, they are recreated.
+			plan.Changes = append(plan.Changes, f.dropTriggers(ft)...)
+			plan.Changes = append(plan.Changes, f.triggers(ft)...)
+		}
+	}
+	return nil
+}
+
+// sameColumns reports if the FTS5 table is defined on the index columns.
+func sameColumns(t *schema.Table, idx *Index) bool {
+	columns := make([]string, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		// Skip the hidden columns of FTS5 tables.
+		if c.Name != t.Name && c.Name != "rank" {
+			columns = append(columns, c.Name)
+		}
+	}
+	if len(columns) != len(idx.Columns) {
+		return false
+	}
+	for i, c := range idx.Columns {
+		if columns[i] != c.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// create returns the changes for creating the FTS5 table of the index
+// with its triggers, and populating it from its content table.
+func (f *fullText) create(ft *fullTextTable) []*migrate.Change {
+	create := &entsql.Builder{}
+	create.WriteString("CREATE VIRTUAL TABLE ").Ident(ft.idx.Name).WriteString(" USING fts5").Wrap(func(b *entsql.Builder) {
+		b.IdentComma(fullTextIndex(ft.idx).Columns...).Comma().WriteString("content=").WriteString(quote(ft.t.Name))
+	})
+	rebuild := &entsql.Builder{}
+	rebuild.WriteString("INSERT INTO ").Ident(ft.idx.Name).Wrap(func(b *entsql.Builder) {
+		b.Ident(ft.idx.Name)
+	}).WriteString(" VALUES ('rebuild')")
+	changes := []*migrate.Change{
+		{
+			Cmd:     create.String(),
+			Comment: fmt.Sprintf("create %q full-text table", ft.idx.Name),
+		},
+	}
+	changes = append(changes, f.triggers(ft)...)
+	return append(changes, &migrate.Change{
+		Cmd:     rebuild.String(),
+		Comment: fmt.Sprintf("populate %q full-text table", ft.idx.Name),
+	})
+}
+
+// drop returns the changes for dropping the FTS5 table of the index and its triggers.
+func (f *fullText) drop(ft *fullTextTable) []*migrate.Change {
+	b := &entsql.Builder{}
+	return append(f.dropTriggers(ft), &migrate.Change{
+		Cmd:     b.WriteString("DROP TABLE ").Ident(ft.idx.Name).String(),
+		Comment: fmt.Sprintf("drop %q full-text table", ft.idx.Name),
+	})
+}
+
+// dropTable returns the changes for dropping an FTS5 table that is not declared
+// by the schema, and its triggers.
+func (f *fullText) dropTable(name string, triggers []string) []*migrate.Change {
+	changes := make([]*migrate.Change, 0, len(triggers)+1)
+	for _, tr := range triggers {
+		b := &entsql.Builder{}
+		changes = append(changes, &migrate.Change{
+			Cmd:     b.WriteString("DROP TRIGGER IF EXISTS ").Ident(tr).String(),
+			Comment: fmt.Sprintf("drop %q trigger", tr),
+		})
+	}
+	b := &entsql.Builder{}
+	return append(changes, &migrate.Change{
+		Cmd:     b.WriteString("DROP TABLE ").Ident(name).String(),
+		Comment: fmt.Sprintf("drop %q full-text table", name),
+	})
+}
+
+// triggers returns the changes for creating the triggers that keep the FTS5 table
+// of the index in sync with its content table. The "rowid" of the FTS5 table is
+// mapped to the "rowid" of the content table.
+func (f *fullText) triggers(ft *fullTextTable) []*migrate.Change {
+	columns := append([]string{"rowid"}, fullTextIndex(ft.idx).Columns...)
+	// insert writes the statement that inserts (or deletes)
+	// the values of the given row to (or from) the index.
+	insert := func(b *entsql.Builder, row string, del bool) {
+		b.WriteString("INSERT INTO ").Ident(ft.idx.Name).Wrap(func(b *entsql.Builder) {
+			if del {
+				b.Ident(ft.idx.Name).Comma()
+			}
+			b.IdentComma(columns...)
+		}).WriteString(" VALUES ").Wrap(func(b *entsql.Builder) {
+			if del {
+				b.WriteString("'delete'").Comma()
+			}
+			for i, c := range columns {
+				if i > 0 {
+					b.Comma()
+				}
+				b.WriteString(row).WriteString(".").Ident(c)
+			}
+		}).WriteString("; ")
+	}
+	trigger := func(name, event string, body func(*entsql.Builder)) *migrate.Change {
+		b := &entsql.Builder{}
+		b.WriteString("CREATE TRIGGER ").Ident(name).WriteString(" AFTER ").WriteString(event).
+			WriteString(" ON ").Ident(ft.t.Name).WriteString(" BEGIN ")
+		body(b)
+		return &migrate.Change{
+			Cmd:     b.WriteString("END").String(),
+			Comment: fmt.Sprintf("create %q trigger", name),
+		}
+	}
+	return []*migrate.Change{
+		trigger(ft.idx.Name+"_ai", "INSERT", func(b *entsql.Builder) {
+			insert(b, "new", false)
+		}),
+		trigger(ft.idx.Name+"_ad", "DELETE", func(b *entsql.Builder) {
+			insert(b, "old", true)
+		}),
+		trigger(ft.idx.Name+"_au", "UPDATE", func(b *entsql.Builder) {
+			insert(b, "old", true)
+			insert(b, "new", false)
+		}),
+	}
+}
+
+// dropTriggers returns the changes for dropping the triggers of the index.
+func (f *fullText) dropTriggers(ft *fullTextTable) []*migrate.Change {
+	changes := make([]*migrate.Change, 0, 3)
+	for _, s := range []string{"_ai", "_ad", "_au"} {
+		b := &entsql.Builder{}
+		changes = append(changes, &migrate.Change{
+			Cmd:     b.WriteString("DROP TRIGGER IF EXISTS ").Ident(ft.idx.Name + s).String(),
+			Comment: fmt.Sprintf("drop %q trigger", ft.idx.Name+s),
+		})
+	}
+	return changes
+}
+
+// quote returns the given name as an SQL string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// reTrigger matches the planned changes that create triggers. Their body may contain
+// multiple statements, and therefore, a custom delimiter is used when they are
+// written to migration files.
+var reTrigger = regexp.MustCompile(`(?i)^\s*CREATE\s+TRIGGER\b`)
+
+// delimitTriggers wraps the trigger changes of the plan with a
+// custom delimiter, that is supported by the migration files lexer.
+func delimitTriggers(plan *migrate.Plan) {
+	for _, c := range plan.Changes {
+		if reTrigger.MatchString(c.Cmd) && strings.Contains(c.Cmd, ";") {
+			// The formatter terminates the command with the default delimiter.
+			c.Cmd = fmt.Sprintf("delimiter //\n%s//\ndelimiter ", c.Cmd)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullText_AtIndex(t *testing.T) {
+	posts := &Table{
+		Name: "posts",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "title", Type: field.TypeString},
+			{Name: "body", Type: field.TypeString},
+		},
+	}
+	idx := &Index{Name: "posts_title_body", Columns: posts.Columns[1:], Annotation: &entsql.IndexAnnotation{FullText: true}}
+	at := schema.NewTable("posts").AddColumns(schema.NewColumn("title"), schema.NewColumn("body"))
+
+	i2 := schema.NewIndex(idx.Name)
+	require.NoError(t, (&MySQL{}).atIndex(idx, at, i2))
+	require.Len(t, i2.Parts, 2)
+	require.Equal(t, []schema.Attr{&mysql.IndexType{T: mysql.IndexTypeFullText}}, i2.Attrs)
+
+	i2 = schema.NewIndex(idx.Name)
+	require.NoError(t, (&Postgres{}).atIndex(idx, at, i2))
+	require.Len(t, i2.Parts, 1)
+	require.Equal(t, &schema.RawExpr{X: `to_tsvector('simple', coalesce("title", '') || ' ' || coalesce("body", ''))`}, i2.Parts[0].X)
+	require.Equal(t, []schema.Attr{&postgres.IndexType{T: postgres.IndexTypeGIN}}, i2.Attrs)
+
+	// Expressions as returned by Postgres.
+	require.Equal(t,
+		normalExpr(`to_tsvector('simple', "title")`),
+		normalExpr(`to_tsvector('simple'::regconfig, (title)::text)`),
+	)
+	require.Equal(t,
+		normalExpr(`to_tsvector('english', coalesce("title", '') || ' ' || coalesce("body", ''))`),
+		normalExpr(`to_tsvector('english'::regconfig, ((COALESCE(title, ''::character varying))::text || ' '::text) || (COALESCE(body, ''::character varying))::text))`),
+	)
+	require.NotEqual(t,
+		normalExpr(`to_tsvector('english', "title")`),
+		normalExpr(`to_tsvector('simple'::regconfig, (title)::text)`),
+	)
+}
+
+func TestMigrate_FullText(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:fulltext?mode=memory&_fk=1")
+	require.NoError(t, err)
+	if _, err := db.ExecContext(ctx, "CREATE VIRTUAL TABLE `t` USING fts5(`c`)"); err != nil {
+		t.Skipf("fts5 is not supported: %v", err)
+	}
+	_, err = db.ExecContext(ctx, "DROP TABLE `t`")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "CREATE TABLE `posts` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `title` text NOT NULL, `body` text NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO `posts` (`title`, `body`) VALUES ('Ent', 'An entity framework for Go'), ('Atlas', 'Manage your database schema as code')")
+	require.NoError(t, err)
+
+	posts := &Table{
+		Name: "posts",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "title", Type: field.TypeString},
+			{Name: "body", Type: field.TypeString},
+		},
+	}
+	posts.PrimaryKey = posts.Columns[:1]
+	posts.Indexes = []*Index{
+		{Name: "posts_title_body", Columns: posts.Columns[1:], Annotation: &entsql.IndexAnnotation{FullText: true}},
+	}
+
+	// Versioned migration.
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "fulltext", posts))
+	requireFileEqual(t, filepath.Join(p, "fulltext.sql"), "CREATE VIRTUAL TABLE `posts_title_body` USING fts5(`title`, `body`, content='posts');\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_ai` AFTER INSERT ON `posts` BEGIN INSERT INTO `posts_title_body`(`rowid`, `title`, `body`) VALUES (new.`rowid`, new.`title`, new.`body`); END//\ndelimiter ;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_ad` AFTER DELETE ON `posts` BEGIN INSERT INTO `posts_title_body`(`posts_title_body`, `rowid`, `title`, `body`) VALUES ('delete', old.`rowid`, old.`title`, old.`body`); END//\ndelimiter ;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_au` AFTER UPDATE ON `posts` BEGIN INSERT INTO `posts_title_body`(`posts_title_body`, `rowid`, `title`, `body`) VALUES ('delete', old.`rowid`, old.`title`, old.`body`); INSERT INTO `posts_title_body`(`rowid`, `title`, `body`) VALUES (new.`rowid`, new.`title`, new.`body`); END//\ndelimiter ;\n"+
+		"INSERT INTO `posts_title_body`(`posts_title_body`) VALUES ('rebuild');\n")
+	c, err := os.ReadFile(filepath.Join(p, "fulltext.sql"))
+	require.NoError(t, err)
+	stmts, err := migrate.Stmts(string(c))
+	require.NoError(t, err)
+	require.Len(t, stmts, 5)
+	require.True(t, strings.HasSuffix(stmts[1].Text, "END"))
+
+	// Online migration.
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, posts))
+	search := func(query string) []int {
+		idx := &sql.FullTextIndex{Name: "posts_title_body", Columns: []string{"title", "body"}}
+		s := sql.Dialect(dialect.SQLite).Select("id").From(sql.Table("posts"))
+		sql.FieldsSearch(idx, query)(s)
+		sql.OrderBySearchRank(idx, query)(s)
+		q, args := s.Query()
+		rows, err := db.QueryContext(ctx, q, args...)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []int
+		require.NoError(t, sql.ScanSlice(rows, &ids))
+		return ids
+	}
+	require.Equal(t, []int{1}, search("framework go"))
+	require.Equal(t, []int{2}, search("schema"))
+	require.Empty(t, search("ent atlas"))
+	_, err = db.ExecContext(ctx, "INSERT INTO `posts` (`title`, `body`) VALUES ('Ent Schema', 'Ent schema with full-text indexes')")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "UPDATE `posts` SET `body` = 'Database migrations' WHERE `id` = 2")
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, search("schema"))
+	_, err = db.ExecContext(ctx, "DELETE FROM `posts` WHERE `id` = 3")
+	require.NoError(t, err)
+	require.Empty(t, search("schema"))
+
+	// No changes are planned for an up-to-date schema.
+	p = t.TempDir()
+	d, err = migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "noop", posts))
+	require.NoFileExists(t, filepath.Join(p, "noop.sql"))
+
+	// Triggers are recreated when the table is modified.
+	posts.Columns = append(posts.Columns, &Column{Name: "author", Type: field.TypeString, Nullable: true})
+	require.NoError(t, m.NamedDiff(ctx, "author", posts))
+	requireFileEqual(t, filepath.Join(p, "author.sql"), "ALTER TABLE `posts` ADD COLUMN `author` text NULL;\n"+
+		"DROP TRIGGER IF EXISTS `posts_title_body_ai`;\n"+
+		"DROP TRIGGER IF EXISTS `posts_title_body_ad`;\n"+
+		"DROP TRIGGER IF EXISTS `posts_title_body_au`;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_ai` AFTER INSERT ON `posts` BEGIN INSERT INTO `posts_title_body`(`rowid`, `title`, `body`) VALUES (new.`rowid`, new.`title`, new.`body`); END//\ndelimiter ;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_ad` AFTER DELETE ON `posts` BEGIN INSERT INTO `posts_title_body`(`posts_title_body`, `rowid`, `title`, `body`) VALUES ('delete', old.`rowid`, old.`title`, old.`body`); END//\ndelimiter ;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_title_body_au` AFTER UPDATE ON `posts` BEGIN INSERT INTO `posts_title_body`(`posts_title_body`, `rowid`, `title`, `body`) VALUES ('delete', old.`rowid`, old.`title`, old.`body`); INSERT INTO `posts_title_body`(`rowid`, `title`, `body`) VALUES (new.`rowid`, new.`title`, new.`body`); END//\ndelimiter ;\n")
+
+	// The FTS5 table is recreated when its columns are changed.
+	posts.Indexes[0].Columns = posts.Columns[1:2]
+	require.NoError(t, m.NamedDiff(ctx, "title", posts))
+	c, err = os.ReadFile(filepath.Join(p, "title.sql"))
+	require.NoError(t, err)
+	require.Contains(t, string(c), "DROP TABLE `posts_title_body`;\nCREATE VIRTUAL TABLE `posts_title_body` USING fts5(`title`, content='posts');\n")
+
+	// The FTS5 table and its triggers are dropped when the index is removed.
+	posts.Indexes = nil
+	require.NoError(t, m.NamedDiff(ctx, "drop", posts))
+	requireFileEqual(t, filepath.Join(p, "drop.sql"), "DROP TRIGGER IF EXISTS `posts_title_body_ad`;\n"+
+		"DROP TRIGGER IF EXISTS `posts_title_body_ai`;\n"+
+		"DROP TRIGGER IF EXISTS `posts_title_body_au`;\n"+
+		"DROP TABLE `posts_title_body`;\n"+
+		"ALTER TABLE `posts` ADD COLUMN `author` text NULL;\n")
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, posts))
+	rows, err := db.QueryContext(ctx, "SELECT `name` FROM `sqlite_master` WHERE `name` LIKE 'posts_title_body%'")
+	require.NoError(t, err)
+	var names []string
+	require.NoError(t, sql.ScanSlice(rows, &names))
+	require.NoError(t, rows.Close())
+	require.Empty(t, names)
+	_, err = db.ExecContext(ctx, "INSERT INTO `posts` (`title`, `body`) VALUES ('Ent', 'Full-text index was removed')")
+	require.NoError(t, err)
+
+	// FTS5 tables that do not belong to the managed tables are not dropped.
+	_, err = db.ExecContext(ctx, "CREATE VIRTUAL TABLE `docs` USING fts5(`c`)")
+	require.NoError(t, err)
+	p = t.TempDir()
+	d, err = migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "noop", posts))
+	require.NoFileExists(t, filepath.Join(p, "noop.sql"))
+}
@@ -0,0 +1,53 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+)
+
+// isSpatial reports if the given index is a spatial index.
+func isSpatial(idx *Index) bool {
+	return idx.Annotation != nil && idx.Annotation.Spatial
+}
+
+// spatialInspector is implemented by dialects that inspect the attributes
+// of spatial columns that are not inspected by Atlas (e.g. the SRID in MySQL).
+type spatialInspector interface {
+	inspectSpatial(context.Context, dialect.ExecQuerier, *schema.Schema) error
+}
+
+// spatial completes the inspection of the spatial columns in the
+// current state, in case the desired state contains such columns.
+type spatial struct {
+	nopFeature
+	drv    sqlDialect
+	tables []*Table
+}
+
+// inspect implements the featureHandler.inspect method.
+func (s *spatial) inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error {
+	si, ok := s.drv.(spatialInspector)
+	if !ok || !hasSpatial(s.tables) {
+		return nil
+	}
+	return si.inspectSpatial(ctx, conn, current)
+}
+
+// hasSpatial reports if any of the tables has a spatial column.
+func hasSpatial(tables []*Table) bool {
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Type == field.TypeGeometry {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgeo"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatial_AtTypeC(t *testing.T) {
+	c1 := &Column{Name: "location", Type: field.TypeGeometry, SchemaType: map[string]string{
+		dialect.MySQL:    "point srid 4326",
+		dialect.Postgres: "geometry(Point,4326)",
+	}}
+	for v, expected := range map[string]string{
+		"8.0.19":         "point srid 4326",
+		"5.7.26":         "point",
+		"10.5.8-MariaDB": "point",
+	} {
+		c2 := schema.NewColumn(c1.Name).SetNull(false)
+		require.NoError(t, (&MySQL{version: v}).atTypeC(c1, c2))
+		require.Equal(t, &schema.SpatialType{T: expected}, c2.Type.Type, v)
+	}
+	c2 := schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&MySQL{version: "8.0.19"}).atTypeC(&Column{Name: "area", Type: field.TypeGeometry}, c2))
+	require.Equal(t, &schema.SpatialType{T: "geometry"}, c2.Type.Type)
+
+	c2 = schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&Postgres{}).atTypeC(c1, c2))
+	require.Equal(t, &postgres.UserDefinedType{T: "geometry(Point,4326)"}, c2.Type.Type)
+	c2 = schema.NewColumn(c1.Name).SetNull(false)
+	require.NoError(t, (&SQLite{}).atTypeC(c1, c2))
+	require.Equal(t, &schema.StringType{T: "text"}, c2.Type.Type)
+}
+
+func TestSpatial_AtIndex(t *testing.T) {
+	places := &Table{
+		Name: "places",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "location", Type: field.TypeGeometry},
+		},
+	}
+	idx := &Index{Name: "place_location", Columns: places.Columns[1:], Annotation: &entsql.IndexAnnotation{Spatial: true}}
+	at := schema.NewTable("places").AddColumns(schema.NewColumn("location"))
+
+	i2 := schema.NewIndex(idx.Name)
+	require.NoError(t, (&MySQL{}).atIndex(idx, at, i2))
+	require.Equal(t, []schema.Attr{&mysql.IndexType{T: mysql.IndexTypeSpatial}}, i2.Attrs)
+	i2 = schema.NewIndex(idx.Name)
+	require.NoError(t, (&Postgres{}).atIndex(idx, at, i2))
+	require.Equal(t, []schema.Attr{&postgres.IndexType{T: postgres.IndexTypeGiST}}, i2.Attrs)
+}
+
+func TestMySQL_InspectSpatial(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT `TABLE_NAME`, `COLUMN_NAME`, `SRS_ID` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = (SELECT DATABASE()) AND `SRS_ID` IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "SRS_ID"}).
+			AddRow("places", "location", 4326).
+			AddRow("unknown", "location", 4326))
+	s := schema.New("test").AddTables(
+		schema.NewTable("places").AddColumns(
+			schema.NewColumn("location").SetType(&schema.SpatialType{T: "point"}),
+			schema.NewColumn("area").SetType(&schema.SpatialType{T: "polygon"}),
+		),
+	)
+	d := &MySQL{Driver: sql.OpenDB(dialect.MySQL, db), version: "8.0.19"}
+	require.NoError(t, d.inspectSpatial(context.Background(), d, s))
+	require.Equal(t, &schema.SpatialType{T: "point srid 4326"}, s.Tables[0].Columns[0].Type.Type)
+	require.Equal(t, &schema.SpatialType{T: "polygon"}, s.Tables[0].Columns[1].Type.Type)
+	require.NoError(t, mk.ExpectationsWereMet())
+
+	// No queries are executed for databases without SRID support.
+	d.version = "5.7.26"
+	require.NoError(t, d.inspectSpatial(context.Background(), d, s))
+	require.NoError(t, mk.ExpectationsWereMet())
+}
+
+func TestMigrate_Spatial(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:spatial?mode=memory&_fk=1")
+	require.NoError(t, err)
+	places := &Table{
+		Name: "places",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "location", Type: field.TypeGeometry, SchemaType: map[string]string{dialect.MySQL: "point srid 4326"}},
+		},
+	}
+	places.PrimaryKey = places.Columns[:1]
+	places.Indexes = []*Index{
+		{Name: "place_location", Columns: places.Columns[1:], Annotation: &entsql.IndexAnnotation{Spatial: true}},
+	}
+
+	// Spatial indexes are skipped in SQLite.
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "spatial", places))
+	requireFileEqual(t, filepath.Join(p, "spatial.sql"), "CREATE TABLE `places` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `location` text NOT NULL);\n")
+
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, places))
+	loc := sqlgeo.Point{X: 34.78, Y: 32.08, SRID: 4326}
+	query, args := sql.Dialect(dialect.SQLite).Insert("places").Columns("location").Values(loc).Query()
+	_, err = db.ExecContext(ctx, query, args...)
+	require.NoError(t, err)
+	query, args = sql.Dialect(dialect.SQLite).Select("location").From(sql.Table("places")).Query()
+	rows, err := db.QueryContext(ctx, query, args...)
+	require.NoError(t, err)
+	var scanned sqlgeo.Point
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&scanned))
+	require.NoError(t, rows.Close())
+	require.Equal(t, loc, scanned)
+
+	// No changes are planned for an up-to-date schema.
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "noop", places))
+	require.NoFileExists(t, filepath.Join(p, "noop.sql"))
+}
@@ -165,6 +165,12 @@ func (*SQLite) cType(c *Column) (t string) {
 		t = "json"
 	case field.TypeUUID:
 		t = "uuid"
+	case field.TypeGeometry:
+		// Geometries are stored as (E)WKT, that is
+		// supported by the SpatiaLite functions.
+		t = "text"
+	case field.TypeRange:
+		t = "text"
 	case field.TypeOther:
 		t = c.typ
 	default:
@@ -436,6 +442,8 @@ func (d *SQLite) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.JSONType{T: "json"}
 	case field.TypeUUID:
 		t = &sqlite.UUIDType{T: "uuid"}
+	case field.TypeGeometry, field.TypeRange:
+		t = &schema.StringType{T: sqlite.TypeText}
 	case field.TypeOther:
 		t = &schema.UnsupportedType{T: c1.typ}
 	default:
@@ -526,3 +534,21 @@ func (tx *tx) ExecContext(ctx context.Context, query string, args ...any) (stdsq
 	}
 	return r, nil
 }
+
+// inspectTriggers returns the triggers of the given tables.
+func (d *SQLite) inspectTriggers(ctx context.Context, conn dialect.ExecQuerier, _ *schema.Schema, tables []string) ([]*dbObject, []*dbObject, error) {
+	names := make([]any, len(tables))
+	for i := range tables {
+		names[i] = tables[i]
+	}
+	query, args := sql.Select("tbl_name", "name", "sql").
+		From(sql.Table("sqlite_master")).
+		Where(sql.And(sql.EQ("type", "trigger"), sql.In("tbl_name", names...))).
+		OrderBy("name").
+		Query()
+	triggers, err := scanObjects(ctx, conn, query, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sqlite: reading triggers: %w", err)
+	}
+	return triggers, nil, nil
+}
@@ -0,0 +1,414 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+)
+
+// dbObject describes a trigger or a function that exists in the database.
+type dbObject struct {
+	name  string
+	table string // table of the trigger
+	args  string // identity arguments of the function
+	// Source that holds the marker of the objects created by the migration.
+	// i.e. the trigger body in MySQL and SQLite, and the object comment in
+	// PostgreSQL.
+	source string
+}
+
+// reMarker matches the marker of the triggers and functions that were created by
+// the migration. The marker holds the hash of the definition of the object, and
+// allows detecting changes without comparing the definitions that are normalized
+// by the database.
+var reMarker = regexp.MustCompile(`ent:([0-9a-f]{16})`)
+
+// hash returns the hash of the object definition, or an empty
+// string if the object was not created by the migration.
+func (o *dbObject) hash() string {
+	if m := reMarker.FindStringSubmatch(o.source); len(m) == 2 {
+		return m[1]
+	}
+	return ""
+}
+
+// triggerInspector is implemented by dialects that support inspecting the
+// triggers of the given tables, and the functions of the schema.
+type triggerInspector interface {
+	inspectTriggers(context.Context, dialect.ExecQuerier, *schema.Schema, []string) (triggers, functions []*dbObject, err error)
+}
+
+// triggers handles the triggers and the stored functions declared by the tables.
+// Only objects that were created by the migration (i.e. marked with the hash of
+// their definition) are changed or dropped, and others are left untouched.
+type triggers struct {
+	nopFeature
+	drv     sqlDialect
+	dialect string
+	tables  []*Table
+	// Triggers and functions that exist in the database.
+	current, functions []*dbObject
+}
+
+// newTriggers returns the triggers handler for the given tables.
+func newTriggers(drv sqlDialect, d string, tables []*Table) *triggers {
+	return &triggers{drv: drv, dialect: d, tables: tables}
+}
+
+// inspect loads the triggers of the tables that exist in the database, and its functions. The query is executed
+// also when no triggers are declared, as triggers that were created by the migration are dropped when removed.
+func (tr *triggers) inspect(ctx context.Context, conn dialect.ExecQuerier, current *schema.Schema) error {
+	ti, ok := tr.drv.(triggerInspector)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, t := range tr.tables {
+		if _, ok := current.Table(t.Name); ok {
+			names = append(names, t.Name)
+		}
+	}
+	if len(names) == 0 && !tr.hasFunctions() {
+		return nil
+	}
+	var err error
+	tr.current, tr.functions, err = ti.inspectTriggers(ctx, conn, current, names)
+	return err
+}
+
+// hasFunctions reports if the tables declare stored functions.
+func (tr *triggers) hasFunctions() bool {
+	if tr.dialect != dialect.Postgres {
+		return false
+	}
+	for _, t := range tr.tables {
+		if t.Annotation != nil && len(t.Annotation.Functions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// triggerDef describes a trigger or a function that is declared by the tables.
+type triggerDef struct {
+	table, args, name string
+	hash              string
+	create            []*migrate.Change
+}
+
+// plan adds the trigger and function changes to the plan. Triggers (and functions) that were removed or changed are
+// dropped before the changes computed by Atlas, and new or changed ones are created after them. In SQLite, triggers of
+// modified tables are recreated, as they are dropped when tables are copied during the migration.
+func (tr *triggers) plan(_ context.Context, _ dialect.ExecQuerier, changes []schema.Change, plan *migrate.Plan) error {
+	triggers, functions, err := tr.desired()
+	if err != nil {
+		return err
+	}
+	modified := make(map[string]bool)
+	if tr.dialect == dialect.SQLite {
+		for _, c := range changes {
+			if m, ok := c.(*schema.ModifyTable); ok {
+				modified[m.T.Name] = true
+			}
+		}
+	}
+	var (
+		before, after []*migrate.Change
+		current       = make(map[string]*dbObject, len(tr.current))
+	)
+	for _, o := range tr.current {
+		current[tr.key(o.table, o.name)] = o
+		d, ok := triggers[tr.key(o.table, o.name)]
+		if h := o.hash(); h != "" && (!ok || d.hash != h || d.table != o.table || modified[o.table]) {
+			before = append(before, tr.dropTrigger(o))
+		}
+	}
+	// Functions are dropped after the triggers that depend on them,
+	// and changed functions are replaced (as they may still be used).
+	for _, o := range tr.functions {
+		if _, ok := functions[o.name]; !ok && o.hash() != "" {
+			before = append(before, tr.dropFunction(o))
+		}
+	}
+	for _, d := range sortedDefs(functions) {
+		if o := tr.function(d.name); o == nil || o.hash() != d.hash || o.args != d.args {
+			after = append(after, d.create...)
+		}
+	}
+	for _, d := range sortedDefs(triggers) {
+		o, ok := current[tr.key(d.table, d.name)]
+		switch {
+		case !ok:
+		case o.hash() == "":
+			return fmt.Errorf("sql/schema: trigger %q already exists, and was not created by the migration", d.name)
+		case o.hash() == d.hash && o.table == d.table && !modified[d.table]:
+			continue
+		}
+		after = append(after, d.create...)
+	}
+	plan.Changes = append(append(before, plan.Changes...), after...)
+	return nil
+}
+
+// key returns the key of the trigger. Trigger names are unique per table in
+// PostgreSQL, and per schema in MySQL and SQLite.
+func (tr *triggers) key(table, name string) string {
+	if tr.dialect == dialect.Postgres {
+		return table + "." + name
+	}
+	return name
+}
+
+// function returns the function that exists in the database with the given name.
+func (tr *triggers) function(name string) *dbObject {
+	for _, o := range tr.functions {
+		if o.name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// desired returns the triggers and the functions that are declared by the tables in the dialect, by their keys.
+func (tr *triggers) desired() (map[string]*triggerDef, map[string]*triggerDef, error) {
+	var (
+		triggers  = make(map[string]*triggerDef)
+		functions = make(map[string]*triggerDef)
+		declared  = make(map[string]*entsql.Function)
+	)
+	addFunc := func(f *entsql.Function) error {
+		if f1, ok := declared[f.Name]; ok && !reflect.DeepEqual(f1, f) {
+			return fmt.Errorf("sql/schema: function %q is declared multiple times with different definitions", f.Name)
+		}
+		declared[f.Name] = f
+		functions[f.Name] = tr.pgFunction(f)
+		return nil
+	}
+	for _, t := range tr.tables {
+		if t.Annotation == nil {
+			continue
+		}
+		if tr.dialect == dialect.Postgres {
+			for _, f := range t.Annotation.Functions {
+				if err := addFunc(f); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		for _, trg := range t.Annotation.Triggers {
+			defs, fn, err := tr.trigger(t, trg)
+			if err != nil {
+				return nil, nil, err
+			}
+			if fn != nil {
+				if err := addFunc(fn); err != nil {
+					return nil, nil, err
+				}
+			}
+			for _, d := range defs {
+				k := tr.key(d.table, d.name)
+				if _, ok := triggers[k]; ok {
+					return nil, nil, fmt.Errorf("sql/schema: trigger %q is declared multiple times", d.name)
+				}
+				triggers[k] = d
+			}
+		}
+	}
+	return triggers, functions, nil
+}
+
+// trigger returns the definitions of the trigger in the dialect. In PostgreSQL,
+// the trigger function that is created from its body is returned as well.
+func (tr *triggers) trigger(t *Table, trg *entsql.Trigger) ([]*triggerDef, *entsql.Function, error) {
+	body := trg.Definition(tr.dialect)
+	if tr.dialect == dialect.Postgres {
+		if body == "" && trg.Function == "" {
+			return nil, nil, nil
+		}
+		return tr.pgTrigger(t, trg, body)
+	}
+	switch {
+	case body == "":
+		return nil, nil, nil
+	case trg.Timing == entsql.TriggerInsteadOf && tr.dialect == dialect.MySQL:
+		return nil, nil, fmt.Errorf("sql/schema: INSTEAD OF triggers are not supported by MySQL (trigger %q)", trg.Name)
+	}
+	defs := make([]*triggerDef, 0, len(trg.Events))
+	for _, e := range trg.Events {
+		name := trg.Name
+		if len(trg.Events) > 1 {
+			name += "_" + strings.ToLower(string(e))
+		}
+		stmt := func(marker string) string {
+			b := builder(tr.dialect)
+			b.WriteString("CREATE TRIGGER ").Ident(name).Pad().WriteString(string(trg.Timing)).Pad().WriteString(string(e)).
+				WriteString(" ON ").Ident(t.Name).WriteString(" FOR EACH ROW")
+			if trg.When != "" && tr.dialect == dialect.SQLite {
+				b.WriteString(" WHEN ").WriteString(trg.When)
+			}
+			b.WriteString(" BEGIN ").WriteString(marker)
+			// MySQL does not support trigger conditions. Hence, the body is wrapped with an IF statement.
+			if trg.When != "" && tr.dialect == dialect.MySQL {
+				b.WriteString("IF ").WriteString(trg.When).WriteString(" THEN ").WriteString(terminate(body)).WriteString(" END IF; ")
+			} else {
+				b.WriteString(terminate(body)).Pad()
+			}
+			return b.WriteString("END").String()
+		}
+		h := definitionHash(stmt(""))
+		defs = append(defs, &triggerDef{
+			table: t.Name,
+			name:  name,
+			hash:  h,
+			create: []*migrate.Change{{
+				Cmd:     stmt(fmt.Sprintf("/* ent:%s */ ", h)),
+				Comment: fmt.Sprintf("create %q trigger", name),
+			}},
+		})
+	}
+	return defs, nil, nil
+}
+
+// pgTrigger returns the definition of a PostgreSQL trigger, and its trigger function if it is defined by a body.
+func (tr *triggers) pgTrigger(t *Table, trg *entsql.Trigger, body string) ([]*triggerDef, *entsql.Function, error) {
+	var fn *entsql.Function
+	name := trg.Function
+	if body != "" {
+		name = trg.Name
+		fn = &entsql.Function{Name: trg.Name, Returns: "trigger", Body: "BEGIN " + terminate(body) + " END"}
+	}
+	b := builder(dialect.Postgres)
+	b.WriteString("CREATE TRIGGER ").Ident(trg.Name).Pad().WriteString(string(trg.Timing)).Pad()
+	for i, e := range trg.Events {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteString(string(e))
+	}
+	b.WriteString(" ON ").Ident(t.Name).WriteString(" FOR EACH ROW")
+	if trg.When != "" {
+		b.WriteString(" WHEN (").WriteString(trg.When).WriteString(")")
+	}
+	b.WriteString(" EXECUTE FUNCTION ").Ident(name).WriteString("()")
+	d := &triggerDef{table: t.Name, name: trg.Name, hash: definitionHash(b.String())}
+	c := builder(dialect.Postgres)
+	c.WriteString("COMMENT ON TRIGGER ").Ident(trg.Name).WriteString(" ON ").Ident(t.Name).WriteString(" IS ").WriteString(quote("ent:" + d.hash))
+	d.create = []*migrate.Change{
+		{Cmd: b.String(), Comment: fmt.Sprintf("create %q trigger", trg.Name)},
+		{Cmd: c.String(), Comment: fmt.Sprintf("mark %q trigger", trg.Name)},
+	}
+	return []*triggerDef{d}, fn, nil
+}
+
+// pgFunction returns the definition of a PostgreSQL function. The body is written
+// as a string literal (instead of a dollar-quoted string), as it is supported by
+// the lexer of the migration files.
+func (tr *triggers) pgFunction(f *entsql.Function) *triggerDef {
+	lang := f.Language
+	if lang == "" {
+		lang = "plpgsql"
+	}
+	b := builder(dialect.Postgres)
+	b.WriteString("CREATE OR REPLACE FUNCTION ").Ident(f.Name).WriteString("(" + f.Args + ")").
+		WriteString(" RETURNS ").WriteString(f.Returns).WriteString(" LANGUAGE ").WriteString(lang).
+		WriteString(" AS ").WriteString(quote(f.Body))
+	d := &triggerDef{name: f.Name, args: f.Args, hash: definitionHash(b.String())}
+	c := builder(dialect.Postgres)
+	c.WriteString("COMMENT ON FUNCTION ").Ident(f.Name).WriteString("(" + f.Args + ")").WriteString(" IS ").WriteString(quote("ent:" + d.hash))
+	d.create = []*migrate.Change{
+		{Cmd: b.String(), Comment: fmt.Sprintf("create %q function", f.Name)},
+		{Cmd: c.String(), Comment: fmt.Sprintf("mark %q function", f.Name)},
+	}
+	return d
+}
+
+// dropTrigger returns the change for dropping the trigger.
+func (tr *triggers) dropTrigger(o *dbObject) *migrate.Change {
+	b := builder(tr.dialect)
+	b.WriteString("DROP TRIGGER IF EXISTS ").Ident(o.name)
+	if tr.dialect == dialect.Postgres {
+		b.WriteString(" ON ").Ident(o.table)
+	}
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("drop %q trigger", o.name),
+	}
+}
+
+// dropFunction returns the change for dropping the function.
+func (tr *triggers) dropFunction(o *dbObject) *migrate.Change {
+	b := builder(tr.dialect)
+	b.WriteString("DROP FUNCTION IF EXISTS ").Ident(o.name).WriteString("(" + o.args + ")")
+	return &migrate.Change{
+		Cmd:     b.String(),
+		Comment: fmt.Sprintf("drop %q function", o.name),
+	}
+}
+
+// sortedDefs returns the definitions sorted by their names (and tables).
+func sortedDefs(defs map[string]*triggerDef) []*triggerDef {
+	sorted := make([]*triggerDef, 0, len(defs))
+	for _, d := range defs {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].name != sorted[j].name {
+			return sorted[i].name < sorted[j].name
+		}
+		return sorted[i].table < sorted[j].table
+	})
+	return sorted
+}
+
+// definitionHash returns the hash of the object definition.
+func definitionHash(def string) string {
+	h := sha256.Sum256([]byte(def))
+	return hex.EncodeToString(h[:8])
+}
+
+// terminate ensures the statements are terminated with a semicolon.
+func terminate(stmts string) string {
+	stmts = strings.TrimSpace(stmts)
+	if !strings.HasSuffix(stmts, ";") {
+		stmts += ";"
+	}
+	return stmts
+}
+
+// scanObjects scans the rows of the triggers (or functions) query. The first column holds
+// the table of the trigger (or the arguments of the function), and the second its name.
+func scanObjects(ctx context.Context, conn dialect.ExecQuerier, query string, args []any) ([]*dbObject, error) {
+	rows := &sql.Rows{}
+	if err := conn.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var objects []*dbObject
+	for rows.Next() {
+		var (
+			o      dbObject
+			source sql.NullString
+		)
+		if err := rows.Scan(&o.table, &o.name, &source); err != nil {
+			return nil, err
+		}
+		o.source = source.String
+		objects = append(objects, &o)
+	}
+	return objects, rows.Err()
+}
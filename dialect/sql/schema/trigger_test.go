@@ -0,0 +1,259 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_Triggers(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:triggers?mode=memory&_fk=1")
+	require.NoError(t, err)
+	var (
+		users = &Table{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: field.TypeInt, Increment: true},
+				{Name: "posts_count", Type: field.TypeInt, Default: 0},
+			},
+		}
+		posts = &Table{
+			Name: "posts",
+			Columns: []*Column{
+				{Name: "id", Type: field.TypeInt, Increment: true},
+				{Name: "user_id", Type: field.TypeInt},
+			},
+			Annotation: entsql.Triggers(&entsql.Trigger{
+				Name:   "posts_count",
+				Timing: entsql.TriggerAfter,
+				Events: []entsql.TriggerEvent{entsql.TriggerInsert, entsql.TriggerDelete},
+				Bodies: map[string]string{
+					dialect.SQLite: "UPDATE `users` SET `posts_count` = (SELECT COUNT(*) FROM `posts` WHERE `user_id` = `users`.`id`)",
+				},
+			}),
+		}
+	)
+	users.PrimaryKey = users.Columns[:1]
+	posts.PrimaryKey = posts.Columns[:1]
+	_, err = db.ExecContext(ctx, "CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `posts_count` integer NOT NULL DEFAULT 0)")
+	require.NoError(t, err)
+	// Triggers that were not created by the migration are not changed.
+	_, err = db.ExecContext(ctx, "CREATE TRIGGER `users_noop` AFTER UPDATE ON `users` BEGIN SELECT 1; END")
+	require.NoError(t, err)
+
+	// Versioned migration.
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Name }}.sql")),
+		template.Must(template.New("").Parse(`{{ range .Changes }}{{ printf "%s;\n" .Cmd }}{{ end }}`)),
+	)
+	require.NoError(t, err)
+	m, err := NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "triggers", users, posts))
+	requireTriggersFile(t, filepath.Join(p, "triggers.sql"), "CREATE TABLE `posts` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `user_id` integer NOT NULL);\n"+
+		"delimiter //\nCREATE TRIGGER `posts_count_delete` AFTER DELETE ON `posts` FOR EACH ROW BEGIN /* ent:hash */ UPDATE `users` SET `posts_count` = (SELECT COUNT(*) FROM `posts` WHERE `user_id` = `users`.`id`); END//\ndelimiter ;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_count_insert` AFTER INSERT ON `posts` FOR EACH ROW BEGIN /* ent:hash */ UPDATE `users` SET `posts_count` = (SELECT COUNT(*) FROM `posts` WHERE `user_id` = `users`.`id`); END//\ndelimiter ;\n")
+	c, err := os.ReadFile(filepath.Join(p, "triggers.sql"))
+	require.NoError(t, err)
+	stmts, err := migrate.Stmts(string(c))
+	require.NoError(t, err)
+	require.Len(t, stmts, 3)
+
+	// Online migration.
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users, posts))
+	count := func() (n int) {
+		require.NoError(t, db.DB().QueryRowContext(ctx, "SELECT `posts_count` FROM `users` WHERE `id` = 1").Scan(&n))
+		return n
+	}
+	_, err = db.ExecContext(ctx, "INSERT INTO `users` (`id`) VALUES (1)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO `posts` (`user_id`) VALUES (1), (1)")
+	require.NoError(t, err)
+	require.Equal(t, 2, count())
+	_, err = db.ExecContext(ctx, "DELETE FROM `posts` WHERE `id` = 1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count())
+
+	// No changes are planned for an up-to-date schema.
+	p = t.TempDir()
+	d, err = migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "noop", users, posts))
+	require.NoFileExists(t, filepath.Join(p, "noop.sql"))
+
+	// Changed triggers are recreated, and removed ones are dropped.
+	posts.Annotation.Triggers[0].Events = posts.Annotation.Triggers[0].Events[:1]
+	require.NoError(t, m.NamedDiff(ctx, "insert", users, posts))
+	requireTriggersFile(t, filepath.Join(p, "insert.sql"), "DROP TRIGGER IF EXISTS `posts_count_delete`;\n"+
+		"DROP TRIGGER IF EXISTS `posts_count_insert`;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_count` AFTER INSERT ON `posts` FOR EACH ROW BEGIN /* ent:hash */ UPDATE `users` SET `posts_count` = (SELECT COUNT(*) FROM `posts` WHERE `user_id` = `users`.`id`); END//\ndelimiter ;\n")
+
+	// Triggers are recreated when the table is modified.
+	m, err = NewMigrate(db)
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users, posts))
+	posts.Columns = append(posts.Columns, &Column{Name: "title", Type: field.TypeString, Nullable: true})
+	m, err = NewMigrate(db, WithDir(d), WithFormatter(f))
+	require.NoError(t, err)
+	require.NoError(t, m.NamedDiff(ctx, "title", users, posts))
+	requireTriggersFile(t, filepath.Join(p, "title.sql"), "DROP TRIGGER IF EXISTS `posts_count`;\n"+
+		"ALTER TABLE `posts` ADD COLUMN `title` text NULL;\n"+
+		"delimiter //\nCREATE TRIGGER `posts_count` AFTER INSERT ON `posts` FOR EACH ROW BEGIN /* ent:hash */ UPDATE `users` SET `posts_count` = (SELECT COUNT(*) FROM `posts` WHERE `user_id` = `users`.`id`); END//\ndelimiter ;\n")
+
+	// Triggers that were not created by the migration cannot be replaced.
+	users.Annotation = entsql.Triggers(&entsql.Trigger{Name: "users_noop", Timing: entsql.TriggerAfter, Events: []entsql.TriggerEvent{entsql.TriggerUpdate}, Body: "SELECT 1"})
+	require.EqualError(t, m.NamedDiff(ctx, "noop", users, posts), `sql/schema: trigger "users_noop" already exists, and was not created by the migration`)
+}
+
+func TestTriggers_Postgres(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape(`SELECT "c"."relname", "t"."tgname", obj_description("t"."oid", 'pg_trigger') FROM "pg_catalog"."pg_trigger" AS "t" JOIN "pg_catalog"."pg_class" AS "c" ON "c"."oid" = "t"."tgrelid" JOIN "pg_catalog"."pg_namespace" AS "n" ON "n"."oid" = "c"."relnamespace" WHERE NOT "t"."tgisinternal" AND "n"."nspname" = $1 AND "c"."relname" IN ($2) ORDER BY "t"."tgname"`)).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "tgname", "comment"}).
+			AddRow("users", "users_audit", "ent:0123456789abcdef").
+			AddRow("users", "users_manual", nil))
+	mk.ExpectQuery(escape(`SELECT pg_get_function_identity_arguments("p"."oid"), "p"."proname", obj_description("p"."oid", 'pg_proc') FROM "pg_catalog"."pg_proc" AS "p" JOIN "pg_catalog"."pg_namespace" AS "n" ON "n"."oid" = "p"."pronamespace" WHERE "n"."nspname" = $1 AND obj_description("p"."oid", 'pg_proc') LIKE $2 ORDER BY "p"."proname"`)).
+		WithArgs("public", "ent:%").
+		WillReturnRows(sqlmock.NewRows([]string{"args", "proname", "comment"}).
+			AddRow("", "users_audit", "ent:0123456789abcdef"))
+	users := &Table{
+		Name: "users",
+		Annotation: &entsql.Annotation{
+			Triggers: []*entsql.Trigger{
+				{
+					Name:   "set_update_time",
+					Timing: entsql.TriggerBefore,
+					Events: []entsql.TriggerEvent{entsql.TriggerInsert, entsql.TriggerUpdate},
+					Body:   "NEW.update_time = now(); RETURN NEW;",
+				},
+				{
+					Name:     "users_touch",
+					Timing:   entsql.TriggerAfter,
+					Events:   []entsql.TriggerEvent{entsql.TriggerUpdate},
+					When:     "OLD.name IS DISTINCT FROM NEW.name",
+					Function: "touch",
+				},
+			},
+			Functions: []*entsql.Function{
+				{Name: "touch", Returns: "trigger", Language: "sql", Body: "SELECT 'it''s'"},
+			},
+		},
+	}
+	tr := newTriggers(&Postgres{}, dialect.Postgres, []*Table{users})
+	require.NoError(t, tr.inspect(context.Background(), sql.OpenDB(dialect.Postgres, db), schema.New("public").AddTables(schema.NewTable("users"))))
+	require.NoError(t, mk.ExpectationsWereMet())
+
+	plan := &migrate.Plan{Changes: []*migrate.Change{{Cmd: `ALTER TABLE "users" ADD COLUMN "name" character varying NULL`}}}
+	require.NoError(t, tr.plan(context.Background(), nil, nil, plan))
+	cmds := planCmds(plan)
+	for i := range cmds {
+		cmds[i] = reMarker.ReplaceAllString(cmds[i], "ent:hash")
+	}
+	require.Equal(t, []string{
+		`DROP TRIGGER IF EXISTS "users_audit" ON "users"`,
+		`DROP FUNCTION IF EXISTS "users_audit"()`,
+		`ALTER TABLE "users" ADD COLUMN "name" character varying NULL`,
+		`CREATE OR REPLACE FUNCTION "set_update_time"() RETURNS trigger LANGUAGE plpgsql AS 'BEGIN NEW.update_time = now(); RETURN NEW; END'`,
+		`COMMENT ON FUNCTION "set_update_time"() IS 'ent:hash'`,
+		`CREATE OR REPLACE FUNCTION "touch"() RETURNS trigger LANGUAGE sql AS 'SELECT ''it''''s'''`,
+		`COMMENT ON FUNCTION "touch"() IS 'ent:hash'`,
+		`CREATE TRIGGER "set_update_time" BEFORE INSERT OR UPDATE ON "users" FOR EACH ROW EXECUTE FUNCTION "set_update_time"()`,
+		`COMMENT ON TRIGGER "set_update_time" ON "users" IS 'ent:hash'`,
+		`CREATE TRIGGER "users_touch" AFTER UPDATE ON "users" FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION "touch"()`,
+		`COMMENT ON TRIGGER "users_touch" ON "users" IS 'ent:hash'`,
+	}, cmds)
+
+	// Trigger names are unique per table, and functions with the same name must have the same definition.
+	posts := &Table{
+		Name:       "posts",
+		Annotation: entsql.Triggers(users.Annotation.Triggers[0]),
+	}
+	tr = newTriggers(&Postgres{}, dialect.Postgres, []*Table{users, posts})
+	plan = &migrate.Plan{}
+	require.NoError(t, tr.plan(context.Background(), nil, nil, plan))
+	require.Len(t, plan.Changes, 10)
+	posts = &Table{
+		Name: "posts",
+		Annotation: &entsql.Annotation{
+			Functions: []*entsql.Function{{Name: "touch", Returns: "trigger", Body: "BEGIN RETURN NULL; END"}},
+		},
+	}
+	tr = newTriggers(&Postgres{}, dialect.Postgres, []*Table{users, posts})
+	require.EqualError(t, tr.plan(context.Background(), nil, nil, &migrate.Plan{}), `sql/schema: function "touch" is declared multiple times with different definitions`)
+}
+
+func TestTriggers_MySQL(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(escape("SELECT `EVENT_OBJECT_TABLE`, `TRIGGER_NAME`, `ACTION_STATEMENT` FROM `INFORMATION_SCHEMA`.`TRIGGERS` WHERE `TRIGGER_SCHEMA` = (SELECT DATABASE()) AND `EVENT_OBJECT_TABLE` IN (?) ORDER BY `TRIGGER_NAME`")).
+		WithArgs("users").
+		WillReturnRows(sqlmock.NewRows([]string{"EVENT_OBJECT_TABLE", "TRIGGER_NAME", "ACTION_STATEMENT"}))
+	users := &Table{
+		Name: "users",
+		Annotation: entsql.Triggers(
+			&entsql.Trigger{
+				Name:   "users_status",
+				Timing: entsql.TriggerBefore,
+				Events: []entsql.TriggerEvent{entsql.TriggerUpdate},
+				When:   "NEW.status <> OLD.status",
+				Body:   "SET NEW.status_time = NOW()",
+			},
+			// Triggers without a body for the dialect are skipped.
+			&entsql.Trigger{
+				Name:     "users_touch",
+				Timing:   entsql.TriggerAfter,
+				Events:   []entsql.TriggerEvent{entsql.TriggerUpdate},
+				Function: "touch",
+			},
+		),
+	}
+	tr := newTriggers(&MySQL{}, dialect.MySQL, []*Table{users})
+	require.NoError(t, tr.inspect(context.Background(), sql.OpenDB(dialect.MySQL, db), schema.New("test").AddTables(schema.NewTable("users"))))
+	require.NoError(t, mk.ExpectationsWereMet())
+	plan := &migrate.Plan{}
+	require.NoError(t, tr.plan(context.Background(), nil, nil, plan))
+	require.Len(t, plan.Changes, 1)
+	require.Regexp(t, "^CREATE TRIGGER `users_status` BEFORE UPDATE ON `users` FOR EACH ROW BEGIN /\\* ent:[0-9a-f]{16} \\*/ IF NEW.status <> OLD.status THEN SET NEW.status_time = NOW\\(\\); END IF; END$", plan.Changes[0].Cmd)
+
+	// The trigger is not changed if its definition was not changed.
+	tr.current = []*dbObject{{table: "users", name: "users_status", source: plan.Changes[0].Cmd}}
+	plan = &migrate.Plan{}
+	require.NoError(t, tr.plan(context.Background(), nil, nil, plan))
+	require.Empty(t, plan.Changes)
+
+	users.Annotation.Triggers[0].Timing = entsql.TriggerInsteadOf
+	require.EqualError(t, tr.plan(context.Background(), nil, nil, &migrate.Plan{}), `sql/schema: INSTEAD OF triggers are not supported by MySQL (trigger "users_status")`)
+}
+
+// requireTriggersFile requires the content of the migration file to be equal
+// to the expected one, ignoring the hashes of the trigger definitions.
+func requireTriggersFile(t *testing.T, name, contents string) {
+	c, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, contents, reMarker.ReplaceAllString(string(c), "ent:hash"))
+}
@@ -60,11 +60,15 @@ func (d *DirWriter) Flush(name string) error {
 	case len(d.changes) == 0:
 		return errors.New("writer has no changes to flush")
 	default:
-		return migrate.NewPlanner(nil, d.Dir, migrate.PlanFormat(d.Formatter)).
-			WritePlan(&migrate.Plan{
-				Name:    name,
-				Changes: d.changes,
-			})
+		// Statements that cannot be executed in a transaction
+		// block (e.g. CREATE INDEX CONCURRENTLY) are written to
+		// a separate file that follows the transactional one.
+		for _, p := range splitPlan(&migrate.Plan{Name: name, Transactional: true, Changes: d.changes}) {
+			if err := migrate.NewPlanner(nil, d.Dir, migrate.PlanFormat(d.Formatter)).WritePlan(p); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 }
 
@@ -0,0 +1,171 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+// This file provides dialect-aware helpers for querying full-text indexes
+// (entsql.FullText) in both ent/schema and generated code.
+
+// DefaultFullTextConfig is the text search configuration
+// used by full-text indexes and predicates in PostgreSQL.
+const DefaultFullTextConfig = "simple"
+
+// FullTextIndex describes a full-text index. It is used by the
+// search predicates and ordering, and by the migration engine.
+type FullTextIndex struct {
+	// Name of the index. In SQLite, it is also the
+	// name of the FTS5 table that holds the index.
+	Name string
+	// Columns are the indexed columns.
+	Columns []string
+	// Config is the text search configuration that is used
+	// in PostgreSQL. Defaults to DefaultFullTextConfig.
+	Config string
+}
+
+// TSVector returns the "to_tsvector" expression of the index columns, that
+// PostgreSQL full-text indexes and the search predicates are defined on.
+func (i *FullTextIndex) TSVector() string {
+	b := &Builder{dialect: dialect.Postgres}
+	i.tsvector(b, b.Ident)
+	return b.String()
+}
+
+// tsvector writes the "to_tsvector" expression of the index
+// columns to the builder using the given identifier writer.
+func (i *FullTextIndex) tsvector(b *Builder, ident func(string) *Builder) {
+	b.WriteString("to_tsvector(").WriteString(i.config()).WriteString(", ")
+	if len(i.Columns) == 1 {
+		ident(i.Columns[0])
+	} else {
+		// Concatenate multiple columns into one document, as the
+		// index and the predicates must use the same expression.
+		for j, c := range i.Columns {
+			if j > 0 {
+				b.WriteString(" || ' ' || ")
+			}
+			b.WriteString("coalesce(")
+			ident(c)
+			b.WriteString(", '')")
+		}
+	}
+	b.WriteByte(')')
+}
+
+// config returns the quoted text search configuration.
+func (i *FullTextIndex) config() string {
+	c := i.Config
+	if c == "" {
+		c = DefaultFullTextConfig
+	}
+	return "'" + strings.ReplaceAll(c, "'", "''") + "'"
+}
+
+// FieldsSearch returns a raw predicate to check if the columns of the given
+// full-text index match the search query. In MySQL, the query is evaluated in
+// natural language mode, in PostgreSQL using "plainto_tsquery", and in SQLite
+// all terms of the query must be matched by the FTS5 table of the index.
+func FieldsSearch(idx *FullTextIndex, query string) func(*Selector) {
+	return func(s *Selector) {
+		switch s.Dialect() {
+		case dialect.MySQL:
+			s.Where(P(func(b *Builder) {
+				matchAgainst(s, b, idx, query)
+			}))
+		case dialect.Postgres:
+			s.Where(P(func(b *Builder) {
+				tsvector(s, b, idx)
+				b.WriteString(" @@ ")
+				tsquery(b, idx, query)
+			}))
+		default: // SQLite.
+			q, ok := ftsQuery(query)
+			if !ok {
+				s.Where(False())
+				return
+			}
+			s.Where(In(s.C("rowid"), Select("rowid").From(Table(idx.Name)).Where(P(func(b *Builder) {
+				b.Ident(idx.Name).WriteString(" MATCH ").Arg(q)
+			}))))
+		}
+	}
+}
+
+// OrderBySearchRank returns a raw ordering function that orders the results by their
+// relevance (rank) to the search query, the most relevant first. It is usually used in
+// conjunction with the FieldsSearch predicate.
+func OrderBySearchRank(idx *FullTextIndex, query string) func(*Selector) {
+	return func(s *Selector) {
+		switch s.Dialect() {
+		case dialect.MySQL:
+			s.OrderExpr(ExprFunc(func(b *Builder) {
+				matchAgainst(s, b, idx, query)
+				b.WriteString(" DESC")
+			}))
+		case dialect.Postgres:
+			s.OrderExpr(ExprFunc(func(b *Builder) {
+				b.WriteString("ts_rank(")
+				tsvector(s, b, idx)
+				b.Comma()
+				tsquery(b, idx, query)
+				b.WriteString(") DESC")
+			}))
+		default: // SQLite.
+			q, ok := ftsQuery(query)
+			if !ok {
+				return
+			}
+			// Rank values of FTS5 are negative, and lower values indicate better matches.
+			s.OrderExpr(ExprFunc(func(b *Builder) {
+				b.WriteString("(SELECT ").Ident("rank").WriteString(" FROM ").Ident(idx.Name).
+					WriteString(" WHERE ").Ident(idx.Name).WriteString(" MATCH ").Arg(q).
+					WriteString(" AND ").Ident("rowid").WriteOp(OpEQ).Ident(s.C("rowid")).
+					WriteString(")")
+			}))
+		}
+	}
+}
+
+// matchAgainst writes the MySQL MATCH ... AGAINST expression.
+func matchAgainst(s *Selector, b *Builder, idx *FullTextIndex, query string) {
+	b.WriteString("MATCH(")
+	for i, c := range idx.Columns {
+		if i > 0 {
+			b.Comma()
+		}
+		b.Ident(s.C(c))
+	}
+	b.WriteString(") AGAINST(").Arg(query).WriteString(" IN NATURAL LANGUAGE MODE)")
+}
+
+// tsvector writes the "to_tsvector" expression of the index using the selector columns.
+func tsvector(s *Selector, b *Builder, idx *FullTextIndex) {
+	idx.tsvector(b, func(c string) *Builder {
+		return b.Ident(s.C(c))
+	})
+}
+
+// tsquery writes the "plainto_tsquery" expression of the search query.
+func tsquery(b *Builder, idx *FullTextIndex, query string) {
+	b.WriteString("plainto_tsquery(").WriteString(idx.config()).WriteString(", ").Arg(query).WriteByte(')')
+}
+
+// ftsQuery converts the search query to an FTS5 query where all terms
+// must be matched, and terms are quoted to avoid FTS5 syntax errors.
+func ftsQuery(query string) (string, bool) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return "", false
+	}
+	for i, t := range terms {
+		terms[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " "), true
+}
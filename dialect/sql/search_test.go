@@ -0,0 +1,56 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullTextIndex_TSVector(t *testing.T) {
+	idx := &FullTextIndex{Name: "users_name", Columns: []string{"name"}}
+	require.Equal(t, `to_tsvector('simple', "name")`, idx.TSVector())
+	idx = &FullTextIndex{Name: "posts_title_body", Columns: []string{"title", "body"}, Config: "english"}
+	require.Equal(t, `to_tsvector('english', coalesce("title", '') || ' ' || coalesce("body", ''))`, idx.TSVector())
+}
+
+func TestFieldsSearch(t *testing.T) {
+	idx := &FullTextIndex{Name: "posts_title_body", Columns: []string{"title", "body"}}
+	t.Run("MySQL", func(t *testing.T) {
+		s := Dialect(dialect.MySQL).Select("*").From(Table("posts"))
+		FieldsSearch(idx, "ent orm")(s)
+		OrderBySearchRank(idx, "ent orm")(s)
+		query, args := s.Query()
+		require.Equal(t, "SELECT * FROM `posts` WHERE MATCH(`posts`.`title`, `posts`.`body`) AGAINST(? IN NATURAL LANGUAGE MODE) ORDER BY MATCH(`posts`.`title`, `posts`.`body`) AGAINST(? IN NATURAL LANGUAGE MODE) DESC", query)
+		require.Equal(t, []any{"ent orm", "ent orm"}, args)
+	})
+	t.Run("PostgreSQL", func(t *testing.T) {
+		s := Dialect(dialect.Postgres).Select("*").From(Table("posts"))
+		FieldsSearch(idx, "ent orm")(s)
+		OrderBySearchRank(idx, "ent orm")(s)
+		query, args := s.Query()
+		vector := `to_tsvector('simple', coalesce("posts"."title", '') || ' ' || coalesce("posts"."body", ''))`
+		require.Equal(t, `SELECT * FROM "posts" WHERE `+vector+` @@ plainto_tsquery('simple', $1) ORDER BY ts_rank(`+vector+`, plainto_tsquery('simple', $2)) DESC`, query)
+		require.Equal(t, []any{"ent orm", "ent orm"}, args)
+	})
+	t.Run("SQLite", func(t *testing.T) {
+		s := Dialect(dialect.SQLite).Select("*").From(Table("posts"))
+		FieldsSearch(idx, `ent "orm"`)(s)
+		OrderBySearchRank(idx, `ent "orm"`)(s)
+		query, args := s.Query()
+		require.Equal(t, "SELECT * FROM `posts` WHERE `posts`.`rowid` IN (SELECT `rowid` FROM `posts_title_body` WHERE `posts_title_body` MATCH ?) ORDER BY (SELECT `rank` FROM `posts_title_body` WHERE `posts_title_body` MATCH ? AND `rowid` = `posts`.`rowid`)", query)
+		require.Equal(t, []any{`"ent" """orm"""`, `"ent" """orm"""`}, args)
+
+		s = Dialect(dialect.SQLite).Select("*").From(Table("posts"))
+		FieldsSearch(idx, " ")(s)
+		OrderBySearchRank(idx, " ")(s)
+		query, args = s.Query()
+		require.Equal(t, "SELECT * FROM `posts` WHERE FALSE", query)
+		require.Empty(t, args)
+	})
+}
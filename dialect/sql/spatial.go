@@ -0,0 +1,93 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"entgo.io/ent/dialect"
+)
+
+// This file provides dialect-aware predicates for spatial columns
+// (field.Point and field.Geometry). The given geometries are expected
+// to be formatted by their type (e.g. sqlgeo.Point, sqlgeo.Geometry),
+// and in SQLite, the SpatiaLite extension must be loaded.
+
+// GeoIntersects returns a predicate to check if the
+// geometry column intersects (shares any portion of
+// space with) the given geometry.
+func GeoIntersects(col string, v any) *Predicate { return P().GeoIntersects(col, v) }
+
+// GeoIntersects returns a predicate to check if the geometry column intersects the given geometry.
+func (p *Predicate) GeoIntersects(col string, v any) *Predicate {
+	return p.Append(func(b *Builder) {
+		geoFunc(b, "ST_Intersects", col, v)
+	})
+}
+
+// GeoContains returns a predicate to check if the geometry column
+// contains the given geometry. i.e. no points of the given geometry
+// lie in the exterior of the column geometry.
+func GeoContains(col string, v any) *Predicate { return P().GeoContains(col, v) }
+
+// GeoContains returns a predicate to check if the geometry column contains the given geometry.
+func (p *Predicate) GeoContains(col string, v any) *Predicate {
+	return p.Append(func(b *Builder) {
+		geoFunc(b, "ST_Contains", col, v)
+	})
+}
+
+// GeoWithinDistance returns a predicate to check if the geometry column is within
+// the given distance from the given geometry. The distance is measured in the units
+// of the spatial reference system of the geometries (e.g. degrees for SRID 4326).
+func GeoWithinDistance(col string, v any, distance float64) *Predicate {
+	return P().GeoWithinDistance(col, v, distance)
+}
+
+// GeoWithinDistance returns a predicate to check if the geometry column is within the given distance from the given geometry.
+func (p *Predicate) GeoWithinDistance(col string, v any, distance float64) *Predicate {
+	return p.Append(func(b *Builder) {
+		switch b.dialect {
+		case dialect.Postgres:
+			b.WriteString("ST_DWithin(").Ident(col).Comma().Arg(v).Comma().Arg(distance).WriteByte(')')
+		default:
+			geoFunc(b, "ST_Distance", col, v)
+			b.WriteOp(OpLTE).Arg(distance)
+		}
+	})
+}
+
+// geoFunc writes a call to the spatial function with the given column and geometry.
+func geoFunc(b *Builder, fn, col string, v any) {
+	b.WriteString(fn).Wrap(func(b *Builder) {
+		switch b.dialect {
+		case dialect.SQLite:
+			// Geometries are stored as (E)WKT in SQLite, and
+			// should be converted before they are compared.
+			b.WriteString("GeomFromEWKT(").Ident(col).WriteString("), GeomFromEWKT(").Arg(v).WriteByte(')')
+		default:
+			b.Ident(col).Comma().Arg(v)
+		}
+	})
+}
+
+// FieldGeoIntersects returns a raw predicate to check if the field intersects the given geometry.
+func FieldGeoIntersects(name string, v any) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(GeoIntersects(s.C(name), v))
+	}
+}
+
+// FieldGeoContains returns a raw predicate to check if the field contains the given geometry.
+func FieldGeoContains(name string, v any) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(GeoContains(s.C(name), v))
+	}
+}
+
+// FieldGeoWithinDistance returns a raw predicate to check if the field is within the given distance from the given geometry.
+func FieldGeoWithinDistance(name string, v any, distance float64) func(*Selector) {
+	return func(s *Selector) {
+		s.Where(GeoWithinDistance(s.C(name), v, distance))
+	}
+}
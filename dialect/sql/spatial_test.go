@@ -0,0 +1,91 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/stretchr/testify/require"
+)
+
+// wkt is a geometry argument that formats its placeholder.
+type wkt string
+
+func (wkt) FormatParam(placeholder string, info *StmtInfo) string {
+	if info.Dialect == dialect.SQLite {
+		return placeholder
+	}
+	return "ST_GeomFromText(" + placeholder + ", 4326)"
+}
+
+func TestSpatialPredicates(t *testing.T) {
+	p := wkt("POINT(1 2)")
+	tests := []struct {
+		dialect string
+		pred    func(*Selector)
+		query   string
+		args    []any
+	}{
+		{
+			dialect: dialect.MySQL,
+			pred:    FieldGeoIntersects("area", p),
+			query:   "SELECT * FROM `places` WHERE ST_Intersects(`places`.`area`, ST_GeomFromText(?, 4326))",
+			args:    []any{p},
+		},
+		{
+			dialect: dialect.Postgres,
+			pred:    FieldGeoIntersects("area", p),
+			query:   `SELECT * FROM "places" WHERE ST_Intersects("places"."area", ST_GeomFromText($1, 4326))`,
+			args:    []any{p},
+		},
+		{
+			dialect: dialect.SQLite,
+			pred:    FieldGeoIntersects("area", p),
+			query:   "SELECT * FROM `places` WHERE ST_Intersects(GeomFromEWKT(`places`.`area`), GeomFromEWKT(?))",
+			args:    []any{p},
+		},
+		{
+			dialect: dialect.MySQL,
+			pred:    FieldGeoContains("area", p),
+			query:   "SELECT * FROM `places` WHERE ST_Contains(`places`.`area`, ST_GeomFromText(?, 4326))",
+			args:    []any{p},
+		},
+		{
+			dialect: dialect.Postgres,
+			pred:    FieldGeoContains("area", p),
+			query:   `SELECT * FROM "places" WHERE ST_Contains("places"."area", ST_GeomFromText($1, 4326))`,
+			args:    []any{p},
+		},
+		{
+			dialect: dialect.MySQL,
+			pred:    FieldGeoWithinDistance("location", p, 0.5),
+			query:   "SELECT * FROM `places` WHERE ST_Distance(`places`.`location`, ST_GeomFromText(?, 4326)) <= ?",
+			args:    []any{p, 0.5},
+		},
+		{
+			dialect: dialect.Postgres,
+			pred:    FieldGeoWithinDistance("location", p, 0.5),
+			query:   `SELECT * FROM "places" WHERE ST_DWithin("places"."location", ST_GeomFromText($1, 4326), $2)`,
+			args:    []any{p, 0.5},
+		},
+		{
+			dialect: dialect.SQLite,
+			pred:    FieldGeoWithinDistance("location", p, 0.5),
+			query:   "SELECT * FROM `places` WHERE ST_Distance(GeomFromEWKT(`places`.`location`), GeomFromEWKT(?)) <= ?",
+			args:    []any{p, 0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			s := Dialect(tt.dialect).Select().From(Table("places"))
+			tt.pred(s)
+			query, args := s.Query()
+			require.Equal(t, tt.query, query)
+			require.Equal(t, tt.args, args)
+		})
+	}
+}
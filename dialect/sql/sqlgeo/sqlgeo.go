@@ -0,0 +1,153 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlgeo provides the Go types of spatial fields (field.Point and
+// field.Geometry), and their encoding to (and decoding from) the database.
+//
+// Values are sent to the database in their well-known text (WKT) form. In
+// MySQL and PostgreSQL, they are converted to geometries using ST_GeomFromText
+// with their SRID, and in SQLite they are stored as (extended) WKT, that can be
+// read by the SpatiaLite GeomFromEWKT function.
+package sqlgeo
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+type (
+	// Shape is the interface implemented by all geometry shapes.
+	Shape interface {
+		// WKT returns the well-known text representation of the shape.
+		WKT() string
+		shape()
+	}
+
+	// Point is a single location in coordinate space. It is also used as the
+	// Go type of field.Point fields, in which case, its SRID is stored as well.
+	// The SRID of points that are nested in other shapes is ignored.
+	Point struct {
+		X, Y float64
+		SRID int
+	}
+
+	// LineString is a curve with linear interpolation between points.
+	LineString []Point
+
+	// Polygon is a planar surface defined by an exterior ring and zero or more
+	// interior rings (holes). Each ring is a closed LineString.
+	Polygon []LineString
+
+	// MultiPoint is a collection of points.
+	MultiPoint []Point
+
+	// MultiLineString is a collection of line strings.
+	MultiLineString []LineString
+
+	// MultiPolygon is a collection of polygons.
+	MultiPolygon []Polygon
+
+	// Collection is a collection of shapes of any type.
+	Collection []Shape
+
+	// Geometry holds a shape of any type and its spatial reference
+	// system identifier (SRID). It is used as the Go type of field.Geometry
+	// fields, and as the argument of spatial predicates.
+	//
+	//	sqlgeo.Geometry{
+	//		Shape: sqlgeo.Polygon{{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}}},
+	//		SRID:  4326,
+	//	}
+	Geometry struct {
+		Shape Shape
+		SRID  int
+	}
+)
+
+func (Point) shape()           {}
+func (LineString) shape()      {}
+func (Polygon) shape()         {}
+func (MultiPoint) shape()      {}
+func (MultiLineString) shape() {}
+func (MultiPolygon) shape()    {}
+func (Collection) shape()      {}
+
+// Value implements the driver.Valuer interface.
+func (p Point) Value() (driver.Value, error) {
+	return p.WKT(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *Point) Scan(src any) error {
+	if src == nil {
+		*p = Point{}
+		return nil
+	}
+	s, srid, err := decode(src)
+	if err != nil {
+		return err
+	}
+	v, ok := s.(Point)
+	if !ok {
+		return fmt.Errorf("sqlgeo: unexpected shape %T for point", s)
+	}
+	*p = Point{X: v.X, Y: v.Y, SRID: srid}
+	return nil
+}
+
+// FormatParam implements the sql.ParamFormatter interface.
+func (p Point) FormatParam(placeholder string, info *sql.StmtInfo) string {
+	return formatParam(placeholder, info, p.SRID)
+}
+
+// Value implements the driver.Valuer interface.
+func (g Geometry) Value() (driver.Value, error) {
+	if g.Shape == nil {
+		return nil, nil
+	}
+	return g.Shape.WKT(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (g *Geometry) Scan(src any) error {
+	if src == nil {
+		*g = Geometry{}
+		return nil
+	}
+	s, srid, err := decode(src)
+	if err != nil {
+		return err
+	}
+	*g = Geometry{Shape: s, SRID: srid}
+	return nil
+}
+
+// FormatParam implements the sql.ParamFormatter interface.
+func (g Geometry) FormatParam(placeholder string, info *sql.StmtInfo) string {
+	return formatParam(placeholder, info, g.SRID)
+}
+
+// formatParam wraps the placeholder of a WKT value with the
+// function that converts it to a geometry with the given SRID.
+func formatParam(placeholder string, info *sql.StmtInfo, srid int) string {
+	switch info.Dialect {
+	case dialect.MySQL, dialect.Postgres:
+		if srid == 0 {
+			return "ST_GeomFromText(" + placeholder + ")"
+		}
+		return "ST_GeomFromText(" + placeholder + ", " + strconv.Itoa(srid) + ")"
+	case dialect.SQLite:
+		if srid == 0 {
+			return placeholder
+		}
+		// Store the value in its EWKT form.
+		return "'SRID=" + strconv.Itoa(srid) + ";' || " + placeholder
+	default:
+		return placeholder
+	}
+}
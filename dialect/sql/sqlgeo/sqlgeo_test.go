@@ -0,0 +1,156 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgeo_test
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgeo"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWKT(t *testing.T) {
+	square := sqlgeo.LineString{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}}
+	hole := sqlgeo.LineString{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 1, Y: 1}}
+	tests := []struct {
+		shape sqlgeo.Shape
+		wkt   string
+	}{
+		{
+			shape: sqlgeo.Point{X: 1.5, Y: -2},
+			wkt:   "POINT(1.5 -2)",
+		},
+		{
+			shape: sqlgeo.LineString{{X: 1, Y: 2}, {X: 3, Y: 4}},
+			wkt:   "LINESTRING(1 2,3 4)",
+		},
+		{
+			shape: sqlgeo.LineString{},
+			wkt:   "LINESTRING EMPTY",
+		},
+		{
+			shape: sqlgeo.Polygon{square, hole},
+			wkt:   "POLYGON((0 0,0 10,10 10,10 0,0 0),(1 1,1 2,2 2,1 1))",
+		},
+		{
+			shape: sqlgeo.MultiPoint{{X: 1, Y: 2}, {X: 3, Y: 4}},
+			wkt:   "MULTIPOINT(1 2,3 4)",
+		},
+		{
+			shape: sqlgeo.MultiLineString{{{X: 1, Y: 2}, {X: 3, Y: 4}}, {{X: 5, Y: 6}, {X: 7, Y: 8}}},
+			wkt:   "MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+		},
+		{
+			shape: sqlgeo.MultiPolygon{{square}, {hole}},
+			wkt:   "MULTIPOLYGON(((0 0,0 10,10 10,10 0,0 0)),((1 1,1 2,2 2,1 1)))",
+		},
+		{
+			shape: sqlgeo.Collection{sqlgeo.Point{X: 1, Y: 2}, sqlgeo.LineString{{X: 1, Y: 2}, {X: 3, Y: 4}}, sqlgeo.MultiPoint{}},
+			wkt:   "GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4),MULTIPOINT EMPTY)",
+		},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			require.Equal(t, tt.wkt, tt.shape.WKT())
+			var g sqlgeo.Geometry
+			require.NoError(t, g.Scan(tt.wkt))
+			require.Equal(t, tt.shape, g.Shape)
+			require.Zero(t, g.SRID)
+		})
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	var g sqlgeo.Geometry
+	require.NoError(t, g.Scan("SRID=4326; multipoint ((1 2), (3.5 4e1))"))
+	require.Equal(t, sqlgeo.Geometry{Shape: sqlgeo.MultiPoint{{X: 1, Y: 2}, {X: 3.5, Y: 40}}, SRID: 4326}, g)
+	require.Equal(t, "SRID=4326;MULTIPOINT(1 2,3.5 40)", g.EWKT())
+
+	for _, s := range []string{
+		"POINT(1)",
+		"POINT(1 2 3)",
+		"POINT Z (1 2 3)",
+		"POINT EMPTY",
+		"POINT(1 2",
+		"POINT(1 2) POINT(3 4)",
+		"CIRCLE(1 2)",
+		"SRID=x;POINT(1 2)",
+	} {
+		require.Error(t, g.Scan(s), s)
+	}
+
+	var p sqlgeo.Point
+	require.NoError(t, p.Scan("SRID=3857;POINT(1 2)"))
+	require.Equal(t, sqlgeo.Point{X: 1, Y: 2, SRID: 3857}, p)
+	require.Error(t, p.Scan("LINESTRING(1 2,3 4)"))
+	require.NoError(t, p.Scan(nil))
+	require.Zero(t, p)
+}
+
+func TestDecodeWKB(t *testing.T) {
+	// SELECT 'SRID=4326;POINT(1 2)'::geometry;
+	pg := "0101000020E6100000000000000000F03F0000000000000040"
+	var p sqlgeo.Point
+	require.NoError(t, p.Scan(pg))
+	require.Equal(t, sqlgeo.Point{X: 1, Y: 2, SRID: 4326}, p)
+	require.NoError(t, p.Scan([]byte(pg)))
+	require.Equal(t, sqlgeo.Point{X: 1, Y: 2, SRID: 4326}, p)
+
+	// SELECT ST_GeomFromText('LINESTRING(1 2,3 4)', 4326);
+	my, err := hex.DecodeString("E6100000010200000002000000000000000000F03F000000000000004000000000000008400000000000001040")
+	require.NoError(t, err)
+	var g sqlgeo.Geometry
+	require.NoError(t, g.Scan(my))
+	require.Equal(t, sqlgeo.Geometry{Shape: sqlgeo.LineString{{X: 1, Y: 2}, {X: 3, Y: 4}}, SRID: 4326}, g)
+
+	// Big-endian multi-point without SRID.
+	be, err := hex.DecodeString("00000000" + "000000000400000001" + "00000000013FF00000000000004000000000000000")
+	require.NoError(t, err)
+	require.NoError(t, g.Scan(be))
+	require.Equal(t, sqlgeo.Geometry{Shape: sqlgeo.MultiPoint{{X: 1, Y: 2}}}, g)
+
+	for _, s := range []string{
+		"0101000020E6100000000000000000F03F",                         // Truncated.
+		"01010000C0000000000000F03F0000000000000040",                 // Z and M flags.
+		"01E9030000000000000000F03F00000000000000400000000000000840", // ISO PointZ.
+		"0109000000000000000000F03F0000000000000040",                 // Unknown type.
+		"0101000000000000000000F03F000000000000004000",               // Trailing bytes.
+		"0104000000FFFFFFFF",                                         // Invalid number of elements.
+	} {
+		require.Error(t, g.Scan(s), s)
+	}
+	require.Error(t, g.Scan(1))
+}
+
+func TestFormatParam(t *testing.T) {
+	p := sqlgeo.Point{X: 1, Y: 2, SRID: 4326}
+	v, err := p.Value()
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", v)
+
+	for d, expected := range map[string]string{
+		dialect.MySQL:    "INSERT INTO `places` (`location`) VALUES (ST_GeomFromText(?, 4326))",
+		dialect.Postgres: `INSERT INTO "places" ("location") VALUES (ST_GeomFromText($1, 4326))`,
+		dialect.SQLite:   "INSERT INTO `places` (`location`) VALUES ('SRID=4326;' || ?)",
+	} {
+		query, args := sql.Dialect(d).Insert("places").Columns("location").Values(p).Query()
+		require.Equal(t, expected, query)
+		require.Equal(t, []any{p}, args)
+	}
+
+	g := sqlgeo.Geometry{Shape: sqlgeo.Point{X: 1, Y: 2}}
+	query, _ := sql.Dialect(dialect.Postgres).Insert("places").Columns("location").Values(g).Query()
+	require.Equal(t, `INSERT INTO "places" ("location") VALUES (ST_GeomFromText($1))`, query)
+	query, _ = sql.Dialect(dialect.SQLite).Insert("places").Columns("location").Values(g).Query()
+	require.Equal(t, "INSERT INTO `places` (`location`) VALUES (?)", query)
+	v, err = sqlgeo.Geometry{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
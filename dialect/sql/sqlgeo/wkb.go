@@ -0,0 +1,277 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgeo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// decode decodes a geometry value that was returned by the database
+// driver, and returns its shape and SRID. The supported formats are:
+//
+//   - (E)WKT, as stored in SQLite.
+//   - Hex-encoded EWKB, as returned by PostgreSQL drivers.
+//   - The MySQL internal format, which is a 4-byte SRID followed by WKB.
+func decode(src any) (Shape, int, error) {
+	var b []byte
+	switch v := src.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return nil, 0, fmt.Errorf("sqlgeo: unexpected type %T for geometry", src)
+	}
+	switch {
+	case len(b) == 0:
+		return nil, 0, errors.New("sqlgeo: empty geometry value")
+	case isWKT(b):
+		return parseWKT(string(b))
+	case isHex(b):
+		d := make([]byte, hex.DecodedLen(len(b)))
+		if _, err := hex.Decode(d, b); err != nil {
+			return nil, 0, fmt.Errorf("sqlgeo: decoding hex EWKB: %w", err)
+		}
+		return decodeWKB(d)
+	case len(b) < 4:
+		return nil, 0, fmt.Errorf("sqlgeo: unexpected geometry value %q", b)
+	default:
+		s, _, err := decodeWKB(b[4:])
+		if err != nil {
+			return nil, 0, err
+		}
+		return s, int(binary.LittleEndian.Uint32(b[:4])), nil
+	}
+}
+
+// isWKT reports if the value looks like an (E)WKT text.
+func isWKT(b []byte) bool {
+	if c := b[0] | 0x20; c < 'a' || c > 'z' {
+		return false
+	}
+	for _, c := range b {
+		if c < ' ' && c != '\t' && c != '\n' && c != '\r' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// isHex reports if the value is hex-encoded.
+func isHex(b []byte) bool {
+	if len(b)%2 != 0 {
+		return false
+	}
+	for _, c := range b {
+		if ('0' > c || c > '9') && ('a' > c|0x20 || c|0x20 > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// WKB geometry types.
+const (
+	wkbPoint = iota + 1
+	wkbLineString
+	wkbPolygon
+	wkbMultiPoint
+	wkbMultiLineString
+	wkbMultiPolygon
+	wkbCollection
+)
+
+// EWKB flags of the geometry type.
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// decodeWKB decodes a shape from its (extended) well-known binary representation.
+func decodeWKB(b []byte) (Shape, int, error) {
+	r := &wkbReader{b: b}
+	s, err := r.shape()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(r.b) > 0 {
+		return nil, 0, fmt.Errorf("sqlgeo: unexpected %d trailing bytes in WKB", len(r.b))
+	}
+	return s, r.srid, nil
+}
+
+// wkbReader reads shapes from their (extended) well-known binary representation.
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+	srid  int
+}
+
+// shape reads a shape with its header.
+func (r *wkbReader) shape() (Shape, error) {
+	o, err := r.read(1)
+	if err != nil {
+		return nil, err
+	}
+	switch o[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("sqlgeo: invalid WKB byte order %d", o[0])
+	}
+	t, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if t&(ewkbZ|ewkbM) != 0 || t > wkbCollection && t < ewkbSRID {
+		return nil, errors.New("sqlgeo: only 2D geometries are supported")
+	}
+	if t&ewkbSRID != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		r.srid, t = int(srid), t&^ewkbSRID
+	}
+	switch t {
+	case wkbPoint:
+		return r.point()
+	case wkbLineString:
+		return r.lineString()
+	case wkbPolygon:
+		return r.polygon()
+	case wkbMultiPoint:
+		var m MultiPoint
+		err := r.each(func() error {
+			p, err := r.shape()
+			if err != nil {
+				return err
+			}
+			pt, ok := p.(Point)
+			if !ok {
+				return fmt.Errorf("sqlgeo: unexpected shape %T in multi-point", p)
+			}
+			m = append(m, pt)
+			return nil
+		})
+		return m, err
+	case wkbMultiLineString:
+		var m MultiLineString
+		err := r.each(func() error {
+			s, err := r.shape()
+			if err != nil {
+				return err
+			}
+			l, ok := s.(LineString)
+			if !ok {
+				return fmt.Errorf("sqlgeo: unexpected shape %T in multi-line string", s)
+			}
+			m = append(m, l)
+			return nil
+		})
+		return m, err
+	case wkbMultiPolygon:
+		var m MultiPolygon
+		err := r.each(func() error {
+			s, err := r.shape()
+			if err != nil {
+				return err
+			}
+			p, ok := s.(Polygon)
+			if !ok {
+				return fmt.Errorf("sqlgeo: unexpected shape %T in multi-polygon", s)
+			}
+			m = append(m, p)
+			return nil
+		})
+		return m, err
+	case wkbCollection:
+		var c Collection
+		err := r.each(func() error {
+			s, err := r.shape()
+			c = append(c, s)
+			return err
+		})
+		return c, err
+	default:
+		return nil, fmt.Errorf("sqlgeo: unknown WKB geometry type %d", t)
+	}
+}
+
+// point reads the coordinates of a point.
+func (r *wkbReader) point() (Point, error) {
+	b, err := r.read(16)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{
+		X: math.Float64frombits(r.order.Uint64(b[:8])),
+		Y: math.Float64frombits(r.order.Uint64(b[8:])),
+	}, nil
+}
+
+// lineString reads the points of a line string.
+func (r *wkbReader) lineString() (LineString, error) {
+	l := LineString{}
+	err := r.each(func() error {
+		p, err := r.point()
+		l = append(l, p)
+		return err
+	})
+	return l, err
+}
+
+// polygon reads the rings of a polygon.
+func (r *wkbReader) polygon() (Polygon, error) {
+	p := Polygon{}
+	err := r.each(func() error {
+		l, err := r.lineString()
+		p = append(p, l)
+		return err
+	})
+	return p, err
+}
+
+// each reads the number of elements, and calls f for each one of them.
+func (r *wkbReader) each(f func() error) error {
+	n, err := r.uint32()
+	if err != nil {
+		return err
+	}
+	// Each element holds at least 4 bytes.
+	if int(n) > len(r.b)/4 {
+		return fmt.Errorf("sqlgeo: invalid WKB number of elements %d", n)
+	}
+	for i := 0; i < int(n); i++ {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	b, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return r.order.Uint32(b), nil
+}
+
+func (r *wkbReader) read(n int) ([]byte, error) {
+	if len(r.b) < n {
+		return nil, errors.New("sqlgeo: unexpected end of WKB")
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b, nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgeo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WKT returns the well-known text representation of the point.
+func (p Point) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("POINT(")
+	writeCoord(b, p)
+	b.WriteByte(')')
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the line string.
+func (l LineString) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("LINESTRING")
+	writeList(b, len(l), func(i int) { writeCoord(b, l[i]) })
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the polygon.
+func (p Polygon) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("POLYGON")
+	writePolygon(b, p)
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the multi-point.
+func (m MultiPoint) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("MULTIPOINT")
+	writeList(b, len(m), func(i int) { writeCoord(b, m[i]) })
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the multi-line string.
+func (m MultiLineString) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("MULTILINESTRING")
+	writePolygon(b, m)
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the multi-polygon.
+func (m MultiPolygon) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("MULTIPOLYGON")
+	writeList(b, len(m), func(i int) {
+		writePolygon(b, m[i])
+	})
+	return b.String()
+}
+
+// WKT returns the well-known text representation of the collection.
+func (c Collection) WKT() string {
+	b := &strings.Builder{}
+	b.WriteString("GEOMETRYCOLLECTION")
+	writeList(b, len(c), func(i int) { b.WriteString(c[i].WKT()) })
+	return b.String()
+}
+
+// EWKT returns the extended well-known text representation of the
+// geometry, that is prefixed with its SRID. e.g. "SRID=4326;POINT(1 2)".
+func (g Geometry) EWKT() string {
+	if g.Shape == nil {
+		return ""
+	}
+	if g.SRID == 0 {
+		return g.Shape.WKT()
+	}
+	return "SRID=" + strconv.Itoa(g.SRID) + ";" + g.Shape.WKT()
+}
+
+// writeCoord writes the coordinates of the point.
+func writeCoord(b *strings.Builder, p Point) {
+	b.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+}
+
+// writePolygon writes a list of line strings (rings).
+func writePolygon[T ~[]LineString](b *strings.Builder, p T) {
+	writeList(b, len(p), func(i int) {
+		writeList(b, len(p[i]), func(j int) { writeCoord(b, p[i][j]) })
+	})
+}
+
+// writeList writes a parenthesized list of n elements,
+// or the EMPTY keyword if the list is empty.
+func writeList(b *strings.Builder, n int, f func(int)) {
+	if n == 0 {
+		b.WriteString(" EMPTY")
+		return
+	}
+	b.WriteByte('(')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		f(i)
+	}
+	b.WriteByte(')')
+}
+
+// parseWKT parses the (extended) well-known text representation
+// of a shape, and returns it with its SRID, if it was defined.
+func parseWKT(s string) (Shape, int, error) {
+	var (
+		srid int
+		err  error
+	)
+	if strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		i := strings.IndexByte(s, ';')
+		if i == -1 {
+			return nil, 0, fmt.Errorf("sqlgeo: missing ';' after SRID in %q", s)
+		}
+		if srid, err = strconv.Atoi(strings.TrimSpace(s[5:i])); err != nil {
+			return nil, 0, fmt.Errorf("sqlgeo: invalid SRID in %q: %w", s, err)
+		}
+		s = s[i+1:]
+	}
+	p := &wktParser{s: s}
+	shape, err := p.shape()
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.skip(); p.pos < len(p.s) {
+		return nil, 0, p.errorf("unexpected input %q", p.s[p.pos:])
+	}
+	return shape, srid, nil
+}
+
+// wktParser is a recursive descent parser for WKT.
+type wktParser struct {
+	s   string
+	pos int
+}
+
+// shape parses a tagged shape. e.g. POINT(1 2).
+func (p *wktParser) shape() (Shape, error) {
+	tag := strings.ToUpper(p.word())
+	switch dim := strings.ToUpper(p.word()); dim {
+	case "":
+	case "EMPTY":
+		return p.empty(tag)
+	default:
+		return nil, p.errorf("%s %s geometries are not supported", tag, dim)
+	}
+	switch tag {
+	case "POINT":
+		var pt Point
+		err := p.list(func() (err error) {
+			pt, err = p.coord()
+			return err
+		})
+		return pt, err
+	case "LINESTRING":
+		return p.lineString()
+	case "POLYGON":
+		return p.polygon()
+	case "MULTIPOINT":
+		var m MultiPoint
+		err := p.list(func() error {
+			var (
+				pt  Point
+				err error
+			)
+			// Points may be wrapped with parentheses, or not.
+			if p.peek() == '(' {
+				err = p.list(func() (err error) {
+					pt, err = p.coord()
+					return err
+				})
+			} else {
+				pt, err = p.coord()
+			}
+			m = append(m, pt)
+			return err
+		})
+		return m, err
+	case "MULTILINESTRING":
+		var m MultiLineString
+		err := p.list(func() error {
+			l, err := p.lineString()
+			m = append(m, l)
+			return err
+		})
+		return m, err
+	case "MULTIPOLYGON":
+		var m MultiPolygon
+		err := p.list(func() error {
+			pg, err := p.polygon()
+			m = append(m, pg)
+			return err
+		})
+		return m, err
+	case "GEOMETRYCOLLECTION":
+		var c Collection
+		err := p.list(func() error {
+			s, err := p.shape()
+			c = append(c, s)
+			return err
+		})
+		return c, err
+	default:
+		return nil, p.errorf("unknown geometry type %q", tag)
+	}
+}
+
+// empty returns the empty shape of the given type.
+func (p *wktParser) empty(tag string) (Shape, error) {
+	switch tag {
+	case "LINESTRING":
+		return LineString{}, nil
+	case "POLYGON":
+		return Polygon{}, nil
+	case "MULTIPOINT":
+		return MultiPoint{}, nil
+	case "MULTILINESTRING":
+		return MultiLineString{}, nil
+	case "MULTIPOLYGON":
+		return MultiPolygon{}, nil
+	case "GEOMETRYCOLLECTION":
+		return Collection{}, nil
+	default:
+		return nil, p.errorf("empty %s is not supported", tag)
+	}
+}
+
+// lineString parses the body of a line string.
+func (p *wktParser) lineString() (LineString, error) {
+	var l LineString
+	err := p.list(func() error {
+		pt, err := p.coord()
+		l = append(l, pt)
+		return err
+	})
+	return l, err
+}
+
+// polygon parses the body of a polygon.
+func (p *wktParser) polygon() (Polygon, error) {
+	var pg Polygon
+	err := p.list(func() error {
+		l, err := p.lineString()
+		pg = append(pg, l)
+		return err
+	})
+	return pg, err
+}
+
+// list parses a parenthesized, comma-separated list of elements.
+func (p *wktParser) list(elem func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := elem(); err != nil {
+			return err
+		}
+		p.skip()
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	return p.expect(')')
+}
+
+// coord parses a pair of coordinates.
+func (p *wktParser) coord() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	if p.skip(); p.peek() != ',' && p.peek() != ')' {
+		return Point{}, p.errorf("only 2D coordinates are supported")
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+// number parses a floating-point number.
+func (p *wktParser) number() (float64, error) {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) != -1 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, p.errorf("invalid number %q", p.s[start:p.pos])
+	}
+	return f, nil
+}
+
+// word parses a keyword.
+func (p *wktParser) word() string {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.s) && ('a' <= p.s[p.pos]|0x20 && p.s[p.pos]|0x20 <= 'z') {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// expect consumes the given byte.
+func (p *wktParser) expect(c byte) error {
+	if p.skip(); p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+// peek returns the next non-space byte, without consuming it.
+func (p *wktParser) peek() byte {
+	if p.skip(); p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// skip skips whitespaces.
+func (p *wktParser) skip() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) != -1 {
+		p.pos++
+	}
+}
+
+func (p *wktParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("sqlgeo: parsing WKT at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
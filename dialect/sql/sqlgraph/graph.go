@@ -215,7 +215,155 @@ func SetNeighbors(dialect string, s *Step) (q *sql.Selector) {
 			Join(set).
 			On(t1.C(s.Edge.Columns[0]), set.C(s.From.Column))
 	}
-	return q
+	return q.WithContext(set.Context())
+}
+
+// SetNeighborsRecursive returns a Selector for evaluating the path-step recursively and
+// getting the vertices that are reachable from the set of vertices in up to depth steps,
+// or in any number of steps if depth is not positive. The step must describe an edge
+// between vertices of the same type (e.g. parent/children). Cycles in the graph do not
+// cause an infinite recursion, because visited vertices are not expanded again (or
+// because the recursion is limited by depth).
+//
+//	SELECT * FROM "nodes" WHERE "nodes"."id" IN (
+//		WITH RECURSIVE "nodes_neighbors"("id", "depth") AS (
+//			SELECT "nodes"."id", 1 FROM "nodes" WHERE "nodes"."parent_id" IN (<set>)
+//			UNION
+//			SELECT "nodes"."id", "t1"."depth" + 1 FROM "nodes"
+//			JOIN "nodes_neighbors" AS "t1" ON "nodes"."parent_id" = "t1"."id" WHERE "t1"."depth" < <depth>
+//		)
+//		SELECT "nodes_neighbors"."id" FROM "nodes_neighbors"
+//	)
+func SetNeighborsRecursive(dialect string, s *Step, depth int) *sql.Selector {
+	var (
+		src, dst string
+		nullable bool // dst is a nullable foreign-key.
+		table    func() *sql.SelectTable
+		set      = s.From.V.(*sql.Selector)
+		builder  = sql.Dialect(dialect)
+	)
+	switch r := s.Edge.Rel; {
+	case r == M2M:
+		src, dst = s.Edge.Columns[0], s.Edge.Columns[1]
+		if s.Edge.Inverse {
+			src, dst = dst, src
+		}
+		table = func() *sql.SelectTable { return builder.Table(s.Edge.Table).Schema(s.Edge.Schema) }
+	case r == M2O || (r == O2O && s.Edge.Inverse):
+		src, dst, nullable = s.From.Column, s.Edge.Columns[0], true
+		table = func() *sql.SelectTable { return builder.Table(s.To.Table).Schema(s.To.Schema) }
+	case r == O2M || (r == O2O && !s.Edge.Inverse):
+		src, dst = s.Edge.Columns[0], s.To.Column
+		table = func() *sql.SelectTable { return builder.Table(s.To.Table).Schema(s.To.Schema) }
+	}
+	// The name of the CTE is derived from the table of the vertices, and is suffixed with
+	// the number of recursive CTEs that are nested in the set (e.g. of chained recursive
+	// steps), in order to keep it unique in the query.
+	name := s.To.Table + "_neighbors"
+	nested, _ := set.Context().Value(recursiveKey{}).(int)
+	if nested > 0 {
+		name = fmt.Sprintf("%s_%d", name, nested+1)
+	}
+	set.Select(set.C(s.From.Column))
+	t1, t2, cte := table(), table(), builder.Table(name)
+	anchor := builder.Select(t1.C(dst)).
+		From(t1).
+		Where(sql.In(t1.C(src), set))
+	next := builder.Select(t2.C(dst)).
+		From(t2).
+		Join(cte).
+		On(t2.C(src), cte.C("id"))
+	if nullable {
+		anchor.Where(sql.NotNull(t1.C(dst)))
+		next.Where(sql.NotNull(t2.C(dst)))
+	}
+	columns := []string{"id"}
+	// The depth column is added only if the recursion is limited, as
+	// it is used for stopping the recursion. Otherwise, the recursion
+	// stops on cycles because the UNION removes visited vertices.
+	if depth > 0 {
+		columns = append(columns, "depth")
+		anchor.AppendSelectExpr(sql.Expr("1"))
+		next.AppendSelectExpr(sql.Expr(cte.C("depth") + " + 1")).
+			Where(sql.LT(cte.C("depth"), depth))
+	}
+	with := builder.WithRecursive(name, columns...).As(anchor.Union(next))
+	view := builder.Table(name)
+	to := builder.Table(s.To.Table).Schema(s.To.Schema)
+	return builder.Select().
+		From(to).
+		Where(sql.In(to.C(s.To.Column), builder.Select(view.C("id")).From(view).Prefix(with))).
+		WithContext(context.WithValue(set.Context(), recursiveKey{}, nested+1))
+}
+
+// recursiveKey is the context key of the number of recursive CTEs that are nested in
+// a selector returned by SetNeighborsRecursive. SetNeighbors keeps it in its result.
+type recursiveKey struct{}
+
+// LimitNeighbors returns a selector modifier that applies the given limit and offset to each
+// partition of rows that share the same value in the given selected column (e.g. the neighbors
+// of each node), instead of applying them to the query as a whole. The order of the selector is
+// kept in each partition. For example, getting the 3 latest comments of each post:
+//
+//	s.Where(sql.InValues(s.C("post_id"), ids...)).OrderBy(sql.Desc(s.C("created_at")))
+//	sqlgraph.LimitNeighbors("post_id", 3, 0)(s)
+//
+// In PostgreSQL, the selector is joined laterally to its partitions, and in other dialects, the
+// rows are numbered in each partition using the ROW_NUMBER window function. The latter is used
+// for MySQL as well, because it is supported by MariaDB, unlike LATERAL joins. Hence, it requires
+// MySQL 8 (or MariaDB 10.2) and SQLite 3.25 or above. Note that the rows of each partition are
+// limited in an unspecified order if the selector is not ordered.
+func LimitNeighbors(partitionBy string, limit, offset int) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		var (
+			b         = sql.Dialect(s.Dialect())
+			partition = s.C(partitionBy)
+			columns   = s.UnqualifiedColumns()
+		)
+		// The partition column may be qualified by a joined
+		// table, like the join-table of M2M edges.
+		for i, c := range s.SelectedColumns() {
+			if columns[i] == partitionBy {
+				partition = c
+				break
+			}
+		}
+		switch s.Dialect() {
+		case dialect.Postgres:
+			// SELECT "t"."c1", ... FROM (SELECT DISTINCT <partition> AS "partition" FROM ... WHERE ...) AS "partitions"
+			// JOIN LATERAL (SELECT ... WHERE ... AND <partition> = "partitions"."partition" ORDER BY ... LIMIT ...) AS "t" ON TRUE
+			partitions := s.Clone().
+				Select(sql.As(partition, "partition")).
+				ClearOrder().
+				Distinct().
+				As("partitions")
+			t := s.Clone().
+				Where(sql.ColumnsEQ(partition, partitions.C("partition"))).
+				Limit(limit).
+				As(s.TableName())
+			if offset > 0 {
+				t.Offset(offset)
+			}
+			*s = *b.Select(t.Columns(columns...)...).
+				From(partitions).
+				JoinLateral(t)
+		default:
+			// SELECT "t"."c1", ... FROM (SELECT ..., (ROW_NUMBER() OVER (PARTITION BY <partition> ORDER BY ...)) AS "row_number"
+			// FROM ... WHERE ...) AS "t" WHERE "t"."row_number" > <offset> AND "t"."row_number" <= <offset+limit>
+			rn := sql.RowNumber().PartitionBy(partition).OrderBy(s.OrderColumns()...)
+			t := s.Clone().
+				AppendSelectExprAs(rn, "row_number").
+				ClearOrder().
+				As(s.TableName())
+			*s = *b.Select(t.Columns(columns...)...).
+				From(t).
+				Where(sql.And(
+					sql.GT(t.C("row_number"), offset),
+					sql.LTE(t.C("row_number"), offset+limit),
+				)).
+				OrderBy(t.C("row_number"))
+		}
+	}
 }
 
 // HasNeighbors applies on the given Selector a neighbors check.
@@ -309,13 +457,25 @@ type (
 	// EdgeSpec holds the information for updating a field
 	// column in the database.
 	EdgeSpec struct {
-		Rel     Rel
-		Inverse bool
-		Table   string
-		Schema  string
-		Columns []string
-		Bidi    bool        // bidirectional edge.
-		Target  *EdgeTarget // target nodes.
+		Rel      Rel
+		Inverse  bool
+		Table    string
+		Schema   string
+		Columns  []string
+		Bidi     bool           // bidirectional edge.
+		Target   *EdgeTarget    // target nodes.
+		Counters []*EdgeCounter // counters of the edge.
+	}
+
+	// EdgeCounter holds the information for maintaining a counter column
+	// that holds the number of edges of the nodes in its table. Counters
+	// are updated in the same transaction the edges are added or removed.
+	EdgeCounter struct {
+		Table  string // table of the nodes that hold the counter.
+		Schema string
+		ID     string // id column of the table.
+		Column string // counter column.
+		Ref    string // column in the edge table that references the counting nodes.
 	}
 
 	// EdgeSpecs used for perform common operations on list of edges.
@@ -506,21 +666,131 @@ func (e *NotFoundError) Error() string {
 type DeleteSpec struct {
 	Node      *NodeSpec
 	Predicate func(*sql.Selector)
+	// Edges holds the edges that are counted by other nodes
+	// (see EdgeSpec.Counters). Their counters are decremented
+	// before the nodes are deleted.
+	Edges []*EdgeSpec
 }
 
 // DeleteNodes applies the DeleteSpec on the graph.
 func DeleteNodes(ctx context.Context, drv dialect.Driver, spec *DeleteSpec) (int, error) {
+	if len(spec.Edges) > 0 {
+		tx, err := drv.Tx(ctx)
+		if err != nil {
+			return 0, err
+		}
+		gr := graph{tx: tx, builder: sql.Dialect(drv.Dialect())}
+		affected, err := gr.deleteNodes(ctx, spec)
+		if err != nil {
+			return 0, rollback(tx, err)
+		}
+		return affected, tx.Commit()
+	}
+	gr := graph{tx: drv, builder: sql.Dialect(drv.Dialect())}
+	return gr.deleteNodes(ctx, spec)
+}
+
+func (g *graph) deleteNodes(ctx context.Context, spec *DeleteSpec) (int, error) {
 	var (
-		res     sql.Result
-		builder = sql.Dialect(drv.Dialect())
+		res      sql.Result
+		selector = g.builder.Select().
+				From(g.builder.Table(spec.Node.Table).Schema(spec.Node.Schema)).
+				WithContext(ctx)
 	)
-	selector := builder.Select().
-		From(builder.Table(spec.Node.Table).Schema(spec.Node.Schema)).
-		WithContext(ctx)
 	if pred := spec.Predicate; pred != nil {
 		pred(selector)
 	}
-	query, args := builder.Delete(spec.Node.Table).Schema(spec.Node.Schema).FromSelect(selector).Query()
+	for _, e := range spec.Edges {
+		for _, c := range e.Counters {
+			where := func(s *sql.Selector) {
+				if pred := spec.Predicate; pred != nil {
+					pred(s)
+				}
+			}
+			// The counted edges of the deleted nodes reside in a join table, and
+			// they are referenced by the column that does not hold the counted node.
+			if e.Rel == M2M {
+				column := e.Columns[0]
+				if column == c.Ref {
+					column = e.Columns[1]
+				}
+				where = func(s *sql.Selector) {
+					t := g.builder.Table(spec.Node.Table).Schema(spec.Node.Schema)
+					nodes := g.builder.Select(t.C(spec.Node.ID.Column)).From(t)
+					if pred := spec.Predicate; pred != nil {
+						pred(nodes)
+					}
+					s.Where(sql.In(column, nodes))
+				}
+			}
+			if err := g.updateCounter(ctx, e, c, -1, where); err != nil {
+				return 0, err
+			}
+		}
+	}
+	query, args := g.builder.Delete(spec.Node.Table).Schema(spec.Node.Schema).FromSelect(selector).Query()
+	if err := g.tx.Exec(ctx, query, args, &res); err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// RecountSpec holds the information for recomputing
+// the edge counters of one or more nodes in the graph.
+type RecountSpec struct {
+	Node      *NodeSpec
+	Edges     []*EdgeSpec // edges that hold the counters.
+	Predicate func(*sql.Selector)
+}
+
+// Recount recomputes the edge counters of the nodes that match the spec predicate
+// and returns the number of updated nodes. It is used for repairing counters that
+// were drifted. For example, by changes that were made outside the graph.
+func Recount(ctx context.Context, drv dialect.Driver, spec *RecountSpec) (int, error) {
+	var (
+		res      sql.Result
+		builder  = sql.Dialect(drv.Dialect())
+		update   = builder.Update(spec.Node.Table).Schema(spec.Node.Schema)
+		selector = builder.Select().
+				From(builder.Table(spec.Node.Table).Schema(spec.Node.Schema)).
+				WithContext(ctx)
+	)
+	for _, e := range spec.Edges {
+		for _, c := range e.Counters {
+			// Edges are counted in a derived table, because MySQL does not allow
+			// referencing the updated table in a subquery (self-referencing edges),
+			// unless the subquery is materialized.
+			t := builder.Table(e.Table).Schema(e.Schema)
+			counts := builder.Select(t.C(c.Ref), sql.As(sql.Count("*"), "n")).
+				From(t).
+				Where(sql.NotNull(t.C(c.Ref))).
+				GroupBy(t.C(c.Ref)).
+				As("t1")
+			count := builder.Select(counts.C("n")).
+				From(counts).
+				Where(sql.ColumnsEQ(counts.C(c.Ref), sql.Table(spec.Node.Table).C(c.ID)))
+			update.Set(c.Column, sql.ExprFunc(func(b *sql.Builder) {
+				b.WriteString("COALESCE")
+				b.Wrap(func(b *sql.Builder) {
+					b.Wrap(func(b *sql.Builder) {
+						b.Join(count)
+					})
+					b.Comma().WriteString("0")
+				})
+			}))
+		}
+	}
+	if update.Empty() {
+		return 0, nil
+	}
+	if pred := spec.Predicate; pred != nil {
+		pred(selector)
+	}
+	query, args := update.FromSelect(selector).Query()
 	if err := drv.Exec(ctx, query, args, &res); err != nil {
 		return 0, err
 	}
@@ -548,6 +818,42 @@ type QuerySpec struct {
 	Assign     func(columns []string, values []any) error
 }
 
+// PartitionBy configures the query to apply its limit and offset to each partition of rows that
+// share the same value in the given column (e.g. the neighbors of each node in edge loading),
+// instead of applying them to the query as a whole. If the query is not ordered, the rows of each
+// partition are ordered by their identifiers. See LimitNeighbors for more info.
+func (q *QuerySpec) PartitionBy(column string) {
+	if q.Limit == 0 && q.Offset == 0 {
+		return
+	}
+	if q.Order == nil && q.Node != nil {
+		var ids []string
+		if q.Node.ID != nil {
+			ids = append(ids, q.Node.ID.Column)
+		}
+		for _, f := range q.Node.CompositeID {
+			ids = append(ids, f.Column)
+		}
+		if len(ids) > 0 {
+			q.Order = func(s *sql.Selector) {
+				for _, c := range ids {
+					s.OrderBy(s.C(c))
+				}
+			}
+		}
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = math.MaxInt32
+	}
+	// The modifiers may be shared with the query builder (e.g. ent queries
+	// that are executed more than once), and therefore, they are copied.
+	modifiers := make([]func(*sql.Selector), len(q.Modifiers), len(q.Modifiers)+1)
+	copy(modifiers, q.Modifiers)
+	q.Modifiers = append(modifiers, LimitNeighbors(column, limit, q.Offset))
+	q.Limit, q.Offset = 0, 0
+}
+
 // QueryNodes queries the nodes in the graph query and scans them to the given values.
 func QueryNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) error {
 	builder := sql.Dialect(drv.Dialect())
@@ -751,7 +1057,16 @@ func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 	if err := update.Err(); err != nil {
 		return err
 	}
+	counters := counterEdges(u.Edges.Add, u.Edges.Clear)
 	if !update.Empty() {
+		// Counters of the edges that are changed by this statement are
+		// decremented for their current nodes and incremented for the new
+		// ones. Hence, no-op changes do not affect the counters.
+		if id != nil && len(counters) > 0 {
+			if err := u.updateNodeCounters(ctx, counters, u.Node.ID.Column, []driver.Value{id}, -1); err != nil {
+				return err
+			}
+		}
 		var res sql.Result
 		query, args := update.Query()
 		if err := tx.Exec(ctx, query, args, &res); err != nil {
@@ -768,6 +1083,11 @@ func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 				return err
 			}
 		}
+		if id != nil && len(counters) > 0 {
+			if err := u.updateNodeCounters(ctx, counters, u.Node.ID.Column, []driver.Value{id}, 1); err != nil {
+				return err
+			}
+		}
 	}
 	if id != nil {
 		// Not an edge schema.
@@ -798,7 +1118,8 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 	var (
 		addEdges   = EdgeSpecs(u.Edges.Add).GroupRel()
 		clearEdges = EdgeSpecs(u.Edges.Clear).GroupRel()
-		multiple   = hasExternalEdges(addEdges, clearEdges)
+		counters   = counterEdges(u.Edges.Add, u.Edges.Clear)
+		multiple   = hasExternalEdges(addEdges, clearEdges) || len(counters) > 0
 		update     = u.builder.Update(u.Node.Table).Schema(u.Node.Schema)
 		selector   = u.builder.Select().
 				From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).
@@ -857,12 +1178,18 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 			return 0, nil
 		}
 		update.Where(matchID(u.Node.ID.Column, ids))
+		if err := u.updateNodeCounters(ctx, counters, u.Node.ID.Column, ids, -1); err != nil {
+			return 0, err
+		}
 		// In case of multi statement update, that change can
 		// affect more than 1 table, and therefore, we return
 		// the list of ids as number of affected records.
 		if _, err := u.updateTable(ctx, update); err != nil {
 			return 0, err
 		}
+		if err := u.updateNodeCounters(ctx, counters, u.Node.ID.Column, ids, 1); err != nil {
+			return 0, err
+		}
 		if err := u.setExternalEdges(ctx, ids, addEdges, clearEdges); err != nil {
 			return 0, err
 		}
@@ -1024,9 +1351,19 @@ func (c *creator) node(ctx context.Context, drv dialect.Driver) error {
 			query, args := insert.Query()
 			return c.tx.Exec(ctx, query, args, nil)
 		}
+		counters := counterEdges(c.Edges)
+		// In case of upsert, the node may already exist and be connected to other nodes.
+		if len(counters) > 0 && len(c.OnConflict) > 0 && c.ID.Value != nil {
+			if err := c.updateNodeCounters(ctx, counters, c.ID.Column, []driver.Value{c.ID.Value}, -1); err != nil {
+				return err
+			}
+		}
 		if err := c.insert(ctx, insert); err != nil {
 			return err
 		}
+		if err := c.updateNodeCounters(ctx, counters, c.ID.Column, []driver.Value{c.ID.Value}, 1); err != nil {
+			return err
+		}
 		if err := c.graph.addM2MEdges(ctx, []driver.Value{c.ID.Value}, edges[M2M]); err != nil {
 			return err
 		}
@@ -1039,7 +1376,7 @@ func (c *creator) node(ctx context.Context, drv dialect.Driver) error {
 
 // mayTx opens a new transaction if the create operation spans across multiple statements.
 func (c *creator) mayTx(ctx context.Context, drv dialect.Driver, edges map[Rel][]*EdgeSpec) (dialect.Tx, error) {
-	if !hasExternalEdges(edges, nil) {
+	if !hasExternalEdges(edges, nil) && !hasCounters(c.Edges) {
 		return dialect.NopTx(drv), nil
 	}
 	tx, err := drv.Tx(ctx)
@@ -1163,9 +1500,15 @@ func (c *batchCreator) nodes(ctx context.Context, drv dialect.Driver) error {
 			query, args := insert.Query()
 			return tx.Exec(ctx, query, args, nil)
 		}
+		if err := c.batchCounters(ctx, -1); err != nil {
+			return err
+		}
 		if err := c.batchInsert(ctx, tx, insert); err != nil {
 			return fmt.Errorf("insert nodes to table %q: %w", c.Nodes[0].Table, err)
 		}
+		if err := c.batchCounters(ctx, 1); err != nil {
+			return err
+		}
 		if err := c.batchAddM2M(ctx, c.BatchCreateSpec); err != nil {
 			return err
 		}
@@ -1188,7 +1531,7 @@ func (c *batchCreator) nodes(ctx context.Context, drv dialect.Driver) error {
 func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver) (dialect.Tx, error) {
 	for _, node := range c.Nodes {
 		for _, edge := range node.Edges {
-			if isExternalEdge(edge) {
+			if isExternalEdge(edge) || len(edge.Counters) > 0 {
 				return drv.Tx(ctx)
 			}
 		}
@@ -1196,6 +1539,32 @@ func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver) (dialect.T
 	return dialect.NopTx(drv), nil
 }
 
+// batchCounters updates the counters of the M2O edges of all nodes at once. Nodes
+// are expected to be new, unless they are upserted with user-provided identifiers.
+func (c *batchCreator) batchCounters(ctx context.Context, sign int64) error {
+	if sign < 0 && (len(c.OnConflict) == 0 || c.Nodes[0].ID.Value == nil) {
+		return nil
+	}
+	var (
+		edges []*EdgeSpec
+		ids   = make(map[string][]driver.Value)
+	)
+	for _, node := range c.Nodes {
+		for _, e := range counterEdges(node.Edges) {
+			if _, ok := ids[e.Columns[0]]; !ok {
+				edges = append(edges, e)
+			}
+			ids[e.Columns[0]] = append(ids[e.Columns[0]], node.ID.Value)
+		}
+	}
+	for _, e := range edges {
+		if err := c.updateNodeCounters(ctx, []*EdgeSpec{e}, c.Nodes[0].ID.Column, ids[e.Columns[0]], sign); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // batchInsert inserts a batch of nodes to their table and sets their ID if it was not provided by the user.
 func (c *batchCreator) batchInsert(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder) error {
 	c.ensureConflict(insert)
@@ -1256,28 +1625,37 @@ func (g *graph) clearM2MEdges(ctx context.Context, ids []driver.Value, edges Edg
 	tables := edges.GroupTable()
 	for _, table := range edgeKeys(tables) {
 		edges := tables[table]
-		preds := make([]*sql.Predicate, 0, len(edges))
-		for _, edge := range edges {
-			fromC, toC := edge.Columns[0], edge.Columns[1]
-			if edge.Inverse {
-				fromC, toC = toC, fromC
-			}
-			// If there are no specific edges (to target-nodes) to remove,
-			// clear all edges that go out (or come in) from the nodes.
-			if len(edge.Target.Nodes) == 0 {
-				preds = append(preds, matchID(fromC, ids))
-				if edge.Bidi {
-					preds = append(preds, matchID(toC, ids))
+		where := func() *sql.Predicate {
+			preds := make([]*sql.Predicate, 0, len(edges))
+			for _, edge := range edges {
+				fromC, toC := edge.Columns[0], edge.Columns[1]
+				if edge.Inverse {
+					fromC, toC = toC, fromC
 				}
-			} else {
-				pk1, pk2 := ids, edge.Target.Nodes
-				preds = append(preds, matchIDs(fromC, pk1, toC, pk2))
-				if edge.Bidi {
-					preds = append(preds, matchIDs(toC, pk1, fromC, pk2))
+				// If there are no specific edges (to target-nodes) to remove,
+				// clear all edges that go out (or come in) from the nodes.
+				if len(edge.Target.Nodes) == 0 {
+					preds = append(preds, matchID(fromC, ids))
+					if edge.Bidi {
+						preds = append(preds, matchID(toC, ids))
+					}
+				} else {
+					pk1, pk2 := ids, edge.Target.Nodes
+					preds = append(preds, matchIDs(fromC, pk1, toC, pk2))
+					if edge.Bidi {
+						preds = append(preds, matchIDs(toC, pk1, fromC, pk2))
+					}
 				}
 			}
+			return sql.Or(preds...)
+		}
+		err := g.updateCounters(ctx, edges[0], -1, func(s *sql.Selector) {
+			s.Where(where())
+		})
+		if err != nil {
+			return err
 		}
-		deleter := g.builder.Delete(table).Where(sql.Or(preds...))
+		deleter := g.builder.Delete(table).Where(where())
 		if edges[0].Schema != "" {
 			// If the Schema field was provided to the EdgeSpec (by the
 			// generated code), it should be the same for all EdgeSpecs.
@@ -1330,16 +1708,44 @@ func (g *graph) addM2MEdges(ctx context.Context, ids []driver.Value, edges EdgeS
 		if len(edges[0].Target.Fields) == 0 {
 			insert.OnConflict(sql.DoNothing())
 		}
+		// Existing edges are ignored by the INSERT statement above. Hence, counters
+		// are updated by the difference between the matched edges before and after
+		// the insertion.
+		where := func(s *sql.Selector) {
+			preds := make([]*sql.Predicate, 0, len(edges))
+			for _, edge := range edges {
+				pk1, pk2 := ids, edge.Target.Nodes
+				if edge.Inverse {
+					pk1, pk2 = pk2, pk1
+				}
+				preds = append(preds, matchIDs(columns[0], pk1, columns[1], pk2))
+				if edge.Bidi {
+					preds = append(preds, matchIDs(columns[0], pk2, columns[1], pk1))
+				}
+			}
+			s.Where(sql.Or(preds...))
+		}
+		if err := g.updateCounters(ctx, edges[0], -1, where); err != nil {
+			return err
+		}
 		query, args := insert.Query()
 		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
 			return fmt.Errorf("add m2m edge for table %s: %w", table, err)
 		}
+		if err := g.updateCounters(ctx, edges[0], 1, where); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (g *graph) batchAddM2M(ctx context.Context, spec *BatchCreateSpec) error {
-	tables := make(map[string]*sql.InsertBuilder)
+	var (
+		tables = make(map[string]*sql.InsertBuilder)
+		// Edges with counters and the ids that are set on their join-table columns.
+		counters = make(map[string]*EdgeSpec)
+		pks      = make(map[string][2][]driver.Value)
+	)
 	for _, node := range spec.Nodes {
 		edges := EdgeSpecs(node.Edges).FilterRel(M2M)
 		for name, edges := range edges.GroupTable() {
@@ -1377,13 +1783,42 @@ func (g *graph) batchAddM2M(ctx context.Context, spec *BatchCreateSpec) error {
 					insert.Values(append([]any{pair[1], pair[0]}, edge.Target.FieldValues()...)...)
 				}
 			}
+			if len(edge.Counters) > 0 {
+				c1, c2 := append(pks[name][0], pk1...), append(pks[name][1], pk2...)
+				if edge.Bidi {
+					c1, c2 = append(c1, pk2...), append(c2, pk1...)
+				}
+				counters[name], pks[name] = edge, [2][]driver.Value{c1, c2}
+			}
 		}
 	}
 	for _, table := range insertKeys(tables) {
+		// Counters are updated by the difference between the edges that are matched
+		// before and after the insertion. Note, the predicate below may match edges
+		// that were not inserted by this statement, but they are counted twice, and
+		// therefore, they do not affect the counters.
+		edge, ok := counters[table]
+		where := func(s *sql.Selector) {
+			s.Where(sql.And(
+				sql.InValues(edge.Columns[0], pks[table][0]...),
+				sql.InValues(edge.Columns[1], pks[table][1]...),
+			))
+		}
+		// New nodes cannot have edges, unless they were upserted.
+		if ok && len(spec.OnConflict) > 0 {
+			if err := g.updateCounters(ctx, edge, -1, where); err != nil {
+				return err
+			}
+		}
 		query, args := tables[table].Query()
 		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
 			return fmt.Errorf("add m2m edge for table %s: %w", table, err)
 		}
+		if ok {
+			if err := g.updateCounters(ctx, edge, 1, where); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -1395,13 +1830,21 @@ func (g *graph) clearFKEdges(ctx context.Context, ids []driver.Value, edges []*E
 		}
 		// O2O relations can be cleared without
 		// passing the target ids.
-		pred := matchID(edge.Columns[0], ids)
-		if nodes := edge.Target.Nodes; len(nodes) > 0 {
-			pred = matchIDs(edge.Target.IDSpec.Column, edge.Target.Nodes, edge.Columns[0], ids)
+		where := func() *sql.Predicate {
+			if nodes := edge.Target.Nodes; len(nodes) > 0 {
+				return matchIDs(edge.Target.IDSpec.Column, edge.Target.Nodes, edge.Columns[0], ids)
+			}
+			return matchID(edge.Columns[0], ids)
+		}
+		err := g.updateCounters(ctx, edge, -1, func(s *sql.Selector) {
+			s.Where(where())
+		})
+		if err != nil {
+			return err
 		}
 		query, args := g.builder.Update(edge.Table).
 			SetNull(edge.Columns[0]).
-			Where(pred).
+			Where(where()).
 			Query()
 		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
 			return fmt.Errorf("add %s edge for table %s: %w", edge.Rel, edge.Table, err)
@@ -1445,6 +1888,106 @@ func (g *graph) addFKEdges(ctx context.Context, ids []driver.Value, edges []*Edg
 		if ids := edge.Target.Nodes; int(affected) < len(ids) {
 			return &ConstraintError{msg: fmt.Sprintf("one of %v is already connected to a different %s", ids, edge.Columns[0])}
 		}
+		err = g.updateCounters(ctx, edge, 1, func(s *sql.Selector) {
+			s.Where(matchID(edge.Target.IDSpec.Column, edge.Target.Nodes))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateCounters updates the counters of the edge by the number of edges that match
+// the given predicate. A negative sign decrements the counters. Note, the predicate
+// is applied on the edge table (i.e. the FK table or the join table).
+func (g *graph) updateCounters(ctx context.Context, e *EdgeSpec, sign int64, where func(*sql.Selector)) error {
+	for _, c := range e.Counters {
+		if err := g.updateCounter(ctx, e, c, sign, where); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *graph) updateCounter(ctx context.Context, e *EdgeSpec, c *EdgeCounter, sign int64, where func(*sql.Selector)) error {
+	t := g.builder.Table(e.Table).Schema(e.Schema)
+	selector := g.builder.Select(t.C(c.Ref), sql.Count("*")).From(t)
+	where(selector)
+	selector.Where(sql.NotNull(t.C(c.Ref))).GroupBy(t.C(c.Ref))
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := g.tx.Query(ctx, query, args, rows); err != nil {
+		return fmt.Errorf("count edges in table %s: %w", e.Table, err)
+	}
+	defer rows.Close()
+	// Nodes are grouped by their number of edges,
+	// in order to update them with a minimal number
+	// of statements.
+	counts := make(map[int64][]driver.Value)
+	for rows.Next() {
+		var (
+			id any
+			n  int64
+		)
+		if err := rows.Scan(&id, &n); err != nil {
+			return fmt.Errorf("scan edge counts: %w", err)
+		}
+		counts[n] = append(counts[n], id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	ns := make([]int64, 0, len(counts))
+	for n := range counts {
+		ns = append(ns, n)
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+	for _, n := range ns {
+		query, args := g.builder.Update(c.Table).
+			Schema(c.Schema).
+			Add(c.Column, sign*n).
+			Where(matchID(c.ID, counts[n])).
+			Query()
+		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
+			return fmt.Errorf("update counter %s of table %s: %w", c.Column, c.Table, err)
+		}
+	}
+	return nil
+}
+
+// counterEdges returns the unique M2O (and inverse O2O) edges that have counters.
+// These edges reside in the node table, and their counters are updated before
+// and after the node columns are changed.
+func counterEdges(edges ...[]*EdgeSpec) []*EdgeSpec {
+	var (
+		unique []*EdgeSpec
+		seen   = make(map[string]bool)
+	)
+	for _, es := range edges {
+		for _, e := range es {
+			if len(e.Counters) == 0 || isExternalEdge(e) || seen[e.Columns[0]] {
+				continue
+			}
+			seen[e.Columns[0]] = true
+			unique = append(unique, e)
+		}
+	}
+	return unique
+}
+
+// updateNodeCounters updates the counters of the M2O edges of the given nodes.
+func (g *graph) updateNodeCounters(ctx context.Context, edges []*EdgeSpec, column string, ids []driver.Value, sign int64) error {
+	for _, e := range edges {
+		err := g.updateCounters(ctx, e, sign, func(s *sql.Selector) {
+			s.Where(matchID(column, ids))
+		})
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1466,6 +2009,16 @@ func hasExternalEdges(addEdges, clearEdges map[Rel][]*EdgeSpec) bool {
 	return false
 }
 
+// hasCounters reports if one of the given edges has counters.
+func hasCounters(edges []*EdgeSpec) bool {
+	for _, e := range edges {
+		if len(e.Counters) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // isExternalEdge reports if the given edge requires an UPDATE
 // or an INSERT to other table.
 func isExternalEdge(e *EdgeSpec) bool {
@@ -442,6 +442,239 @@ JOIN
 	}
 }
 
+func TestSetNeighborsRecursive(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *Step
+		depth     int
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name: "O2M/depth",
+			input: NewStep(
+				From("nodes", "id", sql.Select().From(sql.Table("nodes")).Where(sql.EQ("value", 1))),
+				To("nodes", "id"),
+				Edge(O2M, false, "nodes", "parent_id"),
+			),
+			depth: 3,
+			wantQuery: `
+SELECT *
+FROM "nodes"
+WHERE "nodes"."id" IN
+    (WITH RECURSIVE "nodes_neighbors"("id", "depth") AS
+       (SELECT "nodes"."id", 1
+        FROM "nodes"
+        WHERE "nodes"."parent_id" IN
+            (SELECT "nodes"."id"
+             FROM "nodes"
+             WHERE "value" = $1)
+        UNION SELECT "nodes"."id", "t1"."depth" + 1
+        FROM "nodes"
+        JOIN "nodes_neighbors" AS "t1" ON "nodes"."parent_id" = "t1"."id"
+        WHERE "t1"."depth" < $2)
+     SELECT "nodes_neighbors"."id"
+     FROM "nodes_neighbors")`,
+			wantArgs: []any{1, 3},
+		},
+		{
+			name: "M2O",
+			input: NewStep(
+				From("nodes", "id", sql.Select().From(sql.Table("nodes")).Where(sql.EQ("value", 1))),
+				To("nodes", "id"),
+				Edge(M2O, true, "nodes", "parent_id"),
+			),
+			wantQuery: `
+SELECT *
+FROM "nodes"
+WHERE "nodes"."id" IN
+    (WITH RECURSIVE "nodes_neighbors"("id") AS
+       (SELECT "nodes"."parent_id"
+        FROM "nodes"
+        WHERE "nodes"."id" IN
+            (SELECT "nodes"."id"
+             FROM "nodes"
+             WHERE "value" = $1)
+          AND "nodes"."parent_id" IS NOT NULL
+        UNION SELECT "nodes"."parent_id"
+        FROM "nodes"
+        JOIN "nodes_neighbors" AS "t1" ON "nodes"."id" = "t1"."id"
+        WHERE "nodes"."parent_id" IS NOT NULL)
+     SELECT "nodes_neighbors"."id"
+     FROM "nodes_neighbors")`,
+			wantArgs: []any{1},
+		},
+		{
+			name: "M2M/inverse",
+			input: NewStep(
+				From("users", "id", sql.Select().From(sql.Table("users")).Where(sql.EQ("name", "a8m"))),
+				To("users", "id"),
+				Edge(M2M, true, "user_following", "user_id", "follower_id"),
+			),
+			depth: 2,
+			wantQuery: `
+SELECT *
+FROM "users"
+WHERE "users"."id" IN
+    (WITH RECURSIVE "users_neighbors"("id", "depth") AS
+       (SELECT "user_following"."user_id", 1
+        FROM "user_following"
+        WHERE "user_following"."follower_id" IN
+            (SELECT "users"."id"
+             FROM "users"
+             WHERE "name" = $1)
+        UNION SELECT "user_following"."user_id", "t1"."depth" + 1
+        FROM "user_following"
+        JOIN "users_neighbors" AS "t1" ON "user_following"."follower_id" = "t1"."id"
+        WHERE "t1"."depth" < $2)
+     SELECT "users_neighbors"."id"
+     FROM "users_neighbors")`,
+			wantArgs: []any{"a8m", 2},
+		},
+		{
+			name: "O2M/chained",
+			input: NewStep(
+				From("nodes", "id", SetNeighborsRecursive("postgres", NewStep(
+					From("nodes", "id", sql.Select().From(sql.Table("nodes")).Where(sql.EQ("value", 1))),
+					To("nodes", "id"),
+					Edge(O2M, false, "nodes", "parent_id"),
+				), 0)),
+				To("nodes", "id"),
+				Edge(M2O, true, "nodes", "parent_id"),
+			),
+			depth: 2,
+			wantQuery: `
+SELECT *
+FROM "nodes"
+WHERE "nodes"."id" IN
+    (WITH RECURSIVE "nodes_neighbors_2"("id", "depth") AS
+       (SELECT "nodes"."parent_id", 1
+        FROM "nodes"
+        WHERE "nodes"."id" IN
+            (SELECT "nodes"."id"
+             FROM "nodes"
+             WHERE "nodes"."id" IN
+                 (WITH RECURSIVE "nodes_neighbors"("id") AS
+                    (SELECT "nodes"."id"
+                     FROM "nodes"
+                     WHERE "nodes"."parent_id" IN
+                         (SELECT "nodes"."id"
+                          FROM "nodes"
+                          WHERE "value" = $1)
+                     UNION SELECT "nodes"."id"
+                     FROM "nodes"
+                     JOIN "nodes_neighbors" AS "t1" ON "nodes"."parent_id" = "t1"."id")
+                  SELECT "nodes_neighbors"."id"
+                  FROM "nodes_neighbors"))
+          AND "nodes"."parent_id" IS NOT NULL
+        UNION SELECT "nodes"."parent_id", "t1"."depth" + 1
+        FROM "nodes"
+        JOIN "nodes_neighbors_2" AS "t1" ON "nodes"."id" = "t1"."id"
+        WHERE "nodes"."parent_id" IS NOT NULL
+          AND "t1"."depth" < $2)
+     SELECT "nodes_neighbors_2"."id"
+     FROM "nodes_neighbors_2")`,
+			wantArgs: []any{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := SetNeighborsRecursive("postgres", tt.input, tt.depth)
+			query, args := selector.Query()
+			tt.wantQuery = strings.Join(strings.Fields(tt.wantQuery), " ")
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestLimitNeighbors(t *testing.T) {
+	tests := []struct {
+		dialect       string
+		limit, offset int
+		wantQuery     string
+		wantArgs      []any
+	}{
+		{
+			dialect: dialect.Postgres,
+			limit:   2,
+			offset:  1,
+			wantQuery: `SELECT "comments"."id", "comments"."text", "comments"."post_id"
+FROM (SELECT DISTINCT "comments"."post_id" AS "partition" FROM "comments" WHERE "comments"."post_id" IN ($1, $2)) AS "partitions"
+JOIN LATERAL (SELECT DISTINCT "comments"."id", "comments"."text", "comments"."post_id" FROM "comments"
+WHERE "comments"."post_id" IN ($3, $4) AND "comments"."post_id" = "partitions"."partition"
+ORDER BY "comments"."created_at" DESC LIMIT 2 OFFSET 1) AS "comments" ON TRUE`,
+			wantArgs: []any{1, 2, 1, 2},
+		},
+		{
+			dialect: dialect.MySQL,
+			limit:   2,
+			wantQuery: "SELECT `comments`.`id`, `comments`.`text`, `comments`.`post_id` " +
+				"FROM (SELECT DISTINCT `comments`.`id`, `comments`.`text`, `comments`.`post_id`, " +
+				"(ROW_NUMBER() OVER (PARTITION BY `comments`.`post_id` ORDER BY `comments`.`created_at` DESC)) AS `row_number` " +
+				"FROM `comments` WHERE `comments`.`post_id` IN (?, ?)) AS `comments` " +
+				"WHERE `comments`.`row_number` > ? AND `comments`.`row_number` <= ? ORDER BY `comments`.`row_number`",
+			wantArgs: []any{1, 2, 0, 2},
+		},
+		{
+			dialect: dialect.SQLite,
+			limit:   2,
+			offset:  1,
+			wantQuery: "SELECT `comments`.`id`, `comments`.`text`, `comments`.`post_id` " +
+				"FROM (SELECT DISTINCT `comments`.`id`, `comments`.`text`, `comments`.`post_id`, " +
+				"(ROW_NUMBER() OVER (PARTITION BY `comments`.`post_id` ORDER BY `comments`.`created_at` DESC)) AS `row_number` " +
+				"FROM `comments` WHERE `comments`.`post_id` IN (?, ?)) AS `comments` " +
+				"WHERE `comments`.`row_number` > ? AND `comments`.`row_number` <= ? ORDER BY `comments`.`row_number`",
+			wantArgs: []any{1, 2, 1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			b := sql.Dialect(tt.dialect)
+			t1 := b.Table("comments")
+			s := b.Select(t1.Columns("id", "text", "post_id")...).
+				From(t1).
+				Where(sql.InValues(t1.C("post_id"), 1, 2)).
+				OrderBy(sql.Desc(t1.C("created_at"))).
+				Distinct()
+			LimitNeighbors("post_id", tt.limit, tt.offset)(s)
+			query, args := s.Query()
+			require.Equal(t, strings.ReplaceAll(tt.wantQuery, "\n", " "), query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+
+	spec := &QuerySpec{Limit: 2}
+	spec.PartitionBy("post_id")
+	require.Zero(t, spec.Limit)
+	require.Len(t, spec.Modifiers, 1)
+	spec = &QuerySpec{}
+	spec.PartitionBy("post_id")
+	require.Empty(t, spec.Modifiers)
+
+	// Modifiers of the spec are not modified in place.
+	modifiers := make([]func(*sql.Selector), 1, 2)
+	spec = &QuerySpec{Limit: 2, Modifiers: modifiers}
+	spec.PartitionBy("post_id")
+	require.Len(t, spec.Modifiers, 2)
+	require.Nil(t, modifiers[:2][1], "underlying array should not be shared")
+
+	// Neighbors are ordered by their identifiers by default.
+	for _, d := range []string{dialect.MySQL, dialect.SQLite} {
+		spec = &QuerySpec{
+			Node:  &NodeSpec{Table: "comments", Columns: []string{"id", "post_id"}, ID: &FieldSpec{Column: "id"}},
+			Limit: 2,
+		}
+		spec.PartitionBy("post_id")
+		t1 := sql.Dialect(d).Table("comments")
+		s := sql.Dialect(d).Select(t1.Columns("id", "post_id")...).From(t1)
+		spec.Order(s)
+		spec.Modifiers[0](s)
+		query, _ := s.Query()
+		require.Contains(t, query, "(ROW_NUMBER() OVER (PARTITION BY `comments`.`post_id` ORDER BY `comments`.`id`)) AS `row_number`")
+	}
+}
+
 func TestHasNeighbors(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -2189,6 +2422,221 @@ func TestDeleteNodesSchema(t *testing.T) {
 	require.Equal(t, 2, affected)
 }
 
+func TestEdgeCounters(t *testing.T) {
+	var (
+		counter = &EdgeCounter{Table: "posts", ID: "id", Column: "comment_count", Ref: "post_id"}
+		tagsC   = &EdgeCounter{Table: "tags", ID: "id", Column: "post_count", Ref: "tag_id"}
+		target  = func(nodes ...driver.Value) *EdgeTarget {
+			return &EdgeTarget{Nodes: nodes, IDSpec: &FieldSpec{Column: "id"}}
+		}
+	)
+	t.Run("M2O", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `id` = ? AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(10, 1))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(-1, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(escape("UPDATE `comments` SET `post_id` = ? WHERE `id` = ?")).
+			WithArgs(20, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `id` = ? AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(20, 1))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(1, 20).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), &UpdateSpec{
+			Node: &NodeSpec{
+				Table: "comments",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt, Value: 1},
+			},
+			Edges: EdgeMut{
+				Clear: []*EdgeSpec{
+					{Rel: M2O, Inverse: true, Table: "comments", Columns: []string{"post_id"}, Target: target(), Counters: []*EdgeCounter{counter}},
+				},
+				Add: []*EdgeSpec{
+					{Rel: M2O, Inverse: true, Table: "comments", Columns: []string{"post_id"}, Target: target(20), Counters: []*EdgeCounter{counter}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("O2M", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `post_id` = ? AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(10, 3))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(-3, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(escape("UPDATE `comments` SET `post_id` = NULL WHERE `post_id` = ?")).
+			WithArgs(10).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(escape("UPDATE `comments` SET `post_id` = ? WHERE `id` IN (?, ?) AND `post_id` IS NULL")).
+			WithArgs(10, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `id` IN (?, ?) AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(10, 2))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(2, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err = UpdateNode(context.Background(), sql.OpenDB("", db), &UpdateSpec{
+			Node: &NodeSpec{
+				Table: "posts",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt, Value: 10},
+			},
+			Edges: EdgeMut{
+				Clear: []*EdgeSpec{
+					{Rel: O2M, Table: "comments", Columns: []string{"post_id"}, Target: target(), Counters: []*EdgeCounter{counter}},
+				},
+				Add: []*EdgeSpec{
+					{Rel: O2M, Table: "comments", Columns: []string{"post_id"}, Target: target(1, 2), Counters: []*EdgeCounter{counter}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("M2M", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `post_tags`.`tag_id`, COUNT(*) FROM `post_tags` WHERE `post_id` = ? AND `tag_id` IN (?, ?) AND `post_tags`.`tag_id` IS NOT NULL GROUP BY `post_tags`.`tag_id`")).
+			WithArgs(10, 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "count"}).AddRow(1, 1))
+		mock.ExpectExec(escape("UPDATE `tags` SET `post_count` = COALESCE(`tags`.`post_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(-1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(escape("INSERT INTO `post_tags` (`post_id`, `tag_id`) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE `post_id` = `post_tags`.`post_id`, `tag_id` = `post_tags`.`tag_id`")).
+			WithArgs(10, 1, 10, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(escape("SELECT `post_tags`.`tag_id`, COUNT(*) FROM `post_tags` WHERE `post_id` = ? AND `tag_id` IN (?, ?) AND `post_tags`.`tag_id` IS NOT NULL GROUP BY `post_tags`.`tag_id`")).
+			WithArgs(10, 1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "count"}).AddRow(1, 1).AddRow(2, 1))
+		mock.ExpectExec(escape("UPDATE `tags` SET `post_count` = COALESCE(`tags`.`post_count`, 0) + ? WHERE `id` IN (?, ?)")).
+			WithArgs(1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		err = UpdateNode(context.Background(), sql.OpenDB(dialect.MySQL, db), &UpdateSpec{
+			Node: &NodeSpec{
+				Table: "posts",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt, Value: 10},
+			},
+			Edges: EdgeMut{
+				Add: []*EdgeSpec{
+					{Rel: M2M, Table: "post_tags", Columns: []string{"post_id", "tag_id"}, Target: target(1, 2), Counters: []*EdgeCounter{tagsC}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("BatchCreate", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("INSERT INTO `comments` (`post_id`, `text`) VALUES (?, ?), (?, ?)")).
+			WithArgs(10, "a", 10, "b").
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `id` IN (?, ?) AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(10, 2))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(2, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		node := func(text string) *CreateSpec {
+			return &CreateSpec{
+				Table:  "comments",
+				ID:     &FieldSpec{Column: "id", Type: field.TypeInt},
+				Fields: []*FieldSpec{{Column: "text", Type: field.TypeString, Value: text}},
+				Edges: []*EdgeSpec{
+					{Rel: M2O, Inverse: true, Table: "comments", Columns: []string{"post_id"}, Target: target(10), Counters: []*EdgeCounter{counter}},
+				},
+			}
+		}
+		err = BatchCreate(context.Background(), sql.OpenDB(dialect.MySQL, db), &BatchCreateSpec{
+			Nodes: []*CreateSpec{node("a"), node("b")},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `comments`.`post_id`, COUNT(*) FROM `comments` WHERE `comments`.`text` = ? AND `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`")).
+			WithArgs("spam").
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(10, 2).AddRow(20, 1).AddRow(30, 2))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` = ?")).
+			WithArgs(-1, 20).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE(`posts`.`comment_count`, 0) + ? WHERE `id` IN (?, ?)")).
+			WithArgs(-2, 10, 30).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(escape("SELECT `comment_tags`.`tag_id`, COUNT(*) FROM `comment_tags` WHERE `comment_id` IN (SELECT `comments`.`id` FROM `comments` WHERE `comments`.`text` = ?) AND `comment_tags`.`tag_id` IS NOT NULL GROUP BY `comment_tags`.`tag_id`")).
+			WithArgs("spam").
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "count"}))
+		mock.ExpectExec(escape("DELETE FROM `comments` WHERE `comments`.`text` = ?")).
+			WithArgs("spam").
+			WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectCommit()
+		affected, err := DeleteNodes(context.Background(), sql.OpenDB("", db), &DeleteSpec{
+			Node: &NodeSpec{
+				Table: "comments",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+			},
+			Predicate: func(s *sql.Selector) {
+				s.Where(sql.EQ(s.C("text"), "spam"))
+			},
+			Edges: []*EdgeSpec{
+				{Rel: O2M, Table: "comments", Columns: []string{"post_id"}, Counters: []*EdgeCounter{counter}},
+				{Rel: M2M, Table: "comment_tags", Columns: []string{"tag_id", "comment_id"}, Counters: []*EdgeCounter{{Table: "tags", ID: "id", Column: "comment_count", Ref: "tag_id"}}},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, affected)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Recount", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectExec(escape("UPDATE `posts` SET `comment_count` = COALESCE((SELECT `t1`.`n` FROM (SELECT `comments`.`post_id`, COUNT(*) AS `n` FROM `comments` WHERE `comments`.`post_id` IS NOT NULL GROUP BY `comments`.`post_id`) AS `t1` WHERE `t1`.`post_id` = `posts`.`id`), 0) WHERE `posts`.`id` > ?")).
+			WithArgs(10).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+		affected, err := Recount(context.Background(), sql.OpenDB("", db), &RecountSpec{
+			Node: &NodeSpec{
+				Table: "posts",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+			},
+			Edges: []*EdgeSpec{
+				{Rel: O2M, Table: "comments", Columns: []string{"post_id"}, Counters: []*EdgeCounter{counter}},
+			},
+			Predicate: func(s *sql.Selector) {
+				s.Where(sql.GT(s.C("id"), 10))
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, affected)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestQueryNodes(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -0,0 +1,299 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlrange provides Go types for PostgreSQL range columns (e.g.
+// int8range and tstzrange) that can be used as the GoType of the range
+// fields. Ranges are written to and read from the database in their text
+// representation, and therefore, can be stored in other dialects as text.
+package sqlrange
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bounds describes the bounds of a range. The zero value describes a range with
+// an inclusive lower bound and an exclusive upper bound ("[)"), which is also
+// the canonical form of discrete ranges (e.g. int8range) in PostgreSQL.
+type Bounds struct {
+	// LowerExclusive and UpperInclusive control the
+	// inclusivity of the lower and upper bounds.
+	LowerExclusive bool
+	UpperInclusive bool
+	// LowerUnbounded and UpperUnbounded indicate that the range has no
+	// lower (or upper) bound, and its lower (or upper) value is ignored.
+	LowerUnbounded bool
+	UpperUnbounded bool
+	// Empty indicates that the range contains no points.
+	Empty bool
+}
+
+// Int is a range of int64 values. It can be used for int4range and int8range columns.
+type Int struct {
+	Lower, Upper int64
+	Bounds
+}
+
+// NewInt returns a new Int range with an inclusive
+// lower bound and an exclusive upper bound.
+func NewInt(lower, upper int64) Int {
+	return Int{Lower: lower, Upper: upper}
+}
+
+// Contains reports if the range contains the given value.
+func (r Int) Contains(v int64) bool {
+	return contains(r.Bounds, func(upper bool) int {
+		b := r.Lower
+		if upper {
+			b = r.Upper
+		}
+		switch {
+		case v < b:
+			return -1
+		case v > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// String returns the text representation of the range. e.g. [1,10).
+func (r Int) String() string {
+	return format(r.Bounds, strconv.FormatInt(r.Lower, 10), strconv.FormatInt(r.Upper, 10))
+}
+
+// Value implements the driver.Valuer interface.
+func (r Int) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *Int) Scan(src any) (err error) {
+	var lower, upper string
+	if r.Bounds, lower, upper, err = parse(src); err != nil {
+		return err
+	}
+	r.Lower, r.Upper = 0, 0
+	if lower != "" {
+		if r.Lower, err = strconv.ParseInt(lower, 10, 64); err != nil {
+			return fmt.Errorf("sqlrange: invalid lower bound %q: %w", lower, err)
+		}
+	}
+	if upper != "" {
+		if r.Upper, err = strconv.ParseInt(upper, 10, 64); err != nil {
+			return fmt.Errorf("sqlrange: invalid upper bound %q: %w", upper, err)
+		}
+	}
+	return nil
+}
+
+// Time is a range of time values. It can be used for tstzrange, tsrange and daterange columns.
+type Time struct {
+	Lower, Upper time.Time
+	Bounds
+}
+
+// NewTime returns a new Time range with an inclusive
+// lower bound and an exclusive upper bound.
+func NewTime(lower, upper time.Time) Time {
+	return Time{Lower: lower, Upper: upper}
+}
+
+// Contains reports if the range contains the given value.
+func (r Time) Contains(v time.Time) bool {
+	return contains(r.Bounds, func(upper bool) int {
+		b := r.Lower
+		if upper {
+			b = r.Upper
+		}
+		switch {
+		case v.Before(b):
+			return -1
+		case v.After(b):
+			return 1
+		}
+		return 0
+	})
+}
+
+// String returns the text representation of the range.
+// e.g. ["2022-01-01T10:00:00Z","2022-01-01T11:00:00Z").
+func (r Time) String() string {
+	return format(r.Bounds, strconv.Quote(r.Lower.Format(time.RFC3339Nano)), strconv.Quote(r.Upper.Format(time.RFC3339Nano)))
+}
+
+// Value implements the driver.Valuer interface.
+func (r Time) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *Time) Scan(src any) (err error) {
+	var lower, upper string
+	if r.Bounds, lower, upper, err = parse(src); err != nil {
+		return err
+	}
+	r.Lower, r.Upper = time.Time{}, time.Time{}
+	if lower != "" {
+		if r.Lower, err = parseTime(lower); err != nil {
+			return err
+		}
+	}
+	if upper != "" {
+		if r.Upper, err = parseTime(upper); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeLayouts holds the layouts of time values in range literals, as returned
+// by PostgreSQL for the different range types (and the format used by Time).
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+// parseTime parses a time bound of a range.
+func parseTime(s string) (time.Time, error) {
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("sqlrange: invalid time bound %q", s)
+}
+
+// contains reports if a value is contained in a range with the given
+// bounds. The cmp function compares the value with the lower (or upper)
+// bound of the range.
+func contains(b Bounds, cmp func(upper bool) int) bool {
+	if b.Empty {
+		return false
+	}
+	if !b.LowerUnbounded {
+		if c := cmp(false); c < 0 || c == 0 && b.LowerExclusive {
+			return false
+		}
+	}
+	if !b.UpperUnbounded {
+		if c := cmp(true); c > 0 || c == 0 && !b.UpperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+// format returns the text representation of a range.
+func format(b Bounds, lower, upper string) string {
+	if b.Empty {
+		return "empty"
+	}
+	var sb strings.Builder
+	switch {
+	case b.LowerExclusive, b.LowerUnbounded:
+		sb.WriteByte('(')
+	default:
+		sb.WriteByte('[')
+	}
+	if !b.LowerUnbounded {
+		sb.WriteString(lower)
+	}
+	sb.WriteByte(',')
+	if !b.UpperUnbounded {
+		sb.WriteString(upper)
+	}
+	if b.UpperInclusive && !b.UpperUnbounded {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// parse parses the text representation of a range, and returns its bounds
+// and the unquoted values of its lower and upper bounds. An empty value is
+// returned for unbounded sides.
+func parse(src any) (b Bounds, lower, upper string, err error) {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		// NULL values reset the range to its zero value.
+		return b, "", "", nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return b, "", "", fmt.Errorf("sqlrange: unexpected range type %T", src)
+	}
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		return Bounds{Empty: true}, "", "", nil
+	}
+	if len(s) < 3 || s[0] != '[' && s[0] != '(' || s[len(s)-1] != ']' && s[len(s)-1] != ')' {
+		return b, "", "", fmt.Errorf("sqlrange: malformed range literal %q", s)
+	}
+	b.LowerExclusive, b.UpperInclusive = s[0] == '(', s[len(s)-1] == ']'
+	values, err := split(s[1 : len(s)-1])
+	if err != nil {
+		return b, "", "", fmt.Errorf("sqlrange: malformed range literal %q: %w", s, err)
+	}
+	lower, upper = values[0], values[1]
+	b.LowerUnbounded, b.UpperUnbounded = lower == "", upper == ""
+	if b.LowerUnbounded {
+		b.LowerExclusive = false
+	}
+	if b.UpperUnbounded {
+		b.UpperInclusive = false
+	}
+	return b, lower, upper, nil
+}
+
+// split splits the body of a range literal into its two
+// bounds, and removes the quoting of their values.
+func split(s string) ([2]string, error) {
+	var (
+		values [2]string
+		sb     strings.Builder
+		i      int
+		quoted bool
+	)
+	for j := 0; j < len(s); j++ {
+		switch c := s[j]; {
+		case c == '\\' && j+1 < len(s):
+			j++
+			sb.WriteByte(s[j])
+		case c == '"' && quoted && j+1 < len(s) && s[j+1] == '"':
+			j++
+			sb.WriteByte('"')
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			if i > 0 {
+				return values, fmt.Errorf("unexpected ','")
+			}
+			values[i] = sb.String()
+			sb.Reset()
+			i++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if quoted {
+		return values, fmt.Errorf("unterminated quoted value")
+	}
+	if i != 1 {
+		return values, fmt.Errorf("expect 2 bounds")
+	}
+	values[1] = sb.String()
+	return values, nil
+}
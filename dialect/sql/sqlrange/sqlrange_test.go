@@ -0,0 +1,82 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlrange_test
+
+import (
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlrange"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		r   sqlrange.Int
+		lit string
+	}{
+		{r: sqlrange.NewInt(1, 10), lit: "[1,10)"},
+		{r: sqlrange.Int{Lower: -5, Upper: 5, Bounds: sqlrange.Bounds{LowerExclusive: true, UpperInclusive: true}}, lit: "(-5,5]"},
+		{r: sqlrange.Int{Upper: 5, Bounds: sqlrange.Bounds{LowerUnbounded: true}}, lit: "(,5)"},
+		{r: sqlrange.Int{Lower: 1, Bounds: sqlrange.Bounds{UpperUnbounded: true}}, lit: "[1,)"},
+		{r: sqlrange.Int{Bounds: sqlrange.Bounds{Empty: true}}, lit: "empty"},
+	}
+	for _, tt := range tests {
+		v, err := tt.r.Value()
+		require.NoError(t, err)
+		require.Equal(t, tt.lit, v)
+		var r sqlrange.Int
+		require.NoError(t, r.Scan([]byte(tt.lit)))
+		require.Equal(t, tt.r, r)
+	}
+
+	r := sqlrange.NewInt(1, 10)
+	require.True(t, r.Contains(1))
+	require.True(t, r.Contains(9))
+	require.False(t, r.Contains(10))
+	require.False(t, r.Contains(0))
+	r.LowerExclusive, r.UpperInclusive = true, true
+	require.False(t, r.Contains(1))
+	require.True(t, r.Contains(10))
+	r.LowerUnbounded = true
+	require.True(t, r.Contains(-100))
+	require.False(t, sqlrange.Int{Bounds: sqlrange.Bounds{Empty: true}}.Contains(0))
+
+	for _, s := range []string{"", "[1,10", "1,10)", "[1,2,3)", "[a,10)", "[1,b)", `["1,10)`} {
+		require.Error(t, r.Scan(s), s)
+	}
+	require.Error(t, r.Scan(1))
+	require.NoError(t, r.Scan(nil))
+	require.Zero(t, r)
+}
+
+func TestTime(t *testing.T) {
+	start := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	r := sqlrange.NewTime(start, start.Add(time.Hour))
+	v, err := r.Value()
+	require.NoError(t, err)
+	require.Equal(t, `["2022-01-01T10:00:00Z","2022-01-01T11:00:00Z")`, v)
+	require.True(t, r.Contains(start))
+	require.True(t, r.Contains(start.Add(time.Minute)))
+	require.False(t, r.Contains(start.Add(time.Hour)))
+
+	var scanned sqlrange.Time
+	require.NoError(t, scanned.Scan(v))
+	require.True(t, scanned.Lower.Equal(r.Lower))
+	require.True(t, scanned.Upper.Equal(r.Upper))
+	require.Equal(t, r.Bounds, scanned.Bounds)
+
+	// Formats returned by PostgreSQL for tstzrange, tsrange and daterange.
+	require.NoError(t, scanned.Scan(`["2022-01-01 10:00:00+00","2022-01-01 11:30:00.5+05:30")`))
+	require.True(t, scanned.Lower.Equal(start))
+	require.True(t, scanned.Upper.Equal(time.Date(2022, 1, 1, 6, 0, 0, 5e8, time.UTC)))
+	require.NoError(t, scanned.Scan(`("2022-01-01 10:00:00",]`))
+	require.True(t, scanned.Lower.Equal(start))
+	require.Equal(t, sqlrange.Bounds{LowerExclusive: true, UpperUnbounded: true}, scanned.Bounds)
+	require.NoError(t, scanned.Scan("[2022-01-01,2022-01-02)"))
+	require.True(t, scanned.Upper.Equal(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)))
+	require.Error(t, scanned.Scan("[yesterday,today)"))
+}
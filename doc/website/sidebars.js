@@ -57,6 +57,9 @@ module.exports = {
         'templates',
         'extensions',
         'graphql',
+        'grpc',
+        'rest',
+        'gql',
         'sql-integration',
         'ci',
         'testing',
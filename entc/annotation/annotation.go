@@ -0,0 +1,63 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package annotation provides the builtin schema annotations that configure the code generation of the
+// schema types. The package has no dependencies on the code generator, as it is imported by the schemas.
+package annotation
+
+import "entgo.io/ent/schema"
+
+// FeaturesAnnotation is a builtin schema annotation for selecting
+// the codegen features that are generated for the schema.
+type FeaturesAnnotation struct {
+	Names []string // Feature names.
+}
+
+// Name implements the schema.Annotation interface.
+func (*FeaturesAnnotation) Name() string {
+	return "Features"
+}
+
+// Merge implements the schema.Merger interface.
+func (f *FeaturesAnnotation) Merge(other schema.Annotation) schema.Annotation {
+	ant, ok := other.(*FeaturesAnnotation)
+	if !ok || ant == nil {
+		return f
+	}
+	names := append([]string(nil), f.Names...)
+	for _, n := range ant.Names {
+		if !contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return &FeaturesAnnotation{Names: names}
+}
+
+// Features is a builtin schema annotation for selecting the codegen features
+// that are generated for the schema. Only features that can be enabled per
+// schema are accepted (e.g. "sql/upsert", "sql/lock" or "namedges"), and other
+// features are enabled globally using the codegen configuration. For example:
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			annotation.Features("sql/upsert", "namedges"),
+//		}
+//	}
+func Features(names ...string) *FeaturesAnnotation {
+	return &FeaturesAnnotation{Names: names}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	_ schema.Annotation = (*FeaturesAnnotation)(nil)
+	_ schema.Merger     = (*FeaturesAnnotation)(nil)
+)
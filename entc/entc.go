@@ -15,6 +15,7 @@ import (
 	"reflect"
 	"strings"
 
+	"entgo.io/ent/entc/export"
 	"entgo.io/ent/entc/gen"
 	"entgo.io/ent/entc/internal"
 	"entgo.io/ent/entc/load"
@@ -40,6 +41,23 @@ func LoadGraph(schemaPath string, cfg *gen.Config) (*gen.Graph, error) {
 	return gen.NewGraph(cfg, spec.Schemas...)
 }
 
+// ExportGraph loads the schema package from the given schema path, and returns
+// its graph in the stable and versioned format defined in the export package.
+//
+//	g, err := entc.ExportGraph("./ent/schema", &gen.Config{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	g.WriteJSON(os.Stdout)
+//
+func ExportGraph(schemaPath string, cfg *gen.Config) (*export.Graph, error) {
+	g, err := LoadGraph(schemaPath, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return export.NewGraph(g), nil
+}
+
 // Generate runs the codegen on the schema path. The default target
 // directory for the assets, is one directory above the schema path.
 // Hence, if the schema package resides in "<project>/ent/schema",
@@ -127,6 +145,18 @@ func FeatureNames(names ...string) Option {
 	}
 }
 
+// TypeFeatures sets the default list of features that are generated for types that do
+// not select their features using the annotation.Features annotation. Calling it without
+// arguments disables the per-type features (e.g. sql/upsert) for these types.
+//
+//	entc.Generate("./ent/schema", &gen.Config{}, entc.FeatureNames("sql/upsert"), entc.TypeFeatures())
+func TypeFeatures(names ...string) Option {
+	return func(cfg *gen.Config) error {
+		cfg.TypeFeatures = append(make([]string, 0, len(names)), names...)
+		return nil
+	}
+}
+
 // Annotation is used to attach arbitrary metadata to the schema objects in codegen.
 // Unlike schema annotations, being serializable to JSON raw value is not mandatory.
 //
@@ -154,6 +184,22 @@ func Annotations(annotations ...Annotation) Option {
 	}
 }
 
+// Cache enables the codegen cache and stores it in the given directory, or in the
+// user cache directory if it is empty. Types that were not changed since the previous
+// run are skipped, and their templates are not executed. The ENTCACHE environment
+// variable overrides this option ("off" disables the cache).
+//
+//	entc.Generate("./ent/schema", &gen.Config{}, entc.Cache(""))
+func Cache(dir string) Option {
+	return func(cfg *gen.Config) (err error) {
+		if dir == "" {
+			dir, err = gen.UserCacheDir()
+		}
+		cfg.Cache = dir
+		return err
+	}
+}
+
 // BuildFlags appends the given build flags to the codegen config.
 func BuildFlags(flags ...string) Option {
 	return func(cfg *gen.Config) error {
@@ -0,0 +1,242 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package export provides a stable and versioned representation of the schema graph
+// that can be serialized to JSON or YAML, and consumed by external tools (e.g. doc sites,
+// linters or API generators) without depending on the internals of the entc/gen package.
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"entgo.io/ent/entc/gen"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the version of the export format. It is bumped on breaking
+// changes to the format, like renaming or removing an attribute.
+const Version = "1"
+
+type (
+	// Graph is the exported representation of the schema graph.
+	Graph struct {
+		Version  string   `json:"version" yaml:"version"`
+		Package  string   `json:"package" yaml:"package"`
+		Schema   string   `json:"schema,omitempty" yaml:"schema,omitempty"`
+		Features []string `json:"features,omitempty" yaml:"features,omitempty"`
+		Types    []*Type  `json:"types" yaml:"types"`
+	}
+
+	// Type is the exported representation of a schema type.
+	Type struct {
+		Name         string         `json:"name" yaml:"name"`
+		Table        string         `json:"table" yaml:"table"`
+		ID           *Field         `json:"id,omitempty" yaml:"id,omitempty"`
+		CompositeID  []string       `json:"composite_id,omitempty" yaml:"composite_id,omitempty"`
+		EdgeSchema   bool           `json:"edge_schema,omitempty" yaml:"edge_schema,omitempty"`
+		Fields       []*Field       `json:"fields,omitempty" yaml:"fields,omitempty"`
+		Edges        []*Edge        `json:"edges,omitempty" yaml:"edges,omitempty"`
+		Indexes      []*Index       `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+		ForeignKeys  []*ForeignKey  `json:"foreign_keys,omitempty" yaml:"foreign_keys,omitempty"`
+		Hooks        int            `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+		Interceptors int            `json:"interceptors,omitempty" yaml:"interceptors,omitempty"`
+		Policy       int            `json:"policy,omitempty" yaml:"policy,omitempty"`
+		Annotations  map[string]any `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	}
+
+	// Field is the exported representation of a field.
+	Field struct {
+		Name          string         `json:"name" yaml:"name"`
+		Type          string         `json:"type" yaml:"type"`
+		GoType        string         `json:"go_type" yaml:"go_type"`
+		GoPackage     string         `json:"go_package,omitempty" yaml:"go_package,omitempty"`
+		Storage       *Storage       `json:"storage" yaml:"storage"`
+		Unique        bool           `json:"unique,omitempty" yaml:"unique,omitempty"`
+		Optional      bool           `json:"optional,omitempty" yaml:"optional,omitempty"`
+		Nillable      bool           `json:"nillable,omitempty" yaml:"nillable,omitempty"`
+		Immutable     bool           `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+		Sensitive     bool           `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+		Default       bool           `json:"default,omitempty" yaml:"default,omitempty"`
+		UpdateDefault bool           `json:"update_default,omitempty" yaml:"update_default,omitempty"`
+		Enums         []string       `json:"enums,omitempty" yaml:"enums,omitempty"`
+		Validators    int            `json:"validators,omitempty" yaml:"validators,omitempty"`
+		Edge          string         `json:"edge,omitempty" yaml:"edge,omitempty"`
+		Comment       string         `json:"comment,omitempty" yaml:"comment,omitempty"`
+		Annotations   map[string]any `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	}
+
+	// Storage describes how a field is stored in the database.
+	Storage struct {
+		Column     string            `json:"column" yaml:"column"`
+		Size       int64             `json:"size,omitempty" yaml:"size,omitempty"`
+		Nullable   bool              `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+		SchemaType map[string]string `json:"schema_type,omitempty" yaml:"schema_type,omitempty"`
+	}
+
+	// Edge is the exported representation of an edge.
+	Edge struct {
+		Name        string         `json:"name" yaml:"name"`
+		Type        string         `json:"type" yaml:"type"`
+		Inverse     bool           `json:"inverse,omitempty" yaml:"inverse,omitempty"`
+		Ref         string         `json:"ref,omitempty" yaml:"ref,omitempty"`
+		Relation    string         `json:"relation" yaml:"relation"`
+		Unique      bool           `json:"unique,omitempty" yaml:"unique,omitempty"`
+		Optional    bool           `json:"optional,omitempty" yaml:"optional,omitempty"`
+		Immutable   bool           `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+		Field       string         `json:"field,omitempty" yaml:"field,omitempty"`
+		Through     string         `json:"through,omitempty" yaml:"through,omitempty"`
+		Table       string         `json:"table" yaml:"table"`
+		Columns     []string       `json:"columns" yaml:"columns"`
+		Comment     string         `json:"comment,omitempty" yaml:"comment,omitempty"`
+		Annotations map[string]any `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	}
+
+	// Index is the exported representation of an index.
+	Index struct {
+		Name        string         `json:"name" yaml:"name"`
+		Unique      bool           `json:"unique,omitempty" yaml:"unique,omitempty"`
+		Columns     []string       `json:"columns" yaml:"columns"`
+		Annotations map[string]any `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	}
+
+	// ForeignKey is the exported representation of a foreign-key
+	// column that is held by the type table.
+	ForeignKey struct {
+		Column     string `json:"column" yaml:"column"`
+		References string `json:"references" yaml:"references"` // referenced type.
+		Edge       string `json:"edge" yaml:"edge"`             // edge that defines the relation. e.g. "User.pets".
+	}
+)
+
+// NewGraph returns the exported representation of the given graph.
+func NewGraph(g *gen.Graph) *Graph {
+	eg := &Graph{Version: Version, Types: make([]*Type, 0, len(g.Nodes))}
+	if g.Config != nil {
+		eg.Package, eg.Schema = g.Config.Package, g.Config.Schema
+		for _, f := range g.Config.Features {
+			eg.Features = append(eg.Features, f.Name)
+		}
+	}
+	for _, n := range g.Nodes {
+		eg.Types = append(eg.Types, newType(n))
+	}
+	return eg
+}
+
+// WriteJSON writes the JSON encoding of the graph to w.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteYAML writes the YAML encoding of the graph to w.
+func (g *Graph) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(g); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func newType(n *gen.Type) *Type {
+	t := &Type{
+		Name:         n.Name,
+		Table:        n.Table(),
+		EdgeSchema:   n.IsEdgeSchema(),
+		Hooks:        n.NumHooks(),
+		Interceptors: n.NumInterceptors(),
+		Policy:       n.NumPolicy(),
+		Annotations:  n.Annotations,
+	}
+	if n.HasOneFieldID() {
+		t.ID = newField(n.ID)
+	} else {
+		for _, f := range n.EdgeSchema.ID {
+			t.CompositeID = append(t.CompositeID, f.Name)
+		}
+	}
+	for _, f := range n.Fields {
+		t.Fields = append(t.Fields, newField(f))
+	}
+	for _, e := range n.Edges {
+		t.Edges = append(t.Edges, newEdge(e))
+	}
+	for _, idx := range n.Indexes {
+		t.Indexes = append(t.Indexes, &Index{Name: idx.Name, Unique: idx.Unique, Columns: idx.Columns, Annotations: idx.Annotations})
+	}
+	for _, fk := range n.ForeignKeys {
+		e, ref := fk.Edge, fk.Edge.Type
+		if !e.OwnFK() {
+			ref = e.Owner
+		}
+		t.ForeignKeys = append(t.ForeignKeys, &ForeignKey{Column: e.Rel.Column(), References: ref.Name, Edge: e.Owner.Name + "." + e.Name})
+	}
+	return t
+}
+
+func newField(f *gen.Field) *Field {
+	c := f.Column()
+	ef := &Field{
+		Name:          f.Name,
+		Type:          strings.ToLower(strings.TrimPrefix(f.Type.Type.ConstName(), "Type")),
+		GoType:        f.Type.String(),
+		GoPackage:     f.Type.PkgPath,
+		Unique:        f.Unique,
+		Optional:      f.Optional,
+		Nillable:      f.Nillable,
+		Immutable:     f.Immutable,
+		Sensitive:     f.Sensitive(),
+		Default:       f.Default,
+		UpdateDefault: f.UpdateDefault,
+		Validators:    f.Validators,
+		Comment:       f.Comment(),
+		Annotations:   f.Annotations,
+		Storage: &Storage{
+			Column:     c.Name,
+			Size:       c.Size,
+			Nullable:   c.Nullable,
+			SchemaType: c.SchemaType,
+		},
+	}
+	if f.IsEnum() {
+		ef.Enums = f.EnumValues()
+	}
+	if f.IsEdgeField() {
+		if e, err := f.Edge(); err == nil {
+			ef.Edge = e.Name
+		}
+	}
+	return ef
+}
+
+func newEdge(e *gen.Edge) *Edge {
+	ee := &Edge{
+		Name:        e.Name,
+		Type:        e.Type.Name,
+		Inverse:     e.IsInverse(),
+		Relation:    e.Rel.Type.String(),
+		Unique:      e.Unique,
+		Optional:    e.Optional,
+		Immutable:   e.Immutable,
+		Table:       e.Rel.Table,
+		Columns:     e.Rel.Columns,
+		Comment:     e.Comment(),
+		Annotations: e.Annotations,
+	}
+	if e.Ref != nil {
+		ee.Ref = e.Ref.Name
+	}
+	if f := e.Field(); f != nil {
+		ee.Field = f.Name
+	}
+	if e.Through != nil {
+		ee.Through = e.Through.Name
+	}
+	return ee
+}
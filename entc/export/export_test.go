@@ -0,0 +1,80 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraph(t *testing.T) {
+	storage, err := gen.NewStorage("sql")
+	require.NoError(t, err)
+	user := &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true, Comment: "user name"},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime, Ident: "time.Time", PkgPath: "time"}, Optional: true, Nillable: true},
+			{Name: "password", Info: &field.TypeInfo{Type: field.TypeString}, Sensitive: true, SchemaType: map[string]string{"mysql": "char(64)"}},
+		},
+		Edges: []*load.Edge{
+			{Name: "pets", Type: "Pet"},
+		},
+		Indexes: []*load.Index{
+			{Fields: []string{"name", "created_at"}},
+		},
+		Annotations: map[string]any{"Custom": map[string]any{"key": "value"}},
+	}
+	pet := &load.Schema{
+		Name: "Pet",
+		Edges: []*load.Edge{
+			{Name: "owner", Type: "User", Inverse: true, RefName: "pets", Unique: true},
+		},
+	}
+	g, err := gen.NewGraph(&gen.Config{Package: "entc/export", Storage: storage, Features: []gen.Feature{gen.FeatureUpsert}}, user, pet)
+	require.NoError(t, err)
+
+	eg := NewGraph(g)
+	require.Equal(t, Version, eg.Version)
+	require.Equal(t, "entc/export", eg.Package)
+	require.Equal(t, []string{gen.FeatureUpsert.Name}, eg.Features)
+	require.Len(t, eg.Types, 2)
+
+	u := eg.Types[0]
+	require.Equal(t, "User", u.Name)
+	require.Equal(t, "users", u.Table)
+	require.Equal(t, &Field{Name: "id", Type: "int", GoType: "int", Storage: &Storage{Column: "id"}}, u.ID)
+	require.Len(t, u.Fields, 3)
+	require.Equal(t, &Field{Name: "name", Type: "string", GoType: "string", Unique: true, Comment: "user name", Storage: &Storage{Column: "name"}}, u.Fields[0])
+	require.Equal(t, &Field{Name: "created_at", Type: "time", GoType: "time.Time", GoPackage: "time", Optional: true, Nillable: true, Storage: &Storage{Column: "created_at", Nullable: true}}, u.Fields[1])
+	require.True(t, u.Fields[2].Sensitive)
+	require.Equal(t, map[string]string{"mysql": "char(64)"}, u.Fields[2].Storage.SchemaType)
+	require.Equal(t, []*Edge{{Name: "pets", Type: "Pet", Ref: "owner", Relation: "O2M", Optional: true, Table: "pets", Columns: []string{"user_pets"}}}, u.Edges)
+	require.Equal(t, []*Index{{Name: "user_name_created_at", Columns: []string{"name", "created_at"}}}, u.Indexes)
+	require.Empty(t, u.ForeignKeys)
+	require.Equal(t, map[string]any{"key": "value"}, u.Annotations["Custom"])
+
+	p := eg.Types[1]
+	require.Equal(t, []*Edge{{Name: "owner", Type: "User", Inverse: true, Ref: "pets", Relation: "M2O", Unique: true, Optional: true, Table: "pets", Columns: []string{"user_pets"}}}, p.Edges)
+	require.Equal(t, []*ForeignKey{{Column: "user_pets", References: "User", Edge: "User.pets"}}, p.ForeignKeys)
+
+	var b bytes.Buffer
+	require.NoError(t, eg.WriteJSON(&b))
+	var decoded Graph
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	require.Equal(t, eg.Types[1], decoded.Types[1])
+
+	b.Reset()
+	require.NoError(t, eg.WriteYAML(&b))
+	require.Contains(t, b.String(), "version: \"1\"\npackage: entc/export\n")
+	require.Contains(t, b.String(), "  - name: Pet\n    table: pets\n")
+}
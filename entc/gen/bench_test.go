@@ -5,8 +5,7 @@
 package gen_test
 
 import (
-	"os"
-	"path/filepath"
+	"runtime"
 	"testing"
 
 	"entgo.io/ent/entc"
@@ -16,26 +15,47 @@ import (
 )
 
 func BenchmarkGraph_Gen(b *testing.B) {
-	target := filepath.Join(os.TempDir(), "ent")
-	require.NoError(b, os.MkdirAll(target, os.ModePerm), "creating tmpdir")
-	defer os.RemoveAll(target)
-	storage, err := gen.NewStorage("sql")
-	require.NoError(b, err)
-	graph, err := entc.LoadGraph("../integration/ent/schema", &gen.Config{
-		Storage: storage,
-		IDType:  &field.TypeInfo{Type: field.TypeInt},
-		Target:  target,
-		Package: "entgo.io/ent/entc/integration/ent",
-		Templates: []*gen.Template{
-			gen.MustParse(gen.NewTemplate("template").
-				Funcs(gen.Funcs).
-				ParseGlob("../integration/ent/template/*.tmpl")),
-		},
-	})
-	require.NoError(b, err)
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		err := graph.Gen()
+	graph := func(b *testing.B, cache string) *gen.Graph {
+		storage, err := gen.NewStorage("sql")
+		require.NoError(b, err)
+		graph, err := entc.LoadGraph("../integration/ent/schema", &gen.Config{
+			Cache:   cache,
+			Storage: storage,
+			IDType:  &field.TypeInfo{Type: field.TypeInt},
+			Target:  b.TempDir(),
+			Package: "entgo.io/ent/entc/integration/ent",
+			Templates: []*gen.Template{
+				gen.MustParse(gen.NewTemplate("template").
+					Funcs(gen.Funcs).
+					ParseGlob("../integration/ent/template/*.tmpl")),
+			},
+		})
 		require.NoError(b, err)
+		return graph
 	}
+	b.Run("Serial", func(b *testing.B) {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+		g := graph(b, "")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, g.Gen())
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		g := graph(b, "")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, g.Gen())
+		}
+	})
+	// Incremental runs codegen on an unchanged graph,
+	// where all files are skipped using the cache.
+	b.Run("Incremental", func(b *testing.B) {
+		g := graph(b, b.TempDir())
+		require.NoError(b, g.Gen())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, g.Gen())
+		}
+	})
 }
@@ -0,0 +1,403 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package gen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+)
+
+// cacheEnv is the environment variable that overrides the Config.Cache option. Setting it
+// to "off" disables the cache, "on" stores the cache in the user cache directory, and any
+// other value is used as the cache directory.
+const cacheEnv = "ENTCACHE"
+
+type (
+	// genCache is the manifest of a previous codegen run on a target directory. It maps
+	// the generated files to the input keys of their types, and to the hashes of their
+	// template output and formatted content. It allows skipping the template execution of
+	// types that were not changed since the previous run, the formatting of files that were
+	// not changed, and removing files that are not generated anymore.
+	//
+	// The cache is stored outside the target directory, in order to keep the generated
+	// output identical to a run without the cache.
+	genCache struct {
+		// Version identifies the generator build, as the formatting
+		// of files may change between versions of the tools.
+		Version string `json:"version"`
+		// Files are keyed by their path relative to the target directory.
+		Files map[string]*cacheEntry `json:"files"`
+		path  string
+		mu    sync.Mutex
+	}
+
+	// cacheEntry holds the hashes of a generated file.
+	cacheEntry struct {
+		Key     string       `json:"key,omitempty"`     // input key of the type. See typeKeys.
+		In      string       `json:"in,omitempty"`      // imports state before the execution.
+		Imports *importState `json:"imports,omitempty"` // imports state after the execution.
+		Raw     string       `json:"raw"`               // template output.
+		Out     string       `json:"out"`               // formatted file content.
+	}
+
+	// importState is the serialized state of an ImportTracker. Templates share the
+	// tracker state in their execution order, and therefore, the state that is left
+	// by a skipped template is restored from the cache for the next template.
+	importState struct {
+		Paths map[string]string `json:"paths"`
+		Names map[string]string `json:"names"`
+	}
+)
+
+// openCache opens the cache of the given target directory, stored in the given cache
+// directory, or in the one set by the ENTCACHE environment variable. A nil cache is
+// returned if caching is disabled or is not available, and its methods are no-op in
+// this case.
+func openCache(dir, target string) *genCache {
+	switch env := os.Getenv(cacheEnv); env {
+	case "":
+	case "off":
+		return nil
+	case "on":
+		var err error
+		if dir, err = UserCacheDir(); err != nil {
+			return nil
+		}
+	default:
+		dir = env
+	}
+	if dir == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil
+	}
+	c := &genCache{
+		Version: buildVersion(),
+		Files:   make(map[string]*cacheEntry),
+		path:    filepath.Join(dir, hash([]byte(abs))[:16]+".json"),
+	}
+	buf, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	prev := &genCache{}
+	if err := json.Unmarshal(buf, prev); err == nil && prev.Version == c.Version && prev.Files != nil {
+		c.Files = prev.Files
+	}
+	return c
+}
+
+// UserCacheDir returns the default directory of the codegen cache, under the user cache directory.
+func UserCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "ent", "gen"), nil
+}
+
+// unchanged reports if the template output of the file at the given path was not changed since
+// the previous run, and the formatted file on disk was not modified. i.e. it can be skipped. The
+// content of the formatted file is returned in this case.
+func (c *genCache) unchanged(rel, path string, raw []byte) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	e, ok := c.Files[rel]
+	c.mu.Unlock()
+	if !ok || e.Raw != hash(raw) {
+		return nil, false
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil || hash(buf) != e.Out {
+		return nil, false
+	}
+	return buf, true
+}
+
+// fresh returns the cache entry of the file at the given path if it was generated for the same
+// type key in the previous run, and the formatted file on disk was not modified. i.e. its template
+// execution can be skipped. The content of the formatted file is returned in this case.
+func (c *genCache) fresh(rel, path, key string) (*cacheEntry, []byte, bool) {
+	if c == nil || key == "" {
+		return nil, nil, false
+	}
+	c.mu.Lock()
+	e, ok := c.Files[rel]
+	c.mu.Unlock()
+	if !ok || e.Key != key || e.Imports == nil {
+		return nil, nil, false
+	}
+	buf, err := os.ReadFile(path)
+	if err != nil || hash(buf) != e.Out {
+		return nil, nil, false
+	}
+	return e, buf, true
+}
+
+// set records the inputs of a generated file, and the hashes of its
+// template output and formatted content. The given entry may be nil.
+func (c *genCache) set(rel string, e *cacheEntry, raw, out []byte) {
+	if c == nil {
+		return
+	}
+	if e == nil {
+		e = &cacheEntry{}
+	}
+	e.Raw, e.Out = hash(raw), hash(out)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Files[rel] = e
+}
+
+// stale returns the files that were generated in the previous run and are not
+// generated anymore, if they were not modified since. The files are removed from
+// the cache, as they are expected to be deleted by the caller.
+func (c *genCache) stale(target string, files map[string][]byte) []string {
+	if c == nil {
+		return nil
+	}
+	var paths []string
+	for rel, e := range c.Files {
+		path := filepath.Join(target, rel)
+		if _, ok := files[path]; ok {
+			continue
+		}
+		delete(c.Files, rel)
+		if buf, err := os.ReadFile(path); err == nil && hash(buf) == e.Out {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// save writes the cache to its directory. Errors are ignored, as the
+// cache is only an optimization, and the next run falls back to a full
+// codegen in case it is missing.
+func (c *genCache) save() {
+	if c == nil {
+		return
+	}
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path, buf, 0644)
+}
+
+// newImportState returns the state of the given tracker.
+func newImportState(i *ImportTracker) *importState {
+	c := i.clone()
+	return &importState{Paths: c.pathToName, Names: c.nameToPath}
+}
+
+// tracker returns a new tracker with the state.
+func (s *importState) tracker() *ImportTracker {
+	i := newImportTracker()
+	for path, name := range s.Paths {
+		i.pathToName[path] = name
+	}
+	for name, path := range s.Names {
+		i.nameToPath[name] = path
+	}
+	return i
+}
+
+// hash returns the hash of the state.
+func (s *importState) hash() string {
+	// Maps are marshaled with sorted keys.
+	buf, _ := json.Marshal(s)
+	return hash(buf)
+}
+
+// typeKeys returns the input keys of the types in the graph. The key of a type is a hash of
+// its schema and annotations, the schemas of its neighbors (the types it has edges to or from,
+// and their edge schemas), and the inputs that are shared by all types: the generator version,
+// the configuration, the names of the types and the templates.
+//
+// Note that Go code that is executed by the generator, like hooks and template functions, is not
+// part of the key. Therefore, the cache should be disabled when it is changed (ENTCACHE=off).
+// Types that are not connected to a type are not part of its key, as the generated code of a
+// type refers only to its neighbors (e.g. their packages and columns for querying its edges).
+func typeKeys(g *Graph) map[*Type]string {
+	names := make([]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		names[i] = n.Name
+	}
+	tmpls := templates.Templates()
+	sort.Slice(tmpls, func(i, j int) bool { return tmpls[i].Name() < tmpls[j].Name() })
+	h := sha256.New()
+	for _, t := range tmpls {
+		if t.Tree != nil && t.Tree.Root != nil {
+			fmt.Fprintf(h, "%s\n%s\n", t.Name(), t.Tree.Root)
+		}
+	}
+	features := make([]string, len(g.Features))
+	for i, f := range g.Features {
+		features[i] = f.Name
+	}
+	var storage string
+	if g.Storage != nil {
+		storage = g.Storage.Name
+	}
+	shared, err := json.Marshal(struct {
+		Version      string
+		Schema       string
+		Package      string
+		Header       string
+		Storage      string
+		IDType       *field.TypeInfo
+		Features     []string
+		TypeFeatures []string
+		Annotations  Annotations
+		BuildFlags   []string
+		Names        []string
+		Templates    string
+	}{
+		buildVersion(), g.Schema, g.Package, g.Header, storage, g.IDType, features,
+		g.TypeFeatures, g.Annotations, g.BuildFlags, names, hex.EncodeToString(h.Sum(nil)),
+	})
+	if err != nil {
+		return nil
+	}
+	var (
+		schemas   = make(map[*Type]string, len(g.Nodes))
+		neighbors = make(map[*Type]map[*Type]struct{}, len(g.Nodes))
+	)
+	link := func(a, b *Type) {
+		if a == nil || b == nil || a == b {
+			return
+		}
+		for _, p := range [][2]*Type{{a, b}, {b, a}} {
+			if neighbors[p[0]] == nil {
+				neighbors[p[0]] = make(map[*Type]struct{})
+			}
+			neighbors[p[0]][p[1]] = struct{}{}
+		}
+	}
+	for _, n := range g.Nodes {
+		buf, err := json.Marshal(struct {
+			Schema      *load.Schema
+			Annotations Annotations
+		}{n.schema, n.Annotations})
+		if err != nil {
+			// Types that cannot be hashed are always executed.
+			continue
+		}
+		schemas[n] = hash(buf)
+		for _, e := range n.Edges {
+			link(n, e.Type)
+			link(n, e.Through)
+		}
+	}
+	keys := make(map[*Type]string, len(g.Nodes))
+	for _, n := range g.Nodes {
+		s, ok := schemas[n]
+		if !ok {
+			continue
+		}
+		deps := []string{s}
+		for m := range neighbors[n] {
+			if s, ok = schemas[m]; !ok {
+				break
+			}
+			deps = append(deps, m.Name+":"+s)
+		}
+		if !ok {
+			continue
+		}
+		sort.Strings(deps[1:])
+		keys[n] = hash(append(shared, strings.Join(deps, "\n")...))
+	}
+	return keys
+}
+
+// buildVersion returns an identifier of the generator build.
+func buildVersion() string {
+	v := runtime.Version()
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return v
+	}
+	for _, m := range info.Deps {
+		switch m.Path {
+		case "entgo.io/ent", "golang.org/x/tools":
+			v += " " + m.Path + "@" + m.Version + m.Sum
+		}
+	}
+	return v
+}
+
+// hash returns the hex-encoded sha256 of b.
+func hash(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// workers returns the number of workers for processing n jobs in parallel.
+func workers(n int) int {
+	w := runtime.GOMAXPROCS(0)
+	if n < w {
+		w = n
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// parallel calls fn for the indexes [0, n) using the given number of workers, and returns
+// the error of the lowest index that failed. The worker index is passed to fn, and allows it
+// to use a worker-local state.
+func parallel(n, workers int, fn func(w, i int) error) error {
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(0, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var (
+		wg   sync.WaitGroup
+		next int64 = -1
+		errs       = make([]error, n)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := int(atomic.AddInt64(&next, 1)); i < n; i = int(atomic.AddInt64(&next, 1)) {
+				errs[i] = fn(w, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
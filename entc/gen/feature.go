@@ -49,6 +49,7 @@ var (
 		Stage:       Experimental,
 		Default:     false,
 		Description: "NamedEdges provides an API for eager-loading edges with dynamic names",
+		perType:     true,
 	}
 
 	// FeatureSnapshot stores a snapshot of ent/schema and auto-solve merge-conflict (issue #852).
@@ -93,6 +94,7 @@ var (
 		Stage:       Experimental,
 		Default:     false,
 		Description: "Allows users to use row-level locking in SQL using the 'FOR {UPDATE|SHARE}' clauses",
+		perType:     true,
 	}
 
 	// FeatureModifier provides a feature-flag for adding query modifiers.
@@ -101,6 +103,7 @@ var (
 		Stage:       Experimental,
 		Default:     false,
 		Description: "Allows users to attach custom modifiers to queries",
+		perType:     true,
 	}
 
 	// FeatureExecQuery provides a feature-flag for exposing the ExecContext/QueryContext methods of the underlying SQL drivers.
@@ -117,6 +120,7 @@ var (
 		Stage:       Experimental,
 		Default:     false,
 		Description: "Allows users to configure the `ON CONFLICT`/`ON DUPLICATE KEY` clause for `INSERT` statements",
+		perType:     true,
 	}
 
 	FeatureVersionedMigration = Feature{
@@ -126,6 +130,15 @@ var (
 		Description: "Allows users to work with versioned migrations / migration files",
 	}
 
+	// FeatureRecursive provides a feature-flag for traversing self-referencing edges recursively.
+	FeatureRecursive = Feature{
+		Name:        "sql/recursive",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to traverse self-referencing edges recursively using 'WITH RECURSIVE' queries",
+		perType:     true,
+	}
+
 	// AllFeatures holds a list of all feature-flags.
 	AllFeatures = []Feature{
 		FeaturePrivacy,
@@ -139,6 +152,7 @@ var (
 		FeatureExecQuery,
 		FeatureUpsert,
 		FeatureVersionedMigration,
+		FeatureRecursive,
 	}
 )
 
@@ -188,6 +202,10 @@ type Feature struct {
 	// and will their output will be written to the configured destination.
 	GraphTemplates []GraphTemplate
 
+	// perType indicates if the feature can be selected per type using the
+	// annotation.Features annotation, as its templates are executed on types.
+	perType bool
+
 	// cleanup used to cleanup all changes when a feature-flag is removed.
 	// e.g. delete files from previous codegen runs.
 	cleanup func(*Config) error
@@ -92,7 +92,7 @@ func quote(v any) any {
 func fieldOps(f *Field) (ops []Op) {
 	switch t := f.Type.Type; {
 	case f.HasGoType() && !f.ConvertedToBasic() && !f.Type.Valuer():
-	case t == field.TypeJSON:
+	case t == field.TypeJSON, t == field.TypeGeometry, t == field.TypeRange:
 	case t == field.TypeBool:
 		ops = boolOps
 	case t == field.TypeString && strings.ToLower(f.Name) != "id":
@@ -105,6 +105,10 @@ func fieldOps(f *Field) (ops []Op) {
 	default:
 		ops = numericOps
 	}
+	// The operation lists share their underlying arrays, and the returned slice is
+	// capped to its length, in order to ensure appending to it (in templates that
+	// may be executed concurrently) does not override one of the other lists.
+	ops = ops[:len(ops):len(ops)]
 	if f.Optional {
 		ops = append(ops, nillableOps...)
 	}
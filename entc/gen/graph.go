@@ -15,9 +15,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"text/template/parse"
 
+	gschema "entgo.io/ent/dialect/gremlin/schema"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/field"
@@ -70,6 +72,12 @@ type (
 		// For example, the PrivacyFeature.
 		Features []Feature
 
+		// TypeFeatures defines the default list of features that are generated for types that do
+		// not select their features using the annotation.Features annotation. Only features that can
+		// be selected per type are accepted (e.g. sql/upsert, sql/lock or namedges), and if it is
+		// nil, these types are generated with all per-type features enabled in Features above.
+		TypeFeatures []string
+
 		// Hooks holds an optional list of Hooks to apply on the graph before/after the code-generation.
 		Hooks []Hook
 
@@ -94,6 +102,12 @@ type (
 		// BuildFlags holds a list of custom build flags to use
 		// when loading the schema packages.
 		BuildFlags []string
+
+		// Cache defines the directory of the codegen cache. If set, the templates of types that
+		// were not changed since the previous run are not executed, and unchanged files are not
+		// formatted and written again. The cache is disabled by default, and the ENTCACHE
+		// environment variable overrides this option (see, the entc.Cache option).
+		Cache string
 	}
 
 	// Graph holds the nodes/entities of the loaded graph schema. Note that, it doesn't
@@ -157,6 +171,9 @@ func (a *Annotations) Set(k string, v any) {
 func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 	defer catch(&err)
 	g = &Graph{Config: c, Nodes: make([]*Type, 0, len(schemas)), Schemas: schemas}
+	for _, name := range c.TypeFeatures {
+		check(checkTypeFeature(name), "invalid type features")
+	}
 	for i := range schemas {
 		g.addNode(schemas[i])
 	}
@@ -173,6 +190,7 @@ func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 		g.addIndexes(schemas[i])
 	}
 	check(g.edgeSchemas(), "resolving edges")
+	check(g.edgeCounters(), "resolving edge counters")
 	aliases(g)
 	g.defaults()
 	return
@@ -219,18 +237,22 @@ func (g *Graph) Gen() error {
 // generate is the default Generator implementation.
 func generate(g *Graph) error {
 	var (
+		jobs     []*genJob
 		assets   assets
 		external []GraphTemplate
 	)
 	templates, external = g.templates()
+	// Types that were not changed since the previous
+	// run are skipped, and their templates are not executed.
+	cache := openCache(g.Config.Cache, g.Config.Target)
+	var keys map[*Type]string
+	if cache != nil {
+		keys = typeKeys(g)
+	}
 	for _, n := range g.Nodes {
 		assets.addDir(filepath.Join(g.Config.Target, n.PackageDir()))
 		for _, tmpl := range Templates {
-			b := bytes.NewBuffer(nil)
-			if err := templates.ExecuteTemplate(b, tmpl.Name, n); err != nil {
-				return fmt.Errorf("execute template %q: %w", tmpl.Name, err)
-			}
-			assets.add(filepath.Join(g.Config.Target, tmpl.Format(n)), b.Bytes())
+			jobs = append(jobs, &genJob{name: tmpl.Name, path: filepath.Join(g.Config.Target, tmpl.Format(n)), data: n, key: keys[n]})
 		}
 	}
 	for _, tmpl := range append(GraphTemplates, external...) {
@@ -240,11 +262,10 @@ func generate(g *Graph) error {
 		if dir := filepath.Dir(tmpl.Format); dir != "." {
 			assets.addDir(filepath.Join(g.Config.Target, dir))
 		}
-		b := bytes.NewBuffer(nil)
-		if err := templates.ExecuteTemplate(b, tmpl.Name, g); err != nil {
-			return fmt.Errorf("execute template %q: %w", tmpl.Name, err)
-		}
-		assets.add(filepath.Join(g.Config.Target, tmpl.Format), b.Bytes())
+		jobs = append(jobs, &genJob{name: tmpl.Name, path: filepath.Join(g.Config.Target, tmpl.Format), data: g})
+	}
+	if err := assets.execute(jobs, g.Config.Target, cache); err != nil {
+		return err
 	}
 	for _, f := range AllFeatures {
 		if f.cleanup == nil || g.featureEnabled(f) {
@@ -254,6 +275,9 @@ func generate(g *Graph) error {
 			return fmt.Errorf("cleanup %q feature assets: %w", f.Name, err)
 		}
 	}
+	// Files that were not changed since the previous
+	// run are skipped, and are not written or formatted.
+	assets.skipUnchanged(g.Config.Target, cache)
 	// Write and format assets only if template execution
 	// finished successfully.
 	if err := assets.write(); err != nil {
@@ -264,7 +288,12 @@ func generate(g *Graph) error {
 	// We can't run "imports" on files when the state is not completed.
 	// Because, "goimports" will drop undefined package. Therefore, it
 	// is suspended to the end of the writing.
-	return assets.format()
+	if err := assets.format(g.Config.Target, cache); err != nil {
+		return err
+	}
+	assets.removeStale(g.Config.Target, cache)
+	cache.save()
+	return nil
 }
 
 // addNode creates a new Type/Node/Ent to the graph.
@@ -574,6 +603,43 @@ func (g *Graph) edgeSchemas() error {
 	return nil
 }
 
+// edgeCounters resolves the counter fields of edges that were defined with edge.Counter.
+// Counters are allowed only on non-unique edges that do not go through an edge schema,
+// and must be stored in integer fields (with a default value) of the type that declares
+// the edge. Counter fields are read-only, and cannot be set using the builders.
+func (g *Graph) edgeCounters() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			if e.def.Counter == "" {
+				continue
+			}
+			f, ok := n.fields[e.def.Counter]
+			switch {
+			case e.Unique:
+				return fmt.Errorf("edge %s.%s: counter is allowed only on non-unique edges", n.Name, e.Name)
+			case e.Through != nil || e.Ref != nil && e.Ref.Through != nil:
+				return fmt.Errorf("edge %s.%s: counter is not supported on edges with an edge schema", n.Name, e.Name)
+			case !n.HasOneFieldID() || !e.Type.HasOneFieldID():
+				return fmt.Errorf("edge %s.%s: counter is not supported on types with composite identifiers", n.Name, e.Name)
+			case !ok:
+				return fmt.Errorf("edge %s.%s: counter field %q was not found", n.Name, e.Name, e.def.Counter)
+			case !f.Type.Type.Integer() || f.IsEdgeField():
+				return fmt.Errorf("edge %s.%s: counter field %q must be an integer field", n.Name, e.Name, f.Name)
+			case !f.Default:
+				return fmt.Errorf("edge %s.%s: counter field %q must have a default value", n.Name, e.Name, f.Name)
+			}
+			for _, c := range n.Edges {
+				if c.Counter == f {
+					return fmt.Errorf("edge %s.%s: counter field %q is already used by edge %q", n.Name, e.Name, f.Name, c.Name)
+				}
+			}
+			e.Counter, f.counter = f, e
+			e.Type.counted = append(e.Type.counted, e)
+		}
+	}
+	return nil
+}
+
 // Tables returns the schema definitions of SQL tables for the graph.
 func (g *Graph) Tables() (all []*schema.Table, err error) {
 	tables := make(map[string]*schema.Table)
@@ -793,6 +859,133 @@ func deleteAction(e *Edge, c *schema.Column) schema.ReferenceOption {
 	return action
 }
 
+// VertexLabels returns the schema definitions of the graph for Gremlin dialects.
+// Fields are mapped to property keys, unique fields and field-based indexes are
+// mapped to composite indexes, and edges are mapped to edge labels with their
+// multiplicity.
+//
+// Since property keys are global in the graph, fields that share the same storage
+// key in different types, but are mapped to different data types (e.g. a string
+// and a JSON field), are mapped to a property key of the generic Object type.
+// Such fields cannot be indexed, as their values have no common type.
+func (g *Graph) VertexLabels() (all []*gschema.VertexLabel, err error) {
+	for _, n := range g.Nodes {
+		v := gschema.NewVertexLabel(n.Label())
+		for _, f := range n.Fields {
+			if f.IsEdgeField() {
+				continue
+			}
+			v.AddProperty(&gschema.Property{
+				Name:   f.StorageKey(),
+				Type:   f.Type.Type,
+				Unique: f.Unique,
+			})
+		}
+		for _, idx := range n.Indexes {
+			// Skip indexes that are defined on edges, as
+			// edges are not stored as vertex properties.
+			if !hasProperties(v, idx.Columns) {
+				continue
+			}
+			if err := v.AddIndex(idx.Name, idx.Unique, idx.Columns); err != nil {
+				return nil, err
+			}
+		}
+		for _, e := range n.Edges {
+			if e.IsInverse() {
+				continue
+			}
+			v.AddEdge(&gschema.EdgeLabel{
+				Name:         e.Label(),
+				Multiplicity: multiplicity(e.Rel.Type),
+			})
+		}
+		all = append(all, v)
+	}
+	if err := resolveKeys(all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// resolveKeys maps properties that share the same key in different
+// vertex labels with different data types to the Object data type.
+func resolveKeys(labels []*gschema.VertexLabel) error {
+	keys := make(map[string][]*gschema.Property)
+	for _, v := range labels {
+		for _, p := range v.Properties {
+			keys[p.Name] = append(keys[p.Name], p)
+		}
+	}
+	for _, v := range labels {
+		for _, idx := range v.Indexes {
+			for _, p := range idx.Properties {
+				if ps := keys[p.Name]; conflicts(ps) {
+					return fmt.Errorf("indexed property key %q of vertex label %q is defined with different types: %s", p.Name, v.Name, dataTypes(ps))
+				}
+			}
+		}
+	}
+	for _, ps := range keys {
+		if !conflicts(ps) {
+			continue
+		}
+		for _, p := range ps {
+			p.Type = field.TypeOther
+		}
+	}
+	return nil
+}
+
+// conflicts reports if the given properties have different data types.
+func conflicts(ps []*gschema.Property) bool {
+	for _, p := range ps[1:] {
+		if p.DataType() != ps[0].DataType() {
+			return true
+		}
+	}
+	return false
+}
+
+// dataTypes returns the distinct data types of the given properties.
+func dataTypes(ps []*gschema.Property) string {
+	var (
+		types []string
+		seen  = make(map[string]bool)
+	)
+	for _, p := range ps {
+		if t := p.DataType(); !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return strings.Join(types, ", ")
+}
+
+// hasProperties reports if all the given properties exist in the vertex label.
+func hasProperties(v *gschema.VertexLabel, names []string) bool {
+	for _, name := range names {
+		if _, ok := v.Property(name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// multiplicity returns the edge label multiplicity for the given relation.
+func multiplicity(r Rel) gschema.Multiplicity {
+	switch r {
+	case O2O:
+		return gschema.One2One
+	case O2M:
+		return gschema.One2Many
+	case M2O:
+		return gschema.Many2One
+	default:
+		return gschema.Simple
+	}
+}
+
 // SupportMigrate reports if the codegen supports schema migration.
 func (g *Graph) SupportMigrate() bool {
 	return g.Storage.SchemaMode.Support(Migrate)
@@ -926,6 +1119,20 @@ func (c Config) FeatureEnabled(name string) (bool, error) {
 	return false, fmt.Errorf("unexpected feature name %q", name)
 }
 
+// checkTypeFeature checks if the given feature can be selected per type.
+func checkTypeFeature(name string) error {
+	for _, f := range AllFeatures {
+		if name != f.Name {
+			continue
+		}
+		if !f.perType {
+			return fmt.Errorf("feature %q cannot be selected per type", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("unexpected feature name %q", name)
+}
+
 // featureEnabled reports if the given feature-flag is enabled.
 func (c Config) featureEnabled(f Feature) bool {
 	for i := range c.Features {
@@ -1004,10 +1211,27 @@ func cleanOldNodes(assets assets, target string) {
 	}
 }
 
-type assets struct {
-	dirs  map[string]struct{}
-	files map[string][]byte
-}
+type (
+	assets struct {
+		dirs  map[string]struct{}
+		files map[string][]byte
+		// skipped holds the formatted content of the files that were not
+		// changed since the previous codegen run, and are not formatted again.
+		skipped map[string][]byte
+		// raw holds the template output of the skipped files that are
+		// siblings of changed files. See assets.write for more info.
+		raw map[string][]byte
+		// entries hold the cache inputs of the executed files.
+		entries map[string]*cacheEntry
+	}
+	// genJob describes the execution of a template into a file.
+	genJob struct {
+		name string // template name.
+		path string // output path.
+		data any    // *Type or *Graph.
+		key  string // input key of the type, if caching is enabled.
+	}
+)
 
 func (a *assets) add(path string, content []byte) {
 	if a.files == nil {
@@ -1023,6 +1247,156 @@ func (a *assets) addDir(path string) {
 	a.dirs[path] = struct{}{}
 }
 
+// skip adds a file whose template execution was skipped to the assets.
+func (a *assets) skip(path string, content []byte) {
+	if a.skipped == nil {
+		a.skipped = make(map[string][]byte)
+	}
+	a.skipped[path] = content
+}
+
+// record records the cache inputs of an executed job.
+func (a *assets) record(j *genJob, in, imports *ImportTracker) {
+	if a.entries == nil {
+		a.entries = make(map[string]*cacheEntry)
+	}
+	a.entries[j.path] = &cacheEntry{Key: j.key, In: newImportState(in).hash(), Imports: newImportState(imports)}
+}
+
+// execute executes the given jobs in parallel, and adds their output to the assets.
+//
+// The output is identical to a serial execution of the jobs. The import-tracking functions
+// that are used by the templates hold a state that is shared between executions in the serial
+// path. Hence, each job is executed by a worker with an empty state, and the jobs that used the
+// state before renewing it are executed again (in order) with the state left by the previous job.
+//
+// Jobs of types that were not changed since the previous run are not executed, if they are given
+// the same imports state as in the previous run, and the state they left is restored from the cache.
+func (a *assets) execute(jobs []*genJob, target string, cache *genCache) error {
+	// The templates are initialized for each codegen run, and so is the imports state.
+	*importTracker = *newImportTracker()
+	var (
+		prev   = make([]*cacheEntry, len(jobs))
+		cached = make([][]byte, len(jobs))
+	)
+	for i, j := range jobs {
+		if rel, err := filepath.Rel(target, j.path); err == nil {
+			prev[i], cached[i], _ = cache.fresh(rel, j.path, j.key)
+		}
+	}
+	n := workers(len(jobs))
+	if n == 1 {
+		for i, j := range jobs {
+			if e := prev[i]; e != nil && e.In == newImportState(importTracker).hash() {
+				*importTracker = *e.Imports.tracker()
+				a.skip(j.path, cached[i])
+				continue
+			}
+			var in *ImportTracker
+			if cache != nil {
+				in = importTracker.clone()
+			}
+			b := bytes.NewBuffer(nil)
+			if err := templates.ExecuteTemplate(b, j.name, j.data); err != nil {
+				return fmt.Errorf("execute template %q: %w", j.name, err)
+			}
+			a.add(j.path, b.Bytes())
+			if cache != nil {
+				a.record(j, in, importTracker)
+			}
+		}
+		return nil
+	}
+	var (
+		pool    = make([]*templateWorker, n)
+		out     = make([][]byte, len(jobs))
+		imports = make([]*ImportTracker, len(jobs))
+		depends = make([]bool, len(jobs))
+	)
+	for i := range pool {
+		w, err := templates.worker()
+		if err != nil {
+			return err
+		}
+		pool[i] = w
+	}
+	err := parallel(len(jobs), n, func(w, i int) (err error) {
+		// Cached jobs are executed below, if their imports state was changed.
+		if prev[i] != nil {
+			return nil
+		}
+		b := bytes.NewBuffer(nil)
+		imports[i], depends[i], err = pool[w].execute(b, jobs[i].name, jobs[i].data, newImportTracker())
+		if err != nil && !depends[i] {
+			return fmt.Errorf("execute template %q: %w", jobs[i].name, err)
+		}
+		out[i] = b.Bytes()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	state := importTracker.clone()
+	for i, j := range jobs {
+		if e := prev[i]; e != nil {
+			if e.In == newImportState(state).hash() {
+				state = e.Imports.tracker()
+				a.skip(j.path, cached[i])
+				continue
+			}
+			depends[i] = true
+		}
+		in := state
+		if depends[i] {
+			b := bytes.NewBuffer(nil)
+			if imports[i], _, err = pool[0].execute(b, j.name, j.data, state.clone()); err != nil {
+				return fmt.Errorf("execute template %q: %w", j.name, err)
+			}
+			out[i] = b.Bytes()
+		}
+		state = imports[i]
+		a.add(j.path, out[i])
+		if cache != nil {
+			a.record(j, in, state)
+		}
+	}
+	// Keep the state of the shared tracker as it is left by the serial execution.
+	*importTracker = *state
+	return nil
+}
+
+// skipUnchanged moves the files that were not changed since
+// the previous codegen run from the assets to the skipped list.
+func (a *assets) skipUnchanged(target string, cache *genCache) {
+	changed := make(map[string]bool)
+	for path, content := range a.files {
+		rel, err := filepath.Rel(target, path)
+		if err != nil {
+			changed[filepath.Dir(path)] = true
+			continue
+		}
+		out, ok := cache.unchanged(rel, path, content)
+		if !ok {
+			changed[filepath.Dir(path)] = true
+			continue
+		}
+		// The inputs of the file may be changed, even if its output was not.
+		cache.set(rel, a.entries[path], content, out)
+		a.skip(path, out)
+		if a.raw == nil {
+			a.raw = make(map[string][]byte)
+		}
+		a.raw[path] = content
+	}
+	for path := range a.raw {
+		delete(a.files, path)
+		// Skipped files in packages without changes are left untouched.
+		if !changed[filepath.Dir(path)] {
+			delete(a.raw, path)
+		}
+	}
+}
+
 // write files and dirs in the assets.
 func (a assets) write() error {
 	for dir := range a.dirs {
@@ -1030,26 +1404,98 @@ func (a assets) write() error {
 			return fmt.Errorf("create dir %q: %w", dir, err)
 		}
 	}
+	// "goimports" resolves missing imports using the sibling files of the package. Therefore,
+	// skipped files that are siblings of changed files are written in their unformatted form,
+	// as in a full codegen, and restored after the formatting. See assets.format for more info.
+	files := make(map[string][]byte, len(a.files)+len(a.raw))
 	for path, content := range a.files {
-		if err := os.WriteFile(path, content, 0644); err != nil {
-			return fmt.Errorf("write file %q: %w", path, err)
+		files[path] = content
+	}
+	for path, content := range a.raw {
+		files[path] = content
+	}
+	return writeFiles(files)
+}
+
+// format runs "goimports" on all assets in parallel, and records the formatted files in the
+// cache. The formatted files are written only after all files were processed, as "goimports"
+// reads the sibling files of the package for resolving missing imports.
+func (a assets) format(target string, cache *genCache) error {
+	var (
+		paths = a.paths()
+		n     = workers(len(paths))
+		out   = make([][]byte, len(paths))
+	)
+	err := parallel(len(paths), n, func(_, i int) error {
+		src, err := imports.Process(paths[i], a.files[paths[i]], nil)
+		if err != nil {
+			return fmt.Errorf("format file %s: %w", paths[i], err)
 		}
+		out[i] = src
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	files := make(map[string][]byte, len(paths)+len(a.raw))
+	for i, path := range paths {
+		files[path] = out[i]
+		if rel, err := filepath.Rel(target, path); err == nil {
+			cache.set(rel, a.entries[path], a.files[path], out[i])
+		}
+	}
+	for path := range a.raw {
+		files[path] = a.skipped[path]
+	}
+	return writeFiles(files)
 }
 
-// format runs "goimports" on all assets.
-func (a assets) format() error {
+// writeFiles writes the given files in parallel.
+func writeFiles(files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return parallel(len(paths), workers(len(paths)), func(_, i int) error {
+		if err := os.WriteFile(paths[i], files[paths[i]], 0644); err != nil {
+			return fmt.Errorf("write file %q: %w", paths[i], err)
+		}
+		return nil
+	})
+}
+
+// removeStale removes files that were generated by the previous codegen run and are not
+// generated anymore (e.g. files of deleted schemas or removed templates), and their
+// directories if they were left empty.
+func (a assets) removeStale(target string, cache *genCache) {
+	all := make(map[string][]byte, len(a.files)+len(a.skipped))
 	for path, content := range a.files {
-		src, err := imports.Process(path, content, nil)
-		if err != nil {
-			return fmt.Errorf("format file %s: %w", path, err)
+		all[path] = content
+	}
+	for path, content := range a.skipped {
+		all[path] = content
+	}
+	for _, path := range cache.stale(target, all) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("remove stale file %s: %s\n", path, err)
+			continue
 		}
-		if err := os.WriteFile(path, src, 0644); err != nil {
-			return fmt.Errorf("write file %s: %w", path, err)
+		// Remove the directory only if it is empty.
+		if dir := filepath.Dir(path); dir != filepath.Clean(target) {
+			_ = os.Remove(dir)
 		}
 	}
-	return nil
+}
+
+// paths returns the sorted paths of the files in the assets.
+func (a assets) paths() []string {
+	paths := make([]string, 0, len(a.files))
+	for path := range a.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
 }
 
 // expect panics if the condition is false.
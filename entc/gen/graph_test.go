@@ -5,12 +5,21 @@
 package gen
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"text/template"
+	"time"
 
+	gschema "entgo.io/ent/dialect/gremlin/schema"
+	"entgo.io/ent/entc/annotation"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -258,6 +267,54 @@ func TestNewGraphThroughDuplicates(t *testing.T) {
 	require.EqualError(t, err, `entc/gen: resolving edges: edge User.groups defined with Through("group_edges", T1.Type), but schema User already has an edge named group_edges`)
 }
 
+func TestNewGraphEdgeCounters(t *testing.T) {
+	post := &load.Schema{
+		Name: "Post",
+		Fields: []*load.Field{
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "comment_count", Info: &field.TypeInfo{Type: field.TypeInt}, Default: true},
+		},
+		Edges: []*load.Edge{
+			{Name: "comments", Type: "Comment", Counter: "comment_count"},
+		},
+	}
+	comment := &load.Schema{
+		Name: "Comment",
+		Edges: []*load.Edge{
+			{Name: "post", Type: "Post", Inverse: true, RefName: "comments", Unique: true},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, comment)
+	require.NoError(t, err)
+	p, c := graph.Nodes[0], graph.Nodes[1]
+	require.Equal(t, p.Fields[1], p.Edges[0].Counter)
+	require.Equal(t, p, p.Edges[0].CounterType())
+	require.Equal(t, []*Edge{p.Edges[0]}, p.CounterEdges())
+	require.Equal(t, []*Edge{p.Edges[0]}, c.CountedBy())
+	require.Equal(t, []*Edge{p.Edges[0]}, c.Edges[0].Counters(), "inverse edge holds the counter of its reference")
+	require.Empty(t, p.CountedBy())
+	require.True(t, p.Fields[1].IsCounter())
+	require.False(t, p.Fields[1].SupportsMutationAdd(), "counters are read-only")
+	require.Equal(t, []*Field{p.Fields[0]}, p.MutableFields(), "counters are read-only")
+
+	for counter, msg := range map[string]string{
+		"title":    `edge Post.comments: counter field "title" must be an integer field`,
+		"comments": `edge Post.comments: counter field "comments" was not found`,
+	} {
+		post.Edges[0].Counter = counter
+		_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, comment)
+		require.EqualError(t, err, "entc/gen: resolving edge counters: "+msg)
+	}
+	post.Edges[0].Counter, post.Fields[1].Default = "comment_count", false
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, comment)
+	require.EqualError(t, err, `entc/gen: resolving edge counters: edge Post.comments: counter field "comment_count" must have a default value`)
+	post.Fields[1].Default = true
+	post.Edges[0].Counter = ""
+	comment.Edges[0].Counter = "comment_count"
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, comment)
+	require.EqualError(t, err, "entc/gen: resolving edge counters: edge Comment.post: counter is allowed only on non-unique edges")
+}
+
 func TestRelation(t *testing.T) {
 	require := require.New(t)
 	_, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, T1)
@@ -496,6 +553,361 @@ func TestGraph_Gen(t *testing.T) {
 	}
 }
 
+func TestGraph_GenParallel(t *testing.T) {
+	schemas := []*load.Schema{
+		{
+			Name: "T1",
+			Fields: []*load.Field{
+				{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true},
+				{Name: "expired_at", Info: &field.TypeInfo{Type: field.TypeTime}, Nillable: true, Optional: true},
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			},
+			Edges: []*load.Edge{
+				{Name: "t2", Type: "T2"},
+			},
+		},
+		{
+			Name: "T2",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "T1", RefName: "t2", Unique: true, Inverse: true},
+			},
+		},
+	}
+	gen := func(t *testing.T, target string, schemas ...*load.Schema) {
+		graph, err := NewGraph(&Config{
+			Package:  "entc/gen",
+			Target:   target,
+			Storage:  drivers[0],
+			IDType:   &field.TypeInfo{Type: field.TypeInt},
+			Features: []Feature{FeatureUpsert, FeatureSnapshot},
+		}, schemas...)
+		require.NoError(t, err)
+		require.NoError(t, graph.Gen())
+	}
+	read := func(t *testing.T, target string) map[string]string {
+		files := make(map[string]string)
+		err := filepath.Walk(target, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			buf, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(target, path)
+			files[rel] = string(buf)
+			return err
+		})
+		require.NoError(t, err)
+		return files
+	}
+	serial, parallel := t.TempDir(), t.TempDir()
+	t.Setenv(cacheEnv, "off")
+	prev := runtime.GOMAXPROCS(1)
+	gen(t, serial, schemas...)
+	runtime.GOMAXPROCS(4)
+	defer runtime.GOMAXPROCS(prev)
+	t.Setenv(cacheEnv, t.TempDir())
+	gen(t, parallel, schemas...)
+	require.Equal(t, read(t, serial), read(t, parallel), "parallel output must be identical to serial output")
+
+	// Unchanged files are skipped in the second run.
+	client := filepath.Join(parallel, "client.go")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(client, old, old))
+	gen(t, parallel, schemas...)
+	info, err := os.Stat(client)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(old), "unchanged file should not be written")
+	require.Equal(t, read(t, serial), read(t, parallel))
+
+	// Modified files on disk are regenerated.
+	require.NoError(t, os.WriteFile(client, []byte("package ent"), 0644))
+	gen(t, parallel, schemas...)
+	require.Equal(t, read(t, serial), read(t, parallel))
+
+	// Files of deleted schemas are removed.
+	t1 := &load.Schema{Name: "T1", Fields: schemas[0].Fields}
+	t.Setenv(cacheEnv, "off")
+	runtime.GOMAXPROCS(1)
+	gen(t, serial, t1)
+	t.Setenv(cacheEnv, t.TempDir())
+	runtime.GOMAXPROCS(4)
+	gen(t, parallel, schemas...)
+	gen(t, parallel, t1)
+	files := read(t, parallel)
+	for name := range files {
+		require.NotContains(t, name, "t2", "stale file was not removed")
+	}
+	require.Equal(t, read(t, serial), files)
+}
+
+func TestGraph_GenCache(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		executed map[string]bool
+	)
+	tmpl := MustParse(NewTemplate("executed").
+		Funcs(template.FuncMap{
+			"executed": func(t *Type) string {
+				mu.Lock()
+				defer mu.Unlock()
+				executed[t.Name] = true
+				return ""
+			},
+		}).
+		Parse(`{{ define "model/additional/executed" }}{{ executed $ }}{{ end }}`))
+	schemas := []*load.Schema{
+		{
+			Name:   "T1",
+			Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+			Edges:  []*load.Edge{{Name: "t2", Type: "T2"}},
+		},
+		{
+			Name:  "T2",
+			Edges: []*load.Edge{{Name: "owner", Type: "T1", RefName: "t2", Unique: true, Inverse: true}},
+		},
+		{
+			Name:   "T3",
+			Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+		},
+	}
+	gen := func(t *testing.T, cache, target string, schemas ...*load.Schema) map[string]bool {
+		executed = make(map[string]bool)
+		graph, err := NewGraph(&Config{
+			Package:   "entc/gen",
+			Target:    target,
+			Storage:   drivers[0],
+			IDType:    &field.TypeInfo{Type: field.TypeInt},
+			Templates: []*Template{tmpl},
+			Cache:     cache,
+		}, schemas...)
+		require.NoError(t, err)
+		require.NoError(t, graph.Gen())
+		return executed
+	}
+	read := func(t *testing.T, target string) map[string]string {
+		files := make(map[string]string)
+		err := filepath.Walk(target, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			buf, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(target, path)
+			files[rel] = string(buf)
+			return err
+		})
+		require.NoError(t, err)
+		return files
+	}
+	changed := []*load.Schema{
+		{
+			Name: "T1",
+			Fields: []*load.Field{
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+				{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}},
+			},
+			Edges: schemas[0].Edges,
+		},
+		schemas[1], schemas[2],
+	}
+	t.Setenv(cacheEnv, "")
+	expected := t.TempDir()
+	gen(t, "", expected, changed...)
+	prev := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(prev)
+	for _, procs := range []int{1, 4} {
+		t.Run(fmt.Sprint(procs), func(t *testing.T) {
+			runtime.GOMAXPROCS(procs)
+			cache, target := t.TempDir(), t.TempDir()
+			require.Equal(t, map[string]bool{"T1": true, "T2": true, "T3": true}, gen(t, cache, target, schemas...))
+			require.Empty(t, gen(t, cache, target, schemas...), "templates of unchanged types should not be executed")
+
+			// Types that are connected to a changed type are executed, and the rest are skipped.
+			where := filepath.Join(target, "t3", "where.go")
+			old := time.Now().Add(-time.Hour).Truncate(time.Second)
+			require.NoError(t, os.Chtimes(where, old, old))
+			require.Equal(t, map[string]bool{"T1": true, "T2": true}, gen(t, cache, target, changed...))
+			info, err := os.Stat(where)
+			require.NoError(t, err)
+			require.True(t, info.ModTime().Equal(old), "files of unchanged types should not be written")
+			require.Equal(t, read(t, expected), read(t, target))
+
+			// Modified files on disk are regenerated.
+			require.NoError(t, os.WriteFile(filepath.Join(target, "t3.go"), []byte("package ent"), 0644))
+			require.Equal(t, map[string]bool{"T3": true}, gen(t, cache, target, changed...))
+			require.Equal(t, read(t, expected), read(t, target))
+
+			// The environment variable overrides the configured cache.
+			t.Setenv(cacheEnv, "off")
+			require.Len(t, gen(t, cache, target, changed...), 3)
+			t.Setenv(cacheEnv, cache)
+			require.Empty(t, gen(t, "", target, changed...))
+		})
+	}
+
+	// Types that are connected to a changed type through another type
+	// (i.e. two hops away) are skipped, and their output is unchanged.
+	schemas = []*load.Schema{
+		{
+			Name:  "T1",
+			Edges: []*load.Edge{{Name: "t2", Type: "T2"}},
+		},
+		{
+			Name: "T2",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "T1", RefName: "t2", Unique: true, Inverse: true},
+				{Name: "t3", Type: "T3", Unique: true},
+			},
+		},
+		{
+			Name:   "T3",
+			Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+		},
+	}
+	changed = []*load.Schema{
+		schemas[0], schemas[1],
+		{
+			Name: "T3",
+			Fields: []*load.Field{
+				{Name: "id", Info: &field.TypeInfo{Type: field.TypeString}},
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Optional: true, Nillable: true},
+			},
+		},
+	}
+	t.Setenv(cacheEnv, "")
+	expected = t.TempDir()
+	gen(t, "", expected, changed...)
+	cache, target := t.TempDir(), t.TempDir()
+	require.Len(t, gen(t, cache, target, schemas...), 3)
+	require.Equal(t, map[string]bool{"T2": true, "T3": true}, gen(t, cache, target, changed...))
+	require.Equal(t, read(t, expected), read(t, target))
+}
+
+func TestGraph_GenTypeFeatures(t *testing.T) {
+	t.Setenv("ENTCACHE", "off")
+	target := t.TempDir()
+	ant := annotation.Features("sql/upsert")
+	graph, err := NewGraph(&Config{
+		Package:      "entc/gen",
+		Target:       target,
+		Storage:      drivers[0],
+		IDType:       &field.TypeInfo{Type: field.TypeInt},
+		Features:     []Feature{FeatureUpsert, FeatureLock},
+		TypeFeatures: []string{"sql/lock"},
+	}, &load.Schema{
+		Name:        "T1",
+		Fields:      []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+		Annotations: map[string]any{ant.Name(): ant},
+	}, &load.Schema{
+		Name:   "T2",
+		Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+	for _, tt := range []struct {
+		file, api string
+		exists    bool
+	}{
+		{"t1_create.go", "OnConflict", true},
+		{"t1_query.go", "ForUpdate", false},
+		{"t2_create.go", "OnConflict", false},
+		{"t2_query.go", "ForUpdate", true},
+	} {
+		buf, err := os.ReadFile(filepath.Join(target, tt.file))
+		require.NoError(t, err)
+		require.Equal(t, tt.exists, bytes.Contains(buf, []byte(tt.api)), "%s: %s", tt.file, tt.api)
+	}
+}
+
+func TestGraph_VertexLabels(t *testing.T) {
+	require := require.New(t)
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, T1, T2, &load.Schema{
+		Name: "T3",
+		Fields: []*load.Field{
+			{Name: "first", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "last", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "email", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true, StorageKey: "mail"},
+		},
+		Edges: []*load.Edge{
+			{Name: "owner", Type: "T1", Unique: true},
+		},
+		Indexes: []*load.Index{
+			{Fields: []string{"first", "last"}, Unique: true},
+			{Fields: []string{"first"}, Edges: []string{"owner"}},
+		},
+	})
+	require.NoError(err)
+	labels, err := graph.VertexLabels()
+	require.NoError(err)
+	require.Len(labels, 3)
+
+	t1 := labels[0]
+	require.Equal("t1", t1.Name)
+	require.Len(t1.Properties, 3)
+	require.Equal(&gschema.Property{Name: "age", Type: field.TypeInt}, t1.Properties[0])
+	require.Equal(&gschema.Property{Name: "expired_at", Type: field.TypeTime}, t1.Properties[1])
+	require.Equal(&gschema.Property{Name: "name", Type: field.TypeString}, t1.Properties[2])
+	require.Equal([]*gschema.EdgeLabel{
+		{Name: "t1_t2", Multiplicity: gschema.Simple},
+		{Name: "t1_t1", Multiplicity: gschema.One2One},
+		{Name: "t1_t2_o2o", Multiplicity: gschema.One2One},
+		{Name: "t1_o2m", Multiplicity: gschema.One2Many},
+		{Name: "t1_m2o", Multiplicity: gschema.Many2One},
+		{Name: "t1_t2_m2o", Multiplicity: gschema.Many2One},
+		{Name: "t1_t2_o2m", Multiplicity: gschema.One2Many},
+		{Name: "t1_t2_m2m", Multiplicity: gschema.Simple},
+		{Name: "t1_t1_m2m", Multiplicity: gschema.Simple},
+	}, t1.Edges)
+
+	t2 := labels[1]
+	require.Equal("t2", t2.Name)
+	require.Equal([]*gschema.Property{{Name: "active", Type: field.TypeBool}}, t2.Properties)
+	require.Equal([]*gschema.EdgeLabel{{Name: "t2_t2_m2m_to", Multiplicity: gschema.Simple}}, t2.Edges)
+
+	t3 := labels[2]
+	require.Equal("t3", t3.Name)
+	require.Equal(&gschema.Property{Name: "mail", Type: field.TypeString, Unique: true}, t3.Properties[2])
+	// Indexes on edges are skipped.
+	require.Len(t3.Indexes, 1)
+	require.Equal("t3_first_last", t3.Indexes[0].Name)
+	require.True(t3.Indexes[0].Unique)
+	require.Equal(t3.Properties[:2], t3.Indexes[0].Properties)
+}
+
+func TestGraph_VertexLabelsConflict(t *testing.T) {
+	require := require.New(t)
+	t3 := &load.Schema{
+		Name: "T3",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeJSON}},
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, T1, T2, t3)
+	require.NoError(err)
+	labels, err := graph.VertexLabels()
+	require.NoError(err)
+	require.Equal(&gschema.Property{Name: "age", Type: field.TypeInt}, labels[0].Properties[0])
+	require.Equal(&gschema.Property{Name: "name", Type: field.TypeOther}, labels[0].Properties[2])
+	require.Equal(&gschema.Property{Name: "name", Type: field.TypeOther}, labels[2].Properties[0])
+	var b strings.Builder
+	m, err := gschema.NewMigrate(gschema.NewWriteDriver(&b))
+	require.NoError(err)
+	require.NoError(m.Create(context.Background(), labels...))
+	require.Contains(b.String(), "mgmt.makePropertyKey('name').dataType(Object.class)")
+
+	// Properties of different types cannot be indexed.
+	t3.Indexes = []*load.Index{{Fields: []string{"name"}}}
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, T1, T2, t3)
+	require.NoError(err)
+	_, err = graph.VertexLabels()
+	require.EqualError(err, `indexed property key "name" of vertex label "t3" is defined with different types: String, Object`)
+}
+
 func ensureStructTag(name string) Hook {
 	return func(next Generator) Generator {
 		return GenerateFunc(func(g *Graph) error {
@@ -44,6 +44,18 @@ func (i *ImportTracker) Empty() (empty string) {
 	return
 }
 
+// clone returns a copy of the tracker.
+func (i *ImportTracker) clone() *ImportTracker {
+	c := newImportTracker()
+	for path, name := range i.pathToName {
+		c.pathToName[path] = name
+	}
+	for name, path := range i.nameToPath {
+		c.nameToPath[name] = path
+	}
+	return c
+}
+
 func (i *ImportTracker) TypeIdent(t *field.TypeInfo) string {
 	path := t.PkgPath
 	if len(path) == 0 {
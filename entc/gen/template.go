@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"go/parser"
 	"go/token"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -132,6 +133,16 @@ var (
 			Format: "migrate/schema.go",
 			Skip:   func(g *Graph) bool { return !g.SupportMigrate() },
 		},
+		{
+			Name:   "dialect/gremlin/migrate",
+			Format: "migrate/migrate.go",
+			Skip:   func(g *Graph) bool { return g.Storage.Name != "gremlin" },
+		},
+		{
+			Name:   "dialect/gremlin/migrate/schema",
+			Format: "migrate/schema.go",
+			Skip:   func(g *Graph) bool { return g.Storage.Name != "gremlin" },
+		},
 		{
 			Name:   "predicate",
 			Format: "predicate/predicate.go",
@@ -277,6 +288,77 @@ func (t *Template) Funcs(funcMap template.FuncMap) *Template {
 	return t
 }
 
+// templateWorker executes templates concurrently with other workers.
+type templateWorker struct {
+	tmpl    *template.Template
+	imports *ImportTracker
+	// renewed indicates if the imports were renewed in the current execution,
+	// and dependent indicates if they were used before it, which means that the
+	// output depends on the imports left by the previous execution.
+	renewed, dependent bool
+}
+
+// worker returns a copy of the template that can be executed concurrently with other copies.
+// The import-tracking functions of the copy use the tracker of the worker, and "xtemplate"
+// executes the templates of the copy.
+func (t *Template) worker() (*templateWorker, error) {
+	c, err := t.Template.Clone()
+	if err != nil {
+		return nil, err
+	}
+	w := &templateWorker{tmpl: c}
+	c.Funcs(template.FuncMap{
+		"importLines": func() []string {
+			w.use()
+			return w.imports.ImportLines()
+		},
+		"addPath": func(paths ...string) string {
+			w.use()
+			return w.imports.AddPath(paths...)
+		},
+		"renewImports": func() string {
+			w.renewed = true
+			return w.imports.Empty()
+		},
+		"typeIdent": func(t *field.TypeInfo) string {
+			w.use()
+			return w.imports.TypeIdent(t)
+		},
+		"addImport": func(alias, path string) string {
+			w.use()
+			return w.imports.AddImport(alias, path)
+		},
+		"importAlias": func(path string) string {
+			w.use()
+			return w.imports.LocalNameOf(path)
+		},
+		"xtemplate": func(name string, v any) (string, error) {
+			buf := bytes.NewBuffer(nil)
+			if err := c.ExecuteTemplate(buf, name, v); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+	})
+	return w, nil
+}
+
+// use marks the execution as dependent if the imports are used before they were renewed.
+func (w *templateWorker) use() {
+	if !w.renewed {
+		w.dependent = true
+	}
+}
+
+// execute executes the named template with the given data and the imports left by a previous
+// execution, and writes its output to wr. It returns the imports left by this execution, and
+// reports if the output depends on the given imports.
+func (w *templateWorker) execute(wr io.Writer, name string, data any, imports *ImportTracker) (*ImportTracker, bool, error) {
+	w.imports, w.renewed, w.dependent = imports, false, false
+	err := w.tmpl.ExecuteTemplate(wr, name, data)
+	return w.imports, w.dependent, err
+}
+
 // SkipIf allows registering a function to determine if the template needs to be skipped or not.
 func (t *Template) SkipIf(cond func(*Graph) bool) *Template {
 	t.condition = cond
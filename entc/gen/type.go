@@ -22,6 +22,7 @@ import (
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/entc/annotation"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -61,6 +62,11 @@ type (
 			ID       []*Field
 			To, From *Edge
 		}
+		// counted holds the edges (of other types) that count this type.
+		counted []*Edge
+		// features holds the features that can be selected per type,
+		// and reports if they are enabled for this type.
+		features map[string]bool
 	}
 
 	// Field holds the information of a type field used for the templates.
@@ -101,6 +107,8 @@ type (
 		Annotations Annotations
 		// referenced foreign-key.
 		fk *ForeignKey
+		// counted edge, if the field is a counter (see edge.Counter).
+		counter *Edge
 	}
 
 	// Edge of a graph between two types.
@@ -142,6 +150,9 @@ type (
 		// Annotations that were defined for the edge in the schema.
 		// The mapping is from the Annotation.Name() to a JSON decoded object.
 		Annotations Annotations
+		// Counter holds the field that counts the edges of this type.
+		// It is stored on the type that declares the edge in the schema.
+		Counter *Field
 	}
 
 	// Relation holds the relational database information for edges.
@@ -176,6 +187,17 @@ type (
 		Annotations Annotations
 	}
 
+	// FullTextIndex holds the information for full-text indexes of types.
+	// It's exported only because it's used by the codegen templates and
+	// should not be used beside that.
+	FullTextIndex struct {
+		*Index
+		// Fields are the indexed fields.
+		Fields []*Field
+		// Config is the text search configuration of the index.
+		Config string
+	}
+
 	// ForeignKey holds the information for foreign-key columns of types.
 	// It's exported only because it's used by the codegen templates and
 	// should not be used beside that.
@@ -266,9 +288,151 @@ func NewType(c *Config, schema *load.Schema) (*Type, error) {
 			typ.fields[f.Name] = tf
 		}
 	}
+	if err := typ.checkExclusions(); err != nil {
+		return nil, err
+	}
+	if err := typ.checkTriggers(); err != nil {
+		return nil, err
+	}
+	if err := typ.checkPartition(); err != nil {
+		return nil, err
+	}
+	if err := typ.setupFeatures(); err != nil {
+		return nil, err
+	}
 	return typ, nil
 }
 
+// setupFeatures resolves the features that can be selected per type. They are enabled for types
+// that list them in their annotation.Features annotation. Types without this annotation use the
+// Config.TypeFeatures, or all the features that are enabled globally in case it is nil.
+func (t *Type) setupFeatures() error {
+	names := t.TypeFeatures
+	if ant := featuresAnnotate(t.Annotations); ant != nil {
+		for _, name := range ant.Names {
+			if err := checkTypeFeature(name); err != nil {
+				return fmt.Errorf("schema %q: %w", t.Name, err)
+			}
+		}
+		names = ant.Names
+	}
+	t.features = make(map[string]bool)
+	for _, f := range AllFeatures {
+		if f.perType {
+			t.features[f.Name] = names == nil && t.featureEnabled(f)
+		}
+	}
+	for _, name := range names {
+		t.features[name] = true
+	}
+	return nil
+}
+
+// checkExclusions checks the EXCLUDE constraints defined in the entsql.Annotation
+// of the type. Columns are not checked, as they can also be edge columns.
+func (t Type) checkExclusions() error {
+	ant := t.EntSQL()
+	if ant == nil {
+		return nil
+	}
+	names := make(map[string]bool, len(ant.Exclusions))
+	for _, e := range ant.Exclusions {
+		switch {
+		case e.Name == "":
+			return fmt.Errorf("exclusion constraint of type %q must have a name", t.Name)
+		case names[e.Name]:
+			return fmt.Errorf("duplicate exclusion constraint %q", e.Name)
+		case len(e.Elements) == 0:
+			return fmt.Errorf("exclusion constraint %q must have at least one element", e.Name)
+		}
+		names[e.Name] = true
+		for _, el := range e.Elements {
+			if (el.Column == "") == (el.Expr == "") || el.Op == "" {
+				return fmt.Errorf("element of exclusion constraint %q must have an operator, and either a column or an expression", e.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPartition checks the partitioning defined in the entsql.Annotation of the type.
+func (t Type) checkPartition() error {
+	ant := t.EntSQL()
+	if ant == nil || ant.Partition == nil {
+		return nil
+	}
+	p := ant.Partition
+	switch {
+	case p.Type != entsql.PartitionRange && p.Type != entsql.PartitionList && p.Type != entsql.PartitionHash:
+		return fmt.Errorf("invalid partition type %q for type %q", p.Type, t.Name)
+	case len(p.Columns) == 0:
+		return fmt.Errorf("partition key of type %q must have at least one column", t.Name)
+	case p.Type == entsql.PartitionHash && (p.Count <= 0 || len(p.Partitions) > 0):
+		return fmt.Errorf("hash partitioning of type %q must define the number of partitions (and not their bounds)", t.Name)
+	case p.Type != entsql.PartitionHash && p.Count != 0:
+		return fmt.Errorf("number of partitions can be set only for hash partitioning (type %q)", t.Name)
+	}
+	names := make(map[string]bool, len(p.Partitions))
+	for _, b := range p.Partitions {
+		switch {
+		case b.Name == "":
+			return fmt.Errorf("partition of type %q must have a name", t.Name)
+		case names[b.Name]:
+			return fmt.Errorf("duplicate partition %q for type %q", b.Name, t.Name)
+		case p.Type == entsql.PartitionList && (len(b.Values) == 0 || b.From != "" || b.To != ""):
+			return fmt.Errorf("list partition %q must define its values (and not range bounds)", b.Name)
+		case p.Type == entsql.PartitionRange && len(b.Values) > 0:
+			return fmt.Errorf("range partition %q must define its bounds (and not values)", b.Name)
+		}
+		names[b.Name] = true
+	}
+	return nil
+}
+
+// checkTriggers checks the triggers and functions defined in the entsql.Annotation of the type.
+func (t Type) checkTriggers() error {
+	ant := t.EntSQL()
+	if ant == nil {
+		return nil
+	}
+	names := make(map[string]bool, len(ant.Triggers))
+	for _, tr := range ant.Triggers {
+		switch {
+		case tr.Name == "":
+			return fmt.Errorf("trigger of type %q must have a name", t.Name)
+		case names[tr.Name]:
+			return fmt.Errorf("duplicate trigger %q for type %q", tr.Name, t.Name)
+		case tr.Timing != entsql.TriggerBefore && tr.Timing != entsql.TriggerAfter && tr.Timing != entsql.TriggerInsteadOf:
+			return fmt.Errorf("invalid timing %q for trigger %q", tr.Timing, tr.Name)
+		case len(tr.Events) == 0:
+			return fmt.Errorf("trigger %q must have at least one event", tr.Name)
+		case tr.Function != "" && (tr.Body != "" || len(tr.Bodies) > 0):
+			return fmt.Errorf("trigger %q cannot have both a function and a body", tr.Name)
+		case tr.Function == "" && tr.Body == "" && len(tr.Bodies) == 0:
+			return fmt.Errorf("trigger %q must have a function or a body", tr.Name)
+		}
+		for _, e := range tr.Events {
+			if e != entsql.TriggerInsert && e != entsql.TriggerUpdate && e != entsql.TriggerDelete {
+				return fmt.Errorf("invalid event %q for trigger %q", e, tr.Name)
+			}
+		}
+		names[tr.Name] = true
+	}
+	functions := make(map[string]bool, len(ant.Functions))
+	for _, f := range ant.Functions {
+		switch {
+		case f.Name == "":
+			return fmt.Errorf("function of type %q must have a name", t.Name)
+		case functions[f.Name]:
+			return fmt.Errorf("duplicate function %q for type %q", f.Name, t.Name)
+		case f.Returns == "" || f.Body == "":
+			return fmt.Errorf("function %q must define its return type and body", f.Name)
+		}
+		functions[f.Name] = true
+	}
+	return nil
+}
+
 // IsEdgeSchema indicates if the type (schema) is used as an edge-schema.
 // i.e. is being used by an edge (or its inverse) with edge.Through modifier.
 func (t Type) IsEdgeSchema() bool {
@@ -306,6 +470,23 @@ func (t Type) EntSQL() *entsql.Annotation {
 	return sqlAnnotate(t.Annotations)
 }
 
+// FeatureEnabled reports if the given feature name is enabled for the type. It overrides
+// Config.FeatureEnabled in templates that are executed on types, and allows generating
+// the features that can be selected per type (e.g. sql/upsert) only for some of them:
+//
+//	{{ with $.FeatureEnabled "sql/upsert" }}
+//		...
+//	{{ end }}
+//
+// These features are resolved once, when the type is created. See Type.setupFeatures for
+// more info. Other features are always enabled globally.
+func (t Type) FeatureEnabled(name string) (bool, error) {
+	if enabled, ok := t.features[name]; ok {
+		return enabled, nil
+	}
+	return t.Config.FeatureEnabled(name)
+}
+
 // Package returns the package name of this node.
 func (t Type) Package() string {
 	if name := t.PackageAlias(); name != "" {
@@ -444,6 +625,59 @@ func (t Type) EdgesWithID() (edges []*Edge) {
 	return
 }
 
+// RecursiveEdges returns all edges that point to the type itself (e.g. parent
+// and children). These edges can be traversed recursively in SQL queries.
+func (t Type) RecursiveEdges() (edges []*Edge) {
+	if t.HasCompositeID() {
+		return nil
+	}
+	for _, e := range t.Edges {
+		if e.Type.Name == t.Name {
+			edges = append(edges, e)
+		}
+	}
+	return
+}
+
+// CounterEdges returns all edges of the type that maintain a counter field
+// (defined using edge.Counter). The counters can be recomputed using Recount.
+func (t Type) CounterEdges() (edges []*Edge) {
+	for _, e := range t.Edges {
+		if e.Counter != nil {
+			edges = append(edges, e)
+		}
+	}
+	return
+}
+
+// CountedBy returns the counter edges of other types (or the type itself)
+// that point to this type. Their counters are decremented when nodes of
+// this type are deleted.
+func (t Type) CountedBy() []*Edge {
+	return t.counted
+}
+
+// FullTextIndexes returns the full-text indexes of the type (defined
+// using entsql.FullText). They are used to generate search predicates.
+func (t Type) FullTextIndexes() (indexes []*FullTextIndex) {
+	for _, idx := range t.Indexes {
+		ant := sqlIndexAnnotate(idx.Annotations)
+		if ant == nil || !ant.FullText {
+			continue
+		}
+		ft := &FullTextIndex{Index: idx, Config: ant.FullTextConfig}
+		for _, c := range idx.Columns {
+			for _, f := range t.Fields {
+				if f.StorageKey() == c {
+					ft.Fields = append(ft.Fields, f)
+				}
+			}
+		}
+		indexes = append(indexes, ft)
+	}
+	return
+}
+
 // RuntimeMixin returns schema mixin that needs to be loaded at
 // runtime. For example, for default values, validators or hooks.
 func (t Type) RuntimeMixin() bool {
@@ -544,6 +778,11 @@ func (t Type) MutableFields() []*Field {
 		if e, err := f.Edge(); err == nil && e.Immutable {
 			continue
 		}
+		// Generated columns are computed by the database,
+		// and counters are maintained by their edges.
+		if f.IsGenerated() || f.IsCounter() {
+			continue
+		}
 		fields = append(fields, f)
 	}
 	return fields
@@ -636,7 +875,8 @@ func (t *Type) AddIndex(idx *load.Index) error {
 	if len(idx.Fields) == 0 && len(idx.Edges) == 0 {
 		return errors.New("missing fields or edges")
 	}
-	switch ant := sqlIndexAnnotate(idx.Annotations); {
+	ant := sqlIndexAnnotate(idx.Annotations)
+	switch {
 	case ant == nil:
 	case len(ant.PrefixColumns) != 0 && ant.Prefix != 0:
 		return fmt.Errorf("index %q cannot contain both entsql.Prefix and entsql.PrefixColumn in annotation", index.Name)
@@ -644,6 +884,12 @@ func (t *Type) AddIndex(idx *load.Index) error {
 		return fmt.Errorf("entsql.Prefix is used in a multicolumn index %q. Use entsql.PrefixColumn instead", index.Name)
 	case len(ant.PrefixColumns) > len(idx.Fields)+len(idx.Fields):
 		return fmt.Errorf("index %q has more entsql.PrefixColumn than column in its definitions", index.Name)
+	case ant.FullText && idx.Unique:
+		return fmt.Errorf("full-text index on %q cannot be unique", idx.Fields)
+	case ant.FullText && len(idx.Edges) > 0:
+		return fmt.Errorf("full-text index on %q cannot contain edges", idx.Fields)
+	case ant.Spatial && (idx.Unique || len(idx.Fields) != 1 || len(idx.Edges) > 0):
+		return fmt.Errorf("spatial index on %q must be a non-unique index on a single field", idx.Fields)
 	}
 	for _, name := range idx.Fields {
 		var f *Field
@@ -652,6 +898,12 @@ func (t *Type) AddIndex(idx *load.Index) error {
 		} else if f = t.fields[name]; f == nil {
 			return fmt.Errorf("unknown index field %q", name)
 		}
+		if ant != nil && ant.FullText && f.Type.Type != field.TypeString {
+			return fmt.Errorf("full-text index field %q must be a string field", name)
+		}
+		if ant != nil && ant.Spatial && f.Type.Type != field.TypeGeometry {
+			return fmt.Errorf("spatial index field %q must be a geometry field", name)
+		}
 		index.Columns = append(index.Columns, f.StorageKey())
 	}
 	for _, name := range idx.Edges {
@@ -875,6 +1127,15 @@ func (t Type) NumHooks() int {
 	return 0
 }
 
+// Position returns the position information of the type declaration in the
+// schema package, or nil if the type was not loaded from a schema package.
+func (t Type) Position() *load.Position {
+	if t.schema != nil {
+		return t.schema.Position
+	}
+	return nil
+}
+
 // HookPositions returns the position information of hooks declared in the type schema.
 func (t Type) HookPositions() []*load.Position {
 	if t.schema != nil {
@@ -962,6 +1223,8 @@ func (t *Type) checkField(tf *Field, f *load.Field) (err error) {
 		err = fmt.Errorf("field %q redeclared for type %q", f.Name, t.Name)
 	case f.Sensitive && f.Tag != "":
 		err = fmt.Errorf("sensitive field %q cannot have struct tags", f.Name)
+	case ant != nil && ant.NativeEnum != "" && f.Info.Type != field.TypeEnum:
+		err = fmt.Errorf("native enum type %q cannot be used by non-enum field %q", ant.NativeEnum, f.Name)
 	case f.Info.Type == field.TypeEnum:
 		if tf.Enums, err = tf.enums(f); err == nil && !tf.HasGoType() {
 			// Enum types should be named as follows: typepkg.Field.
@@ -971,6 +1234,8 @@ func (t *Type) checkField(tf *Field, f *load.Field) (err error) {
 		err = fmt.Errorf("GoType %q for field %q must be converted to the basic %q type for validators", tf.Type, f.Name, tf.Type.Type)
 	case ant != nil && ant.Default != "" && (ant.DefaultExpr != "" || ant.DefaultExprs != nil):
 		err = fmt.Errorf("field %q cannot have both default value and default expression annotations", f.Name)
+	case ant != nil && ant.Generated != nil && (f.Default || f.UpdateDefault || ant.Default != "" || ant.DefaultExpr != "" || ant.DefaultExprs != nil):
+		err = fmt.Errorf("generated field %q cannot have default values", f.Name)
 	}
 	return err
 }
@@ -1237,6 +1502,25 @@ func (f Field) IsJSON() bool { return f.Type != nil && f.Type.Type == field.Type
 // IsOther returns true if the field is an Other field.
 func (f Field) IsOther() bool { return f.Type != nil && f.Type.Type == field.TypeOther }
 
+// IsGeometry returns true if the field is a geometry (spatial) field.
+func (f Field) IsGeometry() bool { return f.Type != nil && f.Type.Type == field.TypeGeometry }
+
+// IsRange returns true if the field is a range field.
+func (f Field) IsRange() bool { return f.Type != nil && f.Type.Type == field.TypeRange }
+
+// RangeElemType returns the type of the elements of a range field. It is resolved from
+// the argument of the "Contains" method of its GoType (e.g. sqlrange.Time), and falls
+// back to "any" for types that do not implement it.
+func (f Field) RangeElemType() string {
+	if f.Type == nil || f.Type.RType == nil {
+		return "any"
+	}
+	if m, ok := f.Type.RType.Methods["Contains"]; ok && len(m.In) == 1 {
+		return m.In[0].Ident
+	}
+	return "any"
+}
+
 // IsString returns true if the field is a string field.
 func (f Field) IsString() bool { return f.Type != nil && f.Type.Type == field.TypeString }
 
@@ -1252,6 +1536,18 @@ func (f Field) IsInt64() bool { return f.Type != nil && f.Type.Type == field.Typ
 // IsEnum returns true if the field is an enum field.
 func (f Field) IsEnum() bool { return f.Type != nil && f.Type.Type == field.TypeEnum }
 
+// IsGenerated reports if the field is a generated (computed) column, and
+// therefore, it is read-only and excluded from INSERT and UPDATE statements.
+func (f Field) IsGenerated() bool {
+	ant := f.EntSQL()
+	return ant != nil && ant.Generated != nil
+}
+
+// IsCounter reports if the field is a counter of one of the type edges (defined
+// using edge.Counter). Counters are maintained by the edge mutations, and cannot
+// be set or incremented using the builders.
+func (f Field) IsCounter() bool { return f.counter != nil }
+
 // IsEdgeField reports if the given field is an edge-field (i.e. a foreign-key)
 // that was referenced by one of the edges.
 func (f Field) IsEdgeField() bool { return f.fk != nil }
@@ -1432,6 +1728,12 @@ func (f Field) Column() *schema.Column {
 	if ant := f.EntSQL(); ant != nil && ant.Collation != "" {
 		c.Collation = ant.Collation
 	}
+	if ant := f.EntSQL(); ant != nil && ant.Generated != nil {
+		c.Generated = ant.Generated
+	}
+	if ant := f.EntSQL(); ant != nil && ant.NativeEnum != "" {
+		c.NativeEnum = ant.NativeEnum
+	}
 	if f.def != nil {
 		c.SchemaType = f.def.SchemaType
 	}
@@ -1458,6 +1760,33 @@ func (f Field) size() int64 {
 	return 0
 }
 
+// MinLen returns the minimum length of a string or bytes field
+// defined in the schema using MinLen or NotEmpty, or 0 if not set.
+func (f Field) MinLen() int {
+	if f.def != nil {
+		return f.def.MinLen
+	}
+	return 0
+}
+
+// MaxLen returns the maximum length of a string or bytes field
+// defined in the schema using MaxLen, or 0 if not set.
+func (f Field) MaxLen() int64 {
+	if f.def != nil && f.def.Size != nil {
+		return *f.def.Size
+	}
+	return 0
+}
+
+// Patterns returns the regular expressions a string
+// field must match, defined in the schema using Match.
+func (f Field) Patterns() []string {
+	if f.def != nil {
+		return f.def.Patterns
+	}
+	return nil
+}
+
 // PK is like Column, but for table primary key.
 func (f Field) PK() *schema.Column {
 	c := &schema.Column{
@@ -1560,7 +1889,7 @@ func (f Field) SignedType() (*field.TypeInfo, error) {
 
 // SupportsMutationAdd reports if the field supports the mutation "Add(T) T" interface.
 func (f Field) SupportsMutationAdd() bool {
-	if !f.Type.Numeric() || f.IsEdgeField() {
+	if !f.Type.Numeric() || f.IsEdgeField() || f.IsGenerated() || f.IsCounter() {
 		return false
 	}
 	return f.ConvertedToBasic() || f.implementsAdder()
@@ -1599,7 +1928,7 @@ func rtypeEqual(t1, t2 *field.RType) bool {
 
 // SupportsMutationAppend reports if the field supports the mutation append operation.
 func (f Field) SupportsMutationAppend() bool {
-	return f.IsJSON() && f.Type.RType != nil && f.Type.RType.Kind == reflect.Slice
+	return f.IsJSON() && f.Type.RType != nil && f.Type.RType.Kind == reflect.Slice && !f.IsGenerated()
 }
 
 var (
@@ -1768,6 +2097,36 @@ func (e Edge) ColumnConstant() string { return pascal(e.Name) + "Column" }
 // PKConstant returns the constant name of the primary key. Used for M2M edges.
 func (e Edge) PKConstant() string { return pascal(e.Name) + "PrimaryKey" }
 
+// Counters returns the edges that hold a counter for the relation of this edge.
+// That is, the edge itself, and its reference edge (if it was defined with a
+// counter as well).
+func (e *Edge) Counters() (edges []*Edge) {
+	if e.Counter != nil {
+		edges = append(edges, e)
+	}
+	if e.Ref != nil && e.Ref.Counter != nil {
+		edges = append(edges, e.Ref)
+	}
+	return
+}
+
+// CounterType returns the type that holds the counter field of the edge.
+func (e Edge) CounterType() *Type {
+	if e.Counter == nil {
+		return nil
+	}
+	return e.Counter.typ
+}
+
+// CounterRefIndex returns the index of the column in the join table that
+// references the counter type. Used for M2M edges.
+func (e Edge) CounterRefIndex() int {
+	if e.IsInverse() {
+		return 1
+	}
+	return 0
+}
+
 // HasConstraint indicates if this edge has a unique constraint check.
 // We check uniqueness when both-directions are unique or one of them.
 // Used by the Gremlin storage-layer.
@@ -2042,6 +2401,18 @@ func sqlAnnotate(annotation map[string]any) *entsql.Annotation {
 	return annotate
 }
 
+// featuresAnnotate extracts the annotation.Features annotation from a loaded annotation format.
+func featuresAnnotate(ants map[string]any) *annotation.FeaturesAnnotation {
+	annotate := &annotation.FeaturesAnnotation{}
+	if ants == nil || ants[annotate.Name()] == nil {
+		return nil
+	}
+	if buf, err := json.Marshal(ants[annotate.Name()]); err == nil {
+		_ = json.Unmarshal(buf, &annotate)
+	}
+	return annotate
+}
+
 // sqlIndexAnnotate extracts the entsql annotation from a loaded annotation format.
 func sqlIndexAnnotate(annotation map[string]any) *entsql.IndexAnnotation {
 	annotate := &entsql.IndexAnnotation{}
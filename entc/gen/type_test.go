@@ -7,6 +7,8 @@ package gen
 import (
 	"testing"
 
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/entc/annotation"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/field"
 
@@ -191,6 +193,23 @@ func TestType_TagTypes(t *testing.T) {
 	require.Equal(t, []string{"json", "sql", "yaml"}, tags)
 }
 
+func TestType_RecursiveEdges(t *testing.T) {
+	user, group := &Type{Name: "User"}, &Type{Name: "Group"}
+	user.Edges = []*Edge{
+		{Name: "groups", Type: group},
+		{Name: "friends", Type: user},
+		{Name: "parent", Type: user, Inverse: "children", Unique: true},
+	}
+	group.Edges = []*Edge{
+		{Name: "users", Type: user, Inverse: "groups"},
+	}
+	edges := user.RecursiveEdges()
+	require.Len(t, edges, 2)
+	require.Equal(t, "friends", edges[0].Name)
+	require.Equal(t, "parent", edges[1].Name)
+	require.Empty(t, group.RecursiveEdges())
+}
+
 func TestType_Package(t *testing.T) {
 	tests := []struct {
 		name string
@@ -249,6 +268,208 @@ func TestType_AddIndex(t *testing.T) {
 	require.NoError(t, err, "valid index on M2O relation and field")
 }
 
+func TestType_FullTextIndexes(t *testing.T) {
+	typ, err := NewType(&Config{}, &load.Schema{
+		Name: "Post",
+		Fields: []*load.Field{
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "body", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "views", Info: &field.TypeInfo{Type: field.TypeInt}},
+		},
+	})
+	require.NoError(t, err)
+	typ.Edges = append(typ.Edges, &Edge{Name: "owner", Inverse: "posts", Rel: Relation{Type: M2O, Columns: []string{"owner_id"}}})
+	ant := func(a *entsql.IndexAnnotation) map[string]any {
+		return map[string]any{a.Name(): a}
+	}
+
+	err = typ.AddIndex(&load.Index{Unique: true, Fields: []string{"title"}, Annotations: ant(entsql.FullText())})
+	require.EqualError(t, err, `full-text index on ["title"] cannot be unique`)
+	err = typ.AddIndex(&load.Index{Fields: []string{"title"}, Edges: []string{"owner"}, Annotations: ant(entsql.FullText())})
+	require.EqualError(t, err, `full-text index on ["title"] cannot contain edges`)
+	err = typ.AddIndex(&load.Index{Fields: []string{"title", "views"}, Annotations: ant(entsql.FullText())})
+	require.EqualError(t, err, `full-text index field "views" must be a string field`)
+	require.Empty(t, typ.FullTextIndexes())
+
+	require.NoError(t, typ.AddIndex(&load.Index{Fields: []string{"views"}}))
+	require.NoError(t, typ.AddIndex(&load.Index{Fields: []string{"title", "body"}, Annotations: ant(&entsql.IndexAnnotation{FullText: true, FullTextConfig: "english"})}))
+	indexes := typ.FullTextIndexes()
+	require.Len(t, indexes, 1)
+	require.Equal(t, "post_title_body", indexes[0].Name)
+	require.Equal(t, "english", indexes[0].Config)
+	require.Equal(t, []*Field{typ.Fields[0], typ.Fields[1]}, indexes[0].Fields)
+}
+
+func TestType_SpatialIndexes(t *testing.T) {
+	typ, err := NewType(&Config{}, &load.Schema{
+		Name: "Place",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "location", Info: &field.TypeInfo{Type: field.TypeGeometry}},
+			{Name: "area", Info: &field.TypeInfo{Type: field.TypeGeometry}, Optional: true},
+		},
+	})
+	require.NoError(t, err)
+	ant := map[string]any{(&entsql.IndexAnnotation{}).Name(): entsql.Spatial()}
+	err = typ.AddIndex(&load.Index{Unique: true, Fields: []string{"location"}, Annotations: ant})
+	require.EqualError(t, err, `spatial index on ["location"] must be a non-unique index on a single field`)
+	err = typ.AddIndex(&load.Index{Fields: []string{"location", "area"}, Annotations: ant})
+	require.EqualError(t, err, `spatial index on ["location" "area"] must be a non-unique index on a single field`)
+	err = typ.AddIndex(&load.Index{Fields: []string{"name"}, Annotations: ant})
+	require.EqualError(t, err, `spatial index field "name" must be a geometry field`)
+	require.NoError(t, typ.AddIndex(&load.Index{Fields: []string{"location"}, Annotations: ant}))
+
+	require.True(t, typ.Fields[1].IsGeometry())
+	require.Empty(t, fieldOps(typ.Fields[1]))
+	require.Equal(t, nillableOps, fieldOps(typ.Fields[2]))
+}
+
+func TestType_Exclusions(t *testing.T) {
+	newType := func(ant *entsql.Annotation) error {
+		_, err := NewType(&Config{}, &load.Schema{
+			Name:        "Booking",
+			Fields:      []*load.Field{{Name: "during", Info: &field.TypeInfo{Type: field.TypeRange}}},
+			Annotations: map[string]any{ant.Name(): ant},
+		})
+		return err
+	}
+	require.NoError(t, newType(entsql.Exclude("no_overlap", entsql.ExcludeWith("room_id", "="), entsql.ExcludeWith("during", "&&"))))
+	require.EqualError(t, newType(entsql.Exclude("", entsql.ExcludeWith("during", "&&"))), `exclusion constraint of type "Booking" must have a name`)
+	require.EqualError(t, newType(entsql.Exclude("no_overlap")), `exclusion constraint "no_overlap" must have at least one element`)
+	require.EqualError(t, newType(entsql.Exclude("no_overlap", entsql.ExcludeWith("during", ""))), `element of exclusion constraint "no_overlap" must have an operator, and either a column or an expression`)
+	require.EqualError(t, newType(entsql.Exclude("no_overlap", &entsql.ExclusionElement{Column: "during", Expr: "during", Op: "&&"})), `element of exclusion constraint "no_overlap" must have an operator, and either a column or an expression`)
+	ant := entsql.Exclude("no_overlap", entsql.ExcludeWith("during", "&&")).Merge(entsql.Exclude("no_overlap", entsql.ExcludeWith("during", "&&"))).(entsql.Annotation)
+	require.Len(t, ant.Exclusions, 1, "constraints with the same name are overridden")
+	ant.Exclusions = append(ant.Exclusions, ant.Exclusions[0])
+	require.EqualError(t, newType(&ant), `duplicate exclusion constraint "no_overlap"`)
+}
+
+func TestType_Partition(t *testing.T) {
+	newType := func(p *entsql.Partition) error {
+		ant := &entsql.Annotation{Partition: p}
+		_, err := NewType(&Config{}, &load.Schema{
+			Name:        "Event",
+			Fields:      []*load.Field{{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}}},
+			Annotations: map[string]any{ant.Name(): ant},
+		})
+		return err
+	}
+	require.NoError(t, newType(entsql.PartitionBy(entsql.PartitionRange, "created_at").Partition))
+	require.NoError(t, newType(&entsql.Partition{Type: entsql.PartitionHash, Columns: []string{"id"}, Count: 4}))
+	require.NoError(t, newType(&entsql.Partition{
+		Type:       entsql.PartitionList,
+		Columns:    []string{"region"},
+		Partitions: []*entsql.PartitionBound{{Name: "eu", Values: []string{"'eu'"}}},
+	}))
+	require.EqualError(t, newType(&entsql.Partition{Type: "INTERVAL", Columns: []string{"id"}}), `invalid partition type "INTERVAL" for type "Event"`)
+	require.EqualError(t, newType(&entsql.Partition{Type: entsql.PartitionRange}), `partition key of type "Event" must have at least one column`)
+	require.EqualError(t, newType(&entsql.Partition{Type: entsql.PartitionHash, Columns: []string{"id"}}), `hash partitioning of type "Event" must define the number of partitions (and not their bounds)`)
+	require.EqualError(t, newType(&entsql.Partition{Type: entsql.PartitionRange, Columns: []string{"id"}, Count: 2}), `number of partitions can be set only for hash partitioning (type "Event")`)
+	require.EqualError(t, newType(&entsql.Partition{
+		Type:       entsql.PartitionRange,
+		Columns:    []string{"created_at"},
+		Partitions: []*entsql.PartitionBound{{Name: "p1", To: "'2023-01-01'"}, {Name: "p1"}},
+	}), `duplicate partition "p1" for type "Event"`)
+	require.EqualError(t, newType(&entsql.Partition{
+		Type:       entsql.PartitionList,
+		Columns:    []string{"region"},
+		Partitions: []*entsql.PartitionBound{{Name: "eu"}},
+	}), `list partition "eu" must define its values (and not range bounds)`)
+	require.EqualError(t, newType(&entsql.Partition{
+		Type:       entsql.PartitionRange,
+		Columns:    []string{"created_at"},
+		Partitions: []*entsql.PartitionBound{{Name: "p1", Values: []string{"1"}}},
+	}), `range partition "p1" must define its bounds (and not values)`)
+}
+
+func TestType_Triggers(t *testing.T) {
+	newType := func(ant *entsql.Annotation) error {
+		_, err := NewType(&Config{}, &load.Schema{
+			Name:        "User",
+			Fields:      []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+			Annotations: map[string]any{ant.Name(): ant},
+		})
+		return err
+	}
+	update := []entsql.TriggerEvent{entsql.TriggerUpdate}
+	require.NoError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Events: update, Body: "RETURN NEW;"})))
+	require.NoError(t, newType(&entsql.Annotation{
+		Triggers:  []*entsql.Trigger{{Name: "touch", Timing: entsql.TriggerAfter, Events: update, Function: "touch"}},
+		Functions: []*entsql.Function{{Name: "touch", Returns: "trigger", Body: "BEGIN RETURN NULL; END"}},
+	}))
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Timing: entsql.TriggerBefore, Events: update, Body: "RETURN NEW;"})), `trigger of type "User" must have a name`)
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: "DURING", Events: update, Body: "RETURN NEW;"})), `invalid timing "DURING" for trigger "touch"`)
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Body: "RETURN NEW;"})), `trigger "touch" must have at least one event`)
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Events: []entsql.TriggerEvent{"TRUNCATE"}, Body: "RETURN NEW;"})), `invalid event "TRUNCATE" for trigger "touch"`)
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Events: update})), `trigger "touch" must have a function or a body`)
+	require.EqualError(t, newType(entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Events: update, Body: "RETURN NEW;", Function: "touch"})), `trigger "touch" cannot have both a function and a body`)
+	ant := entsql.Triggers(&entsql.Trigger{Name: "touch", Timing: entsql.TriggerBefore, Events: update, Body: "RETURN NEW;"})
+	require.Len(t, ant.Merge(ant).(entsql.Annotation).Triggers, 1, "triggers with the same name are overridden")
+	ant.Triggers = append(ant.Triggers, ant.Triggers[0])
+	require.EqualError(t, newType(ant), `duplicate trigger "touch" for type "User"`)
+	require.EqualError(t, newType(&entsql.Annotation{Functions: []*entsql.Function{{Name: "touch", Body: "SELECT 1"}}}), `function "touch" must define its return type and body`)
+}
+
+func TestType_Features(t *testing.T) {
+	newType := func(c *Config, ant *annotation.FeaturesAnnotation) (*Type, error) {
+		s := &load.Schema{Name: "User"}
+		if ant != nil {
+			s.Annotations = map[string]any{ant.Name(): ant}
+		}
+		return NewType(c, s)
+	}
+	enabled := func(typ *Type, name string) bool {
+		ok, err := typ.FeatureEnabled(name)
+		require.NoError(t, err)
+		return ok
+	}
+	c := &Config{Features: []Feature{FeatureUpsert, FeaturePrivacy}}
+	typ, err := newType(c, nil)
+	require.NoError(t, err)
+	require.True(t, enabled(typ, "sql/upsert"), "global features are used by default")
+	require.False(t, enabled(typ, "sql/lock"))
+
+	typ, err = newType(c, annotation.Features("sql/lock"))
+	require.NoError(t, err)
+	require.True(t, enabled(typ, "sql/lock"), "annotation enables the feature")
+	require.False(t, enabled(typ, "sql/upsert"), "annotation overrides the global features")
+	require.True(t, enabled(typ, "privacy"), "global features are not affected")
+
+	c.TypeFeatures = []string{}
+	typ, err = newType(c, nil)
+	require.NoError(t, err)
+	require.False(t, enabled(typ, "sql/upsert"))
+	require.True(t, enabled(typ, "privacy"))
+	c.TypeFeatures = []string{"namedges"}
+	require.False(t, enabled(typ, "namedges"), "features are resolved when the type is created")
+	typ, err = newType(c, nil)
+	require.NoError(t, err)
+	require.True(t, enabled(typ, "namedges"))
+
+	_, err = typ.FeatureEnabled("unknown")
+	require.EqualError(t, err, `unexpected feature name "unknown"`)
+	_, err = newType(c, annotation.Features("privacy"))
+	require.EqualError(t, err, `schema "User": feature "privacy" cannot be selected per type`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0], TypeFeatures: []string{"sql/upsert", "entql"}})
+	require.EqualError(t, err, `entc/gen: invalid type features: feature "entql" cannot be selected per type`)
+
+	ant := annotation.Features("sql/lock", "namedges").Merge(annotation.Features("namedges", "sql/upsert")).(*annotation.FeaturesAnnotation)
+	require.Equal(t, []string{"sql/lock", "namedges", "sql/upsert"}, ant.Names)
+}
+
+func TestField_Range(t *testing.T) {
+	f := &Field{Name: "during", Type: &field.TypeInfo{Type: field.TypeRange, RType: &field.RType{
+		Methods: map[string]struct{ In, Out []*field.RType }{
+			"Contains": {In: []*field.RType{{Ident: "time.Time"}}, Out: []*field.RType{{Ident: "bool"}}},
+		},
+	}}}
+	require.True(t, f.IsRange())
+	require.Equal(t, "time.Time", f.RangeElemType())
+	require.Empty(t, fieldOps(f))
+	f.Type.RType = nil
+	require.Equal(t, "any", f.RangeElemType())
+}
+
 func TestField_Constant(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -296,6 +517,56 @@ func TestField_incremental(t *testing.T) {
 	}
 }
 
+func TestField_Generated(t *testing.T) {
+	generated := dict("EntSQL", dict("generated", dict("expr", "a + b")))
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "a", Info: &field.TypeInfo{Type: field.TypeInt}},
+			{Name: "b", Info: &field.TypeInfo{Type: field.TypeInt}},
+			{Name: "c", Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: generated},
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, typ.Fields[0].IsGenerated())
+	require.True(t, typ.Fields[2].IsGenerated())
+	require.Len(t, typ.MutableFields(), 2)
+	require.Len(t, typ.MutationFields(), 3)
+	c := typ.Fields[2].Column()
+	require.NotNil(t, c.Generated)
+	require.Equal(t, "a + b", c.Generated.Expr)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "c", Default: true, Info: &field.TypeInfo{Type: field.TypeInt}, Annotations: generated},
+		},
+	})
+	require.EqualError(t, err, `generated field "c" cannot have default values`)
+}
+
+func TestField_NativeEnum(t *testing.T) {
+	native := dict("EntSQL", dict("native_enum", "user_status"))
+	typ, err := NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{N: "A", V: "a"}}, Annotations: native},
+		},
+	})
+	require.NoError(t, err)
+	c := typ.Fields[0].Column()
+	require.Equal(t, "user_status", c.NativeEnum)
+	require.Equal(t, []string{"a"}, c.Enums)
+
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: native},
+		},
+	})
+	require.EqualError(t, err, `native enum type "user_status" cannot be used by non-enum field "status"`)
+}
+
 func TestBuilderField(t *testing.T) {
 	tests := []struct {
 		name  string
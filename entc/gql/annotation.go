@@ -0,0 +1,69 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package gql
+
+import (
+	"encoding/json"
+
+	"entgo.io/ent/schema"
+)
+
+// Annotation controls the exposure of schemas, fields and edges in the GraphQL schema.
+type Annotation struct {
+	// Skip excludes the schema, field or edge from the GraphQL schema.
+	Skip bool `json:"skip,omitempty"`
+}
+
+// Skip returns an annotation for excluding the schema, field or edge from the GraphQL schema.
+//
+//	field.String("password").
+//		Annotations(gql.Skip())
+func Skip() *Annotation {
+	return &Annotation{Skip: true}
+}
+
+// Name describes the annotation name.
+func (Annotation) Name() string {
+	return "GraphQL"
+}
+
+// Merge implements the schema.Merger interface.
+func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
+	var ant Annotation
+	switch other := other.(type) {
+	case Annotation:
+		ant = other
+	case *Annotation:
+		if other != nil {
+			ant = *other
+		}
+	default:
+		return a
+	}
+	a.Skip = a.Skip || ant.Skip
+	return a
+}
+
+// decode decodes the annotation from the loaded annotations format.
+func decode(annotations map[string]any) (*Annotation, error) {
+	ant := &Annotation{}
+	v, ok := annotations[ant.Name()]
+	if !ok || v == nil {
+		return ant, nil
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, ant); err != nil {
+		return nil, err
+	}
+	return ant, nil
+}
+
+var (
+	_ schema.Annotation = (*Annotation)(nil)
+	_ schema.Merger     = (*Annotation)(nil)
+)
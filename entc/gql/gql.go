@@ -0,0 +1,541 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package gql provides a codegen extension for generating a GraphQL schema from ent schemas,
+// and resolvers that serve this schema using the generated client.
+//
+//	err := entc.Generate("./schema", &gen.Config{}, entc.Extensions(gql.NewExtension()))
+//
+// The schema (SDL) and its resolvers are generated in the gql directory of the target directory.
+// Resolvers are built on the github.com/graphql-go/graphql package. Each schema is exposed as
+// an object type with a Relay connection type, a WhereInput type for filtering its connections,
+// and a root query field. Non-unique edges are exposed as connections, and types with integer
+// identifiers implement the Node interface using global unique IDs (see, the WithGlobalUniqueID
+// migration option). Edges that are selected in queries are eager-loaded using field collection.
+//
+// Note that the extension enables the sql/execquery feature of the generated client, as the
+// resolution of global IDs queries the types table using the client.QueryContext method.
+package gql
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/internal"
+	"entgo.io/ent/schema/field"
+)
+
+type (
+	// Extension implements the entc.Extension interface for
+	// generating a GraphQL schema and its resolvers.
+	Extension struct {
+		entc.DefaultExtension
+		pkg string
+	}
+
+	// ExtensionOption allows configuring the Extension.
+	ExtensionOption func(*Extension)
+)
+
+// NewExtension returns a new Extension configured with the given options.
+func NewExtension(opts ...ExtensionOption) *Extension {
+	ex := &Extension{pkg: "gql"}
+	for _, opt := range opts {
+		opt(ex)
+	}
+	return ex
+}
+
+// WithPackage sets the name of the generated package, and the directory it is
+// generated in under the target directory. Defaults to "gql".
+func WithPackage(name string) ExtensionOption {
+	return func(ex *Extension) {
+		ex.pkg = name
+	}
+}
+
+// Hooks of the extension.
+func (ex *Extension) Hooks() []gen.Hook {
+	return []gen.Hook{internal.GenerateAfter(ex.generate)}
+}
+
+// Options of the extension. The resolution of global IDs reads the types table
+// using the QueryContext method that is added by the sql/execquery feature, and
+// therefore, the extension enables this feature for the whole generated client.
+func (*Extension) Options() []entc.Option {
+	return []entc.Option{
+		entc.FeatureNames(gen.FeatureExecQuery.Name),
+	}
+}
+
+// generate generates the GraphQL package after the code generation of ent.
+func (ex *Extension) generate(g *gen.Graph) error {
+	s, err := newSchema(g, ex.pkg)
+	if err != nil {
+		return err
+	}
+	return s.Generate()
+}
+
+type (
+	// gqlSchema describes the generated package.
+	gqlSchema struct {
+		// Name of the generated package.
+		Name string
+		// Dir is the directory the package is generated in.
+		Dir string
+		// Graph is the graph the schema is generated from.
+		Graph *gen.Graph
+		// Types are the exposed types.
+		Types []*gqlType
+	}
+
+	// gqlType describes an exposed type.
+	gqlType struct {
+		*gen.Type
+		// Schema of the type.
+		Schema *gqlSchema
+		// Fields are the exposed fields of the type, excluding the ID.
+		Fields []*gqlField
+		// Edges are the exposed edges of the type.
+		Edges []*gqlEdge
+	}
+
+	// gqlField describes an exposed field.
+	gqlField struct {
+		*gen.Field
+		// Owner is the type of the field.
+		Owner *gqlType
+	}
+
+	// gqlEdge describes an exposed edge.
+	gqlEdge struct {
+		*gen.Edge
+		// Target is the type the edge points to.
+		Target *gqlType
+	}
+
+	// whereOp describes a field of a WhereInput type.
+	whereOp struct {
+		gen.Op
+		// Name of the input field.
+		Name string
+		// Pred is the predicate function of the operation.
+		Pred string
+		// Field is the field the operation applies to.
+		Field *gqlField
+	}
+)
+
+// nameRe matches the valid names in GraphQL.
+var nameRe = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// newSchema creates the GraphQL schema of the graph.
+func newSchema(g *gen.Graph, pkg string) (*gqlSchema, error) {
+	if g.Storage.Name != "sql" {
+		return nil, fmt.Errorf("gql: unsupported storage %q", g.Storage.Name)
+	}
+	s := &gqlSchema{Name: pkg, Dir: filepath.Join(g.Target, pkg), Graph: g}
+	types := make(map[string]*gqlType)
+	for _, n := range g.Nodes {
+		ant, err := decode(n.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("gql: decode annotation of type %q: %w", n.Name, err)
+		}
+		if ant.Skip || !n.HasOneFieldID() {
+			continue
+		}
+		t := &gqlType{Type: n, Schema: s}
+		if t.IDField().Scalar() == "" {
+			return nil, fmt.Errorf("gql: type %q: unsupported id type %s", n.Name, n.ID.Type)
+		}
+		for _, f := range n.Fields {
+			ant, err := decode(f.Annotations)
+			if err != nil {
+				return nil, fmt.Errorf("gql: decode annotation of field %s.%s: %w", n.Name, f.Name, err)
+			}
+			gf := &gqlField{Field: f, Owner: t}
+			// Sensitive fields, and fields of unsupported types are not exposed.
+			if ant.Skip || f.Sensitive() || gf.Scalar() == "" {
+				continue
+			}
+			for _, e := range f.Enums {
+				if !nameRe.MatchString(e.Value) || e.Value == "true" || e.Value == "false" || e.Value == "null" {
+					return nil, fmt.Errorf("gql: field %s.%s: enum value %q is not a valid GraphQL name", n.Name, f.Name, e.Value)
+				}
+			}
+			t.Fields = append(t.Fields, gf)
+		}
+		types[n.Name] = t
+		s.Types = append(s.Types, t)
+	}
+	for _, t := range s.Types {
+		for _, e := range t.Type.Edges {
+			ant, err := decode(e.Annotations)
+			if err != nil {
+				return nil, fmt.Errorf("gql: decode annotation of edge %s.%s: %w", t.Name, e.Name, err)
+			}
+			if target, ok := types[e.Type.Name]; ok && !ant.Skip {
+				t.Edges = append(t.Edges, &gqlEdge{Edge: e, Target: target})
+			}
+		}
+		names := map[string]string{"id": "id"}
+		for _, f := range t.Fields {
+			if prev, ok := names[f.GQLName()]; ok {
+				return nil, fmt.Errorf("gql: type %q: field %q conflicts with %q", t.Name, f.Name, prev)
+			}
+			names[f.GQLName()] = f.Name
+		}
+		for _, e := range t.Edges {
+			if prev, ok := names[e.GQLName()]; ok {
+				return nil, fmt.Errorf("gql: type %q: edge %q conflicts with %q", t.Name, e.Name, prev)
+			}
+			names[e.GQLName()] = e.Name
+		}
+	}
+	return s, nil
+}
+
+// Nodes returns the types that implement the Node interface.
+func (s *gqlSchema) Nodes() []*gqlType {
+	var nodes []*gqlType
+	for _, t := range s.Types {
+		if t.IsNode() {
+			nodes = append(nodes, t)
+		}
+	}
+	return nodes
+}
+
+// IsNode reports if the type implements the Node interface. Global unique
+// IDs are allocated only for types with integer identifiers.
+func (t *gqlType) IsNode() bool {
+	return (t.ID.IsInt() || t.ID.IsInt64()) && !t.ID.HasGoType()
+}
+
+// Var returns the name of the builder field that holds the object type.
+func (t *gqlType) Var() string {
+	return lowerFirst(t.Name)
+}
+
+// QueryField returns the name of the root query field of the type.
+func (t *gqlType) QueryField() string {
+	return lowerFirst(plural(t.Name))
+}
+
+// TypePackage returns the import path of the ent package of the type.
+func (t *gqlType) TypePackage() string {
+	return path.Join(t.Schema.Graph.Package, t.PackageDir())
+}
+
+// IDField returns the ID field of the type.
+func (t *gqlType) IDField() *gqlField {
+	return &gqlField{Field: t.ID, Owner: t}
+}
+
+// StdImports returns the standard library packages used by the type fields.
+func (t *gqlType) StdImports() []string {
+	var paths []string
+	for _, p := range t.fieldImports() {
+		if !strings.Contains(strings.Split(p, "/")[0], ".") {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// Imports returns the import specs of the ent packages of the edge types,
+// and of the external types used by the type fields.
+func (t *gqlType) Imports() []string {
+	var specs []string
+	for _, e := range t.Edges {
+		if e.Target.Type == t.Type {
+			continue
+		}
+		spec := strconv.Quote(e.Target.TypePackage())
+		if a := e.Target.PackageAlias(); a != "" {
+			spec = a + " " + spec
+		}
+		if !contains(specs, spec) {
+			specs = append(specs, spec)
+		}
+	}
+	for _, p := range t.fieldImports() {
+		if strings.Contains(strings.Split(p, "/")[0], ".") && !contains(specs, strconv.Quote(p)) {
+			specs = append(specs, strconv.Quote(p))
+		}
+	}
+	return specs
+}
+
+// fieldImports returns the import paths of the types of the ID and the fields.
+func (t *gqlType) fieldImports() []string {
+	var paths []string
+	for _, f := range append([]*gqlField{t.IDField()}, t.Fields...) {
+		if p := f.Type.PkgPath; p != "" && p != t.TypePackage() && !contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// Enums returns the enum fields of the type.
+func (t *gqlType) Enums() []*gqlField {
+	var fields []*gqlField
+	for _, f := range t.Fields {
+		if f.IsEnum() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// WhereOps returns the fields of the WhereInput type that are
+// generated for the ID and the fields of the type.
+func (t *gqlType) WhereOps() []*whereOp {
+	var ops []*whereOp
+	for _, f := range append([]*gqlField{t.IDField()}, t.Fields...) {
+		name, pred := f.GQLName(), f.StructField()
+		if f.Field == t.ID {
+			name, pred = "id", "ID"
+		}
+		for _, op := range f.Ops() {
+			w := &whereOp{Op: op, Name: name + op.Name(), Pred: t.Package() + "." + pred + op.Name(), Field: f}
+			// The equality operation uses the name of the field.
+			if op == gen.EQ {
+				w.Name = name
+			}
+			ops = append(ops, w)
+		}
+	}
+	return ops
+}
+
+// GQLName returns the name of the field in the GraphQL schema.
+func (f *gqlField) GQLName() string {
+	return camel(snake(f.Name))
+}
+
+// EnumType returns the name of the GraphQL enum type of the field.
+func (f *gqlField) EnumType() string {
+	return f.Owner.Name + pascal(f.Name)
+}
+
+// EnumVar returns the name of the builder field that holds the enum type of the field.
+func (f *gqlField) EnumVar() string {
+	return lowerFirst(f.EnumType())
+}
+
+// Scalar returns the name of the GraphQL type of the field, or an
+// empty string if the field type is not supported.
+func (f *gqlField) Scalar() string {
+	switch t := f.Type.Type; {
+	case f.Field == f.Owner.ID:
+		if f.IsUUID() || t == field.TypeString && !f.HasGoType() || f.Type.Numeric() && f.ConvertedToBasic() {
+			return "ID"
+		}
+		return ""
+	case f.IsEnum():
+		return f.EnumType()
+	case f.IsUUID():
+		return "String"
+	case f.IsTime():
+		if f.HasGoType() {
+			return ""
+		}
+		return "DateTime"
+	case f.HasGoType() && !f.ConvertedToBasic():
+		return ""
+	case t == field.TypeBool:
+		return "Boolean"
+	case t == field.TypeString:
+		return "String"
+	case t == field.TypeFloat32 || t == field.TypeFloat64:
+		return "Float"
+	case f.Type.Numeric():
+		return "Int"
+	default:
+		return ""
+	}
+}
+
+// SDLType returns the type of the field in the GraphQL schema.
+func (f *gqlField) SDLType() string {
+	if f.Nillable {
+		return f.Scalar()
+	}
+	return f.Scalar() + "!"
+}
+
+// GoType returns the Go expression of the graphql-go type of the field
+// values (without non-null wrapping).
+func (f *gqlField) GoType() string {
+	if f.IsEnum() {
+		return "b." + f.EnumVar()
+	}
+	return "graphql." + f.Scalar()
+}
+
+// Output returns the Go expression for converting the given field value
+// to its GraphQL value.
+func (f *gqlField) Output(v string) string {
+	switch {
+	case f.IsUUID():
+		return v + ".String()"
+	case f.IsEnum():
+		return v
+	case f.HasGoType():
+		return f.BasicType(v)
+	default:
+		return v
+	}
+}
+
+// Input returns the name of the generated function that converts the GraphQL
+// values of the field to its Go type. The function returns an error as a second value.
+func (f *gqlField) Input() string {
+	switch t := f.Type.String(); {
+	case f.Scalar() == "ID" && f.IsUUID():
+		return fmt.Sprintf("inputText[%s]", t)
+	case f.Scalar() == "ID" && f.Type.Type == field.TypeString:
+		return fmt.Sprintf("inputString[%s]", t)
+	case f.Scalar() == "ID" && f.Type.Type >= field.TypeUint8 && f.Type.Type <= field.TypeUint64:
+		return fmt.Sprintf("inputUintID[%s]", t)
+	case f.Scalar() == "ID":
+		return fmt.Sprintf("inputIntID[%s]", t)
+	case f.IsEnum():
+		return fmt.Sprintf("inputValue[%s]", t)
+	case f.IsUUID():
+		return fmt.Sprintf("inputText[%s]", t)
+	case f.IsTime():
+		return "inputValue[time.Time]"
+	case f.Type.Type == field.TypeBool:
+		return fmt.Sprintf("inputBool[%s]", t)
+	case f.Type.Type == field.TypeString:
+		return fmt.Sprintf("inputString[%s]", t)
+	case f.Scalar() == "Float":
+		return fmt.Sprintf("inputFloat[%s]", t)
+	default:
+		return fmt.Sprintf("inputInt[%s]", t)
+	}
+}
+
+// SDLType returns the type of the input field in the GraphQL schema.
+func (o *whereOp) SDLType() string {
+	switch {
+	case o.Niladic():
+		return "Boolean"
+	case o.Variadic():
+		return "[" + o.Field.Scalar() + "!]"
+	default:
+		return o.Field.Scalar()
+	}
+}
+
+// GoType returns the Go expression of the graphql-go type of the input field.
+func (o *whereOp) GoType() string {
+	switch {
+	case o.Niladic():
+		return "graphql.Boolean"
+	case o.Variadic():
+		return "graphql.NewList(graphql.NewNonNull(" + o.Field.GoType() + "))"
+	default:
+		return o.Field.GoType()
+	}
+}
+
+// GQLName returns the name of the edge in the GraphQL schema.
+func (e *gqlEdge) GQLName() string {
+	return camel(snake(e.Name))
+}
+
+// HasName returns the name of the WhereInput field for checking the edge existence.
+func (e *gqlEdge) HasName() string {
+	return "has" + pascal(e.Name)
+}
+
+// Header returns the header of the generated files.
+func (s *gqlSchema) Header() string {
+	return internal.Header(s.Graph)
+}
+
+// SDLHeader returns the header of the GraphQL schema file.
+func (s *gqlSchema) SDLHeader() string {
+	return strings.ReplaceAll(s.Header(), "//", "#")
+}
+
+var (
+	//go:embed template/*
+	templateDir embed.FS
+	// templates holds the templates of the generated files.
+	templates = template.Must(template.New("gql").
+			Funcs(gen.Funcs).
+			ParseFS(templateDir, "template/*.tmpl"))
+)
+
+// Generate writes the GraphQL schema and the Go files of the package, and removes
+// the files of types that are not exposed anymore.
+func (s *gqlSchema) Generate() error {
+	files := make(map[string][]byte)
+	for name, tmpl := range map[string]string{"schema.graphql": "sdl", "schema.go": "schema"} {
+		b := &bytes.Buffer{}
+		if err := templates.ExecuteTemplate(b, tmpl, s); err != nil {
+			return fmt.Errorf("gql: execute template %q: %w", tmpl, err)
+		}
+		files[name] = b.Bytes()
+	}
+	for _, t := range s.Types {
+		b := &bytes.Buffer{}
+		if err := templates.ExecuteTemplate(b, "type", t); err != nil {
+			return fmt.Errorf("gql: execute template %q on type %q: %w", "type", t.Name, err)
+		}
+		files[snake(t.Name)+".go"] = b.Bytes()
+	}
+	p := &internal.GenPackage{Dir: s.Dir, Header: s.Header(), Files: files}
+	if err := p.Write(); err != nil {
+		return fmt.Errorf("gql: %w", err)
+	}
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func camel(s string) string {
+	return gen.Funcs["camel"].(func(string) string)(s)
+}
+
+func pascal(s string) string {
+	return gen.Funcs["pascal"].(func(string) string)(s)
+}
+
+func snake(s string) string {
+	return gen.Funcs["snake"].(func(string) string)(s)
+}
+
+func plural(s string) string {
+	return gen.Funcs["plural"].(func(string) string)(s)
+}
@@ -0,0 +1,143 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package gql
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/internal/gentest"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	target := t.TempDir()
+	g := gentest.Graph(t, "entc/gql", target, &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}, Nillable: true, Optional: true},
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{"active", "active"}, {"blocked", "blocked"}}, Default: true},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}},
+			{Name: "password", Info: &field.TypeInfo{Type: field.TypeString}, Sensitive: true},
+			{Name: "internal", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: gentest.Annotations(Skip())},
+			{Name: "data", Info: &field.TypeInfo{Type: field.TypeBytes}},
+		},
+		Edges: []*load.Edge{
+			{Name: "pets", Type: "Pet"},
+			{Name: "secrets", Type: "Secret"},
+			{Name: "hidden", Type: "Pet", Annotations: gentest.Annotations(Skip())},
+		},
+	}, &load.Schema{
+		Name: "Pet",
+		Fields: []*load.Field{
+			{Name: "id", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+		Edges: []*load.Edge{
+			{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true},
+		},
+	}, &load.Schema{
+		Name:        "Secret",
+		Annotations: gentest.Annotations(Skip()),
+	})
+	require.NoError(t, NewExtension().generate(g))
+
+	dir := filepath.Join(target, "gql")
+	buf, err := os.ReadFile(filepath.Join(dir, "schema.graphql"))
+	require.NoError(t, err)
+	sdl := string(buf)
+	for _, s := range []string{
+		"interface Node {\n  id: ID!\n}",
+		"  node(id: ID!): Node\n  nodes(ids: [ID!]!): [Node]!",
+		"  users(after: Cursor, first: Int, before: Cursor, last: Int, where: UserWhereInput): UserConnection!",
+		"type User implements Node {\n  id: ID!\n  name: String!\n  age: Int\n  status: UserStatus!\n  createdAt: DateTime!\n  pets(after: Cursor, first: Int, before: Cursor, last: Int, where: PetWhereInput): PetConnection!\n}",
+		"enum UserStatus {\n  active\n  blocked\n}",
+		"type UserConnection {\n  edges: [UserEdge]\n  pageInfo: PageInfo!\n  totalCount: Int!\n}",
+		"type Pet {\n  id: ID!\n  name: String!\n  owner: User\n}",
+		"  nameContainsFold: String\n",
+		"  ageIsNil: Boolean\n",
+		"  statusIn: [UserStatus!]\n",
+		"  idGT: ID\n",
+		"  hasPets: Boolean\n  hasPetsWith: [PetWhereInput!]\n",
+	} {
+		require.Contains(t, sdl, s)
+	}
+	for _, s := range []string{"password", "internal", "data", "hidden", "Secret"} {
+		require.NotContains(t, sdl, s)
+	}
+
+	for _, name := range []string{"schema.go", "user.go", "pet.go"} {
+		_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.AllErrors)
+		require.NoError(t, err, name)
+	}
+	buf, err = os.ReadFile(filepath.Join(dir, "user.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `&graphql.EnumValueConfig{Value: user.Status("active")}`)
+	require.Contains(t, string(buf), "func (b *builder) nodesUser(")
+	buf, err = os.ReadFile(filepath.Join(dir, "pet.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "func (b *builder) nodesPet(")
+
+	// Files of types that are not exposed anymore are removed.
+	// Files that were not generated by the extension are kept.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resolver.go"), []byte("package gql\n"), 0644))
+	g = gentest.Graph(t, "entc/gql", target, &load.Schema{Name: "User"})
+	s, err := newSchema(g, "gql")
+	require.NoError(t, err)
+	require.NoError(t, s.Generate())
+	for name, exists := range map[string]bool{"schema.go": true, "user.go": true, "pet.go": false, "resolver.go": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		require.Equal(t, exists, err == nil, name)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	g := gentest.Graph(t, "entc/gql", t.TempDir(), &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{"Active", "ACTIVE-1"}}},
+		},
+	})
+	_, err := newSchema(g, "gql")
+	require.EqualError(t, err, `gql: field User.status: enum value "ACTIVE-1" is not a valid GraphQL name`)
+
+	g = gentest.Graph(t, "entc/gql", t.TempDir(), &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "pet_name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "petName", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+	})
+	_, err = newSchema(g, "gql")
+	require.EqualError(t, err, `gql: type "User": field "petName" conflicts with "pet_name"`)
+}
+
+func TestExtension_Options(t *testing.T) {
+	cfg := &gen.Config{}
+	for _, opt := range NewExtension().Options() {
+		require.NoError(t, opt(cfg))
+	}
+	require.Equal(t, []gen.Feature{gen.FeatureExecQuery}, cfg.Features)
+	enabled, err := cfg.FeatureEnabled(gen.FeatureExecQuery.Name)
+	require.NoError(t, err)
+	require.True(t, enabled)
+}
+
+func TestAnnotation_Merge(t *testing.T) {
+	ant := Annotation{}.Merge(Skip())
+	require.Equal(t, Annotation{Skip: true}, ant)
+	ant = ant.(Annotation).Merge((*Annotation)(nil))
+	require.Equal(t, Annotation{Skip: true}, ant)
+	ant = Annotation{}.Merge(Annotation{})
+	require.Equal(t, Annotation{}, ant)
+}
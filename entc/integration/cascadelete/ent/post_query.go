@@ -494,7 +494,14 @@ func (pq *PostQuery) loadComments(ctx context.Context, query *CommentQuery, node
 	query.Where(predicate.Comment(func(s *sql.Selector) {
 		s.Where(sql.InValues(post.CommentsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeComment, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Comment, *CommentQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(post.CommentsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Comment](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -422,7 +422,14 @@ func (uq *UserQuery) loadPosts(ctx context.Context, query *PostQuery, nodes []*U
 	query.Where(predicate.Post(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PostsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypePost, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Post, *PostQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(user.PostsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Post](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
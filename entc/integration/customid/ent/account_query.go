@@ -424,7 +424,14 @@ func (aq *AccountQuery) loadToken(ctx context.Context, query *TokenQuery, nodes
 	query.Where(predicate.Token(func(s *sql.Selector) {
 		s.Where(sql.InValues(account.TokenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeToken, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Token, *TokenQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(account.TokenColumn)
+	})
+	neighbors, err := withInterceptors[[]*Token](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
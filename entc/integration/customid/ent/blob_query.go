@@ -558,17 +558,18 @@ func (bq *BlobQuery) loadLinks(ctx context.Context, query *BlobQuery, nodes []*B
 	if err := query.prepareQuery(ctx); err != nil {
 		return err
 	}
-	neighbors, err := query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
-		assign := spec.Assign
-		values := spec.ScanValues
-		spec.ScanValues = func(columns []string) ([]any, error) {
+	neighbors, err := query.sqlAll(ctx, func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		assign := _spec.Assign
+		values := _spec.ScanValues
+		_spec.ScanValues = func(columns []string) ([]any, error) {
 			values, err := values(columns[1:])
 			if err != nil {
 				return nil, err
 			}
 			return append([]any{new(uuid.UUID)}, values...), nil
 		}
-		spec.Assign = func(columns []string, values []any) error {
+		_spec.PartitionBy(blob.LinksPrimaryKey[0])
+		_spec.Assign = func(columns []string, values []any) error {
 			outValue := *values[0].(*uuid.UUID)
 			inValue := *values[1].(*uuid.UUID)
 			if nids[inValue] == nil {
@@ -606,7 +607,14 @@ func (bq *BlobQuery) loadBlobLinks(ctx context.Context, query *BlobLinkQuery, no
 	query.Where(predicate.BlobLink(func(s *sql.Selector) {
 		s.Where(sql.InValues(blob.BlobLinksColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeBlobLink, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*BlobLink, *BlobLinkQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(blob.BlobLinksColumn)
+	})
+	neighbors, err := withInterceptors[[]*BlobLink](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -484,7 +484,14 @@ func (dq *DeviceQuery) loadSessions(ctx context.Context, query *SessionQuery, no
 	query.Where(predicate.Session(func(s *sql.Selector) {
 		s.Where(sql.InValues(device.SessionsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeSession, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Session, *SessionQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(device.SessionsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Session](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -548,7 +548,14 @@ func (dq *DocQuery) loadChildren(ctx context.Context, query *DocQuery, nodes []*
 	query.Where(predicate.Doc(func(s *sql.Selector) {
 		s.Where(sql.InValues(doc.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeDoc, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Doc, *DocQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(doc.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*Doc](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -588,17 +595,18 @@ func (dq *DocQuery) loadRelated(ctx context.Context, query *DocQuery, nodes []*D
 	if err := query.prepareQuery(ctx); err != nil {
 		return err
 	}
-	neighbors, err := query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
-		assign := spec.Assign
-		values := spec.ScanValues
-		spec.ScanValues = func(columns []string) ([]any, error) {
+	neighbors, err := query.sqlAll(ctx, func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		assign := _spec.Assign
+		values := _spec.ScanValues
+		_spec.ScanValues = func(columns []string) ([]any, error) {
 			values, err := values(columns[1:])
 			if err != nil {
 				return nil, err
 			}
 			return append([]any{new(schema.DocID)}, values...), nil
 		}
-		spec.Assign = func(columns []string, values []any) error {
+		_spec.PartitionBy(doc.RelatedPrimaryKey[0])
+		_spec.Assign = func(columns []string, values []any) error {
 			outValue := *values[0].(*schema.DocID)
 			inValue := *values[1].(*schema.DocID)
 			if nids[inValue] == nil {
@@ -483,7 +483,14 @@ func (isq *IntSIDQuery) loadChildren(ctx context.Context, query *IntSIDQuery, no
 	query.Where(predicate.IntSID(func(s *sql.Selector) {
 		s.Where(sql.InValues(intsid.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeIntSID, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*IntSID, *IntSIDQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(intsid.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*IntSID](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
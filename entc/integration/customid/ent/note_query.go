@@ -505,7 +505,14 @@ func (nq *NoteQuery) loadChildren(ctx context.Context, query *NoteQuery, nodes [
 	query.Where(predicate.Note(func(s *sql.Selector) {
 		s.Where(sql.InValues(note.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeNote, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Note, *NoteQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(note.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*Note](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
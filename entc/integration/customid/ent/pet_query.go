@@ -569,7 +569,14 @@ func (pq *PetQuery) loadCars(ctx context.Context, query *CarQuery, nodes []*Pet,
 	query.Where(predicate.Car(func(s *sql.Selector) {
 		s.Where(sql.InValues(pet.CarsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeCar, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Car, *CarQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(pet.CarsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Car](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -609,17 +616,18 @@ func (pq *PetQuery) loadFriends(ctx context.Context, query *PetQuery, nodes []*P
 	if err := query.prepareQuery(ctx); err != nil {
 		return err
 	}
-	neighbors, err := query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
-		assign := spec.Assign
-		values := spec.ScanValues
-		spec.ScanValues = func(columns []string) ([]any, error) {
+	neighbors, err := query.sqlAll(ctx, func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		assign := _spec.Assign
+		values := _spec.ScanValues
+		_spec.ScanValues = func(columns []string) ([]any, error) {
 			values, err := values(columns[1:])
 			if err != nil {
 				return nil, err
 			}
 			return append([]any{new(sql.NullString)}, values...), nil
 		}
-		spec.Assign = func(columns []string, values []any) error {
+		_spec.PartitionBy(pet.FriendsPrimaryKey[0])
+		_spec.Assign = func(columns []string, values []any) error {
 			outValue := values[0].(*sql.NullString).String
 			inValue := values[1].(*sql.NullString).String
 			if nids[inValue] == nil {
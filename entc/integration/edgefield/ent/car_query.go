@@ -423,7 +423,14 @@ func (cq *CarQuery) loadRentals(ctx context.Context, query *RentalQuery, nodes [
 	query.Where(predicate.Rental(func(s *sql.Selector) {
 		s.Where(sql.InValues(car.RentalsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeRental, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Rental, *RentalQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(car.RentalsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Rental](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -535,7 +535,14 @@ func (mq *MetadataQuery) loadChildren(ctx context.Context, query *MetadataQuery,
 	query.Where(predicate.Metadata(func(s *sql.Selector) {
 		s.Where(sql.InValues(metadata.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeMetadata, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Metadata, *MetadataQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(metadata.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*Metadata](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -543,13 +543,13 @@ func newQueryContext(ctx context.Context, typ, op string) context.Context {
 
 func querierAll[V Value, Q interface {
 	sqlAll(context.Context, ...queryHook) (V, error)
-}]() Querier {
+}](hooks ...queryHook) Querier {
 	return QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
 		query, ok := q.(Q)
 		if !ok {
 			return nil, fmt.Errorf("unexpected query type %T", q)
 		}
-		return query.sqlAll(ctx)
+		return query.sqlAll(ctx, hooks...)
 	})
 }
 
@@ -594,7 +594,14 @@ func (fq *FileQuery) loadField(ctx context.Context, query *FieldTypeQuery, nodes
 	query.Where(predicate.FieldType(func(s *sql.Selector) {
 		s.Where(sql.InValues(file.FieldColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeFieldType, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*FieldType, *FieldTypeQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(file.FieldColumn)
+	})
+	neighbors, err := withInterceptors[[]*FieldType](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
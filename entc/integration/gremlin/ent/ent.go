@@ -520,13 +520,13 @@ func newQueryContext(ctx context.Context, typ, op string) context.Context {
 
 func querierAll[V Value, Q interface {
 	gremlinAll(context.Context, ...queryHook) (V, error)
-}]() Querier {
+}](hooks ...queryHook) Querier {
 	return QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
 		query, ok := q.(Q)
 		if !ok {
 			return nil, fmt.Errorf("unexpected query type %T", q)
 		}
-		return query.gremlinAll(ctx)
+		return query.gremlinAll(ctx, hooks...)
 	})
 }
 
@@ -0,0 +1,328 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package migrate
+
+import (
+	"entgo.io/ent/dialect/gremlin/schema"
+	"entgo.io/ent/schema/field"
+)
+
+var (
+	// APIProperties holds the properties for the "api" vertex label.
+	APIProperties = []*schema.Property{}
+	// APIVertex holds the schema information for the "api" vertex label.
+	APIVertex = &schema.VertexLabel{
+		Name:       "api",
+		Properties: APIProperties,
+	}
+	// CardProperties holds the properties for the "card" vertex label.
+	CardProperties = []*schema.Property{
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "update_time", Type: field.TypeTime},
+		{Name: "balance", Type: field.TypeFloat64},
+		{Name: "number", Type: field.TypeString},
+		{Name: "name", Type: field.TypeString},
+	}
+	// CardVertex holds the schema information for the "card" vertex label.
+	CardVertex = &schema.VertexLabel{
+		Name:       "card",
+		Properties: CardProperties,
+		Indexes: []*schema.Index{
+			{
+				Name:       "card_number",
+				Unique:     true,
+				Properties: []*schema.Property{CardProperties[3]},
+			},
+		},
+	}
+	// CommentProperties holds the properties for the "comment" vertex label.
+	CommentProperties = []*schema.Property{
+		{Name: "unique_int", Type: field.TypeInt, Unique: true},
+		{Name: "unique_float", Type: field.TypeFloat64, Unique: true},
+		{Name: "nillable_int", Type: field.TypeInt},
+		{Name: "table", Type: field.TypeString},
+		{Name: "dir", Type: field.TypeOther},
+		{Name: "client", Type: field.TypeString},
+	}
+	// CommentVertex holds the schema information for the "comment" vertex label.
+	CommentVertex = &schema.VertexLabel{
+		Name:       "comment",
+		Properties: CommentProperties,
+	}
+	// FieldTypeProperties holds the properties for the "field_type" vertex label.
+	FieldTypeProperties = []*schema.Property{
+		{Name: "int", Type: field.TypeInt},
+		{Name: "int8", Type: field.TypeInt8},
+		{Name: "int16", Type: field.TypeInt16},
+		{Name: "int32", Type: field.TypeInt32},
+		{Name: "int64", Type: field.TypeInt64},
+		{Name: "optional_int", Type: field.TypeInt},
+		{Name: "optional_int8", Type: field.TypeInt8},
+		{Name: "optional_int16", Type: field.TypeInt16},
+		{Name: "optional_int32", Type: field.TypeInt32},
+		{Name: "optional_int64", Type: field.TypeInt64},
+		{Name: "nillable_int", Type: field.TypeInt},
+		{Name: "nillable_int8", Type: field.TypeInt8},
+		{Name: "nillable_int16", Type: field.TypeInt16},
+		{Name: "nillable_int32", Type: field.TypeInt32},
+		{Name: "nillable_int64", Type: field.TypeInt64},
+		{Name: "validate_optional_int32", Type: field.TypeInt32},
+		{Name: "optional_uint", Type: field.TypeUint},
+		{Name: "optional_uint8", Type: field.TypeUint8},
+		{Name: "optional_uint16", Type: field.TypeUint16},
+		{Name: "optional_uint32", Type: field.TypeUint32},
+		{Name: "optional_uint64", Type: field.TypeUint64},
+		{Name: "state", Type: field.TypeEnum},
+		{Name: "optional_float", Type: field.TypeFloat64},
+		{Name: "optional_float32", Type: field.TypeFloat32},
+		{Name: "text", Type: field.TypeString},
+		{Name: "datetime", Type: field.TypeTime},
+		{Name: "decimal", Type: field.TypeFloat64},
+		{Name: "link_other", Type: field.TypeOther},
+		{Name: "link_other_func", Type: field.TypeOther},
+		{Name: "mac", Type: field.TypeString},
+		{Name: "string_array", Type: field.TypeOther},
+		{Name: "password", Type: field.TypeString},
+		{Name: "string_scanner", Type: field.TypeString},
+		{Name: "duration", Type: field.TypeInt64},
+		{Name: "dir", Type: field.TypeOther},
+		{Name: "ndir", Type: field.TypeString},
+		{Name: "str", Type: field.TypeString},
+		{Name: "null_str", Type: field.TypeString},
+		{Name: "link", Type: field.TypeString},
+		{Name: "null_link", Type: field.TypeString},
+		{Name: "active", Type: field.TypeBool},
+		{Name: "null_active", Type: field.TypeBool},
+		{Name: "deleted", Type: field.TypeBool},
+		{Name: "deleted_at", Type: field.TypeTime},
+		{Name: "raw_data", Type: field.TypeBytes},
+		{Name: "sensitive", Type: field.TypeBytes},
+		{Name: "ip", Type: field.TypeBytes},
+		{Name: "null_int64", Type: field.TypeInt},
+		{Name: "schema_int", Type: field.TypeInt},
+		{Name: "schema_int8", Type: field.TypeInt8},
+		{Name: "schema_int64", Type: field.TypeInt64},
+		{Name: "schema_float", Type: field.TypeFloat64},
+		{Name: "schema_float32", Type: field.TypeFloat32},
+		{Name: "null_float", Type: field.TypeFloat64},
+		{Name: "role", Type: field.TypeEnum},
+		{Name: "priority", Type: field.TypeOther},
+		{Name: "optional_uuid", Type: field.TypeUUID},
+		{Name: "nillable_uuid", Type: field.TypeUUID},
+		{Name: "strings", Type: field.TypeJSON},
+		{Name: "pair", Type: field.TypeBytes},
+		{Name: "nil_pair", Type: field.TypeBytes},
+		{Name: "vstring", Type: field.TypeString},
+		{Name: "triple", Type: field.TypeString},
+		{Name: "big_int", Type: field.TypeInt},
+		{Name: "password_other", Type: field.TypeOther},
+	}
+	// FieldTypeVertex holds the schema information for the "field_type" vertex label.
+	FieldTypeVertex = &schema.VertexLabel{
+		Name:       "field_type",
+		Properties: FieldTypeProperties,
+	}
+	// FileProperties holds the properties for the "file" vertex label.
+	FileProperties = []*schema.Property{
+		{Name: "fsize", Type: field.TypeInt},
+		{Name: "name", Type: field.TypeString},
+		{Name: "user", Type: field.TypeString},
+		{Name: "group", Type: field.TypeString},
+		{Name: "op", Type: field.TypeBool},
+		{Name: "field_id", Type: field.TypeInt},
+	}
+	// FileVertex holds the schema information for the "file" vertex label.
+	FileVertex = &schema.VertexLabel{
+		Name:       "file",
+		Properties: FileProperties,
+		Indexes: []*schema.Index{
+			{
+				Name:       "file_name_size",
+				Unique:     false,
+				Properties: []*schema.Property{FileProperties[1], FileProperties[0]},
+			},
+			{
+				Name:       "file_name_user",
+				Unique:     true,
+				Properties: []*schema.Property{FileProperties[1], FileProperties[2]},
+			},
+		},
+		Edges: []*schema.EdgeLabel{
+			{Name: "file_field", Multiplicity: schema.One2Many},
+		},
+	}
+	// FileTypeProperties holds the properties for the "file_type" vertex label.
+	FileTypeProperties = []*schema.Property{
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "type", Type: field.TypeEnum},
+		{Name: "state", Type: field.TypeEnum},
+	}
+	// FileTypeVertex holds the schema information for the "file_type" vertex label.
+	FileTypeVertex = &schema.VertexLabel{
+		Name:       "file_type",
+		Properties: FileTypeProperties,
+		Edges: []*schema.EdgeLabel{
+			{Name: "file_type_files", Multiplicity: schema.One2Many},
+		},
+	}
+	// GoodsProperties holds the properties for the "goods" vertex label.
+	GoodsProperties = []*schema.Property{}
+	// GoodsVertex holds the schema information for the "goods" vertex label.
+	GoodsVertex = &schema.VertexLabel{
+		Name:       "goods",
+		Properties: GoodsProperties,
+	}
+	// GroupProperties holds the properties for the "group" vertex label.
+	GroupProperties = []*schema.Property{
+		{Name: "active", Type: field.TypeBool},
+		{Name: "expire", Type: field.TypeTime},
+		{Name: "type", Type: field.TypeString},
+		{Name: "max_users", Type: field.TypeInt},
+		{Name: "name", Type: field.TypeString},
+	}
+	// GroupVertex holds the schema information for the "group" vertex label.
+	GroupVertex = &schema.VertexLabel{
+		Name:       "group",
+		Properties: GroupProperties,
+		Edges: []*schema.EdgeLabel{
+			{Name: "group_files", Multiplicity: schema.One2Many},
+			{Name: "group_blocked", Multiplicity: schema.One2Many},
+			{Name: "group_info", Multiplicity: schema.Many2One},
+		},
+	}
+	// GroupInfoProperties holds the properties for the "group_info" vertex label.
+	GroupInfoProperties = []*schema.Property{
+		{Name: "desc", Type: field.TypeString},
+		{Name: "max_users", Type: field.TypeInt},
+	}
+	// GroupInfoVertex holds the schema information for the "group_info" vertex label.
+	GroupInfoVertex = &schema.VertexLabel{
+		Name:       "group_info",
+		Properties: GroupInfoProperties,
+	}
+	// ItemProperties holds the properties for the "item" vertex label.
+	ItemProperties = []*schema.Property{
+		{Name: "text", Type: field.TypeString, Unique: true},
+	}
+	// ItemVertex holds the schema information for the "item" vertex label.
+	ItemVertex = &schema.VertexLabel{
+		Name:       "item",
+		Properties: ItemProperties,
+	}
+	// LicenseProperties holds the properties for the "license" vertex label.
+	LicenseProperties = []*schema.Property{
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "update_time", Type: field.TypeTime},
+	}
+	// LicenseVertex holds the schema information for the "license" vertex label.
+	LicenseVertex = &schema.VertexLabel{
+		Name:       "license",
+		Properties: LicenseProperties,
+	}
+	// NodeProperties holds the properties for the "node" vertex label.
+	NodeProperties = []*schema.Property{
+		{Name: "value", Type: field.TypeInt},
+	}
+	// NodeVertex holds the schema information for the "node" vertex label.
+	NodeVertex = &schema.VertexLabel{
+		Name:       "node",
+		Properties: NodeProperties,
+		Edges: []*schema.EdgeLabel{
+			{Name: "node_next", Multiplicity: schema.One2One},
+		},
+	}
+	// PetProperties holds the properties for the "pet" vertex label.
+	PetProperties = []*schema.Property{
+		{Name: "age", Type: field.TypeOther},
+		{Name: "name", Type: field.TypeString},
+		{Name: "uuid", Type: field.TypeUUID},
+		{Name: "nickname", Type: field.TypeString},
+		{Name: "trained", Type: field.TypeBool},
+	}
+	// PetVertex holds the schema information for the "pet" vertex label.
+	PetVertex = &schema.VertexLabel{
+		Name:       "pet",
+		Properties: PetProperties,
+		Indexes: []*schema.Index{
+			{
+				Name:       "pet_nickname",
+				Unique:     true,
+				Properties: []*schema.Property{PetProperties[3]},
+			},
+		},
+	}
+	// SpecProperties holds the properties for the "spec" vertex label.
+	SpecProperties = []*schema.Property{}
+	// SpecVertex holds the schema information for the "spec" vertex label.
+	SpecVertex = &schema.VertexLabel{
+		Name:       "spec",
+		Properties: SpecProperties,
+		Edges: []*schema.EdgeLabel{
+			{Name: "spec_card", Multiplicity: schema.Simple},
+		},
+	}
+	// TaskProperties holds the properties for the "task" vertex label.
+	TaskProperties = []*schema.Property{
+		{Name: "priority", Type: field.TypeOther},
+		{Name: "priorities", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// TaskVertex holds the schema information for the "task" vertex label.
+	TaskVertex = &schema.VertexLabel{
+		Name:       "task",
+		Properties: TaskProperties,
+	}
+	// UserProperties holds the properties for the "user" vertex label.
+	UserProperties = []*schema.Property{
+		{Name: "optional_int", Type: field.TypeInt},
+		{Name: "age", Type: field.TypeOther},
+		{Name: "name", Type: field.TypeString},
+		{Name: "last", Type: field.TypeString},
+		{Name: "nickname", Type: field.TypeString, Unique: true},
+		{Name: "address", Type: field.TypeString},
+		{Name: "phone", Type: field.TypeString, Unique: true},
+		{Name: "password", Type: field.TypeString},
+		{Name: "role", Type: field.TypeEnum},
+		{Name: "employment", Type: field.TypeEnum},
+		{Name: "sso_cert", Type: field.TypeString},
+	}
+	// UserVertex holds the schema information for the "user" vertex label.
+	UserVertex = &schema.VertexLabel{
+		Name:       "user",
+		Properties: UserProperties,
+		Edges: []*schema.EdgeLabel{
+			{Name: "user_card", Multiplicity: schema.One2One},
+			{Name: "user_pets", Multiplicity: schema.One2Many},
+			{Name: "user_files", Multiplicity: schema.One2Many},
+			{Name: "user_groups", Multiplicity: schema.Simple},
+			{Name: "user_friends", Multiplicity: schema.Simple},
+			{Name: "user_following", Multiplicity: schema.Simple},
+			{Name: "user_team", Multiplicity: schema.One2One},
+			{Name: "user_spouse", Multiplicity: schema.One2One},
+			{Name: "user_parent", Multiplicity: schema.Many2One},
+		},
+	}
+	// VertexLabels holds all the vertex labels in the schema.
+	VertexLabels = []*schema.VertexLabel{
+		APIVertex,
+		CardVertex,
+		CommentVertex,
+		FieldTypeVertex,
+		FileVertex,
+		FileTypeVertex,
+		GoodsVertex,
+		GroupVertex,
+		GroupInfoVertex,
+		ItemVertex,
+		LicenseVertex,
+		NodeVertex,
+		PetVertex,
+		SpecVertex,
+		TaskVertex,
+		UserVertex,
+	}
+)
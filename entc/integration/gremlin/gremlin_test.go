@@ -16,11 +16,13 @@ import (
 	"testing"
 	"time"
 
+	"entgo.io/ent/dialect/gremlin/schema"
 	"entgo.io/ent/entc/integration/gremlin/ent"
 	"entgo.io/ent/entc/integration/gremlin/ent/card"
 	"entgo.io/ent/entc/integration/gremlin/ent/file"
 	"entgo.io/ent/entc/integration/gremlin/ent/group"
 	"entgo.io/ent/entc/integration/gremlin/ent/groupinfo"
+	"entgo.io/ent/entc/integration/gremlin/ent/migrate"
 	"entgo.io/ent/entc/integration/gremlin/ent/node"
 	"entgo.io/ent/entc/integration/gremlin/ent/pet"
 	"entgo.io/ent/entc/integration/gremlin/ent/user"
@@ -48,6 +50,18 @@ func TestGremlin(t *testing.T) {
 	}
 }
 
+// TestSchema runs the schema migration of the generated
+// vertex labels, and writes its script to a buffer.
+func TestSchema(t *testing.T) {
+	var b strings.Builder
+	err := migrate.NewSchema(schema.NewWriteDriver(&b)).Create(context.Background())
+	require.NoError(t, err)
+	// Keys that are shared by fields of different types.
+	for _, k := range []string{"age", "dir", "priority"} {
+		require.Contains(t, b.String(), fmt.Sprintf("mgmt.makePropertyKey('%s').dataType(Object.class)", k))
+	}
+}
+
 var tests = []func(*testing.T, *ent.Client){
 	Tx,
 	Types,
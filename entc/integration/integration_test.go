@@ -1934,6 +1934,43 @@ func EagerLoading(t *testing.T, client *ent.Client) {
 		require.Len(users[2].Edges.Groups, 1)
 		require.Equal(users[2].Edges.Groups[0].Name, "BitBucket")
 	})
+
+	t.Run("LimitPerNode", func(t *testing.T) {
+		skip(t, "MySQL/5")
+		users := client.User.
+			Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Order(ent.Asc(pet.FieldName)).Limit(2)
+			}).
+			WithGroups(func(q *ent.GroupQuery) {
+				q.Order(ent.Desc(group.FieldName)).Offset(1).Limit(1)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Len(users, 3)
+		require.Equal([]string{"xabi1", "xabi2"}, []string{users[0].Edges.Pets[0].Name, users[0].Edges.Pets[1].Name})
+		require.Len(users[0].Edges.Groups, 1)
+		require.Equal("GitHub", users[0].Edges.Groups[0].Name)
+		require.Len(users[1].Edges.Pets, 1)
+		require.Equal("nala", users[1].Edges.Pets[0].Name)
+		require.Empty(users[1].Edges.Groups)
+		require.Equal([]string{"lola1", "lola2"}, []string{users[2].Edges.Pets[0].Name, users[2].Edges.Pets[1].Name})
+		require.Len(users[2].Edges.Groups, 1)
+		require.Equal("BitBucket", users[2].Edges.Groups[0].Name)
+
+		users = client.User.
+			Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Order(ent.Desc(pet.FieldName)).Offset(2)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Len(users[0].Edges.Pets, 1)
+		require.Equal("xabi1", users[0].Edges.Pets[0].Name)
+		require.Empty(users[1].Edges.Pets)
+		require.Len(users[2].Edges.Pets, 2)
+		require.Equal([]string{"lola2", "lola1"}, []string{users[2].Edges.Pets[0].Name, users[2].Edges.Pets[1].Name})
+	})
 }
 
 func limitRows(partitionBy string, limit int, orderBy ...string) func(s *sql.Selector) {
@@ -25,6 +25,8 @@ type Media struct {
 	SourceURI string `json:"source_uri,omitempty"`
 	// media text
 	Text string `json:"text,omitempty"`
+	// TextLength holds the value of the "text_length" field.
+	TextLength int `json:"text_length,omitempty"`
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -32,7 +34,7 @@ func (*Media) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case media.FieldID:
+		case media.FieldID, media.FieldTextLength:
 			values[i] = new(sql.NullInt64)
 		case media.FieldSource, media.FieldSourceURI, media.FieldText:
 			values[i] = new(sql.NullString)
@@ -75,6 +77,12 @@ func (m *Media) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				m.Text = value.String
 			}
+		case media.FieldTextLength:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field text_length", values[i])
+			} else if value.Valid {
+				m.TextLength = int(value.Int64)
+			}
 		}
 	}
 	return nil
@@ -111,6 +119,9 @@ func (m *Media) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("text=")
 	builder.WriteString(m.Text)
+	builder.WriteString(", ")
+	builder.WriteString("text_length=")
+	builder.WriteString(fmt.Sprintf("%v", m.TextLength))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -17,6 +17,8 @@ const (
 	FieldSourceURI = "source_uri"
 	// FieldText holds the string denoting the text field in the database.
 	FieldText = "text"
+	// FieldTextLength holds the string denoting the text_length field in the database.
+	FieldTextLength = "text_length"
 	// Table holds the table name of the media in the database.
 	Table = "media"
 )
@@ -27,6 +29,7 @@ var Columns = []string{
 	FieldSource,
 	FieldSourceURI,
 	FieldText,
+	FieldTextLength,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
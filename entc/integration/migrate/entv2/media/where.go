@@ -71,6 +71,11 @@ func Text(v string) predicate.Media {
 	return predicate.Media(sql.FieldEQ(FieldText, v))
 }
 
+// TextLength applies equality check predicate on the "text_length" field. It's identical to TextLengthEQ.
+func TextLength(v int) predicate.Media {
+	return predicate.Media(sql.FieldEQ(FieldTextLength, v))
+}
+
 // SourceEQ applies the EQ predicate on the "source" field.
 func SourceEQ(v string) predicate.Media {
 	return predicate.Media(sql.FieldEQ(FieldSource, v))
@@ -296,6 +301,56 @@ func TextContainsFold(v string) predicate.Media {
 	return predicate.Media(sql.FieldContainsFold(FieldText, v))
 }
 
+// TextLengthEQ applies the EQ predicate on the "text_length" field.
+func TextLengthEQ(v int) predicate.Media {
+	return predicate.Media(sql.FieldEQ(FieldTextLength, v))
+}
+
+// TextLengthNEQ applies the NEQ predicate on the "text_length" field.
+func TextLengthNEQ(v int) predicate.Media {
+	return predicate.Media(sql.FieldNEQ(FieldTextLength, v))
+}
+
+// TextLengthIn applies the In predicate on the "text_length" field.
+func TextLengthIn(vs ...int) predicate.Media {
+	return predicate.Media(sql.FieldIn(FieldTextLength, vs...))
+}
+
+// TextLengthNotIn applies the NotIn predicate on the "text_length" field.
+func TextLengthNotIn(vs ...int) predicate.Media {
+	return predicate.Media(sql.FieldNotIn(FieldTextLength, vs...))
+}
+
+// TextLengthGT applies the GT predicate on the "text_length" field.
+func TextLengthGT(v int) predicate.Media {
+	return predicate.Media(sql.FieldGT(FieldTextLength, v))
+}
+
+// TextLengthGTE applies the GTE predicate on the "text_length" field.
+func TextLengthGTE(v int) predicate.Media {
+	return predicate.Media(sql.FieldGTE(FieldTextLength, v))
+}
+
+// TextLengthLT applies the LT predicate on the "text_length" field.
+func TextLengthLT(v int) predicate.Media {
+	return predicate.Media(sql.FieldLT(FieldTextLength, v))
+}
+
+// TextLengthLTE applies the LTE predicate on the "text_length" field.
+func TextLengthLTE(v int) predicate.Media {
+	return predicate.Media(sql.FieldLTE(FieldTextLength, v))
+}
+
+// TextLengthIsNil applies the IsNil predicate on the "text_length" field.
+func TextLengthIsNil() predicate.Media {
+	return predicate.Media(sql.FieldIsNull(FieldTextLength))
+}
+
+// TextLengthNotNil applies the NotNil predicate on the "text_length" field.
+func TextLengthNotNil() predicate.Media {
+	return predicate.Media(sql.FieldNotNull(FieldTextLength))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Media) predicate.Media {
 	return predicate.Media(func(s *sql.Selector) {
@@ -92,6 +92,7 @@ var (
 		{Name: "source", Type: field.TypeString, Nullable: true},
 		{Name: "source_uri", Type: field.TypeString, Nullable: true},
 		{Name: "text", Type: field.TypeString, Nullable: true, Size: 2147483647, Comment: "media text"},
+		{Name: "text_length", Type: field.TypeInt, Nullable: true, Generated: &entsql.GeneratedColumn{Exprs: map[string]string{"mysql": "char_length(`text`)", "postgres": "length(text)", "sqlite3": "length(`text`)"}, Type: entsql.Stored}},
 	}
 	// MediaTable holds the schema information for the "media" table.
 	MediaTable = &schema.Table{
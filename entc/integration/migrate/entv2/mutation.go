@@ -2620,6 +2620,7 @@ type MediaMutation struct {
 	source        *string
 	source_uri    *string
 	text          *string
+	text_length   *int
 	clearedFields map[string]struct{}
 	done          bool
 	oldValue      func(context.Context) (*Media, error)
@@ -2871,6 +2872,37 @@ func (m *MediaMutation) ResetText() {
 	delete(m.clearedFields, media.FieldText)
 }
 
+// TextLength returns the value of the "text_length" field in the mutation.
+func (m *MediaMutation) TextLength() (r int, exists bool) {
+	v := m.text_length
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTextLength returns the old "text_length" field's value of the Media entity.
+// If the Media object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MediaMutation) OldTextLength(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTextLength is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTextLength requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTextLength: %w", err)
+	}
+	return oldValue.TextLength, nil
+}
+
+// ResetTextLength resets all changes to the "text_length" field.
+func (m *MediaMutation) ResetTextLength() {
+	m.text_length = nil
+}
+
 // Where appends a list predicates to the MediaMutation builder.
 func (m *MediaMutation) Where(ps ...predicate.Media) {
 	m.predicates = append(m.predicates, ps...)
@@ -2905,7 +2937,7 @@ func (m *MediaMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *MediaMutation) Fields() []string {
-	fields := make([]string, 0, 3)
+	fields := make([]string, 0, 4)
 	if m.source != nil {
 		fields = append(fields, media.FieldSource)
 	}
@@ -2915,6 +2947,9 @@ func (m *MediaMutation) Fields() []string {
 	if m.text != nil {
 		fields = append(fields, media.FieldText)
 	}
+	if m.text_length != nil {
+		fields = append(fields, media.FieldTextLength)
+	}
 	return fields
 }
 
@@ -2929,6 +2964,8 @@ func (m *MediaMutation) Field(name string) (ent.Value, bool) {
 		return m.SourceURI()
 	case media.FieldText:
 		return m.Text()
+	case media.FieldTextLength:
+		return m.TextLength()
 	}
 	return nil, false
 }
@@ -2944,6 +2981,8 @@ func (m *MediaMutation) OldField(ctx context.Context, name string) (ent.Value, e
 		return m.OldSourceURI(ctx)
 	case media.FieldText:
 		return m.OldText(ctx)
+	case media.FieldTextLength:
+		return m.OldTextLength(ctx)
 	}
 	return nil, fmt.Errorf("unknown Media field %s", name)
 }
@@ -2974,6 +3013,8 @@ func (m *MediaMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetText(v)
 		return nil
+	case media.FieldTextLength:
+		return fmt.Errorf("generated Media field %s cannot be set", name)
 	}
 	return fmt.Errorf("unknown Media field %s", name)
 }
@@ -2981,13 +3022,16 @@ func (m *MediaMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *MediaMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *MediaMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	}
 	return nil, false
 }
 
@@ -3053,6 +3097,9 @@ func (m *MediaMutation) ResetField(name string) error {
 	case media.FieldText:
 		m.ResetText()
 		return nil
+	case media.FieldTextLength:
+		m.ResetTextLength()
+		return nil
 	}
 	return fmt.Errorf("unknown Media field %s", name)
 }
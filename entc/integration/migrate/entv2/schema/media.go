@@ -6,6 +6,7 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/field"
@@ -29,6 +30,14 @@ func (Media) Fields() []ent.Field {
 		field.Text("text").
 			Optional().
 			Comment("media text"),
+		// A column that is computed by the database from the text column.
+		field.Int("text_length").
+			Optional().
+			Annotations(entsql.GeneratedAsExprs(map[string]string{
+				dialect.MySQL:    "char_length(`text`)",
+				dialect.Postgres: "length(text)",
+				dialect.SQLite:   "length(`text`)",
+			})),
 	}
 }
 
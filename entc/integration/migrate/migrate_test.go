@@ -68,6 +68,7 @@ func TestMySQL(t *testing.T) {
 			V1ToV2(t, drv.Dialect(), clientv1, clientv2)
 			if version == "8" {
 				CheckConstraint(t, clientv2)
+				GeneratedColumn(t, clientv2)
 				DefaultExpr(t, drv, "SELECT column_default FROM information_schema.columns WHERE table_schema = 'migrate' AND table_name = 'users' AND column_name = ?", "lower(_utf8mb4\\'hello\\')", "to_base64(_utf8mb4\\'ent\\')")
 				PKDefault(t, drv, "SELECT column_default FROM information_schema.columns WHERE table_schema = 'migrate' AND table_name = 'zoos' AND column_name = ?", "floor((rand() * ~((1 << 31))))")
 			}
@@ -135,6 +136,7 @@ func TestPostgres(t *testing.T) {
 				},
 			)
 			CheckConstraint(t, clientv2)
+			GeneratedColumn(t, clientv2)
 			TimePrecision(t, drv, "SELECT datetime_precision FROM information_schema.columns WHERE table_name = $1 AND column_name = $2")
 			PartialIndexes(t, drv, "select indexdef from pg_indexes where indexname=$1", "CREATE INDEX user_phone ON public.users USING btree (phone) WHERE active")
 			JSONDefault(t, drv, `SELECT column_default FROM information_schema.columns WHERE table_name = 'users' AND column_name = $1`)
@@ -234,6 +236,7 @@ func TestSQLite(t *testing.T) {
 	EqualFold(t, client)
 	ContainsFold(t, client)
 	CheckConstraint(t, client)
+	GeneratedColumn(t, client)
 
 	vdrv, err := sql.Open("sqlite3", "file:versioned_ent?mode=memory&cache=shared&_fk=1")
 	require.NoError(t, err)
@@ -654,6 +657,27 @@ func CheckConstraint(t *testing.T, client *entv2.Client) {
 	require.Error(t, err)
 }
 
+func GeneratedColumn(t *testing.T, client *entv2.Client) {
+	ctx := context.Background()
+	t.Log("testing generated columns")
+	client.Media.CreateBulk(
+		client.Media.Create().SetText("generated"),
+		client.Media.Create().SetText("ent"),
+	).ExecX(ctx)
+	lengths := client.Media.Query().
+		Where(media.TextLengthGT(0)).
+		Order(entv2.Asc(media.FieldTextLength)).
+		Select(media.FieldTextLength).
+		IntsX(ctx)
+	require.Equal(t, []int{3, 9}, lengths)
+	n := client.Media.Update().
+		Where(media.TextLength(3)).
+		SetText("entgo").
+		SaveX(ctx)
+	require.Equal(t, 1, n)
+	require.True(t, client.Media.Query().Where(media.TextLength(5)).ExistX(ctx))
+}
+
 func NicknameSearch(t *testing.T, client *entv2.Client) {
 	ctx := context.Background()
 	names := client.User.Query().
@@ -0,0 +1,160 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"database/sql"
+	fmt "fmt"
+	strings "strings"
+
+	post "entgo.io/ent/entc/integration/typefeatures/ent/post"
+	user "entgo.io/ent/entc/integration/typefeatures/ent/user"
+)
+
+// Post is the model entity for the Post schema.
+type Post struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Text holds the value of the "text" field.
+	Text string `json:"text,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PostQuery when eager-loading is set.
+	Edges      PostEdges `json:"edges"`
+	user_posts *int
+}
+
+// PostEdges holds the relations/edges for other nodes in the graph.
+type PostEdges struct {
+	// Author holds the value of the author edge.
+	Author *User `json:"author,omitempty"`
+	// LikedBy holds the value of the liked_by edge.
+	LikedBy []*User `json:"liked_by,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// AuthorOrErr returns the Author value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PostEdges) AuthorOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.Author == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.Author, nil
+	}
+	return nil, &NotLoadedError{edge: "author"}
+}
+
+// LikedByOrErr returns the LikedBy value or an error if the edge
+// was not loaded in eager-loading.
+func (e PostEdges) LikedByOrErr() ([]*User, error) {
+	if e.loadedTypes[1] {
+		return e.LikedBy, nil
+	}
+	return nil, &NotLoadedError{edge: "liked_by"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Post) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case post.FieldID:
+			values[i] = new(sql.NullInt64)
+		case post.FieldText:
+			values[i] = new(sql.NullString)
+		case post.ForeignKeys[0]: // user_posts
+			values[i] = new(sql.NullInt64)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type Post", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Post fields.
+func (po *Post) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case post.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			po.ID = int(value.Int64)
+		case post.FieldText:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field text", values[i])
+			} else if value.Valid {
+				po.Text = value.String
+			}
+		case post.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_posts", value)
+			} else if value.Valid {
+				po.user_posts = new(int)
+				*po.user_posts = int(value.Int64)
+			}
+		}
+	}
+	return nil
+}
+
+// QueryAuthor queries the "author" edge of the Post entity.
+func (po *Post) QueryAuthor() *UserQuery {
+	return NewPostClient(po.config).QueryAuthor(po)
+}
+
+// QueryLikedBy queries the "liked_by" edge of the Post entity.
+func (po *Post) QueryLikedBy() *UserQuery {
+	return NewPostClient(po.config).QueryLikedBy(po)
+}
+
+// Update returns a builder for updating this Post.
+// Note that you need to call Post.Unwrap() before calling this method if this Post
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (po *Post) Update() *PostUpdateOne {
+	return NewPostClient(po.config).UpdateOne(po)
+}
+
+// Unwrap unwraps the Post entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (po *Post) Unwrap() *Post {
+	_tx, ok := po.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Post is not a transactional entity")
+	}
+	po.config.driver = _tx.drv
+	return po
+}
+
+// String implements the fmt.Stringer.
+func (po *Post) String() string {
+	var builder strings.Builder
+	builder.WriteString("Post(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", po.ID))
+	builder.WriteString("text=")
+	builder.WriteString(po.Text)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Posts is a parsable slice of Post.
+type Posts []*Post
+
+func (po Posts) config(cfg config) {
+	for _i := range po {
+		po[_i].config = cfg
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package typefeatures
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/typefeatures/ent"
+	"entgo.io/ent/entc/integration/typefeatures/ent/enttest"
+	"entgo.io/ent/entc/integration/typefeatures/ent/post"
+	"entgo.io/ent/entc/integration/typefeatures/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeFeatures(t *testing.T) {
+	client := enttest.Open(t, dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	client.Post.Create().SetText("foo").SetAuthor(a8m).AddLikedBy(a8m, nati).SaveX(ctx)
+	client.Post.Create().SetText("bar").SetAuthor(a8m).AddLikedBy(nati).SaveX(ctx)
+
+	// Named edges are enabled only for users, and load posts that do not have them.
+	u := client.User.Query().
+		Where(user.ID(a8m.ID)).
+		WithNamedPosts("foo", func(q *ent.PostQuery) {
+			q.Where(post.Text("foo"))
+		}).
+		WithNamedLikedPosts("all", func(q *ent.PostQuery) {
+			q.WithLikedBy()
+		}).
+		OnlyX(ctx)
+	posts, err := u.NamedPosts("foo")
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, "foo", posts[0].Text)
+	liked, err := u.NamedLikedPosts("all")
+	require.NoError(t, err)
+	require.Len(t, liked, 1)
+	require.Len(t, liked[0].Edges.LikedBy, 2)
+
+	// Posts eager-load their users, which have modifiers.
+	p := client.Post.Query().
+		Where(post.Text("bar")).
+		WithAuthor().
+		WithLikedBy(func(q *ent.UserQuery) {
+			q.Modify(func(s *sql.Selector) {
+				s.Where(sql.EQ(s.C(user.FieldName), "nati"))
+			})
+		}).
+		OnlyX(ctx)
+	require.Equal(t, a8m.ID, p.Edges.Author.ID)
+	require.Len(t, p.Edges.LikedBy, 1)
+	require.Equal(t, nati.ID, p.Edges.LikedBy[0].ID)
+
+	// Upsert is enabled only for posts.
+	id := client.Post.Create().
+		SetText("baz").
+		OnConflictColumns(post.FieldID).
+		UpdateNewValues().
+		IDX(ctx)
+	require.Equal(t, "baz", client.Post.GetX(ctx, id).Text)
+}
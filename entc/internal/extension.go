@@ -0,0 +1,106 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"entgo.io/ent/entc/gen"
+
+	"golang.org/x/tools/imports"
+)
+
+// GenerateAfter returns a hook that executes the given function
+// after the code generation of ent. It is used by the extensions
+// that generate packages on top of the generated client.
+func GenerateAfter(fn func(*gen.Graph) error) gen.Hook {
+	return func(next gen.Generator) gen.Generator {
+		return gen.GenerateFunc(func(g *gen.Graph) error {
+			if err := next.Generate(g); err != nil {
+				return err
+			}
+			return fn(g)
+		})
+	}
+}
+
+// Header returns the header of the Go files generated by extensions for the graph.
+func Header(g *gen.Graph) string {
+	h := strings.TrimSpace(g.Header)
+	if h == "" {
+		return "// Code generated by ent, DO NOT EDIT."
+	}
+	lines := strings.Split(h, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GenPackage describes a package that is generated by an extension.
+type GenPackage struct {
+	// Dir is the directory of the package.
+	Dir string
+	// Header of the generated Go files. Go files in the directory that
+	// start with the header, and were not generated, are removed.
+	Header string
+	// Files maps the file names to their content.
+	Files map[string][]byte
+	// Keep reports if a file in the directory should be kept, even if it
+	// starts with the header (e.g. files generated by protoc). Optional.
+	Keep func(name string) bool
+}
+
+// Write formats and writes the files of the package, and removes the generated Go files in
+// the package directory that were not generated in the current run (e.g. of types that were
+// deleted or skipped). Files that were not generated by the extension are kept.
+func (p *GenPackage) Write() error {
+	if err := os.MkdirAll(p.Dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+	for name, buf := range p.Files {
+		path := filepath.Join(p.Dir, name)
+		if filepath.Ext(name) == ".go" {
+			src, err := imports.Process(path, buf, nil)
+			if err != nil {
+				return fmt.Errorf("format file %s: %w", path, err)
+			}
+			buf = src
+		}
+		if err := os.WriteFile(path, buf, 0644); err != nil {
+			return fmt.Errorf("write file %s: %w", path, err)
+		}
+	}
+	return p.clean()
+}
+
+// clean removes the stale generated Go files of the package.
+func (p *GenPackage) clean() error {
+	entries, err := os.ReadDir(p.Dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if _, ok := p.Files[name]; ok || e.IsDir() || filepath.Ext(name) != ".go" || p.Keep != nil && p.Keep(name) {
+			continue
+		}
+		path := filepath.Join(p.Dir, name)
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.HasPrefix(buf, []byte(p.Header)) {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/entc/gen"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAfter(t *testing.T) {
+	var calls []string
+	hook := GenerateAfter(func(*gen.Graph) error {
+		calls = append(calls, "extension")
+		return nil
+	})
+	err := hook(gen.GenerateFunc(func(*gen.Graph) error {
+		calls = append(calls, "ent")
+		return nil
+	})).Generate(&gen.Graph{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ent", "extension"}, calls)
+}
+
+func TestGenPackage_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pkg")
+	header := Header(&gen.Graph{Config: &gen.Config{}})
+	p := &GenPackage{
+		Dir:    dir,
+		Header: header,
+		Files: map[string][]byte{
+			"user.go":     []byte(header + "\npackage pkg\n"),
+			"pet.go":      []byte(header + "\npackage pkg\n"),
+			"schema.json": []byte("{}\n"),
+		},
+		Keep: func(name string) bool { return name == "keep.go" },
+	}
+	require.NoError(t, p.Write())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keep.go"), []byte(header+"\npackage pkg\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user_ext.go"), []byte("package pkg\n"), 0644))
+
+	delete(p.Files, "pet.go")
+	require.NoError(t, p.Write())
+	for name, exists := range map[string]bool{"user.go": true, "pet.go": false, "schema.json": true, "keep.go": true, "user_ext.go": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		require.Equal(t, exists, err == nil, name)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package gentest provides helpers for testing the codegen extensions.
+package gentest
+
+import (
+	"testing"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Graph returns a graph of the given schemas that is
+// generated in the given package and target directory.
+func Graph(t *testing.T, pkg, target string, schemas ...*load.Schema) *gen.Graph {
+	t.Helper()
+	storage, err := gen.NewStorage("sql")
+	require.NoError(t, err)
+	g, err := gen.NewGraph(&gen.Config{
+		Package: pkg,
+		Target:  target,
+		Storage: storage,
+		IDType:  &field.TypeInfo{Type: field.TypeInt},
+	}, schemas...)
+	require.NoError(t, err)
+	return g
+}
+
+// Annotations returns the annotations map of a loaded schema, field or edge.
+func Annotations(ants ...interface{ Name() string }) map[string]any {
+	m := make(map[string]any, len(ants))
+	for _, ant := range ants {
+		m[ant.Name()] = ant
+	}
+	return m
+}
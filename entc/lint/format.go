@@ -0,0 +1,124 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// WriteText writes the diagnostics to w, one per line.
+func WriteText(w io.Writer, diags []*Diagnostic) error {
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the JSON encoding of the diagnostics to w.
+func WriteJSON(w io.Writer, diags []*Diagnostic) error {
+	if diags == nil {
+		diags = []*Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diags)
+}
+
+// SARIF types. See: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type (
+	sarifLog struct {
+		Version string      `json:"version"`
+		Schema  string      `json:"$schema"`
+		Runs    []*sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool      `json:"tool"`
+		Results []*sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string       `json:"name"`
+		InformationURI string       `json:"informationUri"`
+		Rules          []*sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+		DefaultConfig    sarifConfig  `json:"defaultConfiguration"`
+	}
+	sarifConfig struct {
+		Level Severity `json:"level"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string           `json:"ruleId"`
+		Level     Severity         `json:"level"`
+		Message   sarifMessage     `json:"message"`
+		Locations []*sarifLocation `json:"locations,omitempty"`
+	}
+	sarifLocation struct {
+		Physical sarifPhysical `json:"physicalLocation"`
+	}
+	sarifPhysical struct {
+		Artifact sarifArtifact `json:"artifactLocation"`
+		Region   *sarifRegion  `json:"region,omitempty"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+	}
+)
+
+// WriteSARIF writes the diagnostics to w in the SARIF 2.1.0 format, that is
+// supported by CI systems for annotating the source code (e.g. GitHub code scanning).
+// The given rules are written as the rules metadata of the tool.
+func WriteSARIF(w io.Writer, rules []*Rule, diags []*Diagnostic) error {
+	run := &sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{Name: "ent lint", InformationURI: "https://entgo.io", Rules: make([]*sarifRule, 0, len(rules))},
+		},
+		Results: make([]*sarifResult, 0, len(diags)),
+	}
+	for _, r := range rules {
+		level := r.Severity
+		if level == "" {
+			level = SeverityError
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &sarifRule{
+			ID:               r.Name,
+			ShortDescription: sarifMessage{Text: r.Doc},
+			DefaultConfig:    sarifConfig{Level: level},
+		})
+	}
+	for _, d := range diags {
+		r := &sarifResult{RuleID: d.Rule, Level: d.Severity, Message: sarifMessage{Text: d.Subject() + ": " + d.Message}}
+		if d.Filename != "" {
+			loc := &sarifLocation{Physical: sarifPhysical{Artifact: sarifArtifact{URI: filepath.ToSlash(d.Filename)}}}
+			if d.Line > 0 {
+				loc.Physical.Region = &sarifRegion{StartLine: d.Line}
+			}
+			r.Locations = append(r.Locations, loc)
+		}
+		run.Results = append(run.Results, r)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []*sarifRun{run},
+	})
+}
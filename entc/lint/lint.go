@@ -0,0 +1,200 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package lint provides a set of rules for validating ent schemas at generation
+// time, and a gen.Hook for running them as part of the code generation.
+//
+//	err := entc.Generate("./schema", &gen.Config{
+//		Hooks: []gen.Hook{
+//			lint.Hook(lint.StringMaxLen(), lint.EnumUpperCase()),
+//		},
+//	})
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+)
+
+// Severity describes the severity of a diagnostic.
+// The values match the SARIF result levels.
+type Severity string
+
+// List of severity levels.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+type (
+	// A Rule checks the types of the graph and reports their violations.
+	Rule struct {
+		// Name of the rule. e.g. "string-max-len".
+		Name string
+		// Doc is a short description of the rule.
+		Doc string
+		// Severity of the diagnostics that are reported by the rule.
+		// Defaults to SeverityError.
+		Severity Severity
+		// Check is called for each type in the graph.
+		Check func(*Report, *gen.Type)
+	}
+
+	// Report is passed to the rule checks for reporting diagnostics.
+	Report struct {
+		rule  *Rule
+		typ   *gen.Type
+		diags []*Diagnostic
+	}
+
+	// Diagnostic describes a rule violation.
+	Diagnostic struct {
+		Rule     string   `json:"rule"`
+		Severity Severity `json:"severity"`
+		Message  string   `json:"message"`
+		Type     string   `json:"type"`
+		Field    string   `json:"field,omitempty"`
+		Edge     string   `json:"edge,omitempty"`
+		Filename string   `json:"filename,omitempty"`
+		Line     int      `json:"line,omitempty"`
+	}
+
+	// Error is returned by the Hook when one of the rules reports a
+	// diagnostic with an error severity.
+	Error struct {
+		Diagnostics []*Diagnostic
+	}
+)
+
+// NewRule creates a new rule with the given name, description and check function.
+func NewRule(name, doc string, check func(*Report, *gen.Type)) *Rule {
+	return &Rule{Name: name, Doc: doc, Severity: SeverityError, Check: check}
+}
+
+// Typef reports a diagnostic on the type.
+func (r *Report) Typef(format string, a ...any) {
+	r.report(r.typ.Position(), "", "", format, a...)
+}
+
+// Fieldf reports a diagnostic on a field of the type. The diagnostic is
+// positioned at the field declaration if it is known, or at the type otherwise.
+func (r *Report) Fieldf(f *gen.Field, format string, a ...any) {
+	pos := f.Position
+	if pos.String() == "" {
+		pos = r.typ.Position()
+	}
+	r.report(pos, f.Name, "", format, a...)
+}
+
+// Edgef reports a diagnostic on an edge of the type.
+func (r *Report) Edgef(e *gen.Edge, format string, a ...any) {
+	r.report(r.typ.Position(), "", e.Name, format, a...)
+}
+
+func (r *Report) report(pos *load.Position, field, edge, format string, a ...any) {
+	d := &Diagnostic{
+		Rule:     r.rule.Name,
+		Severity: r.rule.Severity,
+		Message:  fmt.Sprintf(format, a...),
+		Type:     r.typ.Name,
+		Field:    field,
+		Edge:     edge,
+	}
+	if d.Severity == "" {
+		d.Severity = SeverityError
+	}
+	if pos != nil {
+		d.Filename, d.Line = pos.Filename, pos.Line
+	}
+	r.diags = append(r.diags, d)
+}
+
+// Run runs the given rules on the graph and returns their diagnostics,
+// sorted by their position.
+func Run(g *gen.Graph, rules ...*Rule) []*Diagnostic {
+	positions(g)
+	var diags []*Diagnostic
+	for _, rule := range rules {
+		for _, n := range g.Nodes {
+			r := &Report{rule: rule, typ: n}
+			rule.Check(r, n)
+			diags = append(diags, r.diags...)
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Filename != diags[j].Filename {
+			return diags[i].Filename < diags[j].Filename
+		}
+		return diags[i].Line < diags[j].Line
+	})
+	return diags
+}
+
+// positions resolves the source positions of the schemas in the graph, if they were
+// loaded from a schema package and were not resolved before. Diagnostics are reported
+// without positions in case they cannot be resolved.
+func positions(g *gen.Graph) {
+	if g.Schema == "" || len(g.Schemas) == 0 || g.Schemas[0].Position != nil {
+		return
+	}
+	_ = (&load.Config{Path: g.Schema, BuildFlags: g.BuildFlags}).Positions(g.Schemas)
+}
+
+// Hook returns a gen.Hook that runs the given rules before the code generation,
+// and fails it if one of them reports a diagnostic with an error severity.
+func Hook(rules ...*Rule) gen.Hook {
+	return func(next gen.Generator) gen.Generator {
+		return gen.GenerateFunc(func(g *gen.Graph) error {
+			var errs []*Diagnostic
+			for _, d := range Run(g, rules...) {
+				if d.Severity == SeverityError {
+					errs = append(errs, d)
+				}
+			}
+			if len(errs) > 0 {
+				return &Error{Diagnostics: errs}
+			}
+			return next.Generate(g)
+		})
+	}
+}
+
+// String returns the textual representation of the diagnostic.
+// e.g. "user.go:12: User.name: string field has no max length (string-max-len)".
+func (d *Diagnostic) String() string {
+	var b strings.Builder
+	if d.Filename != "" {
+		fmt.Fprintf(&b, "%s:%d: ", d.Filename, d.Line)
+	}
+	fmt.Fprintf(&b, "%s: %s (%s)", d.Subject(), d.Message, d.Rule)
+	return b.String()
+}
+
+// Subject returns the schema object that the diagnostic was reported
+// on. e.g. "User", "User.name" (field) or "User.pets" (edge).
+func (d *Diagnostic) Subject() string {
+	switch {
+	case d.Field != "":
+		return d.Type + "." + d.Field
+	case d.Edge != "":
+		return d.Type + "." + d.Edge
+	default:
+		return d.Type
+	}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	lines := make([]string, 0, len(e.Diagnostics)+1)
+	lines = append(lines, fmt.Sprintf("entc/lint: %d schema violation(s) found:", len(e.Diagnostics)))
+	for _, d := range e.Diagnostics {
+		lines = append(lines, "\t"+d.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,168 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func graph(t *testing.T) *gen.Graph {
+	storage, err := gen.NewStorage("sql")
+	require.NoError(t, err)
+	size := int64(255)
+	user := &load.Schema{
+		Name:     "User",
+		Position: &load.Position{Filename: "schema/user.go", Line: 10},
+		Fields: []*load.Field{
+			{Name: "create_time", Info: &field.TypeInfo{Type: field.TypeTime}, Position: &load.Position{MixedIn: true}},
+			{Name: "update_time", Info: &field.TypeInfo{Type: field.TypeTime}, Position: &load.Position{MixedIn: true, Index: 1}},
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Size: &size, Position: &load.Position{Filename: "schema/user.go", Line: 20}},
+			{Name: "nickname", Info: &field.TypeInfo{Type: field.TypeString}, Position: &load.Position{Index: 1, Filename: "schema/user.go", Line: 21}},
+			{Name: "data", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "map[string]interface {}", RType: &field.RType{Ident: "map[string]interface {}", Kind: reflect.Map}}, Position: &load.Position{Index: 2, Filename: "schema/user.go", Line: 22}},
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{N: "ACTIVE", V: "ACTIVE"}, {N: "inactive", V: "inactive"}}, Position: &load.Position{Index: 3, Filename: "schema/user.go", Line: 23}},
+		},
+		Edges: []*load.Edge{
+			{Name: "pets", Type: "Pet"},
+		},
+	}
+	pet := &load.Schema{
+		Name:     "Pet",
+		Position: &load.Position{Filename: "schema/pet.go", Line: 10},
+		Fields: []*load.Field{
+			{Name: "create_time", Info: &field.TypeInfo{Type: field.TypeTime}},
+			{Name: "update_time", Info: &field.TypeInfo{Type: field.TypeTime}},
+		},
+		Edges: []*load.Edge{
+			{Name: "owner", Type: "User", Inverse: true, RefName: "pets", Unique: true},
+		},
+	}
+	g, err := gen.NewGraph(&gen.Config{Package: "entc/lint", Storage: storage}, user, pet)
+	require.NoError(t, err)
+	return g
+}
+
+func TestRules(t *testing.T) {
+	g := graph(t)
+	tests := []struct {
+		rule *Rule
+		want []string
+	}{
+		{
+			rule: TimeMixin(),
+			want: nil,
+		},
+		{
+			rule: StringMaxLen(),
+			want: []string{`schema/user.go:21: User.nickname: string field "nickname" has no maximum length. Use MaxLen for setting it (string-max-len)`},
+		},
+		{
+			rule: ForeignKeyIndex(),
+			want: []string{`schema/pet.go:10: Pet: foreign-key column "user_pets" of edge User.pets is not indexed (fk-index)`},
+		},
+		{
+			rule: NoJSONAny(),
+			want: []string{`schema/user.go:22: User.data: JSON field "data" is defined with an untyped Go type (map[string]interface {}) (no-json-any)`},
+		},
+		{
+			rule: StorageKeyNaming(SnakeCase),
+			want: nil,
+		},
+		{
+			rule: EnumUpperCase(),
+			want: []string{`schema/user.go:23: User.status: enum value "inactive" of field "status" is not in UPPER_CASE (enum-upper-case)`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule.Name, func(t *testing.T) {
+			var got []string
+			for _, d := range Run(g, tt.rule) {
+				got = append(got, d.String())
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	g := graph(t)
+	g.Nodes[1].Fields = nil
+	diags := Run(g, Rules()...)
+	require.Len(t, diags, 5)
+	require.Equal(t, "schema/pet.go", diags[0].Filename, "sorted by position")
+	require.Equal(t, "time-mixin", diags[0].Rule)
+	require.Equal(t, "Pet", diags[0].Subject())
+	require.Equal(t, "schema/user.go", diags[2].Filename)
+	require.Equal(t, 21, diags[2].Line)
+}
+
+func TestHook(t *testing.T) {
+	var called bool
+	next := gen.GenerateFunc(func(*gen.Graph) error {
+		called = true
+		return nil
+	})
+	err := Hook(EnumUpperCase())(next).Generate(graph(t))
+	require.Error(t, err)
+	require.IsType(t, &Error{}, err)
+	require.Contains(t, err.Error(), "1 schema violation(s) found")
+	require.False(t, called)
+
+	rule := EnumUpperCase()
+	rule.Severity = SeverityWarning
+	require.NoError(t, Hook(rule, TimeMixin())(next).Generate(graph(t)))
+	require.True(t, called)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	g := graph(t)
+	rules := []*Rule{EnumUpperCase(), TimeMixin()}
+	var b bytes.Buffer
+	require.NoError(t, WriteSARIF(&b, rules, Run(g, rules...)))
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				Level     string
+				Message   struct{ Text string }
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+						Region           struct{ StartLine int }
+					}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+	require.Len(t, log.Runs[0].Results, 1)
+	r := log.Runs[0].Results[0]
+	require.Equal(t, "enum-upper-case", r.RuleID)
+	require.Equal(t, "error", r.Level)
+	require.Equal(t, `User.status: enum value "inactive" of field "status" is not in UPPER_CASE`, r.Message.Text)
+	require.Equal(t, "schema/user.go", r.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, 23, r.Locations[0].PhysicalLocation.Region.StartLine)
+
+	b.Reset()
+	require.NoError(t, WriteJSON(&b, nil))
+	require.Equal(t, "[]\n", b.String())
+}
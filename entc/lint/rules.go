@@ -0,0 +1,174 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/schema/field"
+)
+
+var (
+	// SnakeCase matches lower snake_case identifiers. e.g. "user_groups".
+	SnakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	// UpperCase matches UPPER_CASE identifiers. e.g. "IN_PROGRESS".
+	UpperCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// Rules returns the built-in rules of the package.
+func Rules() []*Rule {
+	return []*Rule{
+		TimeMixin(),
+		StringMaxLen(),
+		ForeignKeyIndex(),
+		NoJSONAny(),
+		StorageKeyNaming(SnakeCase),
+		EnumUpperCase(),
+	}
+}
+
+// TimeMixin reports types that do not have the "create_time" and
+// "update_time" fields, that are defined by the mixin.Time.
+func TimeMixin() *Rule {
+	return NewRule("time-mixin", "types must have the create_time and update_time fields of mixin.Time", func(r *Report, t *gen.Type) {
+		var missing []string
+		for _, name := range []string{"create_time", "update_time"} {
+			if !hasTimeField(t, name) {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			r.Typef("missing %s time field(s). Use mixin.Time for adding them", strings.Join(missing, " and "))
+		}
+	})
+}
+
+func hasTimeField(t *gen.Type, name string) bool {
+	for _, f := range t.Fields {
+		if f.Name == name && f.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// StringMaxLen reports string fields that do not define their maximum length
+// using the MaxLen option or the entsql.Annotation.
+func StringMaxLen() *Rule {
+	return NewRule("string-max-len", "string fields must define their maximum length", func(r *Report, t *gen.Type) {
+		for _, f := range t.Fields {
+			if f.Type.Type == field.TypeString && !f.IsEdgeField() && f.Column().Size == 0 {
+				r.Fieldf(f, "string field %q has no maximum length. Use MaxLen for setting it", f.Name)
+			}
+		}
+	})
+}
+
+// ForeignKeyIndex reports foreign-key columns that are not covered by an index.
+// A column is covered if it is unique, or if it is the first column of an index
+// or of the composite primary key of an edge schema.
+func ForeignKeyIndex() *Rule {
+	return NewRule("fk-index", "foreign-key columns must be covered by an index", func(r *Report, t *gen.Type) {
+		covered := make(map[string]bool)
+		for _, idx := range t.Indexes {
+			if len(idx.Columns) > 0 {
+				covered[idx.Columns[0]] = true
+			}
+		}
+		if t.HasCompositeID() {
+			covered[t.EdgeSchema.ID[0].StorageKey()] = true
+		}
+		for _, fk := range t.ForeignKeys {
+			column := fk.Edge.Rel.Column()
+			if covered[column] || fk.Field.Unique {
+				continue
+			}
+			switch {
+			case fk.UserDefined:
+				r.Fieldf(fk.Field, "foreign-key column %q of edge %q is not indexed", column, fk.Edge.Name)
+			case fk.Edge.Owner == t:
+				r.Edgef(fk.Edge, "foreign-key column %q of edge %q is not indexed", column, fk.Edge.Name)
+			default:
+				r.Typef("foreign-key column %q of edge %s.%s is not indexed", column, fk.Edge.Owner.Name, fk.Edge.Name)
+			}
+		}
+	})
+}
+
+// NoJSONAny reports JSON fields that are defined with an untyped (any) Go type,
+// or with a type that holds untyped values. e.g. field.JSON("data", map[string]any{}).
+func NoJSONAny() *Rule {
+	return NewRule("no-json-any", "JSON fields must not be defined with an untyped (any) Go type", func(r *Report, t *gen.Type) {
+		for _, f := range t.Fields {
+			if !f.IsJSON() {
+				continue
+			}
+			ident := f.Type.Ident
+			if rt := f.Type.RType; rt != nil {
+				ident = rt.Ident
+			}
+			if strings.Contains(ident, "interface {}") {
+				r.Fieldf(f, "JSON field %q is defined with an untyped Go type (%s)", f.Name, ident)
+			}
+		}
+	})
+}
+
+// StorageKeyNaming reports tables, columns, join tables and indexes
+// that their names do not match the given pattern. e.g. SnakeCase.
+func StorageKeyNaming(re *regexp.Regexp) *Rule {
+	return NewRule("storage-key-naming", "storage keys must match the pattern "+re.String(), func(r *Report, t *gen.Type) {
+		if name := t.Table(); !re.MatchString(name) {
+			r.Typef("table name %q does not match the pattern %s", name, re)
+		}
+		if t.HasOneFieldID() && t.ID != nil && !re.MatchString(t.ID.StorageKey()) {
+			r.Fieldf(t.ID, "column name %q does not match the pattern %s", t.ID.StorageKey(), re)
+		}
+		for _, f := range t.Fields {
+			if name := f.StorageKey(); !re.MatchString(name) {
+				r.Fieldf(f, "column name %q does not match the pattern %s", name, re)
+			}
+		}
+		for _, e := range t.Edges {
+			// Edge storage keys are checked on the edge that owns them.
+			if e.IsInverse() {
+				continue
+			}
+			if e.M2M() && e.Through == nil && !re.MatchString(e.Rel.Table) {
+				r.Edgef(e, "join table name %q does not match the pattern %s", e.Rel.Table, re)
+			}
+			if e.Field() == nil {
+				for _, c := range e.Rel.Columns {
+					if !re.MatchString(c) {
+						r.Edgef(e, "column name %q does not match the pattern %s", c, re)
+					}
+				}
+			}
+		}
+		for _, idx := range t.Indexes {
+			if !re.MatchString(idx.Name) {
+				r.Typef("index name %q does not match the pattern %s", idx.Name, re)
+			}
+		}
+	})
+}
+
+// EnumUpperCase reports enum values that are not in UPPER_CASE.
+func EnumUpperCase() *Rule {
+	return NewRule("enum-upper-case", "enum values must be in UPPER_CASE", func(r *Report, t *gen.Type) {
+		for _, f := range t.Fields {
+			if !f.IsEnum() {
+				continue
+			}
+			for _, v := range f.EnumValues() {
+				if !UpperCase.MatchString(v) {
+					r.Fieldf(f, "enum value %q of field %q is not in UPPER_CASE", v, f.Name)
+				}
+			}
+		}
+	})
+}
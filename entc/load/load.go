@@ -16,13 +16,16 @@ import (
 	"go/types"
 	"os"
 	"os/exec"
+	"path"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -146,6 +149,149 @@ func (c *Config) load() (*SchemaSpec, error) {
 	return &SchemaSpec{PkgPath: pkg.PkgPath, Module: pkg.Module}, nil
 }
 
+// Positions resolves the source positions of the given schemas and their fields, using the
+// syntax of the schema package. It is not part of Load, as parsing the syntax of the package
+// is needed only for reporting diagnostics on the schema (e.g. by the entc/lint package).
+// Only the syntax of the package is loaded, without type-checking it and its dependencies.
+func (c *Config) Positions(schemas []*Schema) error {
+	pkgs, err := packages.Load(&packages.Config{
+		BuildFlags: c.BuildFlags,
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+	}, c.Path)
+	if err != nil {
+		return fmt.Errorf("entc/load: loading package: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("entc/load: missing package information for: %s", c.Path)
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) != 0 {
+		return pkg.Errors[0]
+	}
+	names := make(map[string]bool, len(schemas))
+	for _, s := range schemas {
+		names[s.Name] = true
+	}
+	decls := make(positions, len(schemas))
+	decls.scanTypes(pkg, names)
+	decls.scanFields(pkg)
+	for _, s := range schemas {
+		decls.resolve(s)
+	}
+	return nil
+}
+
+type (
+	// declPos holds the source positions of a schema declaration.
+	declPos struct {
+		pos    token.Position
+		fields map[string]token.Position
+	}
+	// positions maps schema names to their declaration positions.
+	positions map[string]*declPos
+)
+
+// scanTypes records the positions of the type declarations of the given schema names.
+func (ps positions) scanTypes(pkg *packages.Package, names map[string]bool) {
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && names[ts.Name.Name] && ps[ts.Name.Name] == nil {
+					ps[ts.Name.Name] = &declPos{pos: pkg.Fset.Position(ts.Name.Pos()), fields: make(map[string]token.Position)}
+				}
+			}
+		}
+	}
+}
+
+// scanFields records the positions of the fields that are declared
+// in the Fields method of the schemas. e.g. field.String("name").
+func (ps positions) scanFields(pkg *packages.Package) {
+	fieldPkg := reflect.TypeOf(field.Descriptor{}).PkgPath()
+	for _, f := range pkg.Syntax {
+		local := importName(f, fieldPkg)
+		if local == "" {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil || fd.Name.Name != "Fields" || fd.Recv == nil || len(fd.Recv.List) != 1 {
+				continue
+			}
+			recv := fd.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			ident, ok := recv.(*ast.Ident)
+			if !ok || ps[ident.Name] == nil {
+				continue
+			}
+			dp := ps[ident.Name]
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if x, ok := sel.X.(*ast.Ident); !ok || x.Name != local {
+					return true
+				}
+				lit, ok := call.Args[0].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				if name, err := strconv.Unquote(lit.Value); err == nil {
+					if _, ok := dp.fields[name]; !ok {
+						dp.fields[name] = pkg.Fset.Position(call.Pos())
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// importName returns the name that the given file uses for the imported package,
+// or an empty string if the package is not imported (or imported as "_" or ".").
+func importName(f *ast.File, pkgPath string) string {
+	for _, spec := range f.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != pkgPath {
+			continue
+		}
+		if spec.Name == nil {
+			return path.Base(pkgPath)
+		}
+		if spec.Name.Name != "_" && spec.Name.Name != "." {
+			return spec.Name.Name
+		}
+	}
+	return ""
+}
+
+// resolve sets the source positions of the given schema and its fields.
+func (ps positions) resolve(s *Schema) {
+	dp, ok := ps[s.Name]
+	if !ok {
+		return
+	}
+	s.Position = &Position{Filename: dp.pos.Filename, Line: dp.pos.Line}
+	for _, f := range s.Fields {
+		if f.Position == nil || f.Position.MixedIn {
+			continue
+		}
+		if p, ok := dp.fields[f.Name]; ok {
+			f.Position.Filename, f.Position.Line = p.Filename, p.Line
+		}
+	}
+}
+
 var (
 	//go:embed template/main.tmpl schema.go
 	files     embed.FS
@@ -5,6 +5,7 @@
 package load
 
 import (
+	"strings"
 	"testing"
 
 	"entgo.io/ent/schema/field"
@@ -22,6 +23,17 @@ func TestLoad(t *testing.T) {
 	require.Equal(t, "Group", spec.Schemas[0].Name, "ordered alphabetically")
 	require.Equal(t, "Tag", spec.Schemas[1].Name)
 	require.Equal(t, "User", spec.Schemas[2].Name)
+
+	user := spec.Schemas[2]
+	require.Nil(t, user.Position, "positions are resolved only on demand")
+	require.Empty(t, user.Fields[0].Position.String())
+	require.NoError(t, cfg.Positions(spec.Schemas))
+	require.NotNil(t, user.Position)
+	require.True(t, strings.HasSuffix(user.Position.Filename, "schema.go"))
+	require.Equal(t, 13, user.Position.Line)
+	require.Equal(t, 19, user.Fields[0].Position.Line)
+	require.Equal(t, 20, user.Fields[1].Position.Line)
+	require.Equal(t, user.Position.Filename+":20", user.Fields[1].Position.String())
 }
 
 func TestLoadWrongPath(t *testing.T) {
@@ -27,6 +27,9 @@ type Schema struct {
 	Interceptors []*Position    `json:"interceptors,omitempty"`
 	Policy       []*Position    `json:"policy,omitempty"`
 	Annotations  map[string]any `json:"annotations,omitempty"`
+	// Position of the schema declaration. It is resolved by the
+	// loader, and is not part of the schema serialization.
+	Position *Position `json:"-"`
 }
 
 // Position describes a position in the schema.
@@ -34,6 +37,21 @@ type Position struct {
 	Index      int  // Index in the field/hook list.
 	MixedIn    bool // Indicates if the schema object was mixed-in.
 	MixinIndex int  // Mixin index in the mixin list.
+	// Filename and Line describe the location of the declaration in the schema
+	// package source. They are resolved by the loader, and are empty for objects
+	// that were mixed-in or that their declaration could not be found. Both are
+	// not serialized, as they are specific to the machine that loaded the schema.
+	Filename string `json:"-"`
+	Line     int    `json:"-"`
+}
+
+// String returns the "file:line" representation of the position,
+// or an empty string if the source location is unknown.
+func (p *Position) String() string {
+	if p == nil || p.Filename == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", p.Filename, p.Line)
 }
 
 // Field represents an ent.Field that was loaded from a complied user package.
@@ -42,6 +60,8 @@ type Field struct {
 	Info          *field.TypeInfo         `json:"type,omitempty"`
 	Tag           string                  `json:"tag,omitempty"`
 	Size          *int64                  `json:"size,omitempty"`
+	MinLen        int                     `json:"min_len,omitempty"`
+	Patterns      []string                `json:"patterns,omitempty"`
 	Enums         []struct{ N, V string } `json:"enums,omitempty"`
 	Unique        bool                    `json:"unique,omitempty"`
 	Nillable      bool                    `json:"nillable,omitempty"`
@@ -76,6 +96,7 @@ type Edge struct {
 	StorageKey  *edge.StorageKey       `json:"storage_key,omitempty"`
 	Annotations map[string]any         `json:"annotations,omitempty"`
 	Comment     string                 `json:"comment,omitempty"`
+	Counter     string                 `json:"counter,omitempty"`
 }
 
 // Index represents an ent.Index that was loaded from a complied user package.
@@ -102,6 +123,7 @@ func NewEdge(ed *edge.Descriptor) *Edge {
 		Through:     ed.Through,
 		StorageKey:  ed.StorageKey,
 		Comment:     ed.Comment,
+		Counter:     ed.Counter,
 		Annotations: make(map[string]any),
 	}
 	for _, at := range ed.Annotations {
@@ -123,6 +145,8 @@ func NewField(fd *field.Descriptor) (*Field, error) {
 		Name:          fd.Name,
 		Info:          fd.Info,
 		Tag:           fd.Tag,
+		MinLen:        fd.MinLen,
+		Patterns:      fd.Patterns,
 		Enums:         fd.Enums,
 		Unique:        fd.Unique,
 		Nillable:      fd.Nillable,
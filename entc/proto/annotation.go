@@ -0,0 +1,89 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package proto
+
+import (
+	"encoding/json"
+
+	"entgo.io/ent/schema"
+)
+
+type (
+	// MessageAnnotation marks a schema for generating a protobuf message.
+	MessageAnnotation struct{}
+
+	// ServiceAnnotation marks a schema for generating a gRPC service
+	// with the CRUD operations of the type. It requires the type to
+	// be annotated with the MessageAnnotation as well.
+	ServiceAnnotation struct{}
+
+	// FieldAnnotation holds the number of a field in its protobuf message.
+	// Fields without this annotation are not included in the message.
+	FieldAnnotation struct {
+		Number int
+	}
+)
+
+// Message returns a schema annotation for generating a protobuf message for the schema.
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			proto.Message(),
+//			proto.Service(),
+//		}
+//	}
+func Message() *MessageAnnotation {
+	return &MessageAnnotation{}
+}
+
+// Name describes the annotation name.
+func (MessageAnnotation) Name() string {
+	return "ProtoMessage"
+}
+
+// Service returns a schema annotation for generating a gRPC service for the schema.
+func Service() *ServiceAnnotation {
+	return &ServiceAnnotation{}
+}
+
+// Name describes the annotation name.
+func (ServiceAnnotation) Name() string {
+	return "ProtoService"
+}
+
+// Field returns a field annotation that sets the number of the field in its protobuf
+// message. Numbers must be stable between generations, as they identify the fields in
+// the wire format. The number 1 is reserved for the ID field.
+//
+//	field.String("name").
+//		Annotations(proto.Field(2))
+func Field(n int) *FieldAnnotation {
+	return &FieldAnnotation{Number: n}
+}
+
+// Name describes the annotation name.
+func (FieldAnnotation) Name() string {
+	return "ProtoField"
+}
+
+// decode decodes the annotation with the given name from the loaded annotations
+// format to v, and reports if it exists.
+func decode(annotations map[string]any, name string, v any) (bool, error) {
+	ant, ok := annotations[name]
+	if !ok || ant == nil {
+		return false, nil
+	}
+	buf, err := json.Marshal(ant)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(buf, v)
+}
+
+var (
+	_ schema.Annotation = (*MessageAnnotation)(nil)
+	_ schema.Annotation = (*ServiceAnnotation)(nil)
+	_ schema.Annotation = (*FieldAnnotation)(nil)
+)
@@ -0,0 +1,588 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package proto provides a codegen extension for generating protobuf messages from ent
+// schemas, functions for converting ent entities to these messages and back, and gRPC
+// services that implement the CRUD operations of the types using the generated client.
+//
+//	err := entc.Generate("./schema", &gen.Config{}, entc.Extensions(proto.NewExtension()))
+//
+// Schemas opt in using the Message and Service annotations, and their fields are added to
+// the message using the Field annotation that records their (stable) field number:
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			proto.Message(),
+//			proto.Service(),
+//		}
+//	}
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.String("name").
+//				Annotations(proto.Field(2)),
+//		}
+//	}
+//
+// The .proto file and the Go code are generated in the proto/entpb directory of the target
+// directory, and the protobuf and gRPC stubs are generated from the .proto file using protoc
+// (with the protoc-gen-go and protoc-gen-go-grpc plugins), by running `go generate` on it.
+package proto
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/internal"
+	"entgo.io/ent/schema/field"
+)
+
+type (
+	// Extension implements the entc.Extension interface
+	// for generating protobuf messages and gRPC services.
+	Extension struct {
+		entc.DefaultExtension
+		pkg string
+	}
+
+	// ExtensionOption allows configuring the Extension.
+	ExtensionOption func(*Extension)
+)
+
+// NewExtension returns a new Extension configured with the given options.
+func NewExtension(opts ...ExtensionOption) *Extension {
+	ex := &Extension{pkg: "entpb"}
+	for _, opt := range opts {
+		opt(ex)
+	}
+	return ex
+}
+
+// WithPackage sets the name of the protobuf package, and the directory
+// it is generated in under the proto directory. Defaults to "entpb".
+func WithPackage(name string) ExtensionOption {
+	return func(ex *Extension) {
+		ex.pkg = name
+	}
+}
+
+// Hooks of the extension.
+func (ex *Extension) Hooks() []gen.Hook {
+	return []gen.Hook{internal.GenerateAfter(ex.generate)}
+}
+
+// generate generates the protobuf package after the code generation of ent.
+func (ex *Extension) generate(g *gen.Graph) error {
+	f, err := newFile(g, ex.pkg)
+	if err != nil {
+		return err
+	}
+	return f.Generate()
+}
+
+type (
+	// protoFile describes the generated protobuf package.
+	protoFile struct {
+		// Name of the protobuf (and Go) package.
+		Name string
+		// GoPackage is the Go import path of the package.
+		GoPackage string
+		// Dir is the directory the package is generated in.
+		Dir string
+		// Graph is the graph the package is generated from.
+		Graph *gen.Graph
+		// Messages are the messages of the annotated types.
+		Messages []*protoMessage
+	}
+
+	// protoMessage describes the protobuf message of a type.
+	protoMessage struct {
+		*gen.Type
+		// File of the message.
+		File *protoFile
+		// Fields of the message ordered by their numbers.
+		// The first field is the ID field of the type.
+		Fields []*protoField
+		// Service reports if a service is generated for the type.
+		Service bool
+	}
+
+	// protoField describes a field of a protobuf message.
+	protoField struct {
+		*gen.Field
+		// Message of the field.
+		Message *protoMessage
+		// Number of the field in the message.
+		Number int
+	}
+)
+
+// maxNumber is the maximum field number allowed by protobuf.
+const maxNumber = 1<<29 - 1
+
+// newFile creates the protobuf package with the given name for the annotated types of the graph.
+func newFile(g *gen.Graph, name string) (*protoFile, error) {
+	f := &protoFile{
+		Name:      name,
+		GoPackage: path.Join(g.Package, "proto", name),
+		Dir:       filepath.Join(g.Target, "proto", name),
+		Graph:     g,
+	}
+	for _, t := range g.Nodes {
+		msg, err := decode(t.Annotations, MessageAnnotation{}.Name(), &MessageAnnotation{})
+		if err != nil {
+			return nil, fmt.Errorf("proto: decode message annotation of type %q: %w", t.Name, err)
+		}
+		svc, err := decode(t.Annotations, ServiceAnnotation{}.Name(), &ServiceAnnotation{})
+		if err != nil {
+			return nil, fmt.Errorf("proto: decode service annotation of type %q: %w", t.Name, err)
+		}
+		switch {
+		case !msg && svc:
+			return nil, fmt.Errorf("proto: service of type %q requires a message annotation", t.Name)
+		case !msg:
+			continue
+		}
+		m, err := newMessage(f, t, svc)
+		if err != nil {
+			return nil, err
+		}
+		f.Messages = append(f.Messages, m)
+	}
+	return f, nil
+}
+
+// newMessage creates the message of the given type.
+func newMessage(file *protoFile, t *gen.Type, svc bool) (*protoMessage, error) {
+	if !t.HasOneFieldID() {
+		return nil, fmt.Errorf("proto: type %q: composite identifiers are not supported", t.Name)
+	}
+	m := &protoMessage{Type: t, File: file, Service: svc}
+	id := &protoField{Field: t.ID, Message: m, Number: 1}
+	if _, ok := idTypes[t.ID.Type.Type]; !ok || !id.supported() {
+		return nil, fmt.Errorf("proto: type %q: unsupported id type %s", t.Name, t.ID.Type)
+	}
+	m.Fields = append(m.Fields, id)
+	numbers := map[int]string{1: t.ID.Name}
+	for _, tf := range t.Fields {
+		ant := &FieldAnnotation{}
+		ok, err := decode(tf.Annotations, ant.Name(), ant)
+		if err != nil {
+			return nil, fmt.Errorf("proto: decode annotation of field %s.%s: %w", t.Name, tf.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		f := &protoField{Field: tf, Message: m, Number: ant.Number}
+		switch n := ant.Number; {
+		case n == 1:
+			return nil, fmt.Errorf("proto: field %s.%s: number 1 is reserved for the id field", t.Name, tf.Name)
+		case n < 1 || n > maxNumber || n >= 19000 && n <= 19999:
+			return nil, fmt.Errorf("proto: field %s.%s: invalid field number %d", t.Name, tf.Name, n)
+		case numbers[n] != "":
+			return nil, fmt.Errorf("proto: field %s.%s: number %d is already used by field %q", t.Name, tf.Name, n, numbers[n])
+		case !f.supported():
+			return nil, fmt.Errorf("proto: field %s.%s: unsupported type %s", t.Name, tf.Name, tf.Type)
+		}
+		if f.IsEnum() {
+			values := map[string]string{f.EnumValueName("unspecified"): "unspecified"}
+			for _, v := range f.EnumValues() {
+				name := f.EnumValueName(v)
+				if prev, ok := values[name]; ok {
+					return nil, fmt.Errorf("proto: field %s.%s: enum values %q and %q have the same name %s", t.Name, tf.Name, prev, v, name)
+				}
+				values[name] = v
+			}
+		}
+		numbers[ant.Number] = tf.Name
+		m.Fields = append(m.Fields, f)
+	}
+	sort.Slice(m.Fields, func(i, j int) bool { return m.Fields[i].Number < m.Fields[j].Number })
+	return m, nil
+}
+
+var (
+	// idTypes are the types that are supported for ID fields.
+	idTypes = map[field.Type]struct{}{
+		field.TypeString: {}, field.TypeUUID: {},
+		field.TypeInt: {}, field.TypeInt8: {}, field.TypeInt16: {}, field.TypeInt32: {}, field.TypeInt64: {},
+		field.TypeUint: {}, field.TypeUint8: {}, field.TypeUint16: {}, field.TypeUint32: {}, field.TypeUint64: {},
+	}
+	// scalars maps the field types to protobuf scalar types.
+	scalars = map[field.Type]string{
+		field.TypeBool:    "bool",
+		field.TypeBytes:   "bytes",
+		field.TypeString:  "string",
+		field.TypeInt:     "int64",
+		field.TypeInt8:    "int32",
+		field.TypeInt16:   "int32",
+		field.TypeInt32:   "int32",
+		field.TypeInt64:   "int64",
+		field.TypeUint:    "uint64",
+		field.TypeUint8:   "uint32",
+		field.TypeUint16:  "uint32",
+		field.TypeUint32:  "uint32",
+		field.TypeUint64:  "uint64",
+		field.TypeFloat32: "float",
+		field.TypeFloat64: "double",
+	}
+	// goScalars maps the protobuf scalar types to their Go types.
+	goScalars = map[string]string{
+		"bool":   "bool",
+		"bytes":  "[]byte",
+		"string": "string",
+		"int32":  "int32",
+		"int64":  "int64",
+		"uint32": "uint32",
+		"uint64": "uint64",
+		"float":  "float32",
+		"double": "float64",
+	}
+)
+
+// supported reports if the field type can be converted to a protobuf type.
+func (f *protoField) supported() bool {
+	switch t := f.Type.Type; {
+	case t == field.TypeUUID:
+		return f.HasGoType() && f.Type.Stringer()
+	case t == field.TypeTime:
+		return !f.HasGoType()
+	case t == field.TypeEnum:
+		return true
+	default:
+		_, ok := scalars[t]
+		return ok && f.ConvertedToBasic()
+	}
+}
+
+// ProtoType returns the protobuf type of the field.
+func (f *protoField) ProtoType() string {
+	switch {
+	case f.IsEnum():
+		return pascal(f.Name)
+	case f.IsTime():
+		return "google.protobuf.Timestamp"
+	case f.IsUUID():
+		return "string"
+	default:
+		return scalars[f.Type.Type]
+	}
+}
+
+// Label returns the protobuf label of the field. Nillable fields are defined as optional
+// in order to track their presence. Timestamps (messages) and bytes track their presence
+// without it.
+func (f *protoField) Label() string {
+	if f.ProtoPtr() {
+		return "optional "
+	}
+	return ""
+}
+
+// ProtoPtr reports if the field is represented as a pointer in the protobuf Go struct.
+func (f *protoField) ProtoPtr() bool {
+	return f.Nillable && !f.IsTime() && f.Type.Type != field.TypeBytes
+}
+
+// ProtoZero returns the zero value of the field in the protobuf Go struct.
+func (f *protoField) ProtoZero() string {
+	switch t := f.ProtoType(); {
+	case f.IsEnum(), t != "string" && t != "bool" && t != "bytes" && !f.IsTime():
+		return "0"
+	case t == "string":
+		return `""`
+	case t == "bool":
+		return "false"
+	default:
+		return "nil"
+	}
+}
+
+// GoName returns the name of the field in the protobuf Go struct.
+func (f *protoField) GoName() string {
+	return goCamelCase(f.Name)
+}
+
+// ProtoEnumName returns the Go name of the protobuf enum of the field.
+func (f *protoField) ProtoEnumName() string {
+	return f.Message.Name + "_" + f.ProtoType()
+}
+
+// EnumValueName returns the protobuf name of the given enum value.
+func (f *protoField) EnumValueName(v string) string {
+	return strings.ToUpper(snake(f.Name) + "_" + snake(v))
+}
+
+// EnumValueIdent returns the Go identifier of the given enum value in the protobuf package.
+func (f *protoField) EnumValueIdent(v string) string {
+	return f.Message.Name + "_" + f.EnumValueName(v)
+}
+
+// ToProto returns the Go expression for converting the given ent value to its protobuf value.
+// Conversions that can fail (see ToProtoErr) return an error as a second value.
+func (f *protoField) ToProto(v string) string {
+	switch {
+	case f.IsEnum():
+		return fmt.Sprintf("toProto%s%s(%s)", f.Message.Name, f.StructField(), v)
+	case f.IsTime():
+		return fmt.Sprintf("timestamppb.New(%s)", v)
+	case f.IsUUID():
+		return v + ".String()"
+	case f.Type.String() == goScalars[f.ProtoType()]:
+		return v
+	default:
+		return fmt.Sprintf("%s(%s)", goScalars[f.ProtoType()], v)
+	}
+}
+
+// IsSet returns the Go condition for checking if the field is set in the given protobuf
+// message, or an empty string if it is not trackable. Nillable fields are tracked using
+// pointers, and timestamps and enums are tracked by their zero value (nil and UNSPECIFIED).
+func (f *protoField) IsSet(v string) string {
+	switch {
+	case f.Nillable, f.IsTime():
+		return fmt.Sprintf("%s.%s != nil", v, f.GoName())
+	case f.IsEnum():
+		return fmt.Sprintf("%s.%s != %s", v, f.GoName(), f.EnumValueIdent("unspecified"))
+	default:
+		return ""
+	}
+}
+
+// ToProtoErr reports if the ToProto conversion can fail.
+func (f *protoField) ToProtoErr() bool {
+	return f.IsEnum()
+}
+
+// ToEnt returns the Go expression for converting the given protobuf value to its ent value.
+// Conversions that can fail (see ToEntErr) return an error as a second value.
+func (f *protoField) ToEnt(v string) string {
+	switch {
+	case f.IsEnum(), f.IsUUID():
+		return fmt.Sprintf("toEnt%s%s(%s)", f.Message.Name, f.StructField(), v)
+	case f.IsTime():
+		return v + ".AsTime()"
+	case f.Type.String() == goScalars[f.ProtoType()]:
+		return v
+	default:
+		return fmt.Sprintf("%s(%s)", f.Type, v)
+	}
+}
+
+// ToEntErr reports if the ToEnt conversion can fail.
+func (f *protoField) ToEntErr() bool {
+	return f.IsEnum() || f.IsUUID()
+}
+
+// ID returns the ID field of the message.
+func (m *protoMessage) ID() *protoField {
+	return m.Fields[0]
+}
+
+// UpdateFields returns the non-ID fields of the message that can be updated.
+func (m *protoMessage) UpdateFields() []*protoField {
+	var fields []*protoField
+	for _, f := range m.Fields[1:] {
+		if !f.Immutable {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ImmutableFields returns the fields of the message that cannot be updated, including the ID.
+func (m *protoMessage) ImmutableFields() []*protoField {
+	fields := []*protoField{m.ID()}
+	for _, f := range m.Fields[1:] {
+		if f.Immutable {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ToProtoErr reports if the conversion of one of the non-nillable fields to protobuf can fail.
+func (m *protoMessage) ToProtoErr() bool {
+	for _, f := range m.Fields {
+		if !f.Nillable && f.ToProtoErr() {
+			return true
+		}
+	}
+	return false
+}
+
+// ToEntErr reports if the conversion of one of the non-nillable fields to ent can fail.
+func (m *protoMessage) ToEntErr() bool {
+	for _, f := range m.Fields {
+		if !f.Nillable && f.ToEntErr() {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestField returns the name of the message field in the requests of the service.
+func (m *protoMessage) RequestField() string {
+	return snake(m.Name)
+}
+
+// RequestGoField returns the Go name of the message field in the requests of the service.
+func (m *protoMessage) RequestGoField() string {
+	return goCamelCase(m.RequestField())
+}
+
+// ListGoField returns the Go name of the messages field in the List response of the service.
+func (m *protoMessage) ListGoField() string {
+	return goCamelCase(m.RequestField() + "_list")
+}
+
+// ServiceName returns the name of the gRPC service of the message.
+func (m *protoMessage) ServiceName() string {
+	return m.Name + "Service"
+}
+
+// TypePackage returns the import path of the ent package of the type.
+func (m *protoMessage) TypePackage() string {
+	return path.Join(m.File.Graph.Package, m.PackageDir())
+}
+
+// Imports returns the import paths of the external types used by the message fields.
+func (m *protoMessage) Imports() []string {
+	paths := make(map[string]struct{})
+	for _, f := range m.Fields {
+		if p := f.Type.PkgPath; p != "" && p != m.TypePackage() {
+			paths[p] = struct{}{}
+		}
+	}
+	imports := make([]string, 0, len(paths))
+	for p := range paths {
+		imports = append(imports, p)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// HasTime reports if one of the messages has a time field.
+func (f *protoFile) HasTime() bool {
+	for _, m := range f.Messages {
+		for _, mf := range m.Fields {
+			if mf.IsTime() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasService reports if one of the messages has a service.
+func (f *protoFile) HasService() bool {
+	for _, m := range f.Messages {
+		if m.Service {
+			return true
+		}
+	}
+	return false
+}
+
+// Header returns the header of the generated files, without the
+// indentation of its lines (used in multiline string literals).
+func (f *protoFile) Header() string {
+	return internal.Header(f.Graph)
+}
+
+var (
+	//go:embed template/*
+	templateDir embed.FS
+	// templates holds the templates of the generated files.
+	templates = template.Must(template.New("proto").
+			Funcs(gen.Funcs).
+			ParseFS(templateDir, "template/*.tmpl"))
+)
+
+// Generate writes the .proto file and the Go files of the package, and removes the files
+// of types that are not generated anymore. The directory is not created if no type was
+// annotated with the MessageAnnotation.
+func (f *protoFile) Generate() error {
+	if len(f.Messages) == 0 {
+		return nil
+	}
+	files := map[string][]byte{}
+	b := &bytes.Buffer{}
+	if err := templates.ExecuteTemplate(b, "proto", f); err != nil {
+		return fmt.Errorf("proto: execute template %q: %w", "proto", err)
+	}
+	files[f.Name+".proto"] = b.Bytes()
+	b = &bytes.Buffer{}
+	if err := templates.ExecuteTemplate(b, "package", f); err != nil {
+		return fmt.Errorf("proto: execute template %q: %w", "package", err)
+	}
+	files[f.Name+".go"] = b.Bytes()
+	for _, m := range f.Messages {
+		b := &bytes.Buffer{}
+		if err := templates.ExecuteTemplate(b, "message", m); err != nil {
+			return fmt.Errorf("proto: execute template %q on type %q: %w", "message", m.Name, err)
+		}
+		files[snake(m.Name)+".go"] = b.Bytes()
+	}
+	p := &internal.GenPackage{Dir: f.Dir, Header: f.Header(), Files: files, Keep: isProtoc}
+	if err := p.Write(); err != nil {
+		return fmt.Errorf("proto: %w", err)
+	}
+	return nil
+}
+
+// isProtoc reports if the given file was generated by protoc.
+func isProtoc(name string) bool {
+	return strings.HasSuffix(name, ".pb.go")
+}
+
+// snake converts the given name to snake_case.
+func snake(s string) string {
+	return gen.Funcs["snake"].(func(string) string)(s)
+}
+
+// pascal converts the given name to PascalCase.
+func pascal(s string) string {
+	return gen.Funcs["pascal"].(func(string) string)(s)
+}
+
+// goCamelCase returns the Go name of the given protobuf name, using the same
+// conversion as protoc-gen-go. e.g. "created_at" is converted to "CreatedAt".
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isLower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
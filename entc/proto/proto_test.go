@@ -0,0 +1,142 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package proto
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/entc/internal/gentest"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	target := t.TempDir()
+	g := gentest.Graph(t, "entc/proto", target, &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: gentest.Annotations(Field(3))},
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}, Nillable: true, Optional: true, Annotations: gentest.Annotations(Field(2))},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}, Immutable: true, Annotations: gentest.Annotations(Field(4))},
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{"active", "active"}, {"blocked", "blocked"}}, Annotations: gentest.Annotations(Field(5))},
+			{Name: "secret", Info: &field.TypeInfo{Type: field.TypeString}},
+		},
+		Annotations: gentest.Annotations(Message(), Service()),
+	}, &load.Schema{
+		Name:        "Group",
+		Fields:      []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: gentest.Annotations(Field(2))}},
+		Annotations: gentest.Annotations(Message()),
+	}, &load.Schema{
+		Name:   "Pet",
+		Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+	})
+	require.NoError(t, NewExtension().generate(g))
+
+	dir := filepath.Join(target, "proto", "entpb")
+	buf, err := os.ReadFile(filepath.Join(dir, "entpb.proto"))
+	require.NoError(t, err)
+	for _, s := range []string{
+		`option go_package = "entc/proto/proto/entpb";`,
+		"import \"google/protobuf/timestamp.proto\";",
+		"message User {\n  enum Status {\n    STATUS_UNSPECIFIED = 0;\n    STATUS_ACTIVE = 1;\n    STATUS_BLOCKED = 2;\n  }\n  int64 id = 1;\n  optional int64 age = 2;\n  string name = 3;\n  google.protobuf.Timestamp created_at = 4;\n  Status status = 5;\n}",
+		"message Group {\n  int64 id = 1;\n  string name = 2;\n}",
+		"service UserService {",
+		"rpc Update(UpdateUserRequest) returns (User);",
+	} {
+		require.Contains(t, string(buf), s)
+	}
+	require.NotContains(t, string(buf), "secret")
+	require.NotContains(t, string(buf), "GroupService")
+	require.NotContains(t, string(buf), "Pet")
+
+	for _, name := range []string{"entpb.go", "user.go", "group.go"} {
+		_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.AllErrors)
+		require.NoError(t, err, name)
+	}
+	buf, err = os.ReadFile(filepath.Join(dir, "user.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "func NewUserService(client *ent.Client) *UserService")
+	require.Contains(t, string(buf), `case "id", "created_at":`)
+	buf, err = os.ReadFile(filepath.Join(dir, "group.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "GroupService")
+
+	// Files of types that are not annotated anymore are removed.
+	// Files that were not generated by the extension are kept.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.go"), []byte("package entpb\n"), 0644))
+	g = gentest.Graph(t, "entc/proto", target, &load.Schema{
+		Name:        "User",
+		Annotations: gentest.Annotations(Message()),
+	})
+	f, err := newFile(g, "entpb")
+	require.NoError(t, err)
+	require.NoError(t, f.Generate())
+	for name, exists := range map[string]bool{"entpb.go": true, "user.go": true, "group.go": false, "server.go": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		require.Equal(t, exists, err == nil, name)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	str := &field.TypeInfo{Type: field.TypeString}
+	for _, tt := range []struct {
+		name   string
+		schema *load.Schema
+		err    string
+	}{
+		{
+			name:   "service without message",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Service())},
+			err:    `proto: service of type "User" requires a message annotation`,
+		},
+		{
+			name: "reserved number",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Message()), Fields: []*load.Field{
+				{Name: "name", Info: str, Annotations: gentest.Annotations(Field(1))},
+			}},
+			err: "proto: field User.name: number 1 is reserved for the id field",
+		},
+		{
+			name: "invalid number",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Message()), Fields: []*load.Field{
+				{Name: "name", Info: str, Annotations: gentest.Annotations(Field(19000))},
+			}},
+			err: "proto: field User.name: invalid field number 19000",
+		},
+		{
+			name: "duplicate number",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Message()), Fields: []*load.Field{
+				{Name: "first", Info: str, Annotations: gentest.Annotations(Field(2))},
+				{Name: "last", Info: str, Annotations: gentest.Annotations(Field(2))},
+			}},
+			err: `proto: field User.last: number 2 is already used by field "first"`,
+		},
+		{
+			name: "unsupported type",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Message()), Fields: []*load.Field{
+				{Name: "labels", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "[]string"}, Annotations: gentest.Annotations(Field(2))},
+			}},
+			err: "proto: field User.labels: unsupported type []string",
+		},
+		{
+			name: "enum collision",
+			schema: &load.Schema{Name: "User", Annotations: gentest.Annotations(Message()), Fields: []*load.Field{
+				{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{"UNSPECIFIED", "UNSPECIFIED"}}, Annotations: gentest.Annotations(Field(2))},
+			}},
+			err: `proto: field User.status: enum values "unspecified" and "UNSPECIFIED" have the same name STATUS_UNSPECIFIED`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newFile(gentest.Graph(t, "entc/proto", t.TempDir(), tt.schema), "entpb")
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package rest
+
+import (
+	"encoding/json"
+
+	"entgo.io/ent/schema"
+)
+
+// Annotation controls the exposure of schemas, fields and edges in the REST API.
+type Annotation struct {
+	// Skip excludes the schema, field or edge from the API.
+	Skip bool `json:"skip,omitempty"`
+	// ReadOnly exposes the schema, field or edge in the API, but does not allow
+	// changing it. Read-only schemas are exposed without the create, update and
+	// delete operations.
+	ReadOnly bool `json:"read_only,omitempty"`
+}
+
+// Skip returns an annotation for excluding the schema, field or edge from the API.
+//
+//	field.String("password").
+//		Annotations(rest.Skip())
+func Skip() *Annotation {
+	return &Annotation{Skip: true}
+}
+
+// ReadOnly returns an annotation for exposing the schema, field or edge in the API
+// without allowing to change it.
+//
+//	func (Audit) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			rest.ReadOnly(),
+//		}
+//	}
+func ReadOnly() *Annotation {
+	return &Annotation{ReadOnly: true}
+}
+
+// Name describes the annotation name.
+func (Annotation) Name() string {
+	return "REST"
+}
+
+// Merge implements the schema.Merger interface.
+func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
+	var ant Annotation
+	switch other := other.(type) {
+	case Annotation:
+		ant = other
+	case *Annotation:
+		if other != nil {
+			ant = *other
+		}
+	default:
+		return a
+	}
+	a.Skip = a.Skip || ant.Skip
+	a.ReadOnly = a.ReadOnly || ant.ReadOnly
+	return a
+}
+
+// decode decodes the annotation from the loaded annotations format.
+func decode(annotations map[string]any) (*Annotation, error) {
+	ant := &Annotation{}
+	v, ok := annotations[ant.Name()]
+	if !ok || v == nil {
+		return ant, nil
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, ant); err != nil {
+		return nil, err
+	}
+	return ant, nil
+}
+
+var (
+	_ schema.Annotation = (*Annotation)(nil)
+	_ schema.Merger     = (*Annotation)(nil)
+)
@@ -0,0 +1,403 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package rest provides a codegen extension for generating an OpenAPI 3 document from ent
+// schemas, and net/http handlers that serve this API using the generated client.
+//
+//	err := entc.Generate("./schema", &gen.Config{}, entc.Extensions(rest.NewExtension()))
+//
+// The document and the handlers are generated in the rest directory of the target directory.
+// Each schema is exposed as a collection resource (e.g. /users) that supports listing and
+// creating entities, an item resource (e.g. /users/{id}) that supports reading, updating and
+// deleting an entity, and sub-resources for its edges (e.g. /users/{id}/pets). Schemas,
+// fields and edges can be excluded from the API, or made read-only, using the Annotation.
+package rest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/internal"
+	"entgo.io/ent/schema/field"
+)
+
+type (
+	// Extension implements the entc.Extension interface
+	// for generating an OpenAPI document and its handlers.
+	Extension struct {
+		entc.DefaultExtension
+		pkg            string
+		title, version string
+	}
+
+	// ExtensionOption allows configuring the Extension.
+	ExtensionOption func(*Extension)
+)
+
+// NewExtension returns a new Extension configured with the given options.
+func NewExtension(opts ...ExtensionOption) *Extension {
+	ex := &Extension{pkg: "rest", title: "Ent API", version: "0.0.1"}
+	for _, opt := range opts {
+		opt(ex)
+	}
+	return ex
+}
+
+// WithPackage sets the name of the generated package, and the directory it is
+// generated in under the target directory. Defaults to "rest".
+func WithPackage(name string) ExtensionOption {
+	return func(ex *Extension) {
+		ex.pkg = name
+	}
+}
+
+// WithInfo sets the title and the version of the API in the OpenAPI document.
+func WithInfo(title, version string) ExtensionOption {
+	return func(ex *Extension) {
+		ex.title, ex.version = title, version
+	}
+}
+
+// Hooks of the extension.
+func (ex *Extension) Hooks() []gen.Hook {
+	return []gen.Hook{internal.GenerateAfter(ex.generate)}
+}
+
+// generate generates the REST package after the code generation of ent.
+func (ex *Extension) generate(g *gen.Graph) error {
+	a, err := newAPI(g, ex)
+	if err != nil {
+		return err
+	}
+	return a.Generate()
+}
+
+type (
+	// api describes the generated package.
+	api struct {
+		// Name of the generated package.
+		Name string
+		// Dir is the directory the package is generated in.
+		Dir string
+		// Title and Version of the API.
+		Title, Version string
+		// Graph is the graph the API is generated from.
+		Graph *gen.Graph
+		// Types are the exposed types.
+		Types []*apiType
+	}
+
+	// apiType describes an exposed type.
+	apiType struct {
+		*gen.Type
+		// API of the type.
+		API *api
+		// Fields are the exposed fields of the type, excluding the ID.
+		Fields []*apiField
+		// Edges are the exposed edges of the type.
+		Edges []*apiEdge
+		// ReadOnly reports if the type is exposed without its mutations.
+		ReadOnly bool
+	}
+
+	// apiField describes an exposed field.
+	apiField struct {
+		*gen.Field
+		// ReadOnly reports if the field cannot be changed using the API.
+		ReadOnly bool
+	}
+
+	// apiEdge describes an exposed edge.
+	apiEdge struct {
+		*gen.Edge
+		// Source and Target are the types the edge is directed from and to.
+		Source, Target *apiType
+		// ReadOnly reports if the edge cannot be changed using the API.
+		ReadOnly bool
+	}
+)
+
+// newAPI creates the API of the graph.
+func newAPI(g *gen.Graph, ex *Extension) (*api, error) {
+	a := &api{
+		Name:    ex.pkg,
+		Dir:     filepath.Join(g.Target, ex.pkg),
+		Title:   ex.title,
+		Version: ex.version,
+		Graph:   g,
+	}
+	types := make(map[string]*apiType)
+	for _, n := range g.Nodes {
+		ant, err := decode(n.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("rest: decode annotation of type %q: %w", n.Name, err)
+		}
+		if ant.Skip || !n.HasOneFieldID() {
+			continue
+		}
+		if _, ok := (&apiField{Field: n.ID}).Parse("s"); !ok {
+			return nil, fmt.Errorf("rest: type %q: unsupported id type %s", n.Name, n.ID.Type)
+		}
+		t := &apiType{Type: n, API: a, ReadOnly: ant.ReadOnly}
+		for _, f := range n.Fields {
+			ant, err := decode(f.Annotations)
+			if err != nil {
+				return nil, fmt.Errorf("rest: decode annotation of field %s.%s: %w", n.Name, f.Name, err)
+			}
+			if !ant.Skip {
+				t.Fields = append(t.Fields, &apiField{Field: f, ReadOnly: ant.ReadOnly || t.ReadOnly})
+			}
+		}
+		types[n.Name] = t
+		a.Types = append(a.Types, t)
+	}
+	for _, t := range a.Types {
+		for _, e := range t.Type.Edges {
+			ant, err := decode(e.Annotations)
+			if err != nil {
+				return nil, fmt.Errorf("rest: decode annotation of edge %s.%s: %w", t.Name, e.Name, err)
+			}
+			target, ok := types[e.Type.Name]
+			if ant.Skip || !ok {
+				continue
+			}
+			t.Edges = append(t.Edges, &apiEdge{Edge: e, Source: t, Target: target, ReadOnly: ant.ReadOnly || t.ReadOnly})
+		}
+	}
+	return a, nil
+}
+
+// Path returns the path of the collection resource of the type.
+func (t *apiType) Path() string {
+	return "/" + snake(plural(t.Name))
+}
+
+// TypePackage returns the import path of the ent package of the type.
+func (t *apiType) TypePackage() string {
+	return path.Join(t.API.Graph.Package, t.PackageDir())
+}
+
+// StdImports returns the import paths of the standard library types used by the type
+// fields, and by the identifiers of its edges.
+func (t *apiType) StdImports() []string {
+	return t.imports(true)
+}
+
+// Imports returns the import paths of the external types used by the type fields,
+// and by the identifiers of its edges.
+func (t *apiType) Imports() []string {
+	return t.imports(false)
+}
+
+func (t *apiType) imports(std bool) []string {
+	fields := append([]*apiField{t.IDField()}, t.Fields...)
+	for _, e := range t.Edges {
+		fields = append(fields, e.Target.IDField())
+	}
+	var paths []string
+	for _, f := range fields {
+		p := f.Type.PkgPath
+		if p == "" || p == t.TypePackage() || contains(paths, p) {
+			continue
+		}
+		// Standard library packages do not have a dot in their first path element.
+		if isStd := !strings.Contains(strings.Split(p, "/")[0], "."); isStd == std {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// IDField returns the ID field of the type.
+func (t *apiType) IDField() *apiField {
+	return &apiField{Field: t.ID, ReadOnly: !t.ID.UserDefined || t.ReadOnly}
+}
+
+// Filters returns the fields that can be used for filtering the type. Fields
+// that their names conflict with the list parameters are not filterable.
+func (t *apiType) Filters() []*apiField {
+	var fields []*apiField
+	for _, f := range t.Fields {
+		if _, ok := f.Parse("s"); ok && !f.Sensitive() && !contains(reservedParams, f.Name) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Outputs returns the fields that are returned in the responses.
+// Sensitive fields are writable, but are never returned.
+func (t *apiType) Outputs() []*apiField {
+	var fields []*apiField
+	for _, f := range t.Fields {
+		if !f.Sensitive() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// CreateFields returns the fields that can be set on creation.
+func (t *apiType) CreateFields() []*apiField {
+	var fields []*apiField
+	if id := t.IDField(); !id.ReadOnly {
+		fields = append(fields, id)
+	}
+	for _, f := range t.Fields {
+		if !f.ReadOnly {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// UpdateFields returns the fields that can be updated.
+func (t *apiType) UpdateFields() []*apiField {
+	var fields []*apiField
+	for _, f := range t.Fields {
+		if !f.ReadOnly && !f.Immutable {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// CreateEdges returns the edges that can be set on creation. Edges that are
+// defined with an edge-field are set using their field.
+func (t *apiType) CreateEdges() []*apiEdge {
+	var edges []*apiEdge
+	for _, e := range t.Edges {
+		if !e.ReadOnly && e.Field() == nil && e.Through == nil {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// UpdateEdges returns the edges that can be updated.
+func (t *apiType) UpdateEdges() []*apiEdge {
+	var edges []*apiEdge
+	for _, e := range t.CreateEdges() {
+		if !e.Immutable {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Parse returns the Go expression for parsing the field value from the given string
+// expression, and reports if the field type can be parsed. The expression returns an
+// error as a second value.
+func (f *apiField) Parse(s string) (string, bool) {
+	t := f.Type.String()
+	switch {
+	case f.IsUUID():
+		return fmt.Sprintf("parseText[%s](%s)", t, s), true
+	case f.IsTime():
+		return fmt.Sprintf("time.Parse(time.RFC3339, %s)", s), !f.HasGoType()
+	case f.IsEnum():
+		return fmt.Sprintf("parseString[%s](%s)", t, s), true
+	case !f.ConvertedToBasic():
+		return "", false
+	}
+	switch f.Type.Type {
+	case field.TypeString:
+		return fmt.Sprintf("parseString[%s](%s)", t, s), true
+	case field.TypeBool:
+		return fmt.Sprintf("parseBool[%s](%s)", t, s), true
+	case field.TypeInt, field.TypeInt8, field.TypeInt16, field.TypeInt32, field.TypeInt64:
+		return fmt.Sprintf("parseInt[%s](%s)", t, s), true
+	case field.TypeUint, field.TypeUint8, field.TypeUint16, field.TypeUint32, field.TypeUint64:
+		return fmt.Sprintf("parseUint[%s](%s)", t, s), true
+	case field.TypeFloat32, field.TypeFloat64:
+		return fmt.Sprintf("parseFloat[%s](%s)", t, s), true
+	default:
+		return "", false
+	}
+}
+
+// ParseExpr returns the Go expression for parsing the field value from the given string expression.
+func (f *apiField) ParseExpr(s string) string {
+	expr, _ := f.Parse(s)
+	return expr
+}
+
+// Path returns the path segment of the edge sub-resource.
+func (e *apiEdge) Path() string {
+	return e.Name
+}
+
+// Header returns the header of the generated files, without the
+// indentation of its lines (used in multiline string literals).
+func (a *api) Header() string {
+	return internal.Header(a.Graph)
+}
+
+var (
+	//go:embed template/*
+	templateDir embed.FS
+	// templates holds the templates of the generated files.
+	templates = template.Must(template.New("rest").
+			Funcs(gen.Funcs).
+			ParseFS(templateDir, "template/*.tmpl"))
+)
+
+// Generate writes the OpenAPI document and the Go files of the package, and removes
+// the files of types that are not exposed anymore.
+func (a *api) Generate() error {
+	spec, err := json.MarshalIndent(a.Spec(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("rest: marshal openapi document: %w", err)
+	}
+	files := map[string][]byte{
+		"openapi.json": append(spec, '\n'),
+	}
+	b := &bytes.Buffer{}
+	if err := templates.ExecuteTemplate(b, "server", a); err != nil {
+		return fmt.Errorf("rest: execute template %q: %w", "server", err)
+	}
+	files["server.go"] = b.Bytes()
+	for _, t := range a.Types {
+		b := &bytes.Buffer{}
+		if err := templates.ExecuteTemplate(b, "type", t); err != nil {
+			return fmt.Errorf("rest: execute template %q on type %q: %w", "type", t.Name, err)
+		}
+		files[snake(t.Name)+".go"] = b.Bytes()
+	}
+	p := &internal.GenPackage{Dir: a.Dir, Header: a.Header(), Files: files}
+	if err := p.Write(); err != nil {
+		return fmt.Errorf("rest: %w", err)
+	}
+	return nil
+}
+
+// reservedParams are the names of the query parameters of list operations.
+var reservedParams = []string{"with", "limit", "offset"}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// snake converts the given name to snake_case.
+func snake(s string) string {
+	return gen.Funcs["snake"].(func(string) string)(s)
+}
+
+// plural returns the plural form of the given name.
+func plural(s string) string {
+	return gen.Funcs["plural"].(func(string) string)(s)
+}
@@ -0,0 +1,148 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package rest
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/entc/internal/gentest"
+	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	target := t.TempDir()
+	size := int64(10)
+	g := gentest.Graph(t, "entc/rest", target, &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, MinLen: 1, Size: &size, Patterns: []string{"^[a-z]+$"}},
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}, Nillable: true, Optional: true},
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{"active", "active"}, {"blocked", "blocked"}}, Default: true},
+			{Name: "password", Info: &field.TypeInfo{Type: field.TypeString}, Sensitive: true},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}, Immutable: true, Default: true},
+			{Name: "score", Info: &field.TypeInfo{Type: field.TypeFloat64}, Annotations: gentest.Annotations(ReadOnly())},
+			{Name: "internal", Info: &field.TypeInfo{Type: field.TypeString}, Annotations: gentest.Annotations(Skip())},
+		},
+		Edges: []*load.Edge{
+			{Name: "pets", Type: "Pet"},
+			{Name: "secrets", Type: "Secret"},
+		},
+	}, &load.Schema{
+		Name:   "Pet",
+		Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+		Edges: []*load.Edge{
+			{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true},
+		},
+	}, &load.Schema{
+		Name:        "Audit",
+		Fields:      []*load.Field{{Name: "action", Info: &field.TypeInfo{Type: field.TypeString}}},
+		Annotations: gentest.Annotations(ReadOnly()),
+	}, &load.Schema{
+		Name:        "Secret",
+		Annotations: gentest.Annotations(Skip()),
+	})
+	require.NoError(t, NewExtension(WithInfo("Test API", "1.0.0")).generate(g))
+
+	dir := filepath.Join(target, "rest")
+	buf, err := os.ReadFile(filepath.Join(dir, "openapi.json"))
+	require.NoError(t, err)
+	var spec struct {
+		Info struct {
+			Title, Version string
+		}
+		Paths      map[string]map[string]any
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string
+				Properties map[string]map[string]any
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf, &spec))
+	require.Equal(t, "Test API", spec.Info.Title)
+	require.Equal(t, "1.0.0", spec.Info.Version)
+	for p, ops := range map[string][]string{
+		"/users":              {"get", "post"},
+		"/users/{id}":         {"get", "patch", "delete"},
+		"/users/{id}/pets":    {"get"},
+		"/pets/{id}/owner":    {"get"},
+		"/audits":             {"get"},
+		"/audits/{id}":        {"get"},
+		"/users/{id}/secrets": nil,
+		"/secrets":            nil,
+	} {
+		if ops == nil {
+			require.NotContains(t, spec.Paths, p)
+		}
+		for _, op := range ops {
+			require.Contains(t, spec.Paths[p], op, p)
+		}
+	}
+	require.NotContains(t, spec.Paths["/audits"], "post")
+	require.NotContains(t, spec.Components.Schemas, "AuditCreate")
+
+	user := spec.Components.Schemas["User"]
+	require.Equal(t, []string{"id", "name", "age", "status", "created_at", "score"}, user.Required)
+	require.Equal(t, map[string]any{"type": "string", "minLength": 1.0, "maxLength": 10.0, "pattern": "^[a-z]+$"}, user.Properties["name"])
+	require.Equal(t, map[string]any{"type": "integer", "format": "int64", "nullable": true}, user.Properties["age"])
+	require.Equal(t, []any{"active", "blocked"}, user.Properties["status"]["enum"])
+	require.Equal(t, true, user.Properties["password"]["writeOnly"])
+	require.Equal(t, true, user.Properties["created_at"]["readOnly"])
+	require.Equal(t, true, user.Properties["score"]["readOnly"])
+	require.Equal(t, true, user.Properties["id"]["readOnly"])
+	require.NotContains(t, user.Properties, "internal")
+	require.NotContains(t, user.Properties, "secrets")
+	require.Equal(t, map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/Pet"}}, user.Properties["pets"])
+
+	create := spec.Components.Schemas["UserCreate"]
+	require.Equal(t, []string{"name", "password"}, create.Required)
+	require.Contains(t, create.Properties, "created_at")
+	require.NotContains(t, create.Properties, "score")
+	require.NotContains(t, create.Properties, "id")
+	update := spec.Components.Schemas["UserUpdate"]
+	require.NotContains(t, update.Properties, "created_at")
+	require.Contains(t, update.Properties, "pets")
+	require.Equal(t, []string{"name"}, spec.Components.Schemas["PetCreate"].Required)
+
+	for _, name := range []string{"server.go", "user.go", "pet.go", "audit.go"} {
+		_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.AllErrors)
+		require.NoError(t, err, name)
+	}
+	buf, err = os.ReadFile(filepath.Join(dir, "user.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "query.Where(user.StatusEQ(v))")
+	require.NotContains(t, string(buf), "Password:")
+	buf, err = os.ReadFile(filepath.Join(dir, "audit.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "func (s *Server) createAudit")
+
+	// Files of types that are not exposed anymore are removed.
+	// Files that were not generated by the extension are kept.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package rest\n"), 0644))
+	g = gentest.Graph(t, "entc/rest", target, &load.Schema{Name: "User"})
+	a, err := newAPI(g, NewExtension())
+	require.NoError(t, err)
+	require.NoError(t, a.Generate())
+	for name, exists := range map[string]bool{"server.go": true, "user.go": true, "pet.go": false, "audit.go": false, "main.go": true} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		require.Equal(t, exists, err == nil, name)
+	}
+}
+
+func TestAnnotation_Merge(t *testing.T) {
+	ant := Annotation{}.Merge(Skip())
+	ant = ant.(Annotation).Merge(Annotation{ReadOnly: true})
+	require.Equal(t, Annotation{Skip: true, ReadOnly: true}, ant)
+	ant = Annotation{}.Merge((*Annotation)(nil))
+	require.Equal(t, Annotation{}, ant)
+}
@@ -0,0 +1,344 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"entgo.io/ent/schema/field"
+)
+
+// Default and maximum number of entities returned by list operations.
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// object is a JSON object in the OpenAPI document.
+type object = map[string]any
+
+// Spec returns the OpenAPI document of the API.
+func (a *api) Spec() object {
+	paths, schemas := object{}, object{
+		"Error": object{
+			"type":     "object",
+			"required": []string{"code", "message"},
+			"properties": object{
+				"code":    object{"type": "integer"},
+				"message": object{"type": "string"},
+			},
+		},
+	}
+	for _, t := range a.Types {
+		schemas[t.Name] = t.schema()
+		list := object{
+			"summary":     "List " + t.Name + " entities",
+			"operationId": "list" + t.Name,
+			"tags":        []string{t.Name},
+			"parameters":  t.listParams(),
+			"responses": responses(http.StatusOK, object{
+				"description": "The list of " + t.Name + " entities",
+				"content":     jsonContent(object{"type": "array", "items": ref(t.Name)}),
+			}, http.StatusBadRequest),
+		}
+		read := object{
+			"summary":     "Read a " + t.Name + " entity",
+			"operationId": "read" + t.Name,
+			"tags":        []string{t.Name},
+			"parameters":  []any{withParam(t)},
+			"responses": responses(http.StatusOK, object{
+				"description": "The " + t.Name + " entity",
+				"content":     jsonContent(ref(t.Name)),
+			}, http.StatusBadRequest, http.StatusNotFound),
+		}
+		collection, item := object{"get": list}, object{"get": read, "parameters": []any{idParam(t)}}
+		if !t.ReadOnly {
+			schemas[t.Name+"Create"] = t.createSchema()
+			schemas[t.Name+"Update"] = t.updateSchema()
+			collection["post"] = object{
+				"summary":     "Create a " + t.Name + " entity",
+				"operationId": "create" + t.Name,
+				"tags":        []string{t.Name},
+				"requestBody": object{"required": true, "content": jsonContent(ref(t.Name + "Create"))},
+				"responses": responses(http.StatusCreated, object{
+					"description": "The created " + t.Name + " entity",
+					"content":     jsonContent(ref(t.Name)),
+				}, http.StatusBadRequest, http.StatusConflict),
+			}
+			item["patch"] = object{
+				"summary":     "Update a " + t.Name + " entity",
+				"operationId": "update" + t.Name,
+				"tags":        []string{t.Name},
+				"requestBody": object{"required": true, "content": jsonContent(ref(t.Name + "Update"))},
+				"responses": responses(http.StatusOK, object{
+					"description": "The updated " + t.Name + " entity",
+					"content":     jsonContent(ref(t.Name)),
+				}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict),
+			}
+			item["delete"] = object{
+				"summary":     "Delete a " + t.Name + " entity",
+				"operationId": "delete" + t.Name,
+				"tags":        []string{t.Name},
+				"responses": responses(http.StatusNoContent, object{
+					"description": "The " + t.Name + " entity was deleted",
+				}, http.StatusNotFound, http.StatusConflict),
+			}
+		}
+		paths[t.Path()] = collection
+		paths[t.Path()+"/{id}"] = item
+		for _, e := range t.Edges {
+			op := object{
+				"operationId": "read" + t.Name + e.StructField(),
+				"tags":        []string{t.Name},
+			}
+			if e.Unique {
+				op["summary"] = "Read the " + e.Name + " of a " + t.Name + " entity"
+				op["parameters"] = []any{withParam(e.Target)}
+				op["responses"] = responses(http.StatusOK, object{
+					"description": "The " + e.Target.Name + " entity",
+					"content":     jsonContent(ref(e.Target.Name)),
+				}, http.StatusBadRequest, http.StatusNotFound)
+			} else {
+				op["summary"] = "List the " + e.Name + " of a " + t.Name + " entity"
+				op["parameters"] = e.Target.listParams()
+				op["responses"] = responses(http.StatusOK, object{
+					"description": "The list of " + e.Target.Name + " entities",
+					"content":     jsonContent(object{"type": "array", "items": ref(e.Target.Name)}),
+				}, http.StatusBadRequest, http.StatusNotFound)
+			}
+			paths[t.Path()+"/{id}/"+e.Path()] = object{"get": op, "parameters": []any{idParam(t)}}
+		}
+	}
+	return object{
+		"openapi": "3.0.3",
+		"info":    object{"title": a.Title, "version": a.Version},
+		"paths":   paths,
+		"components": object{
+			"schemas": schemas,
+			"responses": object{
+				"BadRequest":    errorResponse("Invalid request"),
+				"NotFound":      errorResponse("Entity not found"),
+				"Conflict":      errorResponse("Constraint violation"),
+				"InternalError": errorResponse("Unexpected error"),
+			},
+		},
+	}
+}
+
+// schema returns the schema of the type in responses.
+func (t *apiType) schema() object {
+	props := object{"id": t.IDField().schema()}
+	required := []string{"id"}
+	for _, f := range t.Fields {
+		props[f.Name] = f.schema()
+		if !f.Sensitive() {
+			required = append(required, f.Name)
+		}
+	}
+	for _, e := range t.Edges {
+		if e.Unique {
+			props[e.Name] = ref(e.Target.Name)
+		} else {
+			props[e.Name] = object{"type": "array", "items": ref(e.Target.Name)}
+		}
+	}
+	return object{"type": "object", "properties": props, "required": required}
+}
+
+// createSchema returns the schema of the request body for creating an entity of the type.
+func (t *apiType) createSchema() object {
+	props, required := object{}, []string{}
+	for _, f := range t.CreateFields() {
+		props[f.Name] = f.valueSchema()
+		if !f.Optional && !f.Default {
+			required = append(required, f.Name)
+		}
+	}
+	for _, e := range t.CreateEdges() {
+		props[e.Name] = e.idsSchema()
+		if !e.Optional && e.Unique {
+			required = append(required, e.Name)
+		}
+	}
+	s := object{"type": "object", "properties": props, "additionalProperties": false}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// updateSchema returns the schema of the request body for updating an entity of the type.
+func (t *apiType) updateSchema() object {
+	props := object{}
+	for _, f := range t.UpdateFields() {
+		props[f.Name] = f.valueSchema()
+	}
+	for _, e := range t.UpdateEdges() {
+		props[e.Name] = e.idsSchema()
+	}
+	return object{"type": "object", "properties": props, "additionalProperties": false}
+}
+
+// listParams returns the query parameters of list operations of the type.
+func (t *apiType) listParams() []any {
+	params := []any{
+		withParam(t),
+		object{
+			"name":        "limit",
+			"in":          "query",
+			"description": "The maximum number of entities to return",
+			"schema":      object{"type": "integer", "minimum": 1, "maximum": maxLimit, "default": defaultLimit},
+		},
+		object{
+			"name":        "offset",
+			"in":          "query",
+			"description": "The number of entities to skip",
+			"schema":      object{"type": "integer", "minimum": 0, "default": 0},
+		},
+	}
+	for _, f := range t.Filters() {
+		params = append(params, object{
+			"name":        f.Name,
+			"in":          "query",
+			"description": "Filter by the " + f.Name + " field",
+			"schema":      f.valueSchema(),
+		})
+	}
+	return params
+}
+
+// schema returns the schema of the field in responses.
+func (f *apiField) schema() object {
+	s := f.valueSchema()
+	switch {
+	case f.Sensitive():
+		s["writeOnly"] = true
+	case f.ReadOnly || f.Immutable:
+		s["readOnly"] = true
+	}
+	if f.Nillable {
+		s["nullable"] = true
+	}
+	return s
+}
+
+// valueSchema returns the schema of the field values.
+func (f *apiField) valueSchema() object {
+	s := object{}
+	switch t := f.Type.Type; {
+	case t == field.TypeBool:
+		s["type"] = "boolean"
+	case t == field.TypeString:
+		s["type"] = "string"
+		if n := f.MinLen(); n > 0 {
+			s["minLength"] = n
+		}
+		if n := f.MaxLen(); n > 0 {
+			s["maxLength"] = n
+		}
+		switch ps := f.Patterns(); len(ps) {
+		case 0:
+		case 1:
+			s["pattern"] = ps[0]
+		default:
+			all := make([]any, len(ps))
+			for i, p := range ps {
+				all[i] = object{"pattern": p}
+			}
+			s["allOf"] = all
+		}
+	case t == field.TypeEnum:
+		s["type"] = "string"
+		s["enum"] = f.EnumValues()
+	case t == field.TypeUUID:
+		s["type"], s["format"] = "string", "uuid"
+	case t == field.TypeTime:
+		s["type"], s["format"] = "string", "date-time"
+	case t == field.TypeBytes:
+		s["type"], s["format"] = "string", "byte"
+	case t == field.TypeInt8 || t == field.TypeInt16 || t == field.TypeInt32:
+		s["type"], s["format"] = "integer", "int32"
+	case t == field.TypeInt || t == field.TypeInt64:
+		s["type"], s["format"] = "integer", "int64"
+	case t == field.TypeUint8 || t == field.TypeUint16 || t == field.TypeUint32:
+		s["type"], s["format"], s["minimum"] = "integer", "int32", 0
+	case t == field.TypeUint || t == field.TypeUint64:
+		s["type"], s["format"], s["minimum"] = "integer", "int64", 0
+	case t == field.TypeFloat32:
+		s["type"], s["format"] = "number", "float"
+	case t == field.TypeFloat64:
+		s["type"], s["format"] = "number", "double"
+	case t == field.TypeJSON && f.Type.RType != nil:
+		switch f.Type.RType.Kind {
+		case reflect.Slice, reflect.Array:
+			s["type"], s["items"] = "array", object{}
+		case reflect.Map, reflect.Struct:
+			s["type"] = "object"
+		}
+	}
+	return s
+}
+
+// idsSchema returns the schema of the edge ids in request bodies.
+func (e *apiEdge) idsSchema() object {
+	id := e.Target.IDField().valueSchema()
+	if e.Unique {
+		return id
+	}
+	return object{"type": "array", "items": id}
+}
+
+func withParam(t *apiType) object {
+	names := make([]string, 0, len(t.Edges))
+	for _, e := range t.Edges {
+		names = append(names, e.Name)
+	}
+	return object{
+		"name":        "with",
+		"in":          "query",
+		"description": "The edges to eager-load",
+		"style":       "form",
+		"explode":     false,
+		"schema":      object{"type": "array", "items": object{"type": "string", "enum": names}},
+	}
+}
+
+func idParam(t *apiType) object {
+	return object{
+		"name":     "id",
+		"in":       "path",
+		"required": true,
+		"schema":   t.IDField().valueSchema(),
+	}
+}
+
+func ref(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+func errorResponse(desc string) object {
+	return object{"description": desc, "content": jsonContent(ref("Error"))}
+}
+
+// responses returns the responses object of an operation with the given
+// success response, and the given error responses.
+func responses(code int, success object, errors ...int) object {
+	names := map[int]string{
+		http.StatusBadRequest: "BadRequest",
+		http.StatusNotFound:   "NotFound",
+		http.StatusConflict:   "Conflict",
+	}
+	r := object{strconv.Itoa(code): success, "500": object{"$ref": "#/components/responses/InternalError"}}
+	for _, c := range errors {
+		r[strconv.Itoa(c)] = object{"$ref": "#/components/responses/" + names[c]}
+	}
+	return r
+}
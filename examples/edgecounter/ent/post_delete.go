@@ -0,0 +1,104 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	context "context"
+
+	"entgo.io/ent/dialect/sql"
+	sqlgraph "entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/examples/edgecounter/ent/post"
+	predicate "entgo.io/ent/examples/edgecounter/ent/predicate"
+	"entgo.io/ent/examples/edgecounter/ent/tag"
+	field "entgo.io/ent/schema/field"
+)
+
+// PostDelete is the builder for deleting a Post entity.
+type PostDelete struct {
+	config
+	hooks    []Hook
+	mutation *PostMutation
+}
+
+// Where appends a list predicates to the PostDelete builder.
+func (pd *PostDelete) Where(ps ...predicate.Post) *PostDelete {
+	pd.mutation.Where(ps...)
+	return pd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (pd *PostDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks[int, PostMutation](ctx, pd.sqlExec, pd.mutation, pd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pd *PostDelete) ExecX(ctx context.Context) int {
+	n, err := pd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (pd *PostDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: post.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: post.FieldID,
+			},
+		},
+	}
+	_spec.Edges = append(_spec.Edges, &sqlgraph.EdgeSpec{
+		Rel:     sqlgraph.M2M,
+		Table:   tag.PostsTable,
+		Columns: tag.PostsPrimaryKey,
+		Counters: []*sqlgraph.EdgeCounter{{
+			Table:  tag.Table,
+			ID:     tag.FieldID,
+			Column: tag.FieldPostCount,
+			Ref:    tag.PostsPrimaryKey[1],
+		}},
+	})
+	if ps := pd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, pd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	pd.mutation.done = true
+	return affected, err
+}
+
+// PostDeleteOne is the builder for deleting a single Post entity.
+type PostDeleteOne struct {
+	pd *PostDelete
+}
+
+// Exec executes the deletion query.
+func (pdo *PostDeleteOne) Exec(ctx context.Context) error {
+	n, err := pdo.pd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{post.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pdo *PostDeleteOne) ExecX(ctx context.Context) {
+	pdo.pd.ExecX(ctx)
+}
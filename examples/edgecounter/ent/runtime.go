@@ -0,0 +1,31 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"entgo.io/ent/examples/edgecounter/ent/post"
+	schema "entgo.io/ent/examples/edgecounter/ent/schema"
+	"entgo.io/ent/examples/edgecounter/ent/tag"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	postFields := schema.Post{}.Fields()
+	_ = postFields
+	// postDescCommentCount is the schema descriptor for comment_count field.
+	postDescCommentCount := postFields[1].Descriptor()
+	// post.DefaultCommentCount holds the default value on creation for the comment_count field.
+	post.DefaultCommentCount = postDescCommentCount.Default.(int)
+	tagFields := schema.Tag{}.Fields()
+	_ = tagFields
+	// tagDescPostCount is the schema descriptor for post_count field.
+	tagDescPostCount := tagFields[1].Descriptor()
+	// tag.DefaultPostCount holds the default value on creation for the post_count field.
+	tag.DefaultPostCount = tagDescPostCount.Default.(int)
+}
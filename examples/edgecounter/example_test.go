@@ -0,0 +1,107 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/examples/edgecounter/ent"
+	"entgo.io/ent/examples/edgecounter/ent/comment"
+	"entgo.io/ent/examples/edgecounter/ent/post"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Example_edgeCounter() {
+	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		log.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	client := ent.NewClient(ent.Driver(drv))
+	defer client.Close()
+	ctx := context.Background()
+	// Run the auto migration tool.
+	if err := client.Schema.Create(ctx); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+	if err := Do(ctx, client, drv); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// ent: 2 go: 1
+	// ent: 4 go: 1
+	// ent: 3 go: 2
+	// ent: 1 go: 2
+	// orm: 2 graph: 1
+	// orm: 1 graph: 1
+	// ent: 1 go: 2
+}
+
+func Do(ctx context.Context, client *ent.Client, drv dialect.Driver) error {
+	var (
+		c1 = client.Comment.Create().SetText("c1").SaveX(ctx)
+		c2 = client.Comment.Create().SetText("c2").SaveX(ctx)
+	)
+	// Comments can be added from both sides of the edge.
+	p1 := client.Post.Create().SetTitle("ent").AddComments(c1, c2).SaveX(ctx)
+	p2 := client.Post.Create().SetTitle("go").SaveX(ctx)
+	client.Comment.Create().SetText("c3").SetPost(p2).ExecX(ctx)
+	printCounts(ctx, client, p1, p2)
+
+	// Including bulk creations.
+	client.Comment.CreateBulk(
+		client.Comment.Create().SetText("c4").SetPost(p1),
+		client.Comment.Create().SetText("c5").SetPost(p1),
+	).ExecX(ctx)
+	printCounts(ctx, client, p1, p2)
+
+	// Moving a comment to another post updates both counters.
+	c1.Update().SetPost(p2).ExecX(ctx)
+	printCounts(ctx, client, p1, p2)
+
+	// Deleting comments decrements the counters of their posts.
+	client.Comment.Delete().Where(comment.TextIn("c4", "c5")).ExecX(ctx)
+	printCounts(ctx, client, p1, p2)
+
+	// M2M edges are counted in the same way.
+	var (
+		orm   = client.Tag.Create().SetName("orm").SaveX(ctx)
+		graph = client.Tag.Create().SetName("graph").SaveX(ctx)
+	)
+	p1.Update().AddTags(orm, graph).ExecX(ctx)
+	p2.Update().AddTags(orm).ExecX(ctx)
+	fmt.Println("orm:", client.Tag.GetX(ctx, orm.ID).PostCount, "graph:", client.Tag.GetX(ctx, graph.ID).PostCount)
+	client.Post.DeleteOne(p2).ExecX(ctx)
+	fmt.Println("orm:", client.Tag.GetX(ctx, orm.ID).PostCount, "graph:", client.Tag.GetX(ctx, graph.ID).PostCount)
+
+	// Counters that drifted (e.g. by changes made outside
+	// of ent) can be recomputed using the Recount method.
+	p3 := client.Post.Create().SetTitle("go").SaveX(ctx)
+	if err := drv.Exec(ctx, "UPDATE `posts` SET `comment_count` = 10 WHERE `id` = ?", []any{p3.ID}, nil); err != nil {
+		return fmt.Errorf("change counter: %w", err)
+	}
+	c1.Update().SetPost(p3).ExecX(ctx)
+	client.Comment.Create().SetText("c6").SetPost(p3).ExecX(ctx)
+	if _, err := client.Post.Recount(ctx, post.ID(p3.ID)); err != nil {
+		return fmt.Errorf("recount posts: %w", err)
+	}
+	printCounts(ctx, client, p1, p3)
+	return nil
+}
+
+func printCounts(ctx context.Context, client *ent.Client, ps ...*ent.Post) {
+	for i, p := range ps {
+		if i > 0 {
+			fmt.Print(" ")
+		}
+		p = client.Post.GetX(ctx, p.ID)
+		fmt.Printf("%s: %d", p.Title, p.CommentCount)
+	}
+	fmt.Println()
+}
@@ -423,7 +423,14 @@ func (cq *CityQuery) loadStreets(ctx context.Context, query *StreetQuery, nodes
 	query.Where(predicate.Street(func(s *sql.Selector) {
 		s.Where(sql.InValues(city.StreetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeStreet, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Street, *StreetQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(city.StreetsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Street](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -492,7 +492,14 @@ func (fq *FileQuery) loadChildren(ctx context.Context, query *FileQuery, nodes [
 	query.Where(predicate.File(func(s *sql.Selector) {
 		s.Where(sql.InValues(file.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeFile, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*File, *FileQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(file.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*File](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
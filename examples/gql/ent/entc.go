@@ -0,0 +1,31 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/gql"
+)
+
+func main() {
+	err := entc.Generate("./schema", &gen.Config{
+		Header: `
+			// Copyright 2019-present Facebook Inc. All rights reserved.
+			// This source code is licensed under the Apache 2.0 license found
+			// in the LICENSE file in the root directory of this source tree.
+
+			// Code generated by ent, DO NOT EDIT.
+		`,
+	}, entc.Extensions(gql.NewExtension()))
+	if err != nil {
+		log.Fatalf("running ent codegen: %v", err)
+	}
+}
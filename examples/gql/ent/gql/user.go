@@ -0,0 +1,650 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package gql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/examples/gql/ent"
+	"entgo.io/ent/examples/gql/ent/group"
+	"entgo.io/ent/examples/gql/ent/pet"
+	"entgo.io/ent/examples/gql/ent/predicate"
+	"entgo.io/ent/examples/gql/ent/user"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// initUser initializes the GraphQL types of the User type.
+func (b *builder) initUser() {
+	b.userRole = graphql.NewEnum(graphql.EnumConfig{
+		Name: "UserRole",
+		Values: graphql.EnumValueConfigMap{
+			"admin": &graphql.EnumValueConfig{Value: user.Role("admin")},
+			"user":  &graphql.EnumValueConfig{Value: user.Role("user")},
+		},
+	})
+	b.user = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{b.node},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.ID),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(*ent.User).ID, nil
+					},
+				},
+				"name": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(*ent.User).Name, nil
+					},
+				},
+				"age": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						if v := p.Source.(*ent.User).Age; v != nil {
+							return (*v), nil
+						}
+						return nil, nil
+					},
+				},
+				"role": &graphql.Field{
+					Type: graphql.NewNonNull(b.userRole),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(*ent.User).Role, nil
+					},
+				},
+				"createdAt": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.DateTime),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source.(*ent.User).CreatedAt, nil
+					},
+				},
+				"pets": &graphql.Field{
+					Type:    graphql.NewNonNull(b.petConnection),
+					Args:    b.connectionArgs(b.petWhereInput),
+					Resolve: resolveUserPets,
+				},
+				"friends": &graphql.Field{
+					Type:    graphql.NewNonNull(b.userConnection),
+					Args:    b.connectionArgs(b.userWhereInput),
+					Resolve: resolveUserFriends,
+				},
+				"groups": &graphql.Field{
+					Type:    graphql.NewNonNull(b.groupConnection),
+					Args:    b.connectionArgs(b.groupWhereInput),
+					Resolve: resolveUserGroups,
+				},
+			}
+		}),
+	})
+	b.userConnection = b.newConnection(b.user)
+	b.userWhereInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UserWhereInput",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"not":              &graphql.InputObjectFieldConfig{Type: b.userWhereInput},
+				"and":              &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.userWhereInput))},
+				"or":               &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.userWhereInput))},
+				"id":               &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"idNEQ":            &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"idIn":             &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+				"idNotIn":          &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+				"idGT":             &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"idGTE":            &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"idLT":             &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"idLTE":            &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"name":             &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameNEQ":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameIn":           &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"nameNotIn":        &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"nameGT":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameGTE":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameLT":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameLTE":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameContains":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameHasPrefix":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameHasSuffix":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameEqualFold":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"nameContainsFold": &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"age":              &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageNEQ":           &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageIn":            &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+				"ageNotIn":         &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+				"ageGT":            &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageGTE":           &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageLT":            &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageLTE":           &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				"ageIsNil":         &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+				"ageNotNil":        &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+				"role":             &graphql.InputObjectFieldConfig{Type: b.userRole},
+				"roleNEQ":          &graphql.InputObjectFieldConfig{Type: b.userRole},
+				"roleIn":           &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.userRole))},
+				"roleNotIn":        &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.userRole))},
+				"createdAt":        &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"createdAtNEQ":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"createdAtIn":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.DateTime))},
+				"createdAtNotIn":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.DateTime))},
+				"createdAtGT":      &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"createdAtGTE":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"createdAtLT":      &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"createdAtLTE":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+				"hasPets":          &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+				"hasPetsWith":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.petWhereInput))},
+				"hasFriends":       &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+				"hasFriendsWith":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.userWhereInput))},
+				"hasGroups":        &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+				"hasGroupsWith":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(b.groupWhereInput))},
+			}
+		}),
+	})
+}
+
+// paginateUser resolves a UserConnection field using the given query.
+func paginateUser(p graphql.ResolveParams, query *ent.UserQuery) (*connection, error) {
+	if v, ok := p.Args["where"]; ok {
+		pred, err := whereUser(v)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(pred)
+	}
+	pg, err := newPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	count := query.Clone()
+	if pg.after != nil {
+		id, err := inputIntID[int](*pg.after)
+		if err != nil {
+			return nil, fmt.Errorf("invalid \"after\" cursor: %w", err)
+		}
+		query = query.Where(user.IDGT(id))
+	}
+	if pg.before != nil {
+		id, err := inputIntID[int](*pg.before)
+		if err != nil {
+			return nil, fmt.Errorf("invalid \"before\" cursor: %w", err)
+		}
+		query = query.Where(user.IDLT(id))
+	}
+	switch {
+	case pg.first != nil:
+		query = query.Order(ent.Asc(user.FieldID)).Limit(*pg.first + 1)
+	case pg.last != nil:
+		query = query.Order(ent.Desc(user.FieldID)).Limit(*pg.last + 1)
+	default:
+		query = query.Order(ent.Asc(user.FieldID))
+	}
+	nodes, err := collectUser(p.Info, query, connectionNodes(p.Info, p.Info.FieldASTs, "User")).All(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(nodes, pg, idUser, count.Count), nil
+}
+
+// idUser returns the ID of the node, used for encoding its cursor.
+func idUser(n *ent.User) any {
+	return n.ID
+}
+
+// collectUser eager-loads the edges that are selected on the User type
+// by the given fields, to avoid querying them separately for each node.
+func collectUser(info graphql.ResolveInfo, query *ent.UserQuery, fields []*ast.Field) *ent.UserQuery {
+	selected := collect(info, fields, "User")
+	if fs := selected["pets"]; eager(fs) {
+		query.WithPets(func(q *ent.PetQuery) {
+			collectPet(info, q.Order(ent.Asc(pet.FieldID)), connectionNodes(info, fs, "Pet"))
+		})
+	}
+	if fs := selected["friends"]; eager(fs) {
+		query.WithFriends(func(q *ent.UserQuery) {
+			collectUser(info, q.Order(ent.Asc(user.FieldID)), connectionNodes(info, fs, "User"))
+		})
+	}
+	if fs := selected["groups"]; eager(fs) {
+		query.WithGroups(func(q *ent.GroupQuery) {
+			collectGroup(info, q.Order(ent.Asc(group.FieldID)), connectionNodes(info, fs, "Group"))
+		})
+	}
+	return query
+}
+
+// resolveUserPets resolves the pets edge of the User type.
+func resolveUserPets(p graphql.ResolveParams) (any, error) {
+	n := p.Source.(*ent.User)
+	if nodes, err := n.Edges.PetsOrErr(); err == nil && len(p.Args) == 0 {
+		return newConnection(nodes, &page{}, idPet, func(context.Context) (int, error) {
+			return len(nodes), nil
+		}), nil
+	}
+	return paginatePet(p, n.QueryPets())
+}
+
+// resolveUserFriends resolves the friends edge of the User type.
+func resolveUserFriends(p graphql.ResolveParams) (any, error) {
+	n := p.Source.(*ent.User)
+	if nodes, err := n.Edges.FriendsOrErr(); err == nil && len(p.Args) == 0 {
+		return newConnection(nodes, &page{}, idUser, func(context.Context) (int, error) {
+			return len(nodes), nil
+		}), nil
+	}
+	return paginateUser(p, n.QueryFriends())
+}
+
+// resolveUserGroups resolves the groups edge of the User type.
+func resolveUserGroups(p graphql.ResolveParams) (any, error) {
+	n := p.Source.(*ent.User)
+	if nodes, err := n.Edges.GroupsOrErr(); err == nil && len(p.Args) == 0 {
+		return newConnection(nodes, &page{}, idGroup, func(context.Context) (int, error) {
+			return len(nodes), nil
+		}), nil
+	}
+	return paginateGroup(p, n.QueryGroups())
+}
+
+// nodesUser loads the User nodes of the given IDs, keyed by their IDs.
+func (b *builder) nodesUser(p graphql.ResolveParams, ids []int64) (map[int64]any, error) {
+	vs := make([]int, len(ids))
+	for i := range ids {
+		vs[i] = int(ids[i])
+	}
+	nodes, err := collectUser(p.Info, b.client.User.Query().Where(user.IDIn(vs...)), p.Info.FieldASTs).All(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[int64]any, len(nodes))
+	for _, n := range nodes {
+		loaded[int64(n.ID)] = n
+	}
+	return loaded, nil
+}
+
+// whereUser converts a UserWhereInput value to a predicate.
+func whereUser(v any) (predicate.User, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected UserWhereInput value %v", v)
+	}
+	var preds []predicate.User
+	if v, ok := m["not"]; ok && v != nil {
+		pred, err := whereUser(v)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, user.Not(pred))
+	}
+	if v, ok := m["and"]; ok && v != nil {
+		ps, err := inputs(v, whereUser)
+		if err != nil {
+			return nil, err
+		}
+		if len(ps) > 0 {
+			preds = append(preds, user.And(ps...))
+		}
+	}
+	if v, ok := m["or"]; ok && v != nil {
+		ps, err := inputs(v, whereUser)
+		if err != nil {
+			return nil, err
+		}
+		if len(ps) > 0 {
+			preds = append(preds, user.Or(ps...))
+		}
+	}
+	if v, ok := m["id"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.id: %w", err)
+		}
+		preds = append(preds, user.IDEQ(vs))
+	}
+	if v, ok := m["idNEQ"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idNEQ: %w", err)
+		}
+		preds = append(preds, user.IDNEQ(vs))
+	}
+	if v, ok := m["idIn"]; ok && v != nil {
+		vs, err := inputs(v, inputIntID[int])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idIn: %w", err)
+		}
+		preds = append(preds, user.IDIn(vs...))
+	}
+	if v, ok := m["idNotIn"]; ok && v != nil {
+		vs, err := inputs(v, inputIntID[int])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idNotIn: %w", err)
+		}
+		preds = append(preds, user.IDNotIn(vs...))
+	}
+	if v, ok := m["idGT"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idGT: %w", err)
+		}
+		preds = append(preds, user.IDGT(vs))
+	}
+	if v, ok := m["idGTE"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idGTE: %w", err)
+		}
+		preds = append(preds, user.IDGTE(vs))
+	}
+	if v, ok := m["idLT"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idLT: %w", err)
+		}
+		preds = append(preds, user.IDLT(vs))
+	}
+	if v, ok := m["idLTE"]; ok && v != nil {
+		vs, err := inputIntID[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.idLTE: %w", err)
+		}
+		preds = append(preds, user.IDLTE(vs))
+	}
+	if v, ok := m["name"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.name: %w", err)
+		}
+		preds = append(preds, user.NameEQ(vs))
+	}
+	if v, ok := m["nameNEQ"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameNEQ: %w", err)
+		}
+		preds = append(preds, user.NameNEQ(vs))
+	}
+	if v, ok := m["nameIn"]; ok && v != nil {
+		vs, err := inputs(v, inputString[string])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameIn: %w", err)
+		}
+		preds = append(preds, user.NameIn(vs...))
+	}
+	if v, ok := m["nameNotIn"]; ok && v != nil {
+		vs, err := inputs(v, inputString[string])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameNotIn: %w", err)
+		}
+		preds = append(preds, user.NameNotIn(vs...))
+	}
+	if v, ok := m["nameGT"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameGT: %w", err)
+		}
+		preds = append(preds, user.NameGT(vs))
+	}
+	if v, ok := m["nameGTE"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameGTE: %w", err)
+		}
+		preds = append(preds, user.NameGTE(vs))
+	}
+	if v, ok := m["nameLT"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameLT: %w", err)
+		}
+		preds = append(preds, user.NameLT(vs))
+	}
+	if v, ok := m["nameLTE"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameLTE: %w", err)
+		}
+		preds = append(preds, user.NameLTE(vs))
+	}
+	if v, ok := m["nameContains"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameContains: %w", err)
+		}
+		preds = append(preds, user.NameContains(vs))
+	}
+	if v, ok := m["nameHasPrefix"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameHasPrefix: %w", err)
+		}
+		preds = append(preds, user.NameHasPrefix(vs))
+	}
+	if v, ok := m["nameHasSuffix"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameHasSuffix: %w", err)
+		}
+		preds = append(preds, user.NameHasSuffix(vs))
+	}
+	if v, ok := m["nameEqualFold"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameEqualFold: %w", err)
+		}
+		preds = append(preds, user.NameEqualFold(vs))
+	}
+	if v, ok := m["nameContainsFold"]; ok && v != nil {
+		vs, err := inputString[string](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.nameContainsFold: %w", err)
+		}
+		preds = append(preds, user.NameContainsFold(vs))
+	}
+	if v, ok := m["age"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.age: %w", err)
+		}
+		preds = append(preds, user.AgeEQ(vs))
+	}
+	if v, ok := m["ageNEQ"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageNEQ: %w", err)
+		}
+		preds = append(preds, user.AgeNEQ(vs))
+	}
+	if v, ok := m["ageIn"]; ok && v != nil {
+		vs, err := inputs(v, inputInt[int])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageIn: %w", err)
+		}
+		preds = append(preds, user.AgeIn(vs...))
+	}
+	if v, ok := m["ageNotIn"]; ok && v != nil {
+		vs, err := inputs(v, inputInt[int])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageNotIn: %w", err)
+		}
+		preds = append(preds, user.AgeNotIn(vs...))
+	}
+	if v, ok := m["ageGT"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageGT: %w", err)
+		}
+		preds = append(preds, user.AgeGT(vs))
+	}
+	if v, ok := m["ageGTE"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageGTE: %w", err)
+		}
+		preds = append(preds, user.AgeGTE(vs))
+	}
+	if v, ok := m["ageLT"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageLT: %w", err)
+		}
+		preds = append(preds, user.AgeLT(vs))
+	}
+	if v, ok := m["ageLTE"]; ok && v != nil {
+		vs, err := inputInt[int](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.ageLTE: %w", err)
+		}
+		preds = append(preds, user.AgeLTE(vs))
+	}
+	if v, ok := m["ageIsNil"]; ok && v != nil {
+		if v, _ := v.(bool); v {
+			preds = append(preds, user.AgeIsNil())
+		}
+	}
+	if v, ok := m["ageNotNil"]; ok && v != nil {
+		if v, _ := v.(bool); v {
+			preds = append(preds, user.AgeNotNil())
+		}
+	}
+	if v, ok := m["role"]; ok && v != nil {
+		vs, err := inputValue[user.Role](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.role: %w", err)
+		}
+		preds = append(preds, user.RoleEQ(vs))
+	}
+	if v, ok := m["roleNEQ"]; ok && v != nil {
+		vs, err := inputValue[user.Role](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.roleNEQ: %w", err)
+		}
+		preds = append(preds, user.RoleNEQ(vs))
+	}
+	if v, ok := m["roleIn"]; ok && v != nil {
+		vs, err := inputs(v, inputValue[user.Role])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.roleIn: %w", err)
+		}
+		preds = append(preds, user.RoleIn(vs...))
+	}
+	if v, ok := m["roleNotIn"]; ok && v != nil {
+		vs, err := inputs(v, inputValue[user.Role])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.roleNotIn: %w", err)
+		}
+		preds = append(preds, user.RoleNotIn(vs...))
+	}
+	if v, ok := m["createdAt"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAt: %w", err)
+		}
+		preds = append(preds, user.CreatedAtEQ(vs))
+	}
+	if v, ok := m["createdAtNEQ"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtNEQ: %w", err)
+		}
+		preds = append(preds, user.CreatedAtNEQ(vs))
+	}
+	if v, ok := m["createdAtIn"]; ok && v != nil {
+		vs, err := inputs(v, inputValue[time.Time])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtIn: %w", err)
+		}
+		preds = append(preds, user.CreatedAtIn(vs...))
+	}
+	if v, ok := m["createdAtNotIn"]; ok && v != nil {
+		vs, err := inputs(v, inputValue[time.Time])
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtNotIn: %w", err)
+		}
+		preds = append(preds, user.CreatedAtNotIn(vs...))
+	}
+	if v, ok := m["createdAtGT"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtGT: %w", err)
+		}
+		preds = append(preds, user.CreatedAtGT(vs))
+	}
+	if v, ok := m["createdAtGTE"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtGTE: %w", err)
+		}
+		preds = append(preds, user.CreatedAtGTE(vs))
+	}
+	if v, ok := m["createdAtLT"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtLT: %w", err)
+		}
+		preds = append(preds, user.CreatedAtLT(vs))
+	}
+	if v, ok := m["createdAtLTE"]; ok && v != nil {
+		vs, err := inputValue[time.Time](v)
+		if err != nil {
+			return nil, fmt.Errorf("UserWhereInput.createdAtLTE: %w", err)
+		}
+		preds = append(preds, user.CreatedAtLTE(vs))
+	}
+	if v, ok := m["hasPets"]; ok && v != nil {
+		if v, _ := v.(bool); v {
+			preds = append(preds, user.HasPets())
+		} else {
+			preds = append(preds, user.Not(user.HasPets()))
+		}
+	}
+	if v, ok := m["hasPetsWith"]; ok && v != nil {
+		ps, err := inputs(v, wherePet)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, user.HasPetsWith(ps...))
+	}
+	if v, ok := m["hasFriends"]; ok && v != nil {
+		if v, _ := v.(bool); v {
+			preds = append(preds, user.HasFriends())
+		} else {
+			preds = append(preds, user.Not(user.HasFriends()))
+		}
+	}
+	if v, ok := m["hasFriendsWith"]; ok && v != nil {
+		ps, err := inputs(v, whereUser)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, user.HasFriendsWith(ps...))
+	}
+	if v, ok := m["hasGroups"]; ok && v != nil {
+		if v, _ := v.(bool); v {
+			preds = append(preds, user.HasGroups())
+		} else {
+			preds = append(preds, user.Not(user.HasGroups()))
+		}
+	}
+	if v, ok := m["hasGroupsWith"]; ok && v != nil {
+		ps, err := inputs(v, whereGroup)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, user.HasGroupsWith(ps...))
+	}
+	switch len(preds) {
+	case 0:
+		return func(*sql.Selector) {}, nil
+	case 1:
+		return preds[0], nil
+	default:
+		return user.And(preds...), nil
+	}
+}
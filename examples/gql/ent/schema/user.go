@@ -0,0 +1,50 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Int("age").
+			Optional().
+			Nillable(),
+		field.Enum("role").
+			Values("admin", "user").
+			Default("user"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.String("password").
+			Sensitive(),
+		field.String("note").
+			Optional().
+			Annotations(gql.Skip()),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("pets", Pet.Type),
+		edge.To("friends", User.Type),
+		edge.From("groups", Group.Type).
+			Ref("users"),
+	}
+}
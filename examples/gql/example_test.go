@@ -0,0 +1,157 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"entgo.io/ent/examples/gql/ent"
+	"entgo.io/ent/examples/gql/ent/gql"
+	"entgo.io/ent/examples/gql/ent/migrate"
+	"github.com/graphql-go/graphql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Example_handler() {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		log.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	// Run the auto migration tool with global unique IDs,
+	// in order to resolve nodes by their IDs.
+	if err := client.Schema.Create(ctx, migrate.WithGlobalUniqueID(true)); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SetPassword("pass").SaveX(ctx)
+	client.Pet.Create().SetName("xabi").SetOwner(a8m).SaveX(ctx)
+	h, err := gql.NewHandler(client)
+	if err != nil {
+		log.Fatalf("failed creating handler: %v", err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	body := `{"query": "{ users(first: 1) { edges { node { id name pets { edges { node { name } } } } } } }"}`
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		log.Fatalf("failed sending request: %v", err)
+	}
+	defer res.Body.Close()
+	var v any
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		log.Fatalf("failed decoding response: %v", err)
+	}
+	out, _ := json.Marshal(v)
+	fmt.Println(string(out))
+
+	// Output:
+	// {"data":{"users":{"edges":[{"node":{"id":"8589934593","name":"a8m","pets":{"edges":[{"node":{"name":"xabi"}}]}}}]}}}
+}
+
+func TestSchema(t *testing.T) {
+	var queries atomic.Int64
+	client, err := ent.Open("sqlite3", "file:schema?mode=memory&cache=shared&_fk=1", ent.Debug(), ent.Log(func(v ...any) {
+		if strings.Contains(fmt.Sprint(v...), "driver.Query") {
+			queries.Add(1)
+		}
+	}))
+	if err != nil {
+		t.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx, migrate.WithGlobalUniqueID(true)); err != nil {
+		t.Fatalf("failed creating schema resources: %v", err)
+	}
+	users := make([]*ent.User, 5)
+	for i := range users {
+		users[i] = client.User.Create().SetName(fmt.Sprintf("user%d", i)).SetAge(20 + i).SetPassword("pass").SaveX(ctx)
+		for j := 0; j < 3; j++ {
+			client.Pet.Create().SetName(fmt.Sprintf("pet%d%d", i, j)).SetOwner(users[i]).SaveX(ctx)
+		}
+	}
+	users[0].Update().SetRole("admin").AddFriends(users[1:]...).ExecX(ctx)
+	grp := client.Group.Create().SetName("ent").AddUsers(users[:2]...).SaveX(ctx)
+	s, err := gql.NewSchema(client)
+	if err != nil {
+		t.Fatalf("failed creating schema: %v", err)
+	}
+	do := func(query string) string {
+		t.Helper()
+		res := graphql.Do(graphql.Params{Schema: s, RequestString: query, Context: ctx})
+		buf, err := json.Marshal(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf)
+	}
+
+	// Pagination.
+	cursor := func(u *ent.User) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(u.ID)))
+	}
+	res := do(`{ users(first: 2) { totalCount pageInfo { hasNextPage hasPreviousPage endCursor } edges { node { name } } } }`)
+	expect(t, res, fmt.Sprintf(`{"data":{"users":{"edges":[{"node":{"name":"user0"}},{"node":{"name":"user1"}}],"pageInfo":{"endCursor":%q,"hasNextPage":true,"hasPreviousPage":false},"totalCount":5}}}`, cursor(users[1])))
+	res = do(fmt.Sprintf(`{ users(first: 2, after: %q) { edges { cursor node { name } } } }`, cursor(users[1])))
+	expect(t, res, fmt.Sprintf(`{"data":{"users":{"edges":[{"cursor":%q,"node":{"name":"user2"}},{"cursor":%q,"node":{"name":"user3"}}]}}}`, cursor(users[2]), cursor(users[3])))
+	res = do(fmt.Sprintf(`{ users(last: 2, before: %q) { pageInfo { hasPreviousPage } edges { node { name } } } }`, cursor(users[3])))
+	expect(t, res, `{"data":{"users":{"edges":[{"node":{"name":"user1"}},{"node":{"name":"user2"}}],"pageInfo":{"hasPreviousPage":true}}}}`)
+	res = do(`{ users(first: 1, last: 1) { totalCount } }`)
+	expect(t, res, `passing both \"first\" and \"last\" to paginate a connection is not supported`)
+	res = do(`{ users(after: "invalid") { totalCount } }`)
+	expect(t, res, `invalid \"after\" cursor`)
+
+	// Filtering.
+	res = do(`{ users(where: {or: [{ageGT: 22}, {role: admin}], nameNEQ: "user4"}) { totalCount edges { node { name role } } } }`)
+	expect(t, res, `{"data":{"users":{"edges":[{"node":{"name":"user0","role":"admin"}},{"node":{"name":"user3","role":"user"}}],"totalCount":2}}}`)
+	res = do(`{ pets(where: {hasOwnerWith: {hasGroupsWith: {name: "ent"}}, nameHasSuffix: "2"}) { edges { node { name owner { name } } } } }`)
+	expect(t, res, `{"data":{"pets":{"edges":[{"node":{"name":"pet02","owner":{"name":"user0"}}},{"node":{"name":"pet12","owner":{"name":"user1"}}}]}}}`)
+	res = do(`{ users(where: {not: {hasGroups: true}, ageIsNil: false}) { totalCount } }`)
+	expect(t, res, `{"data":{"users":{"totalCount":3}}}`)
+
+	// Nodes.
+	res = do(fmt.Sprintf(`{ node(id: "%d") { id ... on Group { name users { totalCount } } } }`, grp.ID))
+	expect(t, res, fmt.Sprintf(`{"data":{"node":{"id":"%d","name":"ent","users":{"totalCount":2}}}}`, grp.ID))
+	res = do(fmt.Sprintf(`{ nodes(ids: ["%d", "%d", "%d"]) { __typename ... on User { name } ...pet } } fragment pet on Pet { name }`, users[1].ID, grp.ID+1, users[0].QueryPets().FirstIDX(ctx)))
+	expect(t, res, `{"data":{"nodes":[{"__typename":"User","name":"user1"},null,{"__typename":"Pet","name":"pet00"}]}}`)
+	res = do(`{ node(id: "42949672960") { id } }`)
+	expect(t, res, `unknown node type of range 10`)
+
+	// Eager loading. The edges of all nodes are loaded in one query per edge.
+	queries.Store(0)
+	res = do(`{ users { edges { node { name pets { edges { node { name owner { name } } } } groups { totalCount } } } } }`)
+	if !strings.Contains(res, `"groups":{"totalCount":1},"name":"user1","pets":{"edges":[{"node":{"name":"pet10","owner":{"name":"user1"}}}`) {
+		t.Fatalf("unexpected result: %s", res)
+	}
+	if n := queries.Load(); n != 4 {
+		t.Fatalf("expect 4 queries, got %d", n)
+	}
+	// Edges with arguments are paginated separately for each node.
+	queries.Store(0)
+	res = do(`{ users(where: {role: admin}) { edges { node { friends(first: 2, where: {ageLT: 24}) { totalCount edges { node { name } } } } } } }`)
+	expect(t, res, `{"data":{"users":{"edges":[{"node":{"friends":{"edges":[{"node":{"name":"user1"}},{"node":{"name":"user2"}}],"totalCount":3}}}]}}}`)
+	if n := queries.Load(); n != 3 {
+		t.Fatalf("expect 3 queries, got %d", n)
+	}
+}
+
+func expect(t *testing.T, res, s string) {
+	t.Helper()
+	if !strings.Contains(res, s) {
+		t.Fatalf("expect result to contain %s, got %s", s, res)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/proto"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			Annotations(proto.Field(2)),
+		field.String("email").
+			Unique().
+			Annotations(proto.Field(3)),
+		field.Int("age").
+			Optional().
+			Nillable().
+			Annotations(proto.Field(4)),
+		field.Enum("status").
+			Values("active", "suspended").
+			Default("active").
+			Annotations(proto.Field(5)),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(proto.Field(6)),
+		// Fields without a number are not exposed.
+		field.String("password").
+			Optional().
+			Sensitive(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("pets", Pet.Type),
+	}
+}
+
+// Annotations of the User.
+func (User) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		proto.Message(),
+		proto.Service(),
+	}
+}
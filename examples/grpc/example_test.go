@@ -0,0 +1,227 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"testing"
+
+	"entgo.io/ent/examples/grpc/ent"
+	"entgo.io/ent/examples/grpc/ent/proto/entpb"
+	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func Example_service() {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		log.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	// Run the auto migration tool.
+	if err := client.Schema.Create(ctx); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+	conn, stop := serve(client)
+	defer stop()
+	users := entpb.NewUserServiceClient(conn)
+
+	a8m, err := users.Create(ctx, &entpb.CreateUserRequest{
+		User: &entpb.User{Name: "a8m", Email: "a8m@entgo.io", Age: proto.Int64(30)},
+	})
+	if err != nil {
+		log.Fatalf("failed creating user: %v", err)
+	}
+	fmt.Println(a8m.Id, a8m.Name, a8m.GetAge(), a8m.Status)
+
+	// Update only the status of the user. The age in the request is ignored.
+	a8m, err = users.Update(ctx, &entpb.UpdateUserRequest{
+		User:       &entpb.User{Id: a8m.Id, Status: entpb.User_STATUS_SUSPENDED},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+	})
+	if err != nil {
+		log.Fatalf("failed updating user: %v", err)
+	}
+	fmt.Println(a8m.Id, a8m.Name, a8m.GetAge(), a8m.Status)
+
+	// Output:
+	// 1 a8m 30 STATUS_ACTIVE
+	// 1 a8m 30 STATUS_SUSPENDED
+}
+
+func TestUserService(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:users?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("failed creating schema resources: %v", err)
+	}
+	conn, stop := serve(client)
+	defer stop()
+	users := entpb.NewUserServiceClient(conn)
+
+	for i := 1; i <= 5; i++ {
+		u, err := users.Create(ctx, &entpb.CreateUserRequest{
+			User: &entpb.User{Name: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@entgo.io", i)},
+		})
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		if u.Age != nil || u.Status != entpb.User_STATUS_ACTIVE || u.CreatedAt == nil {
+			t.Fatalf("unexpected user: %v", u)
+		}
+	}
+	_, err = users.Create(ctx, &entpb.CreateUserRequest{User: &entpb.User{Name: "user1", Email: "user1@entgo.io"}})
+	expectCode(t, err, codes.AlreadyExists)
+	_, err = users.Create(ctx, &entpb.CreateUserRequest{User: &entpb.User{Email: "empty@entgo.io"}})
+	expectCode(t, err, codes.InvalidArgument)
+
+	// Iterate over all pages.
+	var (
+		names []string
+		token string
+	)
+	for {
+		resp, err := users.List(ctx, &entpb.ListUserRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("list users: %v", err)
+		}
+		for _, u := range resp.UserList {
+			names = append(names, u.Name)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	if fmt.Sprint(names) != "[user1 user2 user3 user4 user5]" {
+		t.Fatalf("unexpected users: %v", names)
+	}
+	_, err = users.List(ctx, &entpb.ListUserRequest{PageToken: "invalid"})
+	expectCode(t, err, codes.InvalidArgument)
+
+	// Field masks.
+	u, err := users.Update(ctx, &entpb.UpdateUserRequest{
+		User:       &entpb.User{Id: 1, Name: "a8m", Age: proto.Int64(30)},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "age"}},
+	})
+	if err != nil {
+		t.Fatalf("update user: %v", err)
+	}
+	if u.Name != "a8m" || u.GetAge() != 30 || u.Email != "user1@entgo.io" {
+		t.Fatalf("unexpected user: %v", u)
+	}
+	u, err = users.Update(ctx, &entpb.UpdateUserRequest{
+		User:       &entpb.User{Id: 1},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"age"}},
+	})
+	if err != nil {
+		t.Fatalf("clear user age: %v", err)
+	}
+	if u.Age != nil || u.Name != "a8m" {
+		t.Fatalf("unexpected user: %v", u)
+	}
+	_, err = users.Update(ctx, &entpb.UpdateUserRequest{
+		User:       &entpb.User{Id: 1},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"created_at"}},
+	})
+	expectCode(t, err, codes.InvalidArgument)
+	_, err = users.Update(ctx, &entpb.UpdateUserRequest{
+		User:       &entpb.User{Id: 1},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+	})
+	expectCode(t, err, codes.InvalidArgument)
+
+	// Get and delete.
+	if _, err := users.Delete(ctx, &entpb.DeleteUserRequest{Id: 1}); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	_, err = users.Get(ctx, &entpb.GetUserRequest{Id: 1})
+	expectCode(t, err, codes.NotFound)
+	_, err = users.Delete(ctx, &entpb.DeleteUserRequest{Id: 1})
+	expectCode(t, err, codes.NotFound)
+}
+
+func TestPetService(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:pets?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("failed creating schema resources: %v", err)
+	}
+	conn, stop := serve(client)
+	defer stop()
+	pets := entpb.NewPetServiceClient(conn)
+
+	p, err := pets.Create(ctx, &entpb.CreatePetRequest{Pet: &entpb.Pet{Name: "xabi", Photo: []byte("xabi.png")}})
+	if err != nil {
+		t.Fatalf("create pet: %v", err)
+	}
+	if p.Id == "" || string(p.Photo) != "xabi.png" {
+		t.Fatalf("unexpected pet: %v", p)
+	}
+	// Entities can be converted to protobuf messages without the service.
+	e := client.Pet.Create().SetName("pedro").SaveX(ctx)
+	v, err := entpb.ToProtoPet(e)
+	if err != nil {
+		t.Fatalf("convert pet: %v", err)
+	}
+	if got, err := pets.Get(ctx, &entpb.GetPetRequest{Id: e.ID.String()}); err != nil || !proto.Equal(got, v) {
+		t.Fatalf("get pet: %v, %v", got, err)
+	}
+	_, err = pets.Get(ctx, &entpb.GetPetRequest{Id: "invalid"})
+	expectCode(t, err, codes.InvalidArgument)
+	resp, err := pets.List(ctx, &entpb.ListPetRequest{PageSize: 1})
+	if err != nil || len(resp.PetList) != 1 || resp.NextPageToken == "" {
+		t.Fatalf("list pets: %v, %v", resp, err)
+	}
+	resp, err = pets.List(ctx, &entpb.ListPetRequest{PageSize: 1, PageToken: resp.NextPageToken})
+	if err != nil || len(resp.PetList) != 1 || resp.NextPageToken != "" {
+		t.Fatalf("list pets: %v, %v", resp, err)
+	}
+}
+
+// serve starts an in-process gRPC server with the services of the
+// client, and returns a connection to it and a function to stop it.
+func serve(client *ent.Client) (*grpc.ClientConn, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	entpb.RegisterUserServiceServer(srv, entpb.NewUserService(client))
+	entpb.RegisterPetServiceServer(srv, entpb.NewPetService(client))
+	go srv.Serve(lis)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		log.Fatalf("failed dialing server: %v", err)
+	}
+	return conn, func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("expect error code %s, got: %v", code, err)
+	}
+}
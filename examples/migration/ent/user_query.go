@@ -422,7 +422,14 @@ func (uq *UserQuery) loadCards(ctx context.Context, query *CardQuery, nodes []*U
 	query.Where(predicate.Card(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.CardsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeCard, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Card, *CardQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(user.CardsColumn)
+	})
+	neighbors, err := withInterceptors[[]*Card](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
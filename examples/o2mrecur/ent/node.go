@@ -147,6 +147,22 @@ func (n *Node) String() string {
 	return builder.String()
 }
 
+// QueryParentRecursive queries the "parent" edge of the Node entity recursively, in up
+// to depth steps. A depth that is not positive does not limit the traversal.
+func (n *Node) QueryParentRecursive(depth int) *NodeQuery {
+	return NewNodeClient(n.config).Query().
+		Where(node.ID(n.ID)).
+		QueryParentRecursive(depth)
+}
+
+// QueryChildrenRecursive queries the "children" edge of the Node entity recursively, in up
+// to depth steps. A depth that is not positive does not limit the traversal.
+func (n *Node) QueryChildrenRecursive(depth int) *NodeQuery {
+	return NewNodeClient(n.config).Query().
+		Where(node.ID(n.ID)).
+		QueryChildrenRecursive(depth)
+}
+
 // Nodes is a parsable slice of Node.
 type Nodes []*Node
 
@@ -504,7 +504,14 @@ func (nq *NodeQuery) loadChildren(ctx context.Context, query *NodeQuery, nodes [
 	query.Where(predicate.Node(func(s *sql.Selector) {
 		s.Where(sql.InValues(node.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = newQueryContext(ctx, TypeNode, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := querierAll[[]*Node, *NodeQuery](func(_ context.Context, _spec *sqlgraph.QuerySpec) {
+		_spec.PartitionBy(node.ChildrenColumn)
+	})
+	neighbors, err := withInterceptors[[]*Node](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -611,6 +618,56 @@ func (nq *NodeQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// QueryParentRecursive chains the current query on the "parent" edge recursively, and returns the
+// nodes that are reachable from the nodes of the current query in up to depth steps. A depth
+// that is not positive does not limit the traversal. Cycles in the graph are handled by the
+// query, and each node is returned once.
+func (nq *NodeQuery) QueryParentRecursive(depth int) *NodeQuery {
+	query := (&NodeClient{config: nq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := nq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := nq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(node.Table, node.FieldID, selector),
+			sqlgraph.To(node.Table, node.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, node.ParentTable, node.ParentColumn),
+		)
+		fromU = sqlgraph.SetNeighborsRecursive(nq.driver.Dialect(), step, depth)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryChildrenRecursive chains the current query on the "children" edge recursively, and returns the
+// nodes that are reachable from the nodes of the current query in up to depth steps. A depth
+// that is not positive does not limit the traversal. Cycles in the graph are handled by the
+// query, and each node is returned once.
+func (nq *NodeQuery) QueryChildrenRecursive(depth int) *NodeQuery {
+	query := (&NodeClient{config: nq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := nq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := nq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(node.Table, node.FieldID, selector),
+			sqlgraph.To(node.Table, node.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, node.ChildrenTable, node.ChildrenColumn),
+		)
+		fromU = sqlgraph.SetNeighborsRecursive(nq.driver.Dialect(), step, depth)
+		return fromU, nil
+	}
+	return query
+}
+
 // NodeGroupBy is the group-by builder for Node entities.
 type NodeGroupBy struct {
 	selector
@@ -33,6 +33,8 @@ func Example_O2MRecur() {
 	// Tree leafs [1 3 5]
 	// [1 3 5]
 	// Node(id=1, value=2)
+	// [1 3 4 5]
+	// [2 4]
 }
 
 func Do(ctx context.Context, client *ent.Client) error {
@@ -98,5 +100,23 @@ func Do(ctx context.Context, client *ent.Client) error {
 	fmt.Println(orphan)
 	// Output: Node(id=1, value=2)
 
+	// Get all descendants of the root (recursively).
+	descendants := root.
+		QueryChildrenRecursive(0).       // Not limited by depth.
+		Order(ent.Asc(node.FieldValue)). // Order by their `value` field.
+		GroupBy(node.FieldValue).        // Extract only the `value` field.
+		IntsX(ctx)
+	fmt.Println(descendants)
+	// Output: [1 3 4 5]
+
+	// Get all ancestors of the 3rd node, up to 2 levels.
+	ancestors := n3.
+		QueryParentRecursive(2).
+		Order(ent.Asc(node.FieldValue)).
+		GroupBy(node.FieldValue).
+		IntsX(ctx)
+	fmt.Println(ancestors)
+	// Output: [2 4]
+
 	return nil
 }
@@ -0,0 +1,58 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"regexp"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/rest"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			MaxLen(32),
+		field.String("email").
+			Unique().
+			Match(regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)),
+		field.Int("age").
+			Optional().
+			Nillable(),
+		field.Enum("status").
+			Values("active", "suspended").
+			Default("active"),
+		field.Int("karma").
+			Default(0).
+			Annotations(rest.ReadOnly()),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		// Sensitive fields can be set, but are never returned.
+		field.String("password").
+			Optional().
+			Sensitive(),
+		field.String("note").
+			Optional().
+			Annotations(rest.Skip()),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("pets", Pet.Type),
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/examples/rest/ent"
+	"entgo.io/ent/examples/rest/ent/rest"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func Example_server() {
+	client, err := ent.Open("sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		log.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	// Run the auto migration tool.
+	if err := client.Schema.Create(context.Background()); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+	srv := httptest.NewServer(rest.NewServer(client))
+	defer srv.Close()
+
+	var u rest.User
+	do(srv, http.MethodPost, "/users", `{"name": "a8m", "email": "a8m@entgo.io", "age": 30}`, &u)
+	fmt.Println(u.ID, u.Name, *u.Age, u.Status)
+
+	var p rest.Pet
+	do(srv, http.MethodPost, "/pets", fmt.Sprintf(`{"name": "xabi", "owner": %d}`, u.ID), &p)
+	do(srv, http.MethodGet, "/users/1?with=pets", "", &u)
+	fmt.Println(u.Name, u.Pets[0].Name)
+
+	// Output:
+	// 1 a8m 30 active
+	// a8m xabi
+}
+
+func TestServer(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:server?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("failed opening connection to sqlite: %v", err)
+	}
+	defer client.Close()
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("failed creating schema resources: %v", err)
+	}
+	srv := httptest.NewServer(rest.NewServer(client))
+	defer srv.Close()
+
+	for i := 1; i <= 5; i++ {
+		var u rest.User
+		code := do(srv, http.MethodPost, "/users", fmt.Sprintf(`{"name": "user%d", "email": "user%d@entgo.io", "password": "pass"}`, i, i), &u)
+		if code != http.StatusCreated || u.ID != i || u.Age != nil || u.Status != "active" || u.CreatedAt.IsZero() {
+			t.Fatalf("unexpected user: %d %+v", code, u)
+		}
+	}
+	for body, code := range map[string]int{
+		`{"name": "user1", "email": "user1@entgo.io"}`:                   http.StatusConflict,
+		`{"name": "", "email": "empty@entgo.io"}`:                        http.StatusBadRequest,
+		`{"name": "invalid", "email": "invalid"}`:                        http.StatusBadRequest,
+		`{"name": "karma", "email": "k@entgo.io", "karma": 1}`:           http.StatusBadRequest,
+		`{"name": "note", "email": "n@entgo.io", "note": ""}`:            http.StatusBadRequest,
+		`{"name": "status", "email": "s@entgo.io", "status": "unknown"}`: http.StatusBadRequest,
+	} {
+		if got := do(srv, http.MethodPost, "/users", body, nil); got != code {
+			t.Fatalf("create user %s: expect code %d, got %d", body, code, got)
+		}
+	}
+
+	// Sensitive and skipped fields are not returned.
+	res, err := http.Get(srv.URL + "/users/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil || strings.Contains(string(buf), "password") || strings.Contains(string(buf), "note") {
+		t.Fatalf("unexpected response: %s, %v", buf, err)
+	}
+
+	// Filtering and pagination.
+	var us []*rest.User
+	do(srv, http.MethodGet, "/users?limit=2&offset=1", "", &us)
+	if len(us) != 2 || us[0].ID != 2 || us[1].ID != 3 {
+		t.Fatalf("unexpected users: %v", us)
+	}
+	do(srv, http.MethodGet, "/users?email=user3@entgo.io", "", &us)
+	if len(us) != 1 || us[0].ID != 3 {
+		t.Fatalf("unexpected users: %v", us)
+	}
+	for _, query := range []string{"limit=0", "offset=-1", "status=unknown", "age=old", "with=friends"} {
+		if code := do(srv, http.MethodGet, "/users?"+query, "", nil); code != http.StatusBadRequest {
+			t.Fatalf("list users with %q: expect code 400, got %d", query, code)
+		}
+	}
+
+	// Updates and edges.
+	var p1, p2 rest.Pet
+	do(srv, http.MethodPost, "/pets", `{"name": "xabi"}`, &p1)
+	do(srv, http.MethodPost, "/pets", `{"name": "pedro"}`, &p2)
+	var u rest.User
+	code := do(srv, http.MethodPatch, "/users/2", fmt.Sprintf(`{"age": 30, "status": "suspended", "pets": [%q, %q]}`, p1.ID, p2.ID), &u)
+	if code != http.StatusOK || *u.Age != 30 || u.Status != "suspended" || u.Name != "user2" {
+		t.Fatalf("unexpected user: %d %+v", code, u)
+	}
+	var ps []*rest.Pet
+	if do(srv, http.MethodGet, "/users/2/pets?with=owner", "", &ps); len(ps) != 2 || ps[0].Owner.ID != 2 {
+		t.Fatalf("unexpected pets: %v", ps)
+	}
+	if do(srv, http.MethodGet, "/users/1/pets", "", &ps); len(ps) != 0 {
+		t.Fatalf("unexpected pets: %v", ps)
+	}
+	if do(srv, http.MethodGet, fmt.Sprintf("/pets/%s/owner", p1.ID), "", &u); u.ID != 2 {
+		t.Fatalf("unexpected owner: %+v", u)
+	}
+	if code := do(srv, http.MethodPatch, "/users/2", `{"created_at": "2020-01-01T00:00:00Z"}`, nil); code != http.StatusBadRequest {
+		t.Fatalf("update immutable field: expect code 400, got %d", code)
+	}
+	// Pets that are owned by another user cannot be added.
+	if code := do(srv, http.MethodPatch, "/users/1", fmt.Sprintf(`{"pets": [%q]}`, p1.ID), nil); code != http.StatusConflict {
+		t.Fatalf("update owned pets: expect code 409, got %d", code)
+	}
+
+	// Deletion and errors.
+	if code := do(srv, http.MethodDelete, "/users/5", "", nil); code != http.StatusNoContent {
+		t.Fatalf("delete user: expect code 204, got %d", code)
+	}
+	for _, tt := range []struct {
+		method, path string
+		code         int
+	}{
+		{http.MethodGet, "/users/5", http.StatusNotFound},
+		{http.MethodDelete, "/users/5", http.StatusNotFound},
+		{http.MethodGet, "/users/5/pets", http.StatusNotFound},
+		{http.MethodGet, "/users/x", http.StatusBadRequest},
+		{http.MethodGet, "/pets/1", http.StatusBadRequest},
+		{http.MethodGet, "/users/1/friends", http.StatusNotFound},
+		{http.MethodGet, "/groups", http.StatusNotFound},
+		{http.MethodPut, "/users/1", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/users/1/pets", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/openapi.json", http.StatusOK},
+	} {
+		if code := do(srv, tt.method, tt.path, "", nil); code != tt.code {
+			t.Fatalf("%s %s: expect code %d, got %d", tt.method, tt.path, tt.code, code)
+		}
+	}
+}
+
+// do sends a request to the server, decodes the response body into v,
+// and returns the status code of the response.
+func do(srv *httptest.Server, method, path, body string, v any) int {
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		log.Fatalf("failed creating request: %v", err)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		log.Fatalf("failed sending request: %v", err)
+	}
+	defer res.Body.Close()
+	if v != nil && res.StatusCode < http.StatusMultipleChoices {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			log.Fatalf("failed decoding response: %v", err)
+		}
+	}
+	return res.StatusCode
+}
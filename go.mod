@@ -22,6 +22,7 @@ require (
 	go.opencensus.io v0.24.0
 	golang.org/x/sync v0.1.0
 	golang.org/x/tools v0.3.1-0.20221202221704-aa9f4b2f3d57
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/gofrs/uuid v4.3.1+incompatible
@@ -43,5 +44,4 @@ require (
 	golang.org/x/mod v0.7.0 // indirect
 	golang.org/x/sys v0.2.0 // indirect
 	golang.org/x/text v0.3.7 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
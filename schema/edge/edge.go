@@ -26,6 +26,7 @@ type Descriptor struct {
 	StorageKey  *StorageKey            // optional storage-key configuration.
 	Annotations []schema.Annotation    // edge annotations.
 	Comment     string                 // edge comment.
+	Counter     string                 // counter field name.
 }
 
 // To defines an association edge between two vertices.
@@ -109,6 +110,21 @@ func (b *assocBuilder) Comment(c string) *assocBuilder {
 	return b
 }
 
+// Counter sets the name of an integer field that holds the number of edges
+// of this type. The counter is stored on the type that holds the edge, and
+// it is updated in the same transaction whenever edges are added or removed.
+// The counter field must have a default value, and it is read-only.
+//
+//	field.Int("comment_count").
+//		Default(0)
+//
+//	edge.To("comments", Comment.Type).
+//		Counter("comment_count")
+func (b *assocBuilder) Counter(f string) *assocBuilder {
+	b.desc.Counter = f
+	return b
+}
+
 // StorageKey sets the storage key of the edge.
 //
 //	edge.To("groups", Group.Type).
@@ -195,6 +211,22 @@ func (b *inverseBuilder) Field(f string) *inverseBuilder {
 	return b
 }
 
+// Counter sets the name of an integer field that holds the number of edges
+// of this type. The counter is stored on the type that holds the edge, and
+// it is updated in the same transaction whenever edges are added or removed.
+// The counter field must have a default value, and it is read-only.
+//
+//	field.Int("group_count").
+//		Default(0)
+//
+//	edge.From("groups", Group.Type).
+//		Ref("users").
+//		Counter("group_count")
+func (b *inverseBuilder) Counter(f string) *inverseBuilder {
+	b.desc.Counter = f
+	return b
+}
+
 // Through allows setting an "edge schema" to interact explicitly with M2M edges.
 //
 //	edge.From("liked_users", User.Type).
@@ -50,6 +50,19 @@ func TestEdge(t *testing.T) {
 	assert.Equal("comment", e.Comment)
 	assert.Empty(e.Ref.Field)
 
+	e = edge.To("children", Node.Type).
+		Counter("children_count").
+		From("parent").
+		Unique().
+		Descriptor()
+	assert.Empty(e.Counter)
+	assert.Equal("children_count", e.Ref.Counter)
+	e = edge.From("followers", User.Type).
+		Ref("following").
+		Counter("followers_count").
+		Descriptor()
+	assert.Equal("followers_count", e.Counter)
+
 	t.Log("m2m relation of the same type")
 	from := edge.To("following", User.Type).
 		From("followers").
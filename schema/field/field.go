@@ -12,9 +12,11 @@ import (
 	"math"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/schema"
 )
 
@@ -160,6 +162,68 @@ func Other(name string, typ driver.Valuer) *otherBuilder {
 	return ob
 }
 
+// Point returns a new Field with type geometry, that holds a single location.
+// The second argument defines the GoType and must implement the ValueScanner
+// interface. For example, the sqlgeo.Point type:
+//
+//	field.Point("location", sqlgeo.Point{}).
+//		SRID(4326)
+func Point(name string, typ driver.Valuer) *geometryBuilder {
+	return newGeometry(name, "Point", typ)
+}
+
+// Geometry returns a new Field with type geometry, that holds a shape of any type
+// (e.g. a polygon). The second argument defines the GoType and must implement the
+// ValueScanner interface. For example, the sqlgeo.Geometry type:
+//
+//	field.Geometry("area", sqlgeo.Geometry{}).
+//		SRID(4326)
+func Geometry(name string, typ driver.Valuer) *geometryBuilder {
+	return newGeometry(name, "Geometry", typ)
+}
+
+func newGeometry(name, shape string, typ driver.Valuer) *geometryBuilder {
+	b := &geometryBuilder{desc: &Descriptor{
+		Name: name,
+		Info: &TypeInfo{Type: TypeGeometry},
+	}, shape: shape}
+	b.desc.goType(typ, valueScannerType)
+	return b
+}
+
+// IntRange returns a new Field with type range, that holds a range of integers.
+// The second argument defines the GoType and must implement the ValueScanner
+// interface. For example, the sqlrange.Int type:
+//
+//	field.IntRange("seats", sqlrange.Int{})
+//
+// In PostgreSQL, the field is stored in an int8range column by default, and in
+// other dialects, its text representation is stored in a string column.
+func IntRange(name string, typ driver.Valuer) *rangeBuilder {
+	return newRange(name, "int8range", typ)
+}
+
+// TimeRange returns a new Field with type range, that holds a range of times.
+// The second argument defines the GoType and must implement the ValueScanner
+// interface. For example, the sqlrange.Time type:
+//
+//	field.TimeRange("during", sqlrange.Time{})
+//
+// In PostgreSQL, the field is stored in a tstzrange column by default, and in
+// other dialects, its text representation is stored in a string column.
+func TimeRange(name string, typ driver.Valuer) *rangeBuilder {
+	return newRange(name, "tstzrange", typ)
+}
+
+func newRange(name, pgType string, typ driver.Valuer) *rangeBuilder {
+	b := &rangeBuilder{desc: &Descriptor{
+		Name: name,
+		Info: &TypeInfo{Type: TypeRange},
+	}, pgType: pgType}
+	b.desc.goType(typ, valueScannerType)
+	return b
+}
+
 // stringBuilder is the builder for string fields.
 type stringBuilder struct {
 	desc *Descriptor
@@ -179,6 +243,7 @@ func (b *stringBuilder) Sensitive() *stringBuilder {
 
 // Match adds a regex matcher for this field. Operation fails if the regex fails.
 func (b *stringBuilder) Match(re *regexp.Regexp) *stringBuilder {
+	b.desc.Patterns = append(b.desc.Patterns, re.String())
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
 		if !re.MatchString(v) {
 			return errors.New("value does not match validation")
@@ -191,6 +256,7 @@ func (b *stringBuilder) Match(re *regexp.Regexp) *stringBuilder {
 // MinLen adds a length validator for this field.
 // Operation fails if the length of the string is less than the given value.
 func (b *stringBuilder) MinLen(i int) *stringBuilder {
+	b.desc.MinLen = i
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
 		if len(v) < i {
 			return errors.New("value is less than the required length")
@@ -600,6 +666,7 @@ func (b *bytesBuilder) MaxLen(i int) *bytesBuilder {
 // MinLen adds a length validator for this field.
 // Operation fails if the length of the buffer is less than the given value.
 func (b *bytesBuilder) MinLen(i int) *bytesBuilder {
+	b.desc.MinLen = i
 	b.desc.Validators = append(b.desc.Validators, func(b []byte) error {
 		if len(b) < i {
 			return errors.New("value is less than the required length")
@@ -1112,10 +1179,197 @@ func (b *otherBuilder) Descriptor() *Descriptor {
 	return b.desc
 }
 
+// geometryBuilder is the builder for geometry fields.
+type geometryBuilder struct {
+	desc  *Descriptor
+	shape string
+	srid  int
+}
+
+// SRID sets the spatial reference system identifier of the field.
+// For example, 4326 for the WGS 84 coordinate system (latitude and longitude).
+// In MySQL (8.0 and above) and PostgreSQL, the SRID is part of the column type.
+func (b *geometryBuilder) SRID(srid int) *geometryBuilder {
+	if srid < 0 {
+		b.desc.Err = fmt.Errorf("invalid SRID %d for geometry field", srid)
+	}
+	b.srid = srid
+	return b
+}
+
+// StorageKey sets the storage key of the field.
+// In SQL dialects is the column name and Gremlin is the property.
+func (b *geometryBuilder) StorageKey(key string) *geometryBuilder {
+	b.desc.StorageKey = key
+	return b
+}
+
+// Nillable indicates that this field is a nillable.
+// Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
+func (b *geometryBuilder) Nillable() *geometryBuilder {
+	b.desc.Nillable = true
+	return b
+}
+
+// Optional indicates that this field is optional on create.
+// Unlike edges, fields are required by default.
+func (b *geometryBuilder) Optional() *geometryBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *geometryBuilder) Immutable() *geometryBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *geometryBuilder) Comment(c string) *geometryBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// StructTag sets the struct tag of the field.
+func (b *geometryBuilder) StructTag(s string) *geometryBuilder {
+	b.desc.Tag = s
+	return b
+}
+
+// SchemaType overrides the default database type with a custom
+// schema type (per dialect) for geometry.
+//
+//	field.Geometry("area", sqlgeo.Geometry{}).
+//		SchemaType(map[string]string{
+//			dialect.MySQL:    "polygon",
+//			dialect.Postgres: "geometry(Polygon,4326)",
+//		})
+func (b *geometryBuilder) SchemaType(types map[string]string) *geometryBuilder {
+	b.desc.SchemaType = types
+	return b
+}
+
+// Annotations adds a list of annotations to the field object to be used by
+// codegen extensions.
+//
+//	field.Point("location", sqlgeo.Point{}).
+//		Annotations(
+//			entgql.Skip(),
+//		)
+func (b *geometryBuilder) Annotations(annotations ...schema.Annotation) *geometryBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+// The schema types of MySQL and PostgreSQL are derived from the shape and the
+// SRID of the field, unless they were set explicitly using SchemaType.
+func (b *geometryBuilder) Descriptor() *Descriptor {
+	types := map[string]string{
+		dialect.MySQL:    strings.ToLower(b.shape),
+		dialect.Postgres: "geometry",
+	}
+	switch {
+	case b.srid != 0:
+		types[dialect.MySQL] += " srid " + strconv.Itoa(b.srid)
+		types[dialect.Postgres] += "(" + b.shape + "," + strconv.Itoa(b.srid) + ")"
+	case b.shape != "Geometry":
+		types[dialect.Postgres] += "(" + b.shape + ")"
+	}
+	for d, t := range b.desc.SchemaType {
+		types[d] = t
+	}
+	b.desc.SchemaType = types
+	return b.desc
+}
+
+// rangeBuilder is the builder for range fields.
+type rangeBuilder struct {
+	desc   *Descriptor
+	pgType string
+}
+
+// StorageKey sets the storage key of the field.
+// In SQL dialects is the column name and Gremlin is the property.
+func (b *rangeBuilder) StorageKey(key string) *rangeBuilder {
+	b.desc.StorageKey = key
+	return b
+}
+
+// Nillable indicates that this field is a nillable.
+// Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
+func (b *rangeBuilder) Nillable() *rangeBuilder {
+	b.desc.Nillable = true
+	return b
+}
+
+// Optional indicates that this field is optional on create.
+// Unlike edges, fields are required by default.
+func (b *rangeBuilder) Optional() *rangeBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *rangeBuilder) Immutable() *rangeBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *rangeBuilder) Comment(c string) *rangeBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// StructTag sets the struct tag of the field.
+func (b *rangeBuilder) StructTag(s string) *rangeBuilder {
+	b.desc.Tag = s
+	return b
+}
+
+// SchemaType overrides the default database type with a custom
+// schema type (per dialect) for range.
+//
+//	field.IntRange("seats", sqlrange.Int{}).
+//		SchemaType(map[string]string{
+//			dialect.Postgres: "int4range",
+//		})
+func (b *rangeBuilder) SchemaType(types map[string]string) *rangeBuilder {
+	b.desc.SchemaType = types
+	return b
+}
+
+// Annotations adds a list of annotations to the field object to be used by
+// codegen extensions.
+//
+//	field.TimeRange("during", sqlrange.Time{}).
+//		Annotations(
+//			entgql.Skip(),
+//		)
+func (b *rangeBuilder) Annotations(annotations ...schema.Annotation) *rangeBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+// The PostgreSQL schema type is derived from the range constructor (e.g.
+// tstzrange for TimeRange), unless it was set explicitly using SchemaType.
+func (b *rangeBuilder) Descriptor() *Descriptor {
+	types := map[string]string{dialect.Postgres: b.pgType}
+	for d, t := range b.desc.SchemaType {
+		types[d] = t
+	}
+	b.desc.SchemaType = types
+	return b.desc
+}
+
 // A Descriptor for field configuration.
 type Descriptor struct {
 	Tag           string                  // struct tag.
 	Size          int                     // varchar size.
+	MinLen        int                     // min length of string and bytes fields.
+	Patterns      []string                // patterns of string fields.
 	Name          string                  // field name.
 	Info          *TypeInfo               // field type info.
 	Unique        bool                    // unique index of field.
@@ -21,6 +21,8 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/sqlgeo"
+	"entgo.io/ent/dialect/sql/sqlrange"
 	"entgo.io/ent/schema/field"
 
 	"github.com/google/uuid"
@@ -343,6 +345,11 @@ func TestString(t *testing.T) {
 	assert.True(t, fd.Unique)
 	assert.Len(t, fd.Validators, 2)
 	assert.True(t, fd.Sensitive)
+	assert.Equal(t, []string{"[a-zA-Z0-9]"}, fd.Patterns)
+
+	fd = field.String("name").NotEmpty().MaxLen(10).Descriptor()
+	assert.Equal(t, 1, fd.MinLen)
+	assert.Equal(t, 10, fd.Size)
 
 	fd = field.String("name").GoType(http.Dir("dir")).Descriptor()
 	assert.NoError(t, fd.Err)
@@ -705,6 +712,65 @@ func TestField_Other(t *testing.T) {
 	assert.Error(t, fd.Err, "invalid default value")
 }
 
+func TestField_Geometry(t *testing.T) {
+	fd := field.Point("location", sqlgeo.Point{}).
+		SRID(4326).
+		Optional().
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "location", fd.Name)
+	assert.True(t, fd.Optional)
+	assert.Equal(t, field.TypeGeometry, fd.Info.Type)
+	assert.Equal(t, "sqlgeo.Point", fd.Info.String())
+	assert.Equal(t, "entgo.io/ent/dialect/sql/sqlgeo", fd.Info.PkgPath)
+	assert.Equal(t, map[string]string{dialect.MySQL: "point srid 4326", dialect.Postgres: "geometry(Point,4326)"}, fd.SchemaType)
+
+	fd = field.Point("location", &sqlgeo.Point{}).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.Info.Nillable)
+	assert.Equal(t, map[string]string{dialect.MySQL: "point", dialect.Postgres: "geometry(Point)"}, fd.SchemaType)
+
+	fd = field.Geometry("area", sqlgeo.Geometry{}).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, map[string]string{dialect.MySQL: "geometry", dialect.Postgres: "geometry"}, fd.SchemaType)
+
+	fd = field.Geometry("area", sqlgeo.Geometry{}).
+		SRID(3857).
+		SchemaType(map[string]string{dialect.MySQL: "polygon srid 3857"}).
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, map[string]string{dialect.MySQL: "polygon srid 3857", dialect.Postgres: "geometry(Geometry,3857)"}, fd.SchemaType)
+
+	fd = field.Geometry("area", sqlgeo.Geometry{}).SRID(-1).Descriptor()
+	assert.Error(t, fd.Err)
+	fd = field.Point("location", &custom{}).Descriptor()
+	assert.NoError(t, fd.Err)
+}
+
+func TestField_Range(t *testing.T) {
+	fd := field.TimeRange("during", sqlrange.Time{}).
+		Optional().
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "during", fd.Name)
+	assert.True(t, fd.Optional)
+	assert.Equal(t, field.TypeRange, fd.Info.Type)
+	assert.Equal(t, "sqlrange.Time", fd.Info.String())
+	assert.Equal(t, "entgo.io/ent/dialect/sql/sqlrange", fd.Info.PkgPath)
+	assert.Equal(t, map[string]string{dialect.Postgres: "tstzrange"}, fd.SchemaType)
+
+	fd = field.IntRange("seats", &sqlrange.Int{}).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.Info.Nillable)
+	assert.Equal(t, map[string]string{dialect.Postgres: "int8range"}, fd.SchemaType)
+
+	fd = field.IntRange("seats", sqlrange.Int{}).
+		SchemaType(map[string]string{dialect.Postgres: "int4range", dialect.MySQL: "varchar(64)"}).
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, map[string]string{dialect.Postgres: "int4range", dialect.MySQL: "varchar(64)"}, fd.SchemaType)
+}
+
 type UserRole string
 
 const (
@@ -781,7 +847,7 @@ func TestTypeString(t *testing.T) {
 	assert.Equal(t, "bool", typ.String())
 	typ = field.TypeInvalid
 	assert.Equal(t, "invalid", typ.String())
-	typ = 21
+	typ = 23
 	assert.Equal(t, "invalid", typ.String())
 }
 
@@ -790,6 +856,10 @@ func TestTypeNumeric(t *testing.T) {
 	assert.False(t, typ.Numeric())
 	typ = field.TypeUint8
 	assert.True(t, typ.Numeric())
+	typ = field.TypeGeometry
+	assert.False(t, typ.Numeric())
+	typ = field.TypeRange
+	assert.False(t, typ.Numeric())
 }
 
 func TestTypeValid(t *testing.T) {
@@ -797,7 +867,7 @@ func TestTypeValid(t *testing.T) {
 	assert.True(t, typ.Valid())
 	typ = 0
 	assert.False(t, typ.Valid())
-	typ = 21
+	typ = 23
 	assert.False(t, typ.Valid())
 }
 
@@ -810,6 +880,10 @@ func TestTypeConstName(t *testing.T) {
 	assert.Equal(t, "TypeInt64", typ.ConstName())
 	typ = field.TypeOther
 	assert.Equal(t, "TypeOther", typ.ConstName())
-	typ = 21
+	typ = field.TypeGeometry
+	assert.Equal(t, "TypeGeometry", typ.ConstName())
+	typ = field.TypeRange
+	assert.Equal(t, "TypeRange", typ.ConstName())
+	typ = 23
 	assert.Equal(t, "invalid", typ.ConstName())
 }
@@ -36,6 +36,8 @@ const (
 	TypeUint64
 	TypeFloat32
 	TypeFloat64
+	TypeGeometry
+	TypeRange
 	endTypes
 )
 
@@ -49,7 +51,7 @@ func (t Type) String() string {
 
 // Numeric reports if the given type is a numeric type.
 func (t Type) Numeric() bool {
-	return t >= TypeInt8 && t < endTypes
+	return t >= TypeInt8 && t <= TypeFloat64
 }
 
 // Float reports if the given type is a float type.
@@ -152,35 +154,39 @@ func (t TypeInfo) Stringer() bool {
 
 var (
 	typeNames = [...]string{
-		TypeInvalid: "invalid",
-		TypeBool:    "bool",
-		TypeTime:    "time.Time",
-		TypeJSON:    "json.RawMessage",
-		TypeUUID:    "[16]byte",
-		TypeBytes:   "[]byte",
-		TypeEnum:    "string",
-		TypeString:  "string",
-		TypeOther:   "other",
-		TypeInt:     "int",
-		TypeInt8:    "int8",
-		TypeInt16:   "int16",
-		TypeInt32:   "int32",
-		TypeInt64:   "int64",
-		TypeUint:    "uint",
-		TypeUint8:   "uint8",
-		TypeUint16:  "uint16",
-		TypeUint32:  "uint32",
-		TypeUint64:  "uint64",
-		TypeFloat32: "float32",
-		TypeFloat64: "float64",
+		TypeInvalid:  "invalid",
+		TypeBool:     "bool",
+		TypeTime:     "time.Time",
+		TypeJSON:     "json.RawMessage",
+		TypeUUID:     "[16]byte",
+		TypeBytes:    "[]byte",
+		TypeEnum:     "string",
+		TypeString:   "string",
+		TypeOther:    "other",
+		TypeInt:      "int",
+		TypeInt8:     "int8",
+		TypeInt16:    "int16",
+		TypeInt32:    "int32",
+		TypeInt64:    "int64",
+		TypeUint:     "uint",
+		TypeUint8:    "uint8",
+		TypeUint16:   "uint16",
+		TypeUint32:   "uint32",
+		TypeUint64:   "uint64",
+		TypeFloat32:  "float32",
+		TypeFloat64:  "float64",
+		TypeGeometry: "geometry",
+		TypeRange:    "range",
 	}
 	constNames = [...]string{
-		TypeJSON:  "TypeJSON",
-		TypeUUID:  "TypeUUID",
-		TypeTime:  "TypeTime",
-		TypeEnum:  "TypeEnum",
-		TypeBytes: "TypeBytes",
-		TypeOther: "TypeOther",
+		TypeJSON:     "TypeJSON",
+		TypeUUID:     "TypeUUID",
+		TypeTime:     "TypeTime",
+		TypeEnum:     "TypeEnum",
+		TypeBytes:    "TypeBytes",
+		TypeOther:    "TypeOther",
+		TypeGeometry: "TypeGeometry",
+		TypeRange:    "TypeRange",
 	}
 )
 